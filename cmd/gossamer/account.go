@@ -4,10 +4,15 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/genesis"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/utils"
 
@@ -66,7 +71,7 @@ func accountAction(ctx *cli.Context) error {
 
 	// check if --list is set
 	if keylist := ctx.Bool(ListFlag.Name); keylist {
-		_, err = utils.KeystoreFilepaths(basepath)
+		err = listKeys(basepath, genesisSS58Format(cfg.Init.Genesis))
 		if err != nil {
 			logger.Errorf("failed to list keys: %s", err)
 			return err
@@ -84,9 +89,112 @@ func accountAction(ctx *cli.Context) error {
 		logger.Info("imported private key and saved it to " + file)
 	}
 
+	// check if --import-suri is set
+	if suri := ctx.String(ImportSecretURIFlag.Name); suri != "" {
+		file, err = keystore.ImportSecretURI(suri, keytype, basepath, getKeystorePassword(ctx))
+		if err != nil {
+			logger.Errorf("failed to import secret URI: %s", err)
+			return err
+		}
+
+		logger.Info("imported key derived from secret URI and saved it to " + file)
+	}
+
+	// check if --import-json is set
+	if importjson := ctx.String(ImportJSONFlag.Name); importjson != "" {
+		file, err = keystore.ImportPolkadotJSON(importjson, basepath, getKeystorePassword(ctx))
+		if err != nil {
+			logger.Errorf("failed to import polkadot.js json: %s", err)
+			return err
+		}
+
+		logger.Info("imported polkadot.js json key and saved it to " + file)
+	}
+
+	// check if --export-json is set
+	if exportjson := ctx.String(ExportJSONFlag.Name); exportjson != "" {
+		data, err := keystore.ExportPolkadotJSON(exportjson, basepath, getKeystorePassword(ctx))
+		if err != nil {
+			logger.Errorf("failed to export polkadot.js json: %s", err)
+			return err
+		}
+
+		fmt.Println(string(data))
+	}
+
+	// check if --migrate is set
+	if migrate := ctx.Bool(MigrateFlag.Name); migrate {
+		migrated, err := keystore.MigrateKeys(basepath, getKeystorePassword(ctx))
+		if err != nil {
+			logger.Errorf("failed to migrate keys: %s", err)
+			return err
+		}
+
+		logger.Infof("migrated %d keys to keystore version %d", len(migrated), keystore.KeystoreVersion)
+	}
+
+	return nil
+}
+
+// listKeys prints the keys in the basepath/keystore/ directory, along with their ss58 address for the given
+// address format
+func listKeys(basepath string, format uint16) error {
+	files, err := utils.KeystoreFiles(basepath)
+	if err != nil {
+		return err
+	}
+
+	keystorepath, err := utils.KeystoreDir(basepath)
+	if err != nil {
+		return err
+	}
+
+	for i, file := range files {
+		data, err := os.ReadFile(filepath.Clean(filepath.Join(keystorepath, file)))
+		if err != nil {
+			return err
+		}
+
+		keydata := new(keystore.EncryptedKeystore)
+		err = json.Unmarshal(data, keydata)
+		if err != nil {
+			return fmt.Errorf("cannot decode key file %s: %w", file, err)
+		}
+
+		pub, err := common.HexToBytes(keydata.PublicKey)
+		if err != nil {
+			return fmt.Errorf("cannot decode public key of key file %s: %w", file, err)
+		}
+
+		addr, err := crypto.EncodeSS58(pub, format)
+		if err != nil {
+			return fmt.Errorf("cannot encode address of key file %s: %w", file, err)
+		}
+
+		fmt.Printf("[%d] %s %s (%s)\n", i, addr, file, keydata.Type)
+	}
+
 	return nil
 }
 
+// genesisSS58Format returns the SS58 address format set in the properties of the given genesis file,
+// or the default format if it cannot be read
+func genesisSS58Format(genesisFP string) uint16 {
+	gen, err := genesis.NewGenesisFromJSONRaw(genesisFP)
+	if err != nil {
+		logger.Debugf("cannot read genesis %s, using default ss58 format: %s", genesisFP, err)
+		return crypto.DefaultSS58Format
+	}
+
+	format, err := gen.GenesisData().SS58Format()
+	if err != nil {
+		logger.Warnf("invalid ss58 format in genesis %s, using default ss58 format: %s", genesisFP, err)
+		return crypto.DefaultSS58Format
+	}
+
+	return format
+}
+
 // getKeystorePassword checks if the --password flag is set, if not,
 func getKeystorePassword(ctx *cli.Context) []byte {
 	// check if --password is set
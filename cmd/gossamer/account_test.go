@@ -4,9 +4,15 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/utils"
 
 	"github.com/stretchr/testify/require"
@@ -161,6 +167,60 @@ func TestAccountImportRaw(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestAccountImportSecretURI test "gossamer account --import-suri"
+func TestAccountImportSecretURI(t *testing.T) {
+	testDir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+	directory := fmt.Sprintf("--basepath=%s", testDir)
+
+	err := app.Run([]string{
+		"irrelevant", "account", directory,
+		"--import-suri=//Alice",
+		"--password=1234"})
+	require.NoError(t, err)
+
+	files, err := utils.KeystoreFiles(testDir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	err = app.Run([]string{
+		"irrelevant", "account", directory,
+		"--import-suri=//Alice/soft",
+		"--ed25519",
+		"--password=1234"})
+	require.Error(t, err)
+}
+
+// TestAccountImportExportJSON test "gossamer account --import-json" and "gossamer account --export-json"
+func TestAccountImportExportJSON(t *testing.T) {
+	testDir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+	directory := fmt.Sprintf("--basepath=%s", testDir)
+
+	kp, err := sr25519.NewKeypairFromSecretURI("//Alice")
+	require.NoError(t, err)
+	pj, err := keystore.EncryptPolkadotJSON(kp, []byte("1234"))
+	require.NoError(t, err)
+	data, err := json.Marshal(pj)
+	require.NoError(t, err)
+
+	fp := filepath.Join(testDir, "alice.json")
+	err = os.WriteFile(fp, data, 0600)
+	require.NoError(t, err)
+
+	err = app.Run([]string{"irrelevant", "account", directory, "--import-json=" + fp, "--password=1234"})
+	require.NoError(t, err)
+
+	err = app.Run([]string{"irrelevant", "account", directory, "--export-json=" + kp.Public().Hex(), "--password=1234"})
+	require.NoError(t, err)
+
+	err = app.Run([]string{"irrelevant", "account", directory, "--migrate", "--password=1234"})
+	require.NoError(t, err)
+
+	err = app.Run([]string{"irrelevant", "account", directory, "--export-json=" + kp.Public().Hex(), "--password=wrong"})
+	require.Error(t, err)
+}
+
 // TestAccountList test "gossamer account --list"
 func TestAccountList(t *testing.T) {
 	testDir := utils.NewTestDir(t)
@@ -180,3 +240,21 @@ func TestAccountList(t *testing.T) {
 	err = command.Run(ctx)
 	require.NoError(t, err)
 }
+
+func TestListKeys(t *testing.T) {
+	testDir := t.TempDir()
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	_, err = keystore.GenerateKeypair(crypto.Sr25519Type, kp, testDir, []byte("password"))
+	require.NoError(t, err)
+
+	err = listKeys(testDir, 2)
+	require.NoError(t, err)
+}
+
+func TestGenesisSS58Format(t *testing.T) {
+	require.Equal(t, uint16(2), genesisSS58Format("../../chain/kusama/genesis.json"))
+	require.Equal(t, crypto.DefaultSS58Format, genesisSS58Format("does_not_exist.json"))
+}
@@ -554,6 +554,14 @@ func setDotAccountConfig(ctx *cli.Context, tomlCfg ctoml.AccountConfig, cfg *dot
 		cfg.Unlock = tomlCfg.Unlock
 	}
 
+	if tomlCfg.RemoteSigner != "" {
+		cfg.RemoteSigner = tomlCfg.RemoteSigner
+	}
+
+	if tomlCfg.RemoteSignerToken != "" {
+		cfg.RemoteSignerToken = tomlCfg.RemoteSignerToken
+	}
+
 	// check --key flag and update node configuration
 	if key := ctx.GlobalString(KeyFlag.Name); key != "" {
 		cfg.Key = key
@@ -564,8 +572,23 @@ func setDotAccountConfig(ctx *cli.Context, tomlCfg ctoml.AccountConfig, cfg *dot
 		cfg.Unlock = unlock
 	}
 
+	// check --remote-signer flag and update node configuration
+	if url := ctx.GlobalString(RemoteSignerFlag.Name); url != "" {
+		cfg.RemoteSigner = url
+	}
+
+	// check --remote-signer-token flag and update node configuration
+	if token := ctx.GlobalString(RemoteSignerTokenFlag.Name); token != "" {
+		cfg.RemoteSignerToken = token
+	}
+
+	// check --session-keys-password flag and update node configuration
+	if password := ctx.GlobalString(SessionKeysPasswordFlag.Name); password != "" {
+		cfg.SessionKeysPassword = password
+	}
+
 	logger.Debug("account configuration has key " + cfg.Key +
-		" and unlock " + cfg.Unlock)
+		", unlock " + cfg.Unlock + " and remote signer " + cfg.RemoteSigner)
 }
 
 // setDotCoreConfig sets dot.CoreConfig using flag values from the cli context
@@ -580,6 +603,11 @@ func setDotCoreConfig(ctx *cli.Context, tomlCfg ctoml.CoreConfig, cfg *dot.CoreC
 		cfg.BABELead = ctx.GlobalBool(BABELeadFlag.Name)
 	}
 
+	cfg.WarpSync = tomlCfg.WarpSync
+	if ctx.IsSet(WarpSyncFlag.Name) {
+		cfg.WarpSync = ctx.GlobalBool(WarpSyncFlag.Name)
+	}
+
 	// check --roles flag and update node configuration
 	if roles := ctx.GlobalString(RolesFlag.Name); roles != "" {
 		// convert string to byte
@@ -643,6 +671,7 @@ func setDotNetworkConfig(ctx *cli.Context, tomlCfg ctoml.NetworkConfig, cfg *dot
 	cfg.MinPeers = tomlCfg.MinPeers
 	cfg.MaxPeers = tomlCfg.MaxPeers
 	cfg.PersistentPeers = tomlCfg.PersistentPeers
+	cfg.ReservedOnly = tomlCfg.ReservedOnly
 	cfg.DiscoveryInterval = time.Second * time.Duration(tomlCfg.DiscoveryInterval)
 
 	// check --port flag and update node configuration
@@ -675,6 +704,11 @@ func setDotNetworkConfig(ctx *cli.Context, tomlCfg ctoml.NetworkConfig, cfg *dot
 		cfg.NoMDNS = true
 	}
 
+	// check --reserved-only flag and update node configuration
+	if reservedOnly := ctx.GlobalBool(ReservedOnlyFlag.Name); reservedOnly {
+		cfg.ReservedOnly = true
+	}
+
 	// check --pubip flag and update node configuration
 	if pubip := ctx.GlobalString(PublicIPFlag.Name); pubip != "" {
 		cfg.PublicIP = pubip
@@ -692,10 +726,10 @@ func setDotNetworkConfig(ctx *cli.Context, tomlCfg ctoml.NetworkConfig, cfg *dot
 	logger.Debugf(
 		"network configuration: port=%d bootnodes=%s protocol=%s nobootstrap=%t "+
 			"nomdns=%t minpeers=%d maxpeers=%d persistent-peers=%s "+
-			"discovery-interval=%s",
+			"reserved-only=%t discovery-interval=%s",
 		cfg.Port, strings.Join(cfg.Bootnodes, ","), cfg.ProtocolID, cfg.NoBootstrap,
 		cfg.NoMDNS, cfg.MinPeers, cfg.MaxPeers, strings.Join(cfg.PersistentPeers, ","),
-		cfg.DiscoveryInterval,
+		cfg.ReservedOnly, cfg.DiscoveryInterval,
 	)
 }
 
@@ -360,6 +360,17 @@ func TestAccountConfigFromFlags(t *testing.T) {
 				Unlock: "0",
 			},
 		},
+		{
+			"Test gossamer --remote-signer",
+			[]string{"config", "remote-signer", "remote-signer-token"},
+			[]interface{}{testCfgFile.Name(), "http://127.0.0.1:9955", "secret"},
+			dot.AccountConfig{
+				Key:               testCfg.Account.Key,
+				Unlock:            testCfg.Account.Unlock,
+				RemoteSigner:      "http://127.0.0.1:9955",
+				RemoteSignerToken: "secret",
+			},
+		},
 	}
 
 	for _, c := range testcases {
@@ -521,6 +532,22 @@ func TestNetworkConfigFromFlags(t *testing.T) {
 				MaxPeers:          testCfg.Network.MaxPeers,
 			},
 		},
+		{
+			"Test gossamer --reserved-only",
+			[]string{"config", "reserved-only"},
+			[]interface{}{testCfgFile.Name(), "true"},
+			dot.NetworkConfig{
+				Port:              testCfg.Network.Port,
+				Bootnodes:         testCfg.Network.Bootnodes,
+				ProtocolID:        testCfg.Network.ProtocolID,
+				NoBootstrap:       testCfg.Network.NoBootstrap,
+				NoMDNS:            testCfg.Network.NoMDNS,
+				ReservedOnly:      true,
+				DiscoveryInterval: time.Second * 10,
+				MinPeers:          testCfg.Network.MinPeers,
+				MaxPeers:          testCfg.Network.MaxPeers,
+			},
+		},
 		{
 			"Test gossamer --pubip",
 			[]string{"config", "pubip"},
@@ -0,0 +1,70 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/dot/core"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// executeBlockFromFiles executes the block of the given file against the state proven by the proof of the
+// given file, and returns the hash of the block and the state root after its execution.
+// The block file holds the hex encoded SCALE block and the proof file a JSON array of hex encoded trie nodes,
+// as returned by the state_getReadProof RPC method.
+func executeBlockFromFiles(blockFP, proofFP string, stateRoot common.Hash) (
+	blockHash, newRoot common.Hash, err error) {
+	data, err := os.ReadFile(filepath.Clean(blockFP))
+	if err != nil {
+		return blockHash, newRoot, err
+	}
+
+	enc, err := common.HexToBytes(strings.TrimSpace(string(data)))
+	if err != nil {
+		return blockHash, newRoot, err
+	}
+
+	block := types.NewEmptyBlock()
+	err = scale.Unmarshal(enc, &block)
+	if err != nil {
+		return blockHash, newRoot, err
+	}
+
+	data, err = os.ReadFile(filepath.Clean(proofFP))
+	if err != nil {
+		return blockHash, newRoot, err
+	}
+
+	var hexNodes []string
+	err = json.Unmarshal(data, &hexNodes)
+	if err != nil {
+		return blockHash, newRoot, err
+	}
+
+	proof := make([][]byte, len(hexNodes))
+	for i, n := range hexNodes {
+		proof[i], err = common.HexToBytes(n)
+		if err != nil {
+			return blockHash, newRoot, err
+		}
+	}
+
+	ts, err := core.ExecuteBlockFromProof(&block, stateRoot, proof)
+	if err != nil {
+		return blockHash, newRoot, err
+	}
+
+	newRoot, err = ts.Root()
+	if err != nil {
+		return blockHash, newRoot, err
+	}
+
+	return block.Header.Hash(), newRoot, nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteBlockFromFiles(t *testing.T) {
+	tr := trie.NewEmptyTrie()
+	tr.Put(common.CodeKey, []byte("somecode"))
+	tr.Put([]byte("noot"), []byte("washere"))
+
+	proof, err := trie.GenerateProofFromTrie(tr, [][]byte{[]byte("noot")})
+	require.NoError(t, err)
+
+	hexNodes := make([]string, len(proof))
+	for i, n := range proof {
+		hexNodes[i] = common.BytesToHex(n)
+	}
+
+	proofJSON, err := json.Marshal(hexNodes)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	proofFP := filepath.Join(dir, "proof.json")
+	err = os.WriteFile(proofFP, proofJSON, 0600)
+	require.NoError(t, err)
+
+	block := types.NewEmptyBlock()
+	blockFP := filepath.Join(dir, "block.hex")
+	err = os.WriteFile(blockFP, []byte(common.BytesToHex(block.MustEncode())+"\n"), 0600)
+	require.NoError(t, err)
+
+	_, _, err = executeBlockFromFiles(blockFP, proofFP, tr.MustHash())
+	require.ErrorIs(t, err, rtstorage.ErrIncompleteProof)
+}
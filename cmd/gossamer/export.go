@@ -92,8 +92,10 @@ func dotConfigToToml(dcfg *dot.Config) *ctoml.Config {
 	}
 
 	cfg.Account = ctoml.AccountConfig{
-		Key:    dcfg.Account.Key,
-		Unlock: dcfg.Account.Unlock,
+		Key:               dcfg.Account.Key,
+		Unlock:            dcfg.Account.Unlock,
+		RemoteSigner:      dcfg.Account.RemoteSigner,
+		RemoteSignerToken: dcfg.Account.RemoteSignerToken,
 	}
 
 	cfg.Core = ctoml.CoreConfig{
@@ -101,6 +103,7 @@ func dotConfigToToml(dcfg *dot.Config) *ctoml.Config {
 		BabeAuthority:    dcfg.Core.BabeAuthority,
 		GrandpaAuthority: dcfg.Core.GrandpaAuthority,
 		GrandpaInterval:  uint32(dcfg.Core.GrandpaInterval / time.Second),
+		WarpSync:         dcfg.Core.WarpSync,
 	}
 
 	cfg.Network = ctoml.NetworkConfig{
@@ -109,6 +112,7 @@ func dotConfigToToml(dcfg *dot.Config) *ctoml.Config {
 		ProtocolID:        dcfg.Network.ProtocolID,
 		NoBootstrap:       dcfg.Network.NoBootstrap,
 		NoMDNS:            dcfg.Network.NoMDNS,
+		ReservedOnly:      dcfg.Network.ReservedOnly,
 		DiscoveryInterval: int(dcfg.Network.DiscoveryInterval / time.Second),
 		MinPeers:          dcfg.Network.MinPeers,
 		MaxPeers:          dcfg.Network.MaxPeers,
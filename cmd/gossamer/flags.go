@@ -27,6 +27,21 @@ var (
 		Name:  "key",
 		Usage: "Specify a test keyring account to use: eg --key=alice",
 	}
+	// RemoteSignerFlag is the URL of the signing server holding the validator keys
+	RemoteSignerFlag = cli.StringFlag{
+		Name:  "remote-signer",
+		Usage: "URL of a signing server holding the validator keys, eg. --remote-signer=http://127.0.0.1:9955",
+	}
+	// RemoteSignerTokenFlag is the token authenticating the node to the signing server
+	RemoteSignerTokenFlag = cli.StringFlag{
+		Name:  "remote-signer-token",
+		Usage: "Token authenticating the node to the signing server",
+	}
+	// SessionKeysPasswordFlag is the password the session keys generated by the runtime are encrypted with
+	SessionKeysPasswordFlag = cli.StringFlag{
+		Name:  "session-keys-password",
+		Usage: "Password encrypting the session keys generated by the node, required by author_rotateKeys",
+	}
 	// RolesFlag role of the node (see Table D.2)
 	RolesFlag = cli.StringFlag{
 		Name:  "roles",
@@ -175,6 +190,47 @@ var (
 	}
 )
 
+// ExecuteBlock-only flags
+var (
+	BlockFlag = cli.StringFlag{
+		Name:  "block",
+		Usage: "Path to file of the hex encoded SCALE block to execute",
+	}
+	ProofFlag = cli.StringFlag{
+		Name:  "proof",
+		Usage: "Path to JSON file of the hex encoded trie nodes proving the state the block is executed against",
+	}
+	StateRootFlag = cli.StringFlag{
+		Name:  "state-root",
+		Usage: "State root of the parent block, which the proof is checked against",
+	}
+)
+
+// ExportBlocks and ImportBlocks flags
+var (
+	FromBlockFlag = cli.Uint64Flag{
+		Name:  "from",
+		Usage: "Number of the first block to export",
+		Value: 1,
+	}
+	ToBlockFlag = cli.Uint64Flag{
+		Name:  "to",
+		Usage: "Number of the last block to export, defaults to the best block",
+	}
+	BlocksOutputFlag = cli.StringFlag{
+		Name:  "output",
+		Usage: "Path to the file the blocks are exported to",
+	}
+	BlocksInputFlag = cli.StringFlag{
+		Name:  "input",
+		Usage: "Path to the file of the blocks to import",
+	}
+	BlocksJSONFlag = cli.BoolFlag{
+		Name:  "json",
+		Usage: "Use a JSON block file instead of the SCALE encoded blocks",
+	}
+)
+
 // BuildSpec-only flags
 var (
 	RawFlag = cli.BoolFlag{
@@ -218,6 +274,11 @@ var (
 		Name:  "nomdns",
 		Usage: "Disables network mDNS discovery",
 	}
+	// ReservedOnlyFlag only allows connections with reserved peers
+	ReservedOnlyFlag = cli.BoolFlag{
+		Name:  "reserved-only",
+		Usage: "Only connect to and accept connections from the persistent and reserved peers",
+	}
 	// PublicIPFlag uses the supplied IP for broadcasting
 	PublicIPFlag = cli.StringFlag{
 		Name:  "pubip",
@@ -316,6 +377,26 @@ var (
 		Name:  "import-raw",
 		Usage: "Import  a raw private key",
 	}
+	// ImportSecretURIFlag imports the key derived from a secret URI
+	ImportSecretURIFlag = cli.StringFlag{
+		Name:  "import-suri",
+		Usage: "Import the key derived from a secret URI, such as \"//Alice\" or \"<mnemonic>//hard/soft///<password>\"",
+	}
+	// ImportJSONFlag imports a key exported by polkadot.js
+	ImportJSONFlag = cli.StringFlag{
+		Name:  "import-json",
+		Usage: "Import a key exported by polkadot.js in its encrypted JSON format, decrypted with --password",
+	}
+	// ExportJSONFlag exports a key in the polkadot.js JSON format
+	ExportJSONFlag = cli.StringFlag{
+		Name:  "export-json",
+		Usage: "Print the key with the given hex encoded public key in the encrypted JSON format of polkadot.js",
+	}
+	// MigrateFlag migrates the keystore files to the current keystore format
+	MigrateFlag = cli.BoolFlag{
+		Name:  "migrate",
+		Usage: "Rewrite the keys in an older keystore format with scrypt key derivation, decrypted with --password",
+	}
 	// ListFlag List node keys
 	ListFlag = cli.BoolFlag{
 		Name:  "list",
@@ -371,6 +452,24 @@ var (
 	}
 )
 
+// sync flags
+var (
+	WarpSyncFlag = cli.BoolFlag{
+		Name:  "warp-sync",
+		Usage: `sync to the latest finalised block using GRANDPA warp sync proofs before syncing blocks`,
+	}
+)
+
+// signer flags
+var (
+	// SignerAddressFlag is the address the signing server listens on
+	SignerAddressFlag = cli.StringFlag{
+		Name:  "address",
+		Usage: "Address the signing server listens on",
+		Value: "127.0.0.1:9955",
+	}
+)
+
 // BABE flags
 var (
 	BABELeadFlag = cli.BoolFlag{
@@ -410,6 +509,9 @@ var (
 		// keystore flags
 		KeyFlag,
 		UnlockFlag,
+		RemoteSignerFlag,
+		RemoteSignerTokenFlag,
+		SessionKeysPasswordFlag,
 
 		// network flags
 		PortFlag,
@@ -418,6 +520,7 @@ var (
 		RolesFlag,
 		NoBootstrapFlag,
 		NoMDNSFlag,
+		ReservedOnlyFlag,
 		PublicIPFlag,
 		PublicDNSFlag,
 
@@ -445,6 +548,9 @@ var (
 
 		// BABE flags
 		BABELeadFlag,
+
+		// sync flags
+		WarpSyncFlag,
 	}
 )
 
@@ -479,12 +585,25 @@ var (
 		PasswordFlag,
 		ImportFlag,
 		ImportRawFlag,
+		ImportSecretURIFlag,
+		ImportJSONFlag,
+		ExportJSONFlag,
+		MigrateFlag,
 		ListFlag,
 		Ed25519Flag,
 		Sr25519Flag,
 		Secp256k1Flag,
 	}, GlobalFlags...)
 
+	// SignerFlags are the flags that are valid for use with the signer subcommand
+	SignerFlags = append([]cli.Flag{
+		KeyFlag,
+		UnlockFlag,
+		PasswordFlag,
+		RemoteSignerTokenFlag,
+		SignerAddressFlag,
+	}, GlobalFlags...)
+
 	ImportStateFlags = []cli.Flag{
 		BasePathFlag,
 		ChainFlag,
@@ -494,6 +613,26 @@ var (
 		FirstSlotFlag,
 	}
 
+	ExecuteBlockFlags = []cli.Flag{
+		BlockFlag,
+		ProofFlag,
+		StateRootFlag,
+	}
+
+	// ExportBlocksFlags are the flags that are valid for use with the export-blocks subcommand
+	ExportBlocksFlags = append([]cli.Flag{
+		FromBlockFlag,
+		ToBlockFlag,
+		BlocksOutputFlag,
+		BlocksJSONFlag,
+	}, GlobalFlags...)
+
+	// ImportBlocksFlags are the flags that are valid for use with the import-blocks subcommand
+	ImportBlocksFlags = append([]cli.Flag{
+		BlocksInputFlag,
+		BlocksJSONFlag,
+	}, GlobalFlags...)
+
 	PruningFlags = []cli.Flag{
 		ChainFlag,
 		ConfigFlag,
@@ -11,6 +11,7 @@ import (
 	"github.com/ChainSafe/gossamer/dot"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/utils"
 	"github.com/urfave/cli"
@@ -24,6 +25,10 @@ const (
 	importRuntimeCommandName = "import-runtime"
 	importStateCommandName   = "import-state"
 	pruningStateCommandName  = "prune-state"
+	executeBlockCommandName  = "execute-block"
+	signerCommandName        = "signer"
+	exportBlocksCommandName  = "export-blocks"
+	importBlocksCommandName  = "import-blocks"
 )
 
 // app is the cli application
@@ -110,6 +115,58 @@ var (
 			"\tUsage: gossamer import-state --state state.json --header header.json --first-slot <first slot of network>\n",
 	}
 
+	executeBlockCommand = cli.Command{
+		Action:    FixFlagOrder(executeBlockAction),
+		Name:      executeBlockCommandName,
+		Usage:     "Execute a block against the state proven by a storage proof, without any database",
+		ArgsUsage: "",
+		Flags:     ExecuteBlockFlags,
+		Category:  "EXECUTE-BLOCK",
+		Description: "The execute-block command executes a block against the partial state rebuilt " +
+			"from a storage proof of the state of its parent block.\n" +
+			"The execution fails if the runtime accesses storage not covered by the proof.\n" +
+			"\tUsage: gossamer execute-block --block block.hex --proof proof.json --state-root <parent state root>\n",
+	}
+
+	signerCommand = cli.Command{
+		Action:    FixFlagOrder(signerAction),
+		Name:      signerCommandName,
+		Usage:     "Serve the validator keys of the keystore to nodes started with --remote-signer",
+		ArgsUsage: "",
+		Flags:     SignerFlags,
+		Category:  "SIGNER",
+		Description: "The signer command starts a signing server holding the validator keys, so that they are " +
+			"kept out of the node process.\n" +
+			"Requests must hold the token, and messages which would equivocate are refused.\n" +
+			"\tUsage: gossamer signer --unlock 0,1 --remote-signer-token <token> --address 127.0.0.1:9955\n",
+	}
+
+	exportBlocksCommand = cli.Command{
+		Action:    FixFlagOrder(exportBlocksAction),
+		Name:      exportBlocksCommandName,
+		Usage:     "Export the blocks of the canonical chain with their justifications to a file",
+		ArgsUsage: "",
+		Flags:     ExportBlocksFlags,
+		Category:  "EXPORT-BLOCKS",
+		Description: "The export-blocks command writes the blocks of the canonical chain of the node database to a " +
+			"file, which can be imported by the import-blocks command.\n" +
+			"The blocks are SCALE encoded, or written as a JSON array with --json.\n" +
+			"\tUsage: gossamer export-blocks --from 1 --to 1000 --output blocks.bin\n",
+	}
+
+	importBlocksCommand = cli.Command{
+		Action:    FixFlagOrder(importBlocksAction),
+		Name:      importBlocksCommandName,
+		Usage:     "Verify and import the blocks of a file written by export-blocks",
+		ArgsUsage: "",
+		Flags:     ImportBlocksFlags,
+		Category:  "IMPORT-BLOCKS",
+		Description: "The import-blocks command verifies and imports the blocks of a file written by the " +
+			"export-blocks command into the node database, as if they were received from peers.\n" +
+			"Blocks which have already been imported are skipped.\n" +
+			"\tUsage: gossamer import-blocks --input blocks.bin\n",
+	}
+
 	pruningCommand = cli.Command{
 		Action:    FixFlagOrder(pruneState),
 		Name:      pruningStateCommandName,
@@ -138,6 +195,10 @@ func init() {
 		buildSpecCommand,
 		importRuntimeCommand,
 		importStateCommand,
+		executeBlockCommand,
+		signerCommand,
+		exportBlocksCommand,
+		importBlocksCommand,
 		pruningCommand,
 	}
 	app.Flags = RootFlags
@@ -179,6 +240,87 @@ func importStateAction(ctx *cli.Context) error {
 	return dot.ImportState(cfg.Global.BasePath, stateFP, headerFP, uint64(firstSlot))
 }
 
+// executeBlockAction executes a block against the state rebuilt from a storage proof
+func executeBlockAction(ctx *cli.Context) error {
+	var blockFP, proofFP, stateRoot string
+
+	if blockFP = ctx.String(BlockFlag.Name); blockFP == "" {
+		return errors.New("must provide argument to --block")
+	}
+
+	if proofFP = ctx.String(ProofFlag.Name); proofFP == "" {
+		return errors.New("must provide argument to --proof")
+	}
+
+	if stateRoot = ctx.String(StateRootFlag.Name); stateRoot == "" {
+		return errors.New("must provide argument to --state-root")
+	}
+
+	root, err := common.HexToHash(stateRoot)
+	if err != nil {
+		return fmt.Errorf("invalid state root: %w", err)
+	}
+
+	hash, newRoot, err := executeBlockFromFiles(blockFP, proofFP, root)
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("executed block %s, resulting state root is %s", hash, newRoot)
+	return nil
+}
+
+// exportBlocksAction exports the blocks of the canonical chain to a block file
+func exportBlocksAction(ctx *cli.Context) error {
+	output := ctx.String(BlocksOutputFlag.Name)
+	if output == "" {
+		return errors.New("must provide argument to --output")
+	}
+
+	cfg, err := createDotConfig(ctx)
+	if err != nil {
+		logger.Errorf("failed to create node configuration: %s", err)
+		return err
+	}
+
+	// the blocks are exported up to the best block unless --to is set, which may be 0 to export the genesis block
+	var to *uint64
+	if ctx.IsSet(ToBlockFlag.Name) {
+		number := ctx.Uint64(ToBlockFlag.Name)
+		to = &number
+	}
+
+	count, err := dot.ExportBlocks(cfg, ctx.Uint64(FromBlockFlag.Name), to, output, ctx.Bool(BlocksJSONFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to export blocks: %w", err)
+	}
+
+	logger.Infof("exported %d blocks to %s", count, output)
+	return nil
+}
+
+// importBlocksAction verifies and imports the blocks of a block file
+func importBlocksAction(ctx *cli.Context) error {
+	input := ctx.String(BlocksInputFlag.Name)
+	if input == "" {
+		return errors.New("must provide argument to --input")
+	}
+
+	cfg, err := createDotConfig(ctx)
+	if err != nil {
+		logger.Errorf("failed to create node configuration: %s", err)
+		return err
+	}
+
+	count, err := dot.ImportBlocks(cfg, input, ctx.Bool(BlocksJSONFlag.Name))
+	if err != nil {
+		return fmt.Errorf("failed to import blocks: %w", err)
+	}
+
+	logger.Infof("imported %d blocks from %s", count, input)
+	return nil
+}
+
 // importRuntimeAction generates a genesis file given a .wasm runtime binary.
 func importRuntimeAction(ctx *cli.Context) error {
 	arguments := ctx.Args()
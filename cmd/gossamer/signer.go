@@ -0,0 +1,79 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/signer"
+
+	"github.com/urfave/cli"
+)
+
+// signerProtectionFile is the file of the base path the signing server persists its double-sign protection to
+const signerProtectionFile = "signer_protection.json"
+
+// signerAction is the action for the "signer" subcommand, which serves the validator keys of the keystore to
+// nodes started with --remote-signer
+func signerAction(ctx *cli.Context) error {
+	cfg, err := createDotConfig(ctx)
+	if err != nil {
+		logger.Errorf("failed to create dot configuration: %s", err)
+		return err
+	}
+
+	token := ctx.String(RemoteSignerTokenFlag.Name)
+	if token == "" {
+		return errors.New("must provide a token with --remote-signer-token")
+	}
+
+	ks, err := loadSignerKeystore(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	guard, err := signer.NewEquivocationGuard(filepath.Join(cfg.Global.BasePath, signerProtectionFile))
+	if err != nil {
+		return err
+	}
+
+	srv, err := signer.NewServer(signer.NewLocal(ks), token, guard)
+	if err != nil {
+		return err
+	}
+
+	addr := ctx.String(SignerAddressFlag.Name)
+	logger.Info("starting signing server on " + addr + "...")
+
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           srv,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return httpServer.ListenAndServe()
+}
+
+// loadSignerKeystore loads the test keys and unlocks the keys of the base path the signing server signs with
+func loadSignerKeystore(ctx *cli.Context, cfg *dot.Config) (*keystore.GlobalKeystore, error) {
+	ks := keystore.NewGlobalKeystore()
+	for _, named := range []keystore.Keystore{ks.Acco, ks.Babe, ks.Gran} {
+		err := keystore.LoadKeystore(cfg.Account.Key, named)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s keystore: %w", named.Name(), err)
+		}
+
+		err = unlockKeystore(named, cfg.Global.BasePath, cfg.Account.Unlock, ctx.String(PasswordFlag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to unlock %s keystore: %w", named.Name(), err)
+		}
+	}
+
+	return ks, nil
+}
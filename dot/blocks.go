@@ -0,0 +1,477 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/rpc/modules"
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/sync"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/signer"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/libp2p/go-libp2p-core/protocol"
+)
+
+// blockRecord is a block of a block file with its justification, if any. The binary block file is the number of
+// blocks as a little endian uint64, followed by the SCALE encoded records.
+type blockRecord struct {
+	Block         types.Block
+	Justification *[]byte
+}
+
+// jsonBlockRecord is a block of a JSON block file, which is a JSON array of records
+type jsonBlockRecord struct {
+	Block         modules.ChainBlock `json:"block"`
+	Justification string             `json:"justification,omitempty"`
+}
+
+// ExportBlocks writes the blocks of the canonical chain from number `from` to number `to`, with their
+// justifications, to the output file. The blocks are written up to the best block if `to` is nil. The file holds the
+// SCALE encoded blocks, or a JSON array of the blocks if asJSON is set. It returns the number of blocks written.
+func ExportBlocks(cfg *Config, from uint64, to *uint64, output string, asJSON bool) (uint64, error) {
+	if !NodeInitialized(cfg.Global.BasePath) {
+		return 0, fmt.Errorf("node is not initialised at base path %s", cfg.Global.BasePath)
+	}
+
+	st, err := createStateService(cfg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create state service: %w", err)
+	}
+	defer st.Stop() //nolint:errcheck
+
+	if to == nil {
+		best, err := st.Block.BestBlockNumber()
+		if err != nil {
+			return 0, err
+		}
+
+		bestNumber := best.Uint64()
+		to = &bestNumber
+	}
+
+	if from > *to {
+		return 0, fmt.Errorf("first block %d is after last block %d", from, *to)
+	}
+
+	f, err := os.Create(filepath.Clean(output))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close() //nolint:errcheck
+
+	w := bufio.NewWriter(f)
+	err = writeBlocks(w, st.Block, from, *to, asJSON)
+	if err != nil {
+		return 0, err
+	}
+
+	err = w.Flush()
+	if err != nil {
+		return 0, err
+	}
+
+	return *to - from + 1, f.Close()
+}
+
+func writeBlocks(w io.Writer, bs *state.BlockState, from, to uint64, asJSON bool) error {
+	if asJSON {
+		_, err := io.WriteString(w, "[\n")
+		if err != nil {
+			return err
+		}
+	} else {
+		err := binary.Write(w, binary.LittleEndian, to-from+1)
+		if err != nil {
+			return err
+		}
+	}
+
+	for num := from; num <= to; num++ {
+		block, err := bs.GetBlockByNumber(new(big.Int).SetUint64(num))
+		if err != nil {
+			return fmt.Errorf("failed to get block %d: %w", num, err)
+		}
+
+		has, err := bs.HasJustification(block.Header.Hash())
+		if err != nil {
+			return err
+		}
+
+		var justification []byte
+		if has {
+			justification, err = bs.GetJustification(block.Header.Hash())
+			if err != nil {
+				return fmt.Errorf("failed to get justification of block %d: %w", num, err)
+			}
+		}
+
+		var enc []byte
+		if asJSON {
+			enc, err = encodeJSONBlockRecord(block, justification, num == to)
+		} else {
+			rec := &blockRecord{Block: *block}
+			if has {
+				rec.Justification = &justification
+			}
+			enc, err = scale.Marshal(*rec)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to encode block %d: %w", num, err)
+		}
+
+		_, err = w.Write(enc)
+		if err != nil {
+			return err
+		}
+	}
+
+	if asJSON {
+		_, err := io.WriteString(w, "]\n")
+		return err
+	}
+
+	return nil
+}
+
+func encodeJSONBlockRecord(block *types.Block, justification []byte, last bool) ([]byte, error) {
+	header, err := modules.HeaderToJSON(block.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	exts, err := block.Body.AsEncodedExtrinsics()
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &jsonBlockRecord{
+		Block: modules.ChainBlock{
+			Header: header,
+			Body:   make([]string, len(exts)),
+		},
+	}
+
+	for i, ext := range exts {
+		rec.Block.Body[i] = ext.String()
+	}
+
+	if justification != nil {
+		rec.Justification = common.BytesToHex(justification)
+	}
+
+	enc, err := json.Marshal(rec)
+	if err != nil {
+		return nil, err
+	}
+
+	if !last {
+		enc = append(enc, ',')
+	}
+
+	return append(enc, '\n'), nil
+}
+
+// ImportBlocks imports the blocks of the block file written by ExportBlocks, whose format is JSON if asJSON is
+// set. The blocks are verified and imported as if they were received from peers. Blocks which have already been
+// imported are skipped. It returns the number of blocks read.
+func ImportBlocks(cfg *Config, input string, asJSON bool) (uint64, error) {
+	if !NodeInitialized(cfg.Global.BasePath) {
+		return 0, fmt.Errorf("node is not initialised at base path %s", cfg.Global.BasePath)
+	}
+
+	f, err := os.Open(filepath.Clean(input))
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close() //nolint:errcheck
+
+	st, err := createStateService(cfg)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create state service: %w", err)
+	}
+	defer st.Stop() //nolint:errcheck
+
+	// the blocks are imported as a full node, which does not sign
+	bi, stop, err := createBlockImporter(cfg, st, keystore.NewGlobalKeystore())
+	if err != nil {
+		return 0, err
+	}
+	defer stop()
+
+	var r blockReader
+	if asJSON {
+		r, err = newJSONBlockReader(bufio.NewReader(f))
+	} else {
+		r, err = newSCALEBlockReader(bufio.NewReader(f))
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read block file: %w", err)
+	}
+
+	var count uint64
+	for {
+		block, justification, err := r.next()
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		}
+		if err != nil {
+			return count, fmt.Errorf("failed to read block %d of block file: %w", count, err)
+		}
+
+		bd := &types.BlockData{
+			Hash:          block.Header.Hash(),
+			Header:        &block.Header,
+			Body:          &block.Body,
+			Justification: justification,
+		}
+
+		err = bi.ImportBlock(bd)
+		if err != nil {
+			return count, fmt.Errorf("failed to import block number %s with hash %s: %w",
+				block.Header.Number, bd.Hash, err)
+		}
+
+		count++
+		logger.Debugf("imported block number %s with hash %s", block.Header.Number, bd.Hash)
+	}
+}
+
+// createBlockImporter creates the services verifying and importing blocks, without network. The returned function
+// stops the services.
+func createBlockImporter(cfg *Config, st *state.Service, ks *keystore.GlobalKeystore) (
+	*sync.BlockImporter, func(), error) {
+	ns, err := createRuntimeStorage(st)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sgn := signer.NewLocal(ks)
+	err = loadRuntime(cfg, ns, st, ks, sgn, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ver, err := createBlockVerifier(st)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dh, err := createDigestHandler(st)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	net := offlineNetwork{}
+	cs, err := createCoreService(cfg, ks, st, net, dh)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create core service: %w", err)
+	}
+
+	fgCfg := *cfg
+	fgCfg.Core.GrandpaAuthority = false
+	fg, err := createGRANDPAService(&fgCfg, st, dh, ks.Gran, sgn, net)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bi, err := sync.NewBlockImporter(&sync.Config{
+		LogLvl:             cfg.Log.SyncLvl,
+		BlockState:         st.Block,
+		StorageState:       st.Storage,
+		TransactionState:   st.Transaction,
+		FinalityGadget:     fg,
+		BabeVerifier:       ver,
+		BlockImportHandler: cs,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = dh.Start()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stop := func() {
+		_ = dh.Stop()
+	}
+
+	return bi, stop, nil
+}
+
+// blockReader reads the blocks of a block file. It returns io.EOF after the last block.
+type blockReader interface {
+	next() (*types.Block, *[]byte, error)
+}
+
+type scaleBlockReader struct {
+	decoder   *scale.Decoder
+	remaining uint64
+}
+
+func newSCALEBlockReader(r io.Reader) (*scaleBlockReader, error) {
+	var count uint64
+	err := binary.Read(r, binary.LittleEndian, &count)
+	if err != nil {
+		return nil, err
+	}
+
+	return &scaleBlockReader{
+		decoder:   scale.NewDecoder(r),
+		remaining: count,
+	}, nil
+}
+
+func (r *scaleBlockReader) next() (*types.Block, *[]byte, error) {
+	if r.remaining == 0 {
+		return nil, nil, io.EOF
+	}
+
+	rec := &blockRecord{Block: types.NewEmptyBlock()}
+	err := r.decoder.Decode(rec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r.remaining--
+	return &rec.Block, rec.Justification, nil
+}
+
+type jsonBlockReader struct {
+	decoder *json.Decoder
+}
+
+func newJSONBlockReader(r io.Reader) (*jsonBlockReader, error) {
+	decoder := json.NewDecoder(r)
+	tok, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return nil, errors.New("block file is not a JSON array")
+	}
+
+	return &jsonBlockReader{decoder: decoder}, nil
+}
+
+func (r *jsonBlockReader) next() (*types.Block, *[]byte, error) {
+	if !r.decoder.More() {
+		return nil, nil, io.EOF
+	}
+
+	rec := new(jsonBlockRecord)
+	err := r.decoder.Decode(rec)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header, err := headerFromJSON(&rec.Block.Header)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	exts := make([][]byte, len(rec.Block.Body))
+	for i, ext := range rec.Block.Body {
+		exts[i], err = common.HexToBytes(ext)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid extrinsic: %w", err)
+		}
+	}
+
+	body, err := types.NewBodyFromEncodedBytes(exts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	block := types.NewBlock(*header, *body)
+	if rec.Justification == "" {
+		return &block, nil, nil
+	}
+
+	justification, err := common.HexToBytes(rec.Justification)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid justification: %w", err)
+	}
+
+	return &block, &justification, nil
+}
+
+// headerFromJSON returns the header of its JSON representation returned by the chain RPC methods
+func headerFromJSON(res *modules.ChainBlockHeaderResponse) (*types.Header, error) {
+	parentHash, err := common.HexToHash(res.ParentHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid parent hash: %w", err)
+	}
+
+	number, err := common.HexToBytes(res.Number)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number: %w", err)
+	}
+
+	stateRoot, err := common.HexToHash(res.StateRoot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid state root: %w", err)
+	}
+
+	extrinsicsRoot, err := common.HexToHash(res.ExtrinsicsRoot)
+	if err != nil {
+		return nil, fmt.Errorf("invalid extrinsics root: %w", err)
+	}
+
+	digest := types.NewDigest()
+	for _, log := range res.Digest.Logs {
+		enc, err := common.HexToBytes(log)
+		if err != nil {
+			return nil, fmt.Errorf("invalid digest item: %w", err)
+		}
+
+		item := types.NewDigestItem()
+		err = scale.Unmarshal(enc, &item)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode digest item: %w", err)
+		}
+
+		err = digest.Add(item.Value())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return types.NewHeader(parentHash, stateRoot, extrinsicsRoot, new(big.Int).SetBytes(number), digest)
+}
+
+// offlineNetwork is the network of the services importing blocks from a block file, which do not communicate
+// with peers
+type offlineNetwork struct{}
+
+func (offlineNetwork) GossipMessage(network.NotificationsMessage) {}
+
+func (offlineNetwork) SendMessage(peer.ID, network.NotificationsMessage) error { return nil }
+
+func (offlineNetwork) IsSynced() bool { return true }
+
+func (offlineNetwork) ReportPeer(peerset.ReputationChange, peer.ID) {}
+
+func (offlineNetwork) RegisterNotificationsProtocol(protocol.ID, byte, network.HandshakeGetter,
+	network.HandshakeDecoder, network.HandshakeValidator, network.MessageDecoder,
+	network.NotificationsMessageHandler, network.NotificationsMessageBatchHandler) error {
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBlockFileState(t *testing.T) (*state.BlockState, []*types.Header) {
+	genesis := &types.Header{
+		Number:    big.NewInt(0),
+		StateRoot: trie.EmptyHash,
+		Digest:    types.NewDigest(),
+	}
+
+	bs, err := state.NewBlockStateFromGenesis(state.NewInMemoryDB(t), genesis)
+	require.NoError(t, err)
+
+	headers, _ := state.AddBlocksToState(t, bs, 3, false)
+	err = bs.SetJustification(headers[1].Hash(), []byte("justification"))
+	require.NoError(t, err)
+
+	return bs, headers
+}
+
+func readTestBlocks(t *testing.T, r blockReader) ([]*types.Block, []*[]byte) {
+	var (
+		blocks         []*types.Block
+		justifications []*[]byte
+	)
+
+	for {
+		block, justification, err := r.next()
+		if errors.Is(err, io.EOF) {
+			return blocks, justifications
+		}
+		require.NoError(t, err)
+
+		blocks = append(blocks, block)
+		justifications = append(justifications, justification)
+	}
+}
+
+func TestBlockFile(t *testing.T) {
+	bs, headers := newTestBlockFileState(t)
+
+	for _, asJSON := range []bool{false, true} {
+		buf := new(bytes.Buffer)
+		err := writeBlocks(buf, bs, 2, 3, asJSON)
+		require.NoError(t, err)
+
+		var r blockReader
+		if asJSON {
+			r, err = newJSONBlockReader(buf)
+		} else {
+			r, err = newSCALEBlockReader(buf)
+		}
+		require.NoError(t, err)
+
+		blocks, justifications := readTestBlocks(t, r)
+		require.Len(t, blocks, 2)
+
+		for i, block := range blocks {
+			require.Equal(t, headers[i+1].Hash(), block.Header.Hash())
+
+			expected, err := bs.GetBlockBody(headers[i+1].Hash())
+			require.NoError(t, err)
+			expectedEnc, err := scale.Marshal(*expected)
+			require.NoError(t, err)
+			enc, err := scale.Marshal(block.Body)
+			require.NoError(t, err)
+			require.Equal(t, expectedEnc, enc)
+		}
+
+		require.Equal(t, []byte("justification"), *justifications[0])
+		require.Nil(t, justifications[1])
+	}
+}
+
+func TestNewJSONBlockReader_NotArray(t *testing.T) {
+	_, err := newJSONBlockReader(bytes.NewBufferString(`{"block":{}}`))
+	require.Error(t, err)
+}
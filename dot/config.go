@@ -69,8 +69,12 @@ type InitConfig struct {
 
 // AccountConfig is to marshal/unmarshal account config vars
 type AccountConfig struct {
-	Key    string
-	Unlock string // TODO: change to []int (#1849)
+	Key               string
+	Unlock            string // TODO: change to []int (#1849)
+	RemoteSigner      string // URL of the signing server, the keys of the keystore are used if empty
+	RemoteSignerToken string
+	// SessionKeysPassword encrypts the session keys generated by the runtime, they are not persisted if empty
+	SessionKeysPassword string
 }
 
 // NetworkConfig is to marshal/unmarshal toml network config vars
@@ -83,6 +87,7 @@ type NetworkConfig struct {
 	MinPeers          int
 	MaxPeers          int
 	PersistentPeers   []string
+	ReservedOnly      bool
 	DiscoveryInterval time.Duration
 	PublicIP          string
 	PublicDNS         string
@@ -96,6 +101,7 @@ type CoreConfig struct {
 	GrandpaAuthority bool
 	WasmInterpreter  string
 	GrandpaInterval  time.Duration
+	WarpSync         bool
 }
 
 // RPCConfig is to marshal/unmarshal toml RPC config vars
@@ -45,8 +45,10 @@ type InitConfig struct {
 
 // AccountConfig is to marshal/unmarshal account config vars
 type AccountConfig struct {
-	Key    string `toml:"key,omitempty"`
-	Unlock string `toml:"unlock,omitempty"`
+	Key               string `toml:"key,omitempty"`
+	Unlock            string `toml:"unlock,omitempty"`
+	RemoteSigner      string `toml:"remote-signer,omitempty"`
+	RemoteSignerToken string `toml:"remote-signer-token,omitempty"`
 }
 
 // NetworkConfig is to marshal/unmarshal toml network config vars
@@ -59,6 +61,7 @@ type NetworkConfig struct {
 	MinPeers          int      `toml:"min-peers,omitempty"`
 	MaxPeers          int      `toml:"max-peers,omitempty"`
 	PersistentPeers   []string `toml:"persistent-peers,omitempty"`
+	ReservedOnly      bool     `toml:"reserved-only,omitempty"`
 	DiscoveryInterval int      `toml:"discovery-interval,omitempty"`
 	PublicIP          string   `toml:"public-ip,omitempty"`
 	PublicDNS         string   `toml:"public-dns,omitempty"`
@@ -74,6 +77,7 @@ type CoreConfig struct {
 	WasmInterpreter  string `toml:"wasm-interpreter,omitempty"`
 	GrandpaInterval  uint32 `toml:"grandpa-interval,omitempty"`
 	BABELead         bool   `toml:"babe-lead,omitempty"`
+	WarpSync         bool   `toml:"warp-sync,omitempty"`
 }
 
 // RPCConfig is to marshal/unmarshal toml RPC config vars
@@ -0,0 +1,206 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/cht"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+)
+
+var (
+	errInvalidChildStorageKey = errors.New("child storage key must start with the child storage prefix")
+	errCHTNotFinalised        = errors.New("the CHT of the requested block is not finalised yet")
+)
+
+// chtCacheSize is the number of CHTs kept in memory to answer header requests
+const chtCacheSize = 8
+
+// chtCache holds the most recently used CHTs by CHT number. CHTs only cover finalised blocks, so a cached CHT
+// never changes. The lock must be held while using the cache or a cached CHT, since generating a proof
+// encodes the trie nodes.
+type chtCache struct {
+	sync.Mutex
+	tries map[uint32]*trie.Trie
+	// order holds the cached CHT numbers from the least to the most recently used
+	order []uint32
+}
+
+func newCHTCache() *chtCache {
+	return &chtCache{
+		tries: make(map[uint32]*trie.Trie),
+	}
+}
+
+func (c *chtCache) get(chtNumber uint32) *trie.Trie {
+	t, has := c.tries[chtNumber]
+	if has {
+		c.touch(chtNumber)
+	}
+
+	return t
+}
+
+func (c *chtCache) put(chtNumber uint32, t *trie.Trie) {
+	if _, has := c.tries[chtNumber]; !has && len(c.order) == chtCacheSize {
+		delete(c.tries, c.order[0])
+		c.order = c.order[1:]
+	}
+
+	c.tries[chtNumber] = t
+	c.touch(chtNumber)
+}
+
+// touch moves the CHT number to the end of order
+func (c *chtCache) touch(chtNumber uint32) {
+	for i, n := range c.order {
+		if n == chtNumber {
+			c.order = append(c.order[:i], c.order[i+1:]...)
+			break
+		}
+	}
+
+	c.order = append(c.order, chtNumber)
+}
+
+// GenerateCallProof executes the given runtime function against the state of the given block and
+// returns the proof of the storage accessed by the call, including the runtime code and the storage
+// of child tries.
+// The state changes made by the call are discarded.
+func (s *Service) GenerateCallProof(block common.Hash, method string, data []byte) ([][]byte, error) {
+	stateRoot, err := s.blockState.GetBlockStateRoot(block)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := s.storageState.TrieState(&stateRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	rt, err := s.blockState.GetRuntime(&block)
+	if err != nil {
+		return nil, err
+	}
+
+	ts.EnableRecording()
+	// the runtime code is needed to replay the call against the proof
+	ts.LoadCode()
+
+	rt.SetContextStorage(ts)
+	if _, err = rt.Exec(method, data); err != nil {
+		return nil, fmt.Errorf("failed to execute %s: %w", method, err)
+	}
+
+	return ts.RecordedProof()
+}
+
+// GenerateChildReadProof returns the proof of the given keys of the child trie stored at the given
+// storage key, which must include the child storage prefix, in the state of the given block.
+// The proof holds the nodes of both the main trie and the child trie.
+func (s *Service) GenerateChildReadProof(block common.Hash, storageKey []byte, keys [][]byte) ([][]byte, error) {
+	if !bytes.HasPrefix(storageKey, trie.ChildStorageKeyPrefix) {
+		return nil, errInvalidChildStorageKey
+	}
+
+	stateRoot, err := s.blockState.GetBlockStateRoot(block)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := s.storageState.GenerateTrieProof(stateRoot, [][]byte{storageKey})
+	if err != nil {
+		return nil, err
+	}
+
+	child, err := s.storageState.GetStorageChild(&stateRoot, storageKey[len(trie.ChildStorageKeyPrefix):])
+	if err != nil {
+		return nil, err
+	}
+
+	childProof, err := trie.GenerateProofFromTrie(child, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(proof, childProof...), nil
+}
+
+// GenerateHeaderProof returns the canonical header at the given number and the proof of its hash in
+// the CHT holding it. The CHT must only hold finalised blocks.
+func (s *Service) GenerateHeaderProof(number uint32) (*types.Header, [][]byte, error) {
+	chtNumber, err := cht.Number(number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	finalised, err := s.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, last := cht.BlockRange(chtNumber)
+	if finalised.Number.Cmp(big.NewInt(int64(last))) < 0 {
+		return nil, nil, errCHTNotFinalised
+	}
+
+	s.chts.Lock()
+	defer s.chts.Unlock()
+
+	t, err := s.buildCHT(chtNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	proof, err := cht.GenerateProof(t, number)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hash, err := s.blockState.GetHashByNumber(big.NewInt(int64(number)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header, err := s.blockState.GetHeader(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return header, proof, nil
+}
+
+// buildCHT returns the CHT of the given number, which must be finalised, building it from the canonical
+// block hashes if it isn't cached. The lock of the CHT cache must be held.
+func (s *Service) buildCHT(chtNumber uint32) (*trie.Trie, error) {
+	if t := s.chts.get(chtNumber); t != nil {
+		return t, nil
+	}
+
+	first, last := cht.BlockRange(chtNumber)
+	hashes := make([]common.Hash, 0, cht.Size)
+	for n := first; n <= last; n++ {
+		hash, err := s.blockState.GetHashByNumber(big.NewInt(int64(n)))
+		if err != nil {
+			return nil, err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	t, err := cht.BuildTrie(chtNumber, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	s.chts.put(chtNumber, t)
+	return t, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/core/mocks"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/cht"
+	"github.com/ChainSafe/gossamer/lib/common"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateHeaderProof(t *testing.T) {
+	hashByNumber := func(num *big.Int) common.Hash {
+		return common.BytesToHash(num.Bytes())
+	}
+
+	header := types.NewEmptyHeader()
+	header.Number = big.NewInt(3)
+
+	blockState := new(mocks.BlockState)
+	blockState.On("GetHighestFinalisedHeader").Return(&types.Header{Number: big.NewInt(cht.Size)}, nil)
+	blockState.On("GetHashByNumber", mock.AnythingOfType("*big.Int")).Return(hashByNumber, nil)
+	blockState.On("GetHeader", hashByNumber(big.NewInt(3))).Return(header, nil)
+	blockState.On("GetHeader", hashByNumber(big.NewInt(4))).Return(header, nil)
+
+	s := &Service{
+		blockState: blockState,
+		chts:       newCHTCache(),
+	}
+
+	hashes := make([]common.Hash, cht.Size)
+	for i := range hashes {
+		hashes[i] = hashByNumber(big.NewInt(int64(i + 1)))
+	}
+	expected, err := cht.BuildTrie(0, hashes)
+	require.NoError(t, err)
+
+	res, proof, err := s.GenerateHeaderProof(3)
+	require.NoError(t, err)
+	require.Equal(t, header, res)
+	require.NoError(t, cht.VerifyProof(expected.MustHash(), 3, hashes[2], proof))
+	// the CHT is built from the canonical hash of each block it covers, and the hash of the requested block
+	blockState.AssertNumberOfCalls(t, "GetHashByNumber", cht.Size+1)
+
+	// the cached CHT is used for the next requests
+	_, proof, err = s.GenerateHeaderProof(4)
+	require.NoError(t, err)
+	require.NoError(t, cht.VerifyProof(expected.MustHash(), 4, hashes[3], proof))
+	blockState.AssertNumberOfCalls(t, "GetHashByNumber", cht.Size+2)
+
+	_, _, err = s.GenerateHeaderProof(cht.Size + 1)
+	require.ErrorIs(t, err, errCHTNotFinalised)
+}
+
+func TestCHTCache(t *testing.T) {
+	c := newCHTCache()
+	for n := uint32(0); n < chtCacheSize; n++ {
+		c.put(n, nil)
+	}
+
+	// using the oldest CHT keeps it over the next oldest one
+	c.get(0)
+	c.put(chtCacheSize, nil)
+
+	require.Len(t, c.tries, chtCacheSize)
+	require.Contains(t, c.tries, uint32(0))
+	require.NotContains(t, c.tries, uint32(1))
+	require.Equal(t, uint32(chtCacheSize), c.order[len(c.order)-1])
+}
@@ -36,11 +36,17 @@ func (s *Service) HandleTransactionMessage(peerID peer.ID, msg *network.Transact
 	}
 
 	hash := head.Hash()
-	rt, err := s.blockState.GetRuntime(&hash)
+	pool, err := s.blockState.GetRuntimePool(&hash)
 	if err != nil {
 		return false, err
 	}
 
+	rt, err := pool.Get()
+	if err != nil {
+		return false, err
+	}
+	defer pool.Put(rt)
+
 	for _, tx := range txs {
 		err = func() error {
 			s.storageState.Lock()
@@ -55,7 +61,7 @@ func (s *Service) HandleTransactionMessage(peerID peer.ID, msg *network.Transact
 
 			// validate each transaction
 			externalExt := types.Extrinsic(append([]byte{byte(types.TxnExternal)}, tx...))
-			val, err := rt.ValidateTransaction(externalExt)
+			val, err := rt.ValidateTransaction(externalExt, hash)
 			if err != nil {
 				if errors.Is(err, runtime.ErrInvalidTransaction) {
 					s.net.ReportPeer(peerset.ReputationChange{
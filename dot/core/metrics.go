@@ -0,0 +1,21 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"github.com/ChainSafe/gossamer/dot/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	blockHeight = metrics.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "block_height",
+		Help: "Block height info of the chain",
+	}, []string{"status"})
+
+	importedBlocks = metrics.NewCounterVec(prometheus.CounterOpts{
+		Name: "import_queue_processed_total",
+		Help: "Blocks processed by the block import handler",
+	}, []string{"result"})
+)
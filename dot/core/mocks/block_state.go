@@ -316,6 +316,75 @@ func (_m *BlockState) GetFinalisedNotifierChannel() chan *types.FinalisationInfo
 	return r0
 }
 
+// GetHashByNumber provides a mock function with given fields: num
+func (_m *BlockState) GetHashByNumber(num *big.Int) (common.Hash, error) {
+	ret := _m.Called(num)
+
+	var r0 common.Hash
+	if rf, ok := ret.Get(0).(func(*big.Int) common.Hash); ok {
+		r0 = rf(num)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(common.Hash)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*big.Int) error); ok {
+		r1 = rf(num)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHeader provides a mock function with given fields: _a0
+func (_m *BlockState) GetHeader(_a0 common.Hash) (*types.Header, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *types.Header
+	if rf, ok := ret.Get(0).(func(common.Hash) *types.Header); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Header)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(common.Hash) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetHighestFinalisedHeader provides a mock function with given fields:
+func (_m *BlockState) GetHighestFinalisedHeader() (*types.Header, error) {
+	ret := _m.Called()
+
+	var r0 *types.Header
+	if rf, ok := ret.Get(0).(func() *types.Header); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Header)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetImportedBlockNotifierChannel provides a mock function with given fields:
 func (_m *BlockState) GetImportedBlockNotifierChannel() chan *types.Block {
 	ret := _m.Called()
@@ -355,6 +424,29 @@ func (_m *BlockState) GetRuntime(_a0 *common.Hash) (runtime.Instance, error) {
 	return r0, r1
 }
 
+// GetRuntimePool provides a mock function with given fields: _a0
+func (_m *BlockState) GetRuntimePool(_a0 *common.Hash) (*runtime.InstancePool, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *runtime.InstancePool
+	if rf, ok := ret.Get(0).(func(*common.Hash) *runtime.InstancePool); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*runtime.InstancePool)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*common.Hash) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSlotForBlock provides a mock function with given fields: _a0
 func (_m *BlockState) GetSlotForBlock(_a0 common.Hash) (uint64, error) {
 	ret := _m.Called(_a0)
@@ -9,6 +9,8 @@ import (
 
 	storage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 
+	trie "github.com/ChainSafe/gossamer/lib/trie"
+
 	types "github.com/ChainSafe/gossamer/dot/types"
 )
 
@@ -86,6 +88,29 @@ func (_m *StorageState) GetStorage(root *common.Hash, key []byte) ([]byte, error
 	return r0, r1
 }
 
+// GetStorageChild provides a mock function with given fields: root, keyToChild
+func (_m *StorageState) GetStorageChild(root *common.Hash, keyToChild []byte) (*trie.Trie, error) {
+	ret := _m.Called(root, keyToChild)
+
+	var r0 *trie.Trie
+	if rf, ok := ret.Get(0).(func(*common.Hash, []byte) *trie.Trie); ok {
+		r0 = rf(root, keyToChild)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*trie.Trie)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*common.Hash, []byte) error); ok {
+		r1 = rf(root, keyToChild)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // LoadCode provides a mock function with given fields: root
 func (_m *StorageState) LoadCode(root *common.Hash) ([]byte, error) {
 	ret := _m.Called(root)
@@ -41,6 +41,8 @@ type Service struct {
 	blockAddCh chan *types.Block // for asynchronous block handling
 	sync.Mutex                   // lock for channel
 
+	finalisedCh chan *types.FinalisationInfo
+
 	// Service interfaces
 	blockState       BlockState
 	epochState       EpochState
@@ -54,7 +56,12 @@ type Service struct {
 	codeSubstitutedState CodeSubstitutedState
 
 	// Keystore
-	keys *keystore.GlobalKeystore
+	keys             *keystore.GlobalKeystore
+	keystoreBasePath string
+	keystorePassword []byte
+
+	// chts caches the CHTs used to answer the header requests of light clients
+	chts *chtCache
 }
 
 // Config holds the configuration for the core Service.
@@ -67,6 +74,11 @@ type Config struct {
 	TransactionState TransactionState
 	Network          Network
 	Keystore         *keystore.GlobalKeystore
+	// KeystoreBasePath is the base path of the keystore directory session keys generated
+	// by the runtime are persisted to. If it is empty, generated keys are not persisted.
+	KeystoreBasePath string
+	// KeystorePassword is the password the persisted session keys are encrypted with.
+	KeystorePassword []byte
 	Runtime          runtime.Instance
 	DigestHandler    DigestHandler
 
@@ -110,6 +122,8 @@ func NewService(cfg *Config) (*Service, error) {
 		ctx:                  ctx,
 		cancel:               cancel,
 		keys:                 cfg.Keystore,
+		keystoreBasePath:     cfg.KeystoreBasePath,
+		keystorePassword:     cfg.KeystorePassword,
 		blockState:           cfg.BlockState,
 		epochState:           cfg.EpochState,
 		storageState:         cfg.StorageState,
@@ -119,6 +133,7 @@ func NewService(cfg *Config) (*Service, error) {
 		codeSubstitute:       cfg.CodeSubstitutes,
 		codeSubstitutedState: cfg.CodeSubstitutedState,
 		digestHandler:        cfg.DigestHandler,
+		chts:                 newCHTCache(),
 	}
 
 	return srv, nil
@@ -127,6 +142,9 @@ func NewService(cfg *Config) (*Service, error) {
 // Start starts the core service
 func (s *Service) Start() error {
 	go s.handleBlocksAsync()
+
+	s.finalisedCh = s.blockState.GetFinalisedNotifierChannel()
+	go s.handleFinalisedBlocks()
 	return nil
 }
 
@@ -137,6 +155,10 @@ func (s *Service) Stop() error {
 
 	s.cancel()
 	close(s.blockAddCh)
+
+	if s.finalisedCh != nil {
+		s.blockState.FreeFinalisedNotifierChannel(s.finalisedCh)
+	}
 	return nil
 }
 
@@ -188,13 +210,22 @@ func (s *Service) HandleBlockProduced(block *types.Block, state *rtstorage.TrieS
 	return nil
 }
 
-func (s *Service) handleBlock(block *types.Block, state *rtstorage.TrieState) error {
+func (s *Service) handleBlock(block *types.Block, state *rtstorage.TrieState) (err error) {
 	if block == nil || state == nil {
 		return fmt.Errorf("unable to handle block due to nil parameter")
 	}
 
+	defer func() {
+		if err != nil {
+			importedBlocks.WithLabelValues("failure").Inc()
+			return
+		}
+
+		importedBlocks.WithLabelValues("success").Inc()
+	}()
+
 	// store updates state trie nodes in database
-	err := s.storageState.StoreTrie(state, &block.Header)
+	err = s.storageState.StoreTrie(state, &block.Header)
 	if err != nil {
 		logger.Warnf("failed to store state trie for imported block %s: %s",
 			block.Header.Hash(), err)
@@ -215,6 +246,10 @@ func (s *Service) handleBlock(block *types.Block, state *rtstorage.TrieState) er
 	logger.Debugf("imported block %s and stored state trie with root %s",
 		block.Header.Hash(), state.MustRoot())
 
+	if best, err := s.blockState.BestBlockNumber(); err == nil {
+		blockHeight.WithLabelValues("best").Set(float64(best.Int64()))
+	}
+
 	// handle consensus digests
 	s.digestHandler.HandleDigests(&block.Header)
 
@@ -273,6 +308,7 @@ func (s *Service) handleCodeSubstitution(hash common.Hash, state *rtstorage.Trie
 
 	cfg.Storage = state
 	cfg.Keystore = rt.Keystore()
+	cfg.Signer = rt.Signer()
 	cfg.NodeStorage = rt.NodeStorage()
 	cfg.Network = rt.NetworkService()
 
@@ -321,6 +357,22 @@ func (s *Service) handleBlocksAsync() {
 	}
 }
 
+// handleFinalisedBlocks updates the finalised block height metric as blocks are finalised
+func (s *Service) handleFinalisedBlocks() {
+	if header, err := s.blockState.GetHighestFinalisedHeader(); err == nil {
+		blockHeight.WithLabelValues("finalized").Set(float64(header.Number.Int64()))
+	}
+
+	for {
+		select {
+		case info := <-s.finalisedCh:
+			blockHeight.WithLabelValues("finalized").Set(float64(info.Header.Number.Int64()))
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
 // handleChainReorg checks if there is a chain re-org (ie. new chain head is on a different chain than the
 // previous chain head). If there is a re-org, it moves the transactions that were included on the previous
 // chain back into the transaction pool.
@@ -358,6 +410,8 @@ func (s *Service) handleChainReorg(prev, curr common.Hash) error {
 		return ErrNilRuntime
 	}
 
+	bestHash := s.blockState.BestBlockHash()
+
 	// for each block in the previous chain, re-add its extrinsics back into the pool
 	for _, hash := range subchain {
 		body, err := s.blockState.GetBlockBody(hash)
@@ -384,7 +438,7 @@ func (s *Service) handleChainReorg(prev, curr common.Hash) error {
 			}
 
 			externalExt := types.Extrinsic(append([]byte{byte(types.TxnExternal)}, encExt...))
-			txv, err := rt.ValidateTransaction(externalExt)
+			txv, err := rt.ValidateTransaction(externalExt, bestHash)
 			if err != nil {
 				logger.Debugf("failed to validate transaction for extrinsic %s: %s", ext, err)
 				continue
@@ -399,8 +453,8 @@ func (s *Service) handleChainReorg(prev, curr common.Hash) error {
 }
 
 // maintainTransactionPool removes any transactions that were included in
-// the new block, revalidates the transactions in the pool, and moves
-// them to the queue if valid.
+// the new block, prunes the transactions whose longevity has expired, and moves
+// the transactions whose required tags are now provided from the pool to the queue.
 // See https://github.com/paritytech/substrate/blob/74804b5649eccfb83c90aec87bdca58e5d5c8789/client/transaction-pool/src/lib.rs#L545
 func (s *Service) maintainTransactionPool(block *types.Block) {
 	// remove extrinsics included in a block
@@ -408,29 +462,15 @@ func (s *Service) maintainTransactionPool(block *types.Block) {
 		s.transactionState.RemoveExtrinsic(ext)
 	}
 
-	// re-validate transactions in the pool and move them to the queue
-	txs := s.transactionState.PendingInPool()
-	for _, tx := range txs {
-		// TODO: re-add this, need to update tests (#904)
-		// val, err := s.rt.ValidateTransaction(tx.Extrinsic)
-		// if err != nil {
-		// 	// failed to validate tx, remove it from the pool or queue
-		// 	s.transactionState.RemoveExtrinsic(tx.Extrinsic)
-		// 	continue
-		// }
+	// TODO: re-validate transactions in the pool, need to update tests (#904)
 
-		// tx = transaction.NewValidTransaction(tx.Extrinsic, val)
-
-		h, err := s.transactionState.Push(tx)
-		if err != nil && err == transaction.ErrTransactionExists {
-			// transaction is already in queue, remove it from the pool
-			s.transactionState.RemoveExtrinsicFromPool(tx.Extrinsic)
-			continue
+	if block.Header.Number != nil {
+		for _, h := range s.transactionState.PruneStale(block.Header.Number.Uint64()) {
+			logger.Tracef("pruned stale transaction %s", h)
 		}
-
-		s.transactionState.RemoveExtrinsicFromPool(tx.Extrinsic)
-		logger.Tracef("moved transaction %s to queue", h)
 	}
+
+	s.transactionState.PromoteReady()
 }
 
 // InsertKey inserts keypair into the account keystore
@@ -464,6 +504,68 @@ func (s *Service) DecodeSessionKeys(enc []byte) ([]byte, error) {
 	return rt.DecodeSessionKeys(enc)
 }
 
+// GenerateSessionKeys executes the runtime GenerateSessionKeys at the best block, which generates new
+// session keys into the node's keystore. The generated keys are encrypted with the keystore password and
+// persisted to the keystore directory, and the SCALE encoded public keys are returned. Keys are not generated
+// if they are to be persisted without a password.
+func (s *Service) GenerateSessionKeys() ([]byte, error) {
+	if s.keystoreBasePath != "" && len(s.keystorePassword) == 0 {
+		return nil, fmt.Errorf("cannot persist session keys: %w", keystore.ErrNoSessionKeysPassword)
+	}
+
+	bhash := s.blockState.BestBlockHash()
+	stateRootHash, err := s.storageState.GetStateRootFromBlock(&bhash)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := s.storageState.TrieState(stateRootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	rt, err := s.blockState.GetRuntime(&bhash)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := make(map[string]struct{})
+	for _, ks := range s.keys.Keystores() {
+		for _, pub := range ks.PublicKeys() {
+			existing[string(pub.Encode())] = struct{}{}
+		}
+	}
+
+	rt.SetContextStorage(ts)
+	keys, err := rt.GenerateSessionKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.keystoreBasePath == "" {
+		return keys, nil
+	}
+
+	for _, ks := range s.keys.Keystores() {
+		for _, kp := range ks.Keypairs() {
+			pub := string(kp.Public().Encode())
+			if _, has := existing[pub]; has {
+				continue
+			}
+
+			fp, err := keystore.SaveSessionKey(s.keystoreBasePath, ks.Name(), kp, s.keystorePassword)
+			if err != nil {
+				return nil, fmt.Errorf("cannot persist session key %s: %w", kp.Public().Hex(), err)
+			}
+
+			existing[pub] = struct{}{}
+			logger.Infof("generated session key %s of keystore %s in %s", kp.Public().Hex(), ks.Name(), fp)
+		}
+	}
+
+	return keys, nil
+}
+
 // GetRuntimeVersion gets the current RuntimeVersion
 func (s *Service) GetRuntimeVersion(bhash *common.Hash) (runtime.Version, error) {
 	var stateRootHash *common.Hash
@@ -502,16 +604,22 @@ func (s *Service) HandleSubmittedExtrinsic(ext types.Extrinsic) error {
 		return err
 	}
 
-	rt, err := s.blockState.GetRuntime(nil)
+	pool, err := s.blockState.GetRuntimePool(nil)
 	if err != nil {
 		logger.Critical("failed to get runtime")
 		return err
 	}
 
+	rt, err := pool.Get()
+	if err != nil {
+		return err
+	}
+	defer pool.Put(rt)
+
 	rt.SetContextStorage(ts)
 	// the transaction source is External
 	externalExt := types.Extrinsic(append([]byte{byte(types.TxnExternal)}, ext...))
-	txv, err := rt.ValidateTransaction(externalExt)
+	txv, err := rt.ValidateTransaction(externalExt, s.blockState.BestBlockHash())
 	if err != nil {
 		return err
 	}
@@ -526,7 +634,7 @@ func (s *Service) HandleSubmittedExtrinsic(ext types.Extrinsic) error {
 	return nil
 }
 
-//GetMetadata calls runtime Metadata_metadata function
+// GetMetadata calls runtime Metadata_metadata function
 func (s *Service) GetMetadata(bhash *common.Hash) ([]byte, error) {
 	var (
 		stateRootHash *common.Hash
@@ -545,15 +653,55 @@ func (s *Service) GetMetadata(bhash *common.Hash) ([]byte, error) {
 		return nil, err
 	}
 
-	rt, err := s.blockState.GetRuntime(bhash)
+	pool, err := s.blockState.GetRuntimePool(bhash)
+	if err != nil {
+		return nil, err
+	}
+
+	rt, err := pool.Get()
 	if err != nil {
 		return nil, err
 	}
+	defer pool.Put(rt)
 
 	rt.SetContextStorage(ts)
 	return rt.Metadata()
 }
 
+// CallRuntime executes the given runtime function with the given SCALE encoded parameters
+// against the state of the given block. If the block hash is nil, the best block is used.
+// Any state changes made by the call are discarded.
+func (s *Service) CallRuntime(bhash *common.Hash, method string, params []byte) ([]byte, error) {
+	if bhash == nil {
+		best := s.blockState.BestBlockHash()
+		bhash = &best
+	}
+
+	stateRootHash, err := s.storageState.GetStateRootFromBlock(bhash)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := s.storageState.TrieState(stateRootHash)
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err := s.blockState.GetRuntimePool(bhash)
+	if err != nil {
+		return nil, err
+	}
+
+	rt, err := pool.Get()
+	if err != nil {
+		return nil, err
+	}
+	defer pool.Put(rt)
+
+	rt.SetContextStorage(ts)
+	return rt.Exec(method, params)
+}
+
 // QueryStorage returns the key-value data by block based on `keys` params
 // on every block starting `from` until `to` block, if `to` is not nil
 func (s *Service) QueryStorage(from, to common.Hash, keys ...string) (map[common.Hash]QueryKeyValueChanges, error) {
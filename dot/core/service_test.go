@@ -4,10 +4,13 @@
 package core
 
 import (
+	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math/big"
 	"os"
+	"path/filepath"
 	"sort"
 	"testing"
 	"time"
@@ -19,6 +22,7 @@ import (
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	runtimemocks "github.com/ChainSafe/gossamer/lib/runtime/mocks"
@@ -27,6 +31,7 @@ import (
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -56,6 +61,32 @@ func TestStartService(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestService_HandleFinalisedBlocks(t *testing.T) {
+	bs := new(mocks.BlockState)
+	bs.On("GetHighestFinalisedHeader").Return(&types.Header{Number: big.NewInt(2)}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Service{
+		ctx:         ctx,
+		blockState:  bs,
+		finalisedCh: make(chan *types.FinalisationInfo),
+	}
+
+	done := make(chan struct{})
+	go func() {
+		s.handleFinalisedBlocks()
+		close(done)
+	}()
+
+	s.finalisedCh <- &types.FinalisationInfo{
+		Header: types.Header{Number: big.NewInt(4)},
+	}
+	cancel()
+	<-done
+
+	require.Equal(t, float64(4), testutil.ToFloat64(blockHeight.WithLabelValues("finalized")))
+}
+
 func TestAnnounceBlock(t *testing.T) {
 	net := new(mocks.Network)
 	cfg := &Config{
@@ -312,7 +343,7 @@ func TestHandleChainReorg_WithReorg_Transactions(t *testing.T) {
 	rt, err := s.blockState.GetRuntime(&bhash)
 	require.NoError(t, err)
 
-	validity, err := rt.ValidateTransaction(tx)
+	validity, err := rt.ValidateTransaction(tx, bhash)
 	require.NoError(t, err)
 
 	// get common ancestor
@@ -496,6 +527,16 @@ func TestService_GetMetadata(t *testing.T) {
 	require.Greater(t, len(res), 10000)
 }
 
+func TestService_CallRuntime(t *testing.T) {
+	s := NewTestService(t, nil)
+	res, err := s.CallRuntime(nil, "Metadata_metadata", []byte{})
+	require.NoError(t, err)
+
+	expected, err := s.GetMetadata(nil)
+	require.NoError(t, err)
+	require.Equal(t, expected, res)
+}
+
 func TestService_HandleRuntimeChanges(t *testing.T) {
 	const (
 		updatedSpecVersion        = uint32(262)
@@ -857,6 +898,71 @@ func TestDecodeSessionKeys_WhenGetRuntimeReturnError(t *testing.T) {
 	require.Nil(t, b)
 }
 
+func TestGenerateSessionKeys(t *testing.T) {
+	bestHash := common.NewHash([]byte("best block hash"))
+	stateRoot := common.NewHash([]byte("state root hash"))
+
+	ts, err := rtstorage.NewTrieState(nil)
+	require.NoError(t, err)
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	ks := keystore.NewGlobalKeystore()
+
+	mockInstance := new(runtimemocks.Instance)
+	mockInstance.On("SetContextStorage", ts).Once()
+	mockInstance.On("GenerateSessionKeys").Return(kp.Public().Encode(), nil).Run(func(mock.Arguments) {
+		require.NoError(t, ks.Babe.Insert(kp))
+	}).Once()
+
+	mockBlockState := new(mocks.BlockState)
+	mockBlockState.On("BestBlockHash").Return(bestHash)
+	mockBlockState.On("GetRuntime", &bestHash).Return(mockInstance, nil)
+
+	mockStorageState := new(mocks.StorageState)
+	mockStorageState.On("GetStateRootFromBlock", &bestHash).Return(&stateRoot, nil)
+	mockStorageState.On("TrieState", &stateRoot).Return(ts, nil)
+
+	basePath := t.TempDir()
+	s := &Service{
+		blockState:       mockBlockState,
+		storageState:     mockStorageState,
+		keys:             ks,
+		keystoreBasePath: basePath,
+		keystorePassword: []byte("password"),
+	}
+
+	keys, err := s.GenerateSessionKeys()
+	require.NoError(t, err)
+	require.Equal(t, kp.Public().Encode(), keys)
+	mockInstance.AssertExpectations(t)
+
+	keyFile := filepath.Join(basePath, "keystore", "session", "babe", hex.EncodeToString(kp.Public().Encode())+".key")
+	_, err = keystore.ReadFromFileAndDecrypt(keyFile, nil)
+	require.Error(t, err)
+	priv, err := keystore.ReadFromFileAndDecrypt(keyFile, []byte("password"))
+	require.NoError(t, err)
+	require.Equal(t, kp.Private().Encode(), priv.Encode())
+
+	// the session key is in the keystore again after a restart
+	restarted := keystore.NewGlobalKeystore()
+	loaded, err := keystore.LoadSessionKeys(restarted, basePath, []byte("password"))
+	require.NoError(t, err)
+	require.Equal(t, 1, loaded)
+	require.Equal(t, kp.Public(), restarted.Babe.GetKeypair(kp.Public()).Public())
+}
+
+func TestGenerateSessionKeys_NoPassword(t *testing.T) {
+	s := &Service{
+		keys:             keystore.NewGlobalKeystore(),
+		keystoreBasePath: t.TempDir(),
+	}
+
+	_, err := s.GenerateSessionKeys()
+	require.ErrorIs(t, err, keystore.ErrNoSessionKeysPassword)
+}
+
 func TestGetReadProofAt(t *testing.T) {
 	keysToProof := [][]byte{[]byte("first_key"), []byte("another_key")}
 	mockedProofs := [][]byte{[]byte("proof01"), []byte("proof02")}
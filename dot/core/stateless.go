@@ -0,0 +1,58 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/runtime/wasmer"
+)
+
+// ExecuteBlockFromProof executes the given block against the state with the given root, rebuilt from the given
+// proof nodes only, and returns the state after the execution of the block. The runtime code is read from the
+// proven state. If the runtime accesses storage not covered by the proof, the returned error wraps
+// rtstorage.ErrIncompleteProof.
+func ExecuteBlockFromProof(block *types.Block, stateRoot common.Hash, proof [][]byte) (*rtstorage.TrieState, error) {
+	ts, err := rtstorage.NewTrieStateFromProof(stateRoot, proof)
+	if err != nil {
+		return nil, err
+	}
+
+	code := ts.LoadCode()
+	if err = ts.ProofError(); err != nil {
+		return nil, err
+	}
+
+	if len(code) == 0 {
+		return nil, ErrEmptyRuntimeCode
+	}
+
+	cfg := &wasmer.Config{
+		Imports: wasmer.ImportsNodeRuntime,
+	}
+	cfg.Storage = ts
+
+	rt, err := wasmer.NewInstance(code, cfg)
+	if err != nil {
+		return nil, err
+	}
+	defer rt.Stop()
+
+	_, err = rt.ExecuteBlock(block)
+
+	// the runtime cannot behave as it would against the full state if it accessed storage
+	// missing from the proof, so the incomplete proof is reported over the execution error
+	if proofErr := ts.ProofError(); proofErr != nil {
+		return nil, fmt.Errorf("failed to execute block %s: %w", block.Header.Hash(), proofErr)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute block %s: %w", block.Header.Hash(), err)
+	}
+
+	return ts, nil
+}
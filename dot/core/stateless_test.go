@@ -0,0 +1,91 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package core
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/sync"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteBlockFromProof(t *testing.T) {
+	s := NewTestService(t, nil)
+
+	genHeader, err := s.blockState.BestBlockHeader()
+	require.NoError(t, err)
+
+	rt, err := s.blockState.GetRuntime(nil)
+	require.NoError(t, err)
+
+	ts, err := s.storageState.TrieState(&genHeader.StateRoot)
+	require.NoError(t, err)
+	rt.SetContextStorage(ts)
+
+	block := sync.BuildBlock(t, rt, genHeader, nil)
+
+	// record the state read by the execution of the block, and the runtime code read to instantiate the runtime
+	ts, err = s.storageState.TrieState(&genHeader.StateRoot)
+	require.NoError(t, err)
+	ts.EnableRecording()
+	ts.LoadCode()
+	rt.SetContextStorage(ts)
+
+	_, err = rt.ExecuteBlock(block)
+	require.NoError(t, err)
+
+	proof, err := ts.RecordedProof()
+	require.NoError(t, err)
+
+	res, err := ExecuteBlockFromProof(block, genHeader.StateRoot, proof)
+	require.NoError(t, err)
+	require.NoError(t, res.ProofError())
+	require.Equal(t, ts.MustRoot(), res.MustRoot())
+	require.Equal(t, block.Header.StateRoot, res.MustRoot())
+}
+
+func TestExecuteBlockFromProof_Errors(t *testing.T) {
+	tr := trie.NewEmptyTrie()
+	tr.Put([]byte("noot"), []byte("washere"))
+	tr.Put([]byte("other"), []byte("value"))
+
+	withCode := tr.Snapshot()
+	withCode.Put(common.CodeKey, []byte("somecode"))
+
+	newProof := func(tr *trie.Trie, keys ...[]byte) [][]byte {
+		proof, err := trie.GenerateProofFromTrie(tr, keys)
+		require.NoError(t, err)
+		return proof
+	}
+
+	testCases := map[string]struct {
+		root  common.Hash
+		proof [][]byte
+		err   error
+	}{
+		"no runtime code": {
+			root:  tr.MustHash(),
+			proof: newProof(tr, common.CodeKey),
+			err:   ErrEmptyRuntimeCode,
+		},
+		"runtime code not in proof": {
+			root:  withCode.MustHash(),
+			proof: newProof(withCode, []byte("noot")),
+			err:   rtstorage.ErrIncompleteProof,
+		},
+	}
+
+	block := types.NewEmptyBlock()
+	for name, tc := range testCases {
+		tc := tc
+		t.Run(name, func(t *testing.T) {
+			_, err := ExecuteBlockFromProof(&block, tc.root, tc.proof)
+			require.ErrorIs(t, err, tc.err)
+		})
+	}
+}
@@ -326,6 +326,13 @@ func (h *Handler) handleForcedChange(fc types.GrandpaForcedChange, header *types
 
 	h.grandpaForcedChange = c
 
+	// the forced change is applied on import of the blocks of the chain holding it, so this chain is followed
+	// as the best chain until it is finalised or pruned
+	err = h.blockState.SetBestBlockHint(header.Hash())
+	if err != nil {
+		logger.Warnf("failed to set best block hint to block %s with forced change: %s", header.Hash(), err)
+	}
+
 	auths, err := types.GrandpaAuthoritiesRawToAuthorities(fc.Auths)
 	if err != nil {
 		return err
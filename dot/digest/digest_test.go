@@ -151,6 +151,43 @@ func TestHandler_GrandpaForcedChange(t *testing.T) {
 	require.Equal(t, expected, auths)
 }
 
+func TestHandler_GrandpaForcedChange_BestBlockHint(t *testing.T) {
+	handler := newTestHandler(t)
+	bs := handler.blockState.(*state.BlockState)
+
+	chain, _ := state.AddBlocksToState(t, bs, 4, false)
+
+	// a fork of the chain, which is shorter than the chain
+	fork := &types.Block{
+		Header: types.Header{
+			ParentHash: chain[0].Hash(),
+			Number:     big.NewInt(2),
+			StateRoot:  common.Hash{1},
+			Digest:     types.NewDigest(),
+		},
+		Body: types.Body{},
+	}
+	err := bs.AddBlock(fork)
+	require.NoError(t, err)
+	require.Equal(t, chain[3].Hash(), bs.BestBlockHash())
+
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+
+	fc := types.GrandpaForcedChange{
+		Auths: []types.GrandpaAuthoritiesRaw{
+			{Key: kr.Alice().Public().(*ed25519.PublicKey).AsBytes(), ID: 0},
+		},
+		Delay: 3,
+	}
+
+	err = handler.handleForcedChange(fc, &fork.Header)
+	require.NoError(t, err)
+
+	// the chain holding the forced change is followed
+	require.Equal(t, fork.Header.Hash(), bs.BestBlockHash())
+}
+
 func TestHandler_GrandpaPauseAndResume(t *testing.T) {
 	handler := newTestHandler(t)
 	handler.Start()
@@ -0,0 +1,130 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package dot
+
+import (
+	"errors"
+
+	"github.com/ChainSafe/gossamer/dot/core"
+	"github.com/ChainSafe/gossamer/dot/light"
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/life"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/runtime/wasmer"
+	"github.com/ChainSafe/gossamer/lib/trie"
+)
+
+var errNotSupportedByLightClient = errors.New("not supported by light clients, which keep no state")
+
+// newLightInstanceBuilder returns the light.InstanceBuilder creating the runtime instances
+// that execute the remote calls of light clients
+func newLightInstanceBuilder(cfg *Config) light.InstanceBuilder {
+	return func(code []byte, ts *rtstorage.TrieState) (runtime.Instance, error) {
+		if cfg.Core.WasmInterpreter == life.Name {
+			rtCfg := &life.Config{
+				Resolver: new(life.Resolver),
+			}
+			rtCfg.Storage = ts
+			rtCfg.LogLvl = cfg.Log.RuntimeLvl
+			rtCfg.Role = cfg.Core.Roles
+			return life.NewInstance(code, rtCfg)
+		}
+
+		rtCfg := &wasmer.Config{
+			Imports: wasmer.ImportsNodeRuntime,
+		}
+		rtCfg.Storage = ts
+		rtCfg.LogLvl = cfg.Log.RuntimeLvl
+		rtCfg.Role = cfg.Core.Roles
+		return wasmer.NewInstance(code, rtCfg)
+	}
+}
+
+// lightStorageAPI answers the storage queries of the RPC modules with storage entries fetched
+// from full nodes with read proofs.
+type lightStorageAPI struct {
+	*state.StorageState
+	client *light.Client
+}
+
+// GetStorage returns the storage value of the given key in the state of the best block.
+// Querying the storage of another state root is not supported by light clients.
+func (s *lightStorageAPI) GetStorage(root *common.Hash, key []byte) ([]byte, error) {
+	if root != nil {
+		return nil, errNotSupportedByLightClient
+	}
+
+	return s.GetStorageByBlockHash(nil, key)
+}
+
+// GetStorageByBlockHash returns the storage value of the given key in the state of the given block
+func (s *lightStorageAPI) GetStorageByBlockHash(bhash *common.Hash, key []byte) ([]byte, error) {
+	values, err := s.client.Read(bhash, [][]byte{key})
+	if err != nil {
+		return nil, err
+	}
+
+	return values[0], nil
+}
+
+// GetStorageChild is not supported by light clients
+func (*lightStorageAPI) GetStorageChild(*common.Hash, []byte) (*trie.Trie, error) {
+	return nil, errNotSupportedByLightClient
+}
+
+// GetStorageFromChild is not supported by light clients
+func (*lightStorageAPI) GetStorageFromChild(*common.Hash, []byte, []byte) ([]byte, error) {
+	return nil, errNotSupportedByLightClient
+}
+
+// Entries is not supported by light clients
+func (*lightStorageAPI) Entries(*common.Hash) (map[string][]byte, error) {
+	return nil, errNotSupportedByLightClient
+}
+
+// GetKeysWithPrefix is not supported by light clients
+func (*lightStorageAPI) GetKeysWithPrefix(*common.Hash, []byte) ([][]byte, error) {
+	return nil, errNotSupportedByLightClient
+}
+
+// TrieState is not supported by light clients
+func (*lightStorageAPI) TrieState(*common.Hash) (*rtstorage.TrieState, error) {
+	return nil, errNotSupportedByLightClient
+}
+
+// lightCoreAPI answers the runtime queries of the RPC modules by executing the runtime
+// against the state proven by full nodes.
+type lightCoreAPI struct {
+	*core.Service
+	client *light.Client
+}
+
+// GetRuntimeVersion returns the runtime version of the given block
+func (s *lightCoreAPI) GetRuntimeVersion(bhash *common.Hash) (runtime.Version, error) {
+	return s.client.Version(bhash)
+}
+
+// GetMetadata returns the runtime metadata of the given block
+func (s *lightCoreAPI) GetMetadata(bhash *common.Hash) ([]byte, error) {
+	return s.client.Metadata(bhash)
+}
+
+// CallRuntime executes the given runtime function with the given SCALE encoded parameters
+// against the state of the given block
+func (s *lightCoreAPI) CallRuntime(bhash *common.Hash, method string, params []byte) ([]byte, error) {
+	return s.client.Call(bhash, method, params)
+}
+
+// QueryStorage is not supported by light clients
+func (*lightCoreAPI) QueryStorage(common.Hash, common.Hash, ...string) (
+	map[common.Hash]core.QueryKeyValueChanges, error) {
+	return nil, errNotSupportedByLightClient
+}
+
+// GetReadProofAt is not supported by light clients
+func (*lightCoreAPI) GetReadProofAt(common.Hash, [][]byte) (common.Hash, [][]byte, error) {
+	return common.Hash{}, nil, errNotSupportedByLightClient
+}
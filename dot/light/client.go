@@ -0,0 +1,279 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package light implements the client side of the light client protocol. Storage reads and runtime calls
+// are answered by full nodes with storage proofs, which are checked against the state root of the locally
+// verified block headers.
+package light
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+var logger = log.NewFromGlobal(log.AddContext("pkg", "light"))
+
+var (
+	errNoPeers        = errors.New("no full node peers to send light request to")
+	errNilResponse    = errors.New("light response does not hold the requested data")
+	errInvalidProof   = errors.New("invalid storage proof")
+	errNoRuntimeCode  = errors.New("runtime code is not in call proof")
+	errProofIncorrect = errors.New("storage proof does not match state root")
+	errProofNoKey     = errors.New("storage proof does not cover key")
+)
+
+// Client fetches storage entries and executes runtime calls for light clients, using proofs requested from
+// full node peers.
+type Client struct {
+	blockState  BlockState
+	network     Network
+	newInstance InstanceBuilder
+}
+
+// NewClient returns a new light client. The given InstanceBuilder creates the runtime instances
+// executing the remote calls.
+func NewClient(blockState BlockState, net Network, newInstance InstanceBuilder) *Client {
+	return &Client{
+		blockState:  blockState,
+		network:     net,
+		newInstance: newInstance,
+	}
+}
+
+// Read returns the values of the given keys in the state of the given block, or nil for the keys
+// not in the state. If the block hash is nil, the best block is used.
+func (c *Client) Read(block *common.Hash, keys [][]byte) ([][]byte, error) {
+	header, err := c.header(block)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &network.LightRequest{
+		RemoteReadRequest: &network.RemoteReadRequest{
+			Block: header.Hash().ToBytes(),
+			Keys:  keys,
+		},
+	}
+
+	var values [][]byte
+	err = c.request(req, func(resp *network.LightResponse) error {
+		if resp.RemoteReadResponse == nil {
+			return errNilResponse
+		}
+
+		proof, proofTrie, err := decodeProof(header.StateRoot, resp.RemoteReadResponse.Proof)
+		if err != nil {
+			return err
+		}
+
+		values, err = verifyValues(proof, proofTrie, header.StateRoot, keys)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// Call executes the given runtime function with the given SCALE encoded parameters against the state
+// of the given block. If the block hash is nil, the best block is used.
+func (c *Client) Call(block *common.Hash, method string, data []byte) ([]byte, error) {
+	var res []byte
+	err := c.execute(block, method, data, func(rt runtime.Instance) (err error) {
+		res, err = rt.Exec(method, data)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Version returns the runtime version of the given block. If the block hash is nil, the best block is used.
+func (c *Client) Version(block *common.Hash) (runtime.Version, error) {
+	var version runtime.Version
+	err := c.execute(block, runtime.CoreVersion, []byte{}, func(rt runtime.Instance) (err error) {
+		version, err = rt.Version()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// Metadata returns the runtime metadata of the given block. If the block hash is nil, the best block is used.
+func (c *Client) Metadata(block *common.Hash) ([]byte, error) {
+	var metadata []byte
+	err := c.execute(block, runtime.Metadata, []byte{}, func(rt runtime.Instance) (err error) {
+		metadata, err = rt.Metadata()
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// execute requests the proof of execution of the given runtime call and runs exec with a runtime instance
+// holding the proven part of the state. If exec accesses storage not covered by the proof, its result can't
+// be trusted, so the proof is requested from another peer.
+func (c *Client) execute(block *common.Hash, method string, data []byte, exec func(runtime.Instance) error) error {
+	header, err := c.header(block)
+	if err != nil {
+		return err
+	}
+
+	req := &network.LightRequest{
+		RemoteCallRequest: &network.RemoteCallRequest{
+			Block:  header.Hash().ToBytes(),
+			Method: method,
+			Data:   data,
+		},
+	}
+
+	var execErr error
+	err = c.request(req, func(resp *network.LightResponse) error {
+		if resp.RemoteCallResponse == nil {
+			return errNilResponse
+		}
+
+		proof, proofTrie, err := decodeProof(header.StateRoot, resp.RemoteCallResponse.Proof)
+		if err != nil {
+			return err
+		}
+
+		code, err := verifyValues(proof, proofTrie, header.StateRoot, [][]byte{common.CodeKey})
+		if err != nil {
+			return err
+		}
+
+		if code[0] == nil {
+			return errNoRuntimeCode
+		}
+
+		ts, err := rtstorage.NewTrieStateFromProof(header.StateRoot, proof)
+		if err != nil {
+			return fmt.Errorf("%w: %s", errInvalidProof, err)
+		}
+
+		rt, err := c.newInstance(code[0], ts)
+		if err != nil {
+			return err
+		}
+		defer rt.Stop()
+
+		execErr = exec(rt)
+		return ts.ProofError()
+	})
+	if err != nil {
+		return err
+	}
+
+	return execErr
+}
+
+func (c *Client) header(block *common.Hash) (*types.Header, error) {
+	if block == nil {
+		return c.blockState.GetHeader(c.blockState.BestBlockHash())
+	}
+
+	return c.blockState.GetHeader(*block)
+}
+
+// request sends the request to the full node peers until one of them sends a response accepted by the
+// given handler.
+func (c *Client) request(req *network.LightRequest, handle func(*network.LightResponse) error) error {
+	err := errNoPeers
+	for _, info := range c.network.Peers() {
+		if info.Roles == types.LightClientRole {
+			continue
+		}
+
+		who, decodeErr := peer.Decode(info.PeerID)
+		if decodeErr != nil {
+			continue
+		}
+
+		var resp *network.LightResponse
+		resp, err = c.network.DoLightRequest(who, req)
+		if err != nil {
+			logger.Debugf("failed to send light request to peer %s: %s", who, err)
+			continue
+		}
+
+		if err = handle(resp); err != nil {
+			logger.Debugf("invalid light response from peer %s: %s", who, err)
+			continue
+		}
+
+		return nil
+	}
+
+	return err
+}
+
+// decodeProof decodes the SCALE encoded proof and returns its nodes and the partial trie they hold
+func decodeProof(root common.Hash, encProof []byte) (proof [][]byte, proofTrie *trie.Trie, err error) {
+	if err = scale.Unmarshal(encProof, &proof); err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", errInvalidProof, err)
+	}
+
+	proofTrie = trie.NewEmptyTrie()
+	if err = proofTrie.LoadFromProof(proof, root.ToBytes()); err != nil {
+		return nil, nil, fmt.Errorf("%w: %s", errInvalidProof, err)
+	}
+
+	if proofTrie.RootNode() == nil {
+		return nil, nil, errProofIncorrect
+	}
+
+	return proof, proofTrie, nil
+}
+
+// verifyValues returns the values of the given keys in the proof trie, after checking the proof covers every
+// key and checking the proof of the keys found in the trie with trie.VerifyProof. A key the proof covers but
+// that is not in the trie is not in the state.
+func verifyValues(proof [][]byte, proofTrie *trie.Trie, root common.Hash, keys [][]byte) ([][]byte, error) {
+	values := make([][]byte, len(keys))
+	items := make([]trie.Pair, 0, len(keys))
+	for i, key := range keys {
+		if !proofTrie.IsProven(key) {
+			return nil, fmt.Errorf("%w: 0x%x", errProofNoKey, key)
+		}
+
+		values[i] = proofTrie.Get(key)
+		if values[i] != nil {
+			items = append(items, trie.Pair{Key: key, Value: values[i]})
+		}
+	}
+
+	if len(items) == 0 {
+		return values, nil
+	}
+
+	ok, err := trie.VerifyProof(proof, root.ToBytes(), items)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidProof, err)
+	}
+
+	if !ok {
+		return nil, errProofIncorrect
+	}
+
+	return values, nil
+}
@@ -0,0 +1,232 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package light
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/light/mocks"
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	runtimemocks "github.com/ChainSafe/gossamer/lib/runtime/mocks"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	libp2pcrypto "github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPeer(t *testing.T) peer.ID {
+	t.Helper()
+
+	_, pub, err := libp2pcrypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	id, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+	return id
+}
+
+func newTestReadResponse(t *testing.T, tr *trie.Trie, keys [][]byte) *network.LightResponse {
+	t.Helper()
+
+	proof, err := trie.GenerateProofFromTrie(tr, keys)
+	require.NoError(t, err)
+
+	enc, err := scale.Marshal(proof)
+	require.NoError(t, err)
+
+	return &network.LightResponse{
+		RemoteReadResponse: &network.RemoteReadResponse{
+			Proof: enc,
+		},
+	}
+}
+
+func newTestCallResponse(t *testing.T, tr *trie.Trie, keys [][]byte) *network.LightResponse {
+	t.Helper()
+
+	resp := newTestReadResponse(t, tr, keys)
+	return &network.LightResponse{
+		RemoteCallResponse: &network.RemoteCallResponse{
+			Proof: resp.RemoteReadResponse.Proof,
+		},
+	}
+}
+
+func TestClient_Read(t *testing.T) {
+	tr := trie.NewEmptyTrie()
+	tr.Put([]byte("noot"), []byte("washere"))
+	tr.Put([]byte("nootagain"), []byte("alsowashere"))
+	tr.Put([]byte("other"), []byte("value"))
+
+	other := trie.NewEmptyTrie()
+	other.Put([]byte("noot"), []byte("notthevalue"))
+
+	header := types.NewEmptyHeader()
+	header.Number = big.NewInt(1)
+	header.StateRoot = tr.MustHash()
+	hash := header.Hash()
+
+	bs := new(mocks.BlockState)
+	bs.On("BestBlockHash").Return(hash)
+	bs.On("GetHeader", hash).Return(header, nil)
+
+	keys := [][]byte{[]byte("noot"), []byte("other")}
+	badPeer, goodPeer := newTestPeer(t), newTestPeer(t)
+
+	net := new(mocks.Network)
+	net.On("Peers").Return([]common.PeerInfo{
+		{PeerID: newTestPeer(t).String(), Roles: types.LightClientRole},
+		{PeerID: badPeer.String(), Roles: types.FullNodeRole},
+		{PeerID: goodPeer.String(), Roles: types.FullNodeRole},
+	})
+	net.On("DoLightRequest", badPeer, mock.AnythingOfType("*network.LightRequest")).
+		Return(newTestReadResponse(t, other, keys[:1]), nil)
+	net.On("DoLightRequest", goodPeer, mock.AnythingOfType("*network.LightRequest")).
+		Return(newTestReadResponse(t, tr, keys), nil)
+
+	c := NewClient(bs, net, nil)
+	values, err := c.Read(nil, keys)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("washere"), []byte("value")}, values)
+
+	net.AssertNumberOfCalls(t, "DoLightRequest", 2)
+}
+
+func TestClient_Read_NoPeers(t *testing.T) {
+	header := types.NewEmptyHeader()
+	hash := header.Hash()
+
+	bs := new(mocks.BlockState)
+	bs.On("GetHeader", hash).Return(header, nil)
+
+	net := new(mocks.Network)
+	net.On("Peers").Return([]common.PeerInfo{})
+
+	c := NewClient(bs, net, nil)
+	_, err := c.Read(&hash, [][]byte{[]byte("noot")})
+	require.ErrorIs(t, err, errNoPeers)
+}
+
+func TestClient_Read_InvalidProof(t *testing.T) {
+	tr := trie.NewEmptyTrie()
+	tr.Put([]byte("noot"), []byte("washere"))
+
+	other := trie.NewEmptyTrie()
+	other.Put([]byte("noot"), []byte("notthevalue"))
+
+	header := types.NewEmptyHeader()
+	header.StateRoot = tr.MustHash()
+	hash := header.Hash()
+
+	bs := new(mocks.BlockState)
+	bs.On("GetHeader", hash).Return(header, nil)
+
+	keys := [][]byte{[]byte("noot")}
+	p := newTestPeer(t)
+
+	net := new(mocks.Network)
+	net.On("Peers").Return([]common.PeerInfo{{PeerID: p.String(), Roles: types.FullNodeRole}})
+	net.On("DoLightRequest", p, mock.AnythingOfType("*network.LightRequest")).
+		Return(newTestReadResponse(t, other, keys), nil)
+
+	c := NewClient(bs, net, nil)
+	_, err := c.Read(&hash, keys)
+	require.ErrorIs(t, err, errProofIncorrect)
+}
+
+func TestClient_Read_IncompleteProof(t *testing.T) {
+	tr := trie.NewEmptyTrie()
+	tr.Put([]byte("noot"), []byte("washere"))
+	tr.Put([]byte("other"), []byte("value"))
+
+	header := types.NewEmptyHeader()
+	header.StateRoot = tr.MustHash()
+	hash := header.Hash()
+
+	bs := new(mocks.BlockState)
+	bs.On("GetHeader", hash).Return(header, nil)
+
+	keys := [][]byte{[]byte("noot"), []byte("other")}
+	badPeer, goodPeer := newTestPeer(t), newTestPeer(t)
+
+	net := new(mocks.Network)
+	net.On("Peers").Return([]common.PeerInfo{
+		{PeerID: badPeer.String(), Roles: types.FullNodeRole},
+		{PeerID: goodPeer.String(), Roles: types.FullNodeRole},
+	})
+	// the proof of the first key only doesn't show whether the second key is in the state
+	net.On("DoLightRequest", badPeer, mock.AnythingOfType("*network.LightRequest")).
+		Return(newTestReadResponse(t, tr, keys[:1]), nil)
+	net.On("DoLightRequest", goodPeer, mock.AnythingOfType("*network.LightRequest")).
+		Return(newTestReadResponse(t, tr, keys), nil)
+
+	c := NewClient(bs, net, nil)
+	values, err := c.Read(&hash, keys)
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{[]byte("washere"), []byte("value")}, values)
+	net.AssertNumberOfCalls(t, "DoLightRequest", 2)
+
+	badNet := new(mocks.Network)
+	badNet.On("Peers").Return([]common.PeerInfo{{PeerID: badPeer.String(), Roles: types.FullNodeRole}})
+	badNet.On("DoLightRequest", badPeer, mock.AnythingOfType("*network.LightRequest")).
+		Return(newTestReadResponse(t, tr, keys[:1]), nil)
+
+	c = NewClient(bs, badNet, nil)
+	_, err = c.Read(&hash, keys)
+	require.ErrorIs(t, err, errProofNoKey)
+}
+
+func TestClient_Call_IncompleteProof(t *testing.T) {
+	key := []byte("noot")
+
+	tr := trie.NewEmptyTrie()
+	tr.Put(common.CodeKey, []byte("code"))
+	tr.Put(key, []byte("washere"))
+
+	header := types.NewEmptyHeader()
+	header.StateRoot = tr.MustHash()
+	hash := header.Hash()
+
+	bs := new(mocks.BlockState)
+	bs.On("GetHeader", hash).Return(header, nil)
+
+	badPeer, goodPeer := newTestPeer(t), newTestPeer(t)
+
+	net := new(mocks.Network)
+	net.On("Peers").Return([]common.PeerInfo{
+		{PeerID: badPeer.String(), Roles: types.FullNodeRole},
+		{PeerID: goodPeer.String(), Roles: types.FullNodeRole},
+	})
+	// the call reads a key missing from the proof of the first peer
+	net.On("DoLightRequest", badPeer, mock.AnythingOfType("*network.LightRequest")).
+		Return(newTestCallResponse(t, tr, [][]byte{common.CodeKey}), nil)
+	net.On("DoLightRequest", goodPeer, mock.AnythingOfType("*network.LightRequest")).
+		Return(newTestCallResponse(t, tr, [][]byte{common.CodeKey, key}), nil)
+
+	newInstance := func(code []byte, ts *rtstorage.TrieState) (runtime.Instance, error) {
+		require.Equal(t, []byte("code"), code)
+
+		rt := new(runtimemocks.Instance)
+		rt.On("Exec", "Test_call", []byte{1}).Return(func(string, []byte) []byte {
+			return ts.Get(key)
+		}, nil)
+		rt.On("Stop")
+		return rt, nil
+	}
+
+	c := NewClient(bs, net, newInstance)
+	res, err := c.Call(&hash, "Test_call", []byte{1})
+	require.NoError(t, err)
+	require.Equal(t, []byte("washere"), res)
+
+	net.AssertNumberOfCalls(t, "DoLightRequest", 2)
+}
@@ -0,0 +1,37 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package light
+
+import (
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+//go:generate mockery --name BlockState --structname BlockState --case underscore --keeptree
+
+// BlockState is the interface for the block state
+type BlockState interface {
+	BestBlockHash() common.Hash
+	GetHeader(hash common.Hash) (*types.Header, error)
+}
+
+//go:generate mockery --name Network --structname Network --case underscore --keeptree
+
+// Network is the interface for the network
+type Network interface {
+	// DoLightRequest sends a light client request to the given peer.
+	// If a response is received within a certain time period,
+	// it is returned, otherwise an error is returned.
+	DoLightRequest(to peer.ID, req *network.LightRequest) (*network.LightResponse, error)
+
+	// Peers returns a list of currently connected peers
+	Peers() []common.PeerInfo
+}
+
+// InstanceBuilder creates a runtime instance from the given runtime code, using the given storage
+type InstanceBuilder func(code []byte, storage *rtstorage.TrieState) (runtime.Instance, error)
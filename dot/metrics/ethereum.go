@@ -0,0 +1,78 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metrics
+
+import (
+	"strings"
+
+	ethmetrics "github.com/ethereum/go-ethereum/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// quantiles are the quantiles of the go-ethereum histograms and timers exposed as summaries
+var quantiles = []float64{0.5, 0.75, 0.95, 0.99, 0.999, 0.9999}
+
+// ethCollector collects the metrics of a go-ethereum registry, so that they are gathered along
+// with the prometheus metrics of the registry and served as a single exposition
+type ethCollector struct {
+	registry ethmetrics.Registry
+}
+
+// sampled is implemented by the go-ethereum histograms and timers
+type sampled interface {
+	Count() int64
+	Sum() int64
+	Percentiles([]float64) []float64
+}
+
+// Describe implements prometheus.Collector. It sends no descriptions, since the metrics of the
+// go-ethereum registry are only known once collected, which makes it an unchecked collector.
+func (ethCollector) Describe(chan<- *prometheus.Desc) {}
+
+// Collect implements prometheus.Collector
+func (c ethCollector) Collect(ch chan<- prometheus.Metric) {
+	c.registry.Each(func(name string, i interface{}) {
+		desc := prometheus.NewDesc(ethMetricName(name), name, nil, nil)
+
+		switch m := i.(type) {
+		case ethmetrics.Counter:
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(m.Snapshot().Count()))
+		case ethmetrics.Gauge:
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, float64(m.Snapshot().Value()))
+		case ethmetrics.GaugeFloat64:
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, m.Snapshot().Value())
+		case ethmetrics.Meter:
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, float64(m.Snapshot().Count()))
+		case ethmetrics.Histogram:
+			ch <- newSummary(desc, m.Snapshot())
+		case ethmetrics.Timer:
+			ch <- newSummary(desc, m.Snapshot())
+		default:
+			logger.Debugf("cannot collect go-ethereum metric %s of type %T", name, i)
+		}
+	})
+}
+
+func newSummary(desc *prometheus.Desc, m sampled) prometheus.Metric {
+	values := m.Percentiles(quantiles)
+	summary := make(map[float64]float64, len(quantiles))
+	for i, q := range quantiles {
+		summary[q] = values[i]
+	}
+
+	return prometheus.MustNewConstSummary(desc, uint64(m.Count()), float64(m.Sum()), summary)
+}
+
+// ethMetricName replaces the characters of a go-ethereum metric name that are invalid in
+// prometheus metric names, such as the slashes separating its parts, with underscores
+func ethMetricName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == ':':
+			return r
+		default:
+			return '_'
+		}
+	}, name)
+}
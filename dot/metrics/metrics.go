@@ -4,12 +4,13 @@
 package metrics
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/ChainSafe/gossamer/internal/log"
 	ethmetrics "github.com/ethereum/go-ethereum/metrics"
-	"github.com/ethereum/go-ethereum/metrics/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 )
 
 var logger log.LeveledLogger = log.NewFromGlobal(log.AddContext("pkg", "metrics"))
@@ -29,7 +30,7 @@ func PublishMetrics(address string) {
 // setupMetricsServer starts a dedicated metrics server at the given address.
 func setupMetricsServer(address string) {
 	m := http.NewServeMux()
-	m.Handle("/metrics", prometheus.Handler(ethmetrics.DefaultRegistry))
+	m.Handle("/metrics", handler())
 	logger.Info("Starting metrics server at http://" + address + "/metrics")
 	go func() {
 		if err := http.ListenAndServe(address, m); err != nil {
@@ -37,3 +38,19 @@ func setupMetricsServer(address string) {
 		}
 	}()
 }
+
+// handler serves the metrics gathered from the registry, which include the ones of the go-ethereum registry
+func handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		ErrorLog:      promLogger{},
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
+
+// promLogger logs the errors of the metrics handler
+type promLogger struct{}
+
+// Println implements promhttp.Logger
+func (promLogger) Println(v ...interface{}) {
+	logger.Error(fmt.Sprint(v...))
+}
@@ -0,0 +1,55 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	ethmetrics "github.com/ethereum/go-ethereum/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandler(t *testing.T) {
+	ethmetrics.Enabled = true
+	ethmetrics.GetOrRegisterGauge("test/gauge", ethmetrics.DefaultRegistry).Update(3)
+	ethmetrics.GetOrRegisterTimer("test/timer", ethmetrics.DefaultRegistry).Update(time.Second)
+
+	counter := NewCounterVec(prometheus.CounterOpts{
+		Name: "test_requests_total",
+		Help: "Test requests",
+	}, []string{"result"})
+	counter.WithLabelValues("success").Add(2)
+
+	histogram := NewHistogram(prometheus.HistogramOpts{
+		Name:    "test_duration_seconds",
+		Help:    "Test duration",
+		Buckets: []float64{1},
+	})
+	histogram.Observe(0.5)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	handler().ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	require.Contains(t, body, "test_gauge 3\n")
+	require.Contains(t, body, "# TYPE test_timer summary\n")
+	require.Contains(t, body, "test_timer_count 1\n")
+	require.Contains(t, body, "# TYPE substrate_test_requests_total counter\n")
+	require.Contains(t, body, `substrate_test_requests_total{result="success"} 2`+"\n")
+	require.Contains(t, body, `substrate_test_duration_seconds_bucket{le="1"} 1`+"\n")
+	require.Contains(t, body, "substrate_test_duration_seconds_count 1\n")
+
+	// the metrics are served as a single exposition, so each metric family is described once
+	families, err := new(expfmt.TextParser).TextToMetricFamilies(strings.NewReader(body))
+	require.NoError(t, err)
+	require.Contains(t, families, "test_gauge")
+	require.Contains(t, families, "substrate_test_requests_total")
+}
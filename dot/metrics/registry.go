@@ -0,0 +1,73 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package metrics
+
+import (
+	ethmetrics "github.com/ethereum/go-ethereum/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Namespace is the prefix of the metric names. It matches the one used by substrate,
+// so the dashboards built for substrate nodes can be used with gossamer.
+const Namespace = "substrate"
+
+// registry holds the prometheus metrics registered by the node subsystems
+var registry = prometheus.NewRegistry()
+
+func init() {
+	registry.MustRegister(ethCollector{registry: ethmetrics.DefaultRegistry})
+}
+
+// Registry returns the registry the node subsystems register their prometheus metrics into
+func Registry() *prometheus.Registry {
+	return registry
+}
+
+// NewCounter creates a counter in the substrate namespace and registers it into the registry
+func NewCounter(opts prometheus.CounterOpts) prometheus.Counter {
+	opts.Namespace = Namespace
+	c := prometheus.NewCounter(opts)
+	registry.MustRegister(c)
+	return c
+}
+
+// NewCounterVec creates a counter vector in the substrate namespace and registers it into the registry
+func NewCounterVec(opts prometheus.CounterOpts, labels []string) *prometheus.CounterVec {
+	opts.Namespace = Namespace
+	c := prometheus.NewCounterVec(opts, labels)
+	registry.MustRegister(c)
+	return c
+}
+
+// NewGauge creates a gauge in the substrate namespace and registers it into the registry
+func NewGauge(opts prometheus.GaugeOpts) prometheus.Gauge {
+	opts.Namespace = Namespace
+	g := prometheus.NewGauge(opts)
+	registry.MustRegister(g)
+	return g
+}
+
+// NewGaugeVec creates a gauge vector in the substrate namespace and registers it into the registry
+func NewGaugeVec(opts prometheus.GaugeOpts, labels []string) *prometheus.GaugeVec {
+	opts.Namespace = Namespace
+	g := prometheus.NewGaugeVec(opts, labels)
+	registry.MustRegister(g)
+	return g
+}
+
+// NewHistogram creates a histogram in the substrate namespace and registers it into the registry
+func NewHistogram(opts prometheus.HistogramOpts) prometheus.Histogram {
+	opts.Namespace = Namespace
+	h := prometheus.NewHistogram(opts)
+	registry.MustRegister(h)
+	return h
+}
+
+// NewHistogramVec creates a histogram vector in the substrate namespace and registers it into the registry
+func NewHistogramVec(opts prometheus.HistogramOpts, labels []string) *prometheus.HistogramVec {
+	opts.Namespace = Namespace
+	h := prometheus.NewHistogramVec(opts, labels)
+	registry.MustRegister(h)
+	return h
+}
@@ -88,6 +88,8 @@ type Config struct {
 
 	// PersistentPeers is a list of multiaddrs which the node should remain connected to
 	PersistentPeers []string
+	// ReservedOnly only allows connections with the persistent and reserved peers
+	ReservedOnly bool
 
 	// privateKey the private key for the network p2p identity
 	privateKey crypto.PrivKey
@@ -65,6 +65,27 @@ const (
 	connectTimeout       = time.Second * 5
 )
 
+// indexes of the peer sets, one for each notifications protocol. The block announces
+// set also governs the connections with peers.
+const (
+	blockAnnounceSetID = iota
+	transactionsSetID
+	grandpaSetID
+	numPeerSets
+)
+
+// notificationsSetID returns the index of the peer set of the notifications protocol with the given message type
+func notificationsSetID(messageID byte) int {
+	switch messageID {
+	case TransactionMsgType:
+		return transactionsSetID
+	case ConsensusMsgType:
+		return grandpaSetID
+	default:
+		return blockAnnounceSetID
+	}
+}
+
 // host wraps libp2p host with network host configuration and services
 type host struct {
 	ctx             context.Context
@@ -133,14 +154,18 @@ func newHost(ctx context.Context, cfg *Config) (*host, error) {
 
 	// We have tried to set maxInPeers and maxOutPeers such that number of peer
 	// connections remain between min peers and max peers
-	const reservedOnly = false
+	maxInPeers, maxOutPeers := uint32(cfg.MaxPeers-cfg.MinPeers), uint32(cfg.MaxPeers/2)
 	peerCfgSet := peerset.NewConfigSet(
-		uint32(cfg.MaxPeers-cfg.MinPeers),
-		uint32(cfg.MaxPeers/2),
-		reservedOnly,
+		maxInPeers,
+		maxOutPeers,
+		cfg.ReservedOnly,
 		peerSetSlotAllocTime,
 	)
 
+	// the transactions and GRANDPA sets have their own slots
+	peerCfgSet.AddSet(maxInPeers, maxOutPeers, cfg.ReservedOnly)
+	peerCfgSet.AddSet(maxInPeers, maxOutPeers, cfg.ReservedOnly)
+
 	// create connection manager
 	cm, err := newConnManager(cfg.MinPeers, cfg.MaxPeers, peerCfgSet)
 	if err != nil {
@@ -276,13 +301,15 @@ func (h *host) connect(p peer.AddrInfo) (err error) {
 func (h *host) bootstrap() {
 	for _, info := range h.persistentPeers {
 		h.h.Peerstore().AddAddrs(info.ID, info.Addrs, peerstore.PermanentAddrTTL)
-		h.cm.peerSetHandler.AddReservedPeer(0, info.ID)
+		for setID := 0; setID < numPeerSets; setID++ {
+			h.cm.peerSetHandler.AddReservedPeer(setID, info.ID)
+		}
 	}
 
 	for _, addrInfo := range h.bootnodes {
 		logger.Debugf("bootstrapping to peer %s", addrInfo.ID)
 		h.h.Peerstore().AddAddrs(addrInfo.ID, addrInfo.Addrs, peerstore.PermanentAddrTTL)
-		h.cm.peerSetHandler.AddPeer(0, addrInfo.ID)
+		h.cm.peerSetHandler.AddPeer(blockAnnounceSetID, addrInfo.ID)
 	}
 }
 
@@ -355,7 +382,9 @@ func (h *host) addReservedPeers(addrs ...string) error {
 			return err
 		}
 		h.h.Peerstore().AddAddrs(addrInfo.ID, addrInfo.Addrs, peerstore.PermanentAddrTTL)
-		h.cm.peerSetHandler.AddReservedPeer(0, addrInfo.ID)
+		for setID := 0; setID < numPeerSets; setID++ {
+			h.cm.peerSetHandler.AddReservedPeer(setID, addrInfo.ID)
+		}
 	}
 
 	return nil
@@ -368,7 +397,9 @@ func (h *host) removeReservedPeers(ids ...string) error {
 		if err != nil {
 			return err
 		}
-		h.cm.peerSetHandler.RemoveReservedPeer(0, peerID)
+		for setID := 0; setID < numPeerSets; setID++ {
+			h.cm.peerSetHandler.RemoveReservedPeer(setID, peerID)
+		}
 		h.h.ConnManager().Unprotect(peerID, "")
 	}
 
@@ -4,8 +4,12 @@
 package network
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/ChainSafe/gossamer/dot/peerset"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/pkg/scale"
@@ -14,6 +18,66 @@ import (
 	"github.com/libp2p/go-libp2p-core/peer"
 )
 
+const lightRequestTimeout = time.Second * 10
+
+var (
+	errNoLightProvider    = errors.New("no light provider set")
+	errInvalidBlockHash   = errors.New("invalid block hash in light request")
+	errInvalidBlockNumber = errors.New("invalid block number in light request")
+	errNoChangesTries     = errors.New("changes tries are not supported")
+)
+
+// SetLightProvider sets the LightProvider used to answer light client requests
+func (s *Service) SetLightProvider(provider LightProvider) {
+	s.lightProvider = provider
+}
+
+// DoLightRequest sends a light client request to the given peer.
+// If a response is received within a certain time period, it is returned, otherwise an error is returned.
+func (s *Service) DoLightRequest(to peer.ID, req *LightRequest) (*LightResponse, error) {
+	fullLightID := s.host.protocolID + lightID
+
+	s.host.h.ConnManager().Protect(to, "")
+	defer s.host.h.ConnManager().Unprotect(to, "")
+
+	ctx, cancel := context.WithTimeout(s.ctx, lightRequestTimeout)
+	defer cancel()
+
+	stream, err := s.host.h.NewStream(ctx, to, fullLightID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	if err = s.host.writeToStream(stream, req); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, maxBlockResponseSize)
+	n, err := readStream(stream, buf)
+	if err != nil {
+		return nil, fmt.Errorf("read stream error: %w", err)
+	}
+
+	if n == 0 {
+		return nil, fmt.Errorf("received empty message")
+	}
+
+	resp := NewLightResponse()
+	if err = resp.Decode(buf[:n]); err != nil {
+		s.host.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.BadMessageValue,
+			Reason: peerset.BadMessageReason,
+		}, to)
+		return nil, fmt.Errorf("failed to decode light response: %w", err)
+	}
+
+	return resp, nil
+}
+
 // handleLightStream handles streams with the <protocol-id>/light/2 protocol ID
 func (s *Service) handleLightStream(stream libp2pnetwork.Stream) {
 	s.readStream(stream, s.decodeLightMessage, s.handleLightMsg)
@@ -43,29 +107,35 @@ func (s *Service) handleLightMsg(stream libp2pnetwork.Stream, msg Message) (err
 		return nil
 	}
 
+	if lr.RemoteCallRequest == nil && lr.RemoteHeaderRequest == nil && lr.RemoteChangesRequest == nil &&
+		lr.RemoteReadRequest == nil && lr.RemoteReadChildRequest == nil {
+		logger.Warn("ignoring LightRequest without request data")
+		return nil
+	}
+
+	if s.lightProvider == nil {
+		return errNoLightProvider
+	}
+
 	resp := NewLightResponse()
 	switch {
 	case lr.RemoteCallRequest != nil:
-		resp.RemoteCallResponse, err = remoteCallResp(lr.RemoteCallRequest)
+		resp.RemoteCallResponse, err = s.remoteCallResp(lr.RemoteCallRequest)
 	case lr.RemoteHeaderRequest != nil:
-		resp.RemoteHeaderResponse, err = remoteHeaderResp(lr.RemoteHeaderRequest)
+		resp.RemoteHeaderResponse, err = s.remoteHeaderResp(lr.RemoteHeaderRequest)
 	case lr.RemoteChangesRequest != nil:
 		resp.RemoteChangesResponse, err = remoteChangeResp(lr.RemoteChangesRequest)
 	case lr.RemoteReadRequest != nil:
-		resp.RemoteReadResponse, err = remoteReadResp(lr.RemoteReadRequest)
+		resp.RemoteReadResponse, err = s.remoteReadResp(lr.RemoteReadRequest)
 	case lr.RemoteReadChildRequest != nil:
-		resp.RemoteReadResponse, err = remoteReadChildResp(lr.RemoteReadChildRequest)
-	default:
-		logger.Warn("ignoring LightRequest without request data")
-		return nil
+		resp.RemoteReadResponse, err = s.remoteReadChildResp(lr.RemoteReadChildRequest)
 	}
 
 	if err != nil {
 		return err
 	}
 
-	// TODO(arijit): Remove once we implement the internal APIs. Added to increase code coverage. (#1856)
-	logger.Debugf("LightResponse message: %s", resp)
+	logger.Tracef("sending LightResponse message to peer %s: %s", stream.Conn().RemotePeer(), resp)
 
 	err = s.host.writeToStream(stream, resp)
 	if err != nil {
@@ -130,19 +200,30 @@ func (l *LightRequest) SubProtocol() string {
 	return lightID
 }
 
-// Encode encodes a LightRequest message using SCALE and appends the type byte to the start
+// Encode encodes a LightRequest message using SCALE and appends the type byte to the start.
+// The requests which are not set are encoded as empty requests.
 func (l *LightRequest) Encode() ([]byte, error) {
-	req := request{
-		RemoteCallRequest:      *l.RemoteCallRequest,
-		RemoteReadRequest:      *l.RemoteReadRequest,
-		RemoteHeaderRequest:    *l.RemoteHeaderRequest,
-		RemoteReadChildRequest: *l.RemoteReadChildRequest,
-		RemoteChangesRequest:   *l.RemoteChangesRequest,
+	req := newRequest()
+	if l.RemoteCallRequest != nil {
+		req.RemoteCallRequest = *l.RemoteCallRequest
+	}
+	if l.RemoteReadRequest != nil {
+		req.RemoteReadRequest = *l.RemoteReadRequest
 	}
-	return scale.Marshal(req)
+	if l.RemoteHeaderRequest != nil {
+		req.RemoteHeaderRequest = *l.RemoteHeaderRequest
+	}
+	if l.RemoteReadChildRequest != nil {
+		req.RemoteReadChildRequest = *l.RemoteReadChildRequest
+	}
+	if l.RemoteChangesRequest != nil {
+		req.RemoteChangesRequest = *l.RemoteChangesRequest
+	}
+	return scale.Marshal(*req)
 }
 
-// Decode the message into a LightRequest, it assumes the type byte has been removed
+// Decode the message into a LightRequest, it assumes the type byte has been removed.
+// Only the requests which are not empty are set.
 func (l *LightRequest) Decode(in []byte) error {
 	msg := newRequest()
 	err := scale.Unmarshal(in, msg)
@@ -150,11 +231,22 @@ func (l *LightRequest) Decode(in []byte) error {
 		return err
 	}
 
-	l.RemoteCallRequest = &msg.RemoteCallRequest
-	l.RemoteReadRequest = &msg.RemoteReadRequest
-	l.RemoteHeaderRequest = &msg.RemoteHeaderRequest
-	l.RemoteReadChildRequest = &msg.RemoteReadChildRequest
-	l.RemoteChangesRequest = &msg.RemoteChangesRequest
+	*l = LightRequest{}
+	if len(msg.RemoteCallRequest.Block) > 0 {
+		l.RemoteCallRequest = &msg.RemoteCallRequest
+	}
+	if len(msg.RemoteReadRequest.Block) > 0 {
+		l.RemoteReadRequest = &msg.RemoteReadRequest
+	}
+	if len(msg.RemoteHeaderRequest.Block) > 0 {
+		l.RemoteHeaderRequest = &msg.RemoteHeaderRequest
+	}
+	if len(msg.RemoteReadChildRequest.Block) > 0 {
+		l.RemoteReadChildRequest = &msg.RemoteReadChildRequest
+	}
+	if msg.RemoteChangesRequest.FirstBlock != nil {
+		l.RemoteChangesRequest = &msg.RemoteChangesRequest
+	}
 	return nil
 }
 
@@ -341,12 +433,13 @@ func newRemoteReadResponse() *RemoteReadResponse {
 // RemoteHeaderResponse ...
 type RemoteHeaderResponse struct {
 	Header []*types.Header
-	proof  []byte
+	Proof  []byte
 }
 
 func newRemoteHeaderResponse() *RemoteHeaderResponse {
 	return &RemoteHeaderResponse{
 		Header: nil,
+		Proof:  []byte{},
 	}
 }
 
@@ -450,21 +543,108 @@ func (rr *RemoteReadResponse) String() string {
 
 // String formats a RemoteHeaderResponse as a string
 func (rh *RemoteHeaderResponse) String() string {
-	return fmt.Sprintf("Header =%+v Proof =%s", rh.Header, string(rh.proof))
+	return fmt.Sprintf("Header =%+v Proof =%s", rh.Header, string(rh.Proof))
 }
 
-func remoteCallResp(_ *RemoteCallRequest) (*RemoteCallResponse, error) {
-	return &RemoteCallResponse{}, nil
+func (s *Service) remoteCallResp(req *RemoteCallRequest) (*RemoteCallResponse, error) {
+	block, err := lightRequestBlockHash(req.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := s.lightProvider.GenerateCallProof(block, req.Method, req.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := scale.Marshal(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoteCallResponse{
+		Proof: enc,
+	}, nil
 }
+
+// remoteChangeResp refuses changes requests. Changes tries are never built by gossamer, so it cannot prove
+// the changes of a key, and an empty response would claim the key never changed.
 func remoteChangeResp(_ *RemoteChangesRequest) (*RemoteChangesResponse, error) {
-	return &RemoteChangesResponse{}, nil
+	return nil, errNoChangesTries
 }
-func remoteHeaderResp(_ *RemoteHeaderRequest) (*RemoteHeaderResponse, error) {
-	return &RemoteHeaderResponse{}, nil
+
+func (s *Service) remoteHeaderResp(req *RemoteHeaderRequest) (*RemoteHeaderResponse, error) {
+	if len(req.Block) != 4 {
+		return nil, fmt.Errorf("%w: expected 4 bytes, got %d", errInvalidBlockNumber, len(req.Block))
+	}
+
+	var number uint32
+	if err := scale.Unmarshal(req.Block, &number); err != nil {
+		return nil, fmt.Errorf("%w: %s", errInvalidBlockNumber, err)
+	}
+
+	header, proof, err := s.lightProvider.GenerateHeaderProof(number)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := scale.Marshal(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoteHeaderResponse{
+		Header: []*types.Header{header},
+		Proof:  enc,
+	}, nil
+}
+
+func (s *Service) remoteReadChildResp(req *RemoteReadChildRequest) (*RemoteReadResponse, error) {
+	block, err := lightRequestBlockHash(req.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	proof, err := s.lightProvider.GenerateChildReadProof(block, req.StorageKey, req.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := scale.Marshal(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoteReadResponse{
+		Proof: enc,
+	}, nil
 }
-func remoteReadChildResp(_ *RemoteReadChildRequest) (*RemoteReadResponse, error) {
-	return &RemoteReadResponse{}, nil
+
+func (s *Service) remoteReadResp(req *RemoteReadRequest) (*RemoteReadResponse, error) {
+	block, err := lightRequestBlockHash(req.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	_, proof, err := s.lightProvider.GetReadProofAt(block, req.Keys)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := scale.Marshal(proof)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RemoteReadResponse{
+		Proof: enc,
+	}, nil
 }
-func remoteReadResp(_ *RemoteReadRequest) (*RemoteReadResponse, error) {
-	return &RemoteReadResponse{}, nil
+
+func lightRequestBlockHash(block []byte) (common.Hash, error) {
+	if len(block) != common.HashLength {
+		return common.Hash{}, errInvalidBlockHash
+	}
+
+	return common.BytesToHash(block), nil
 }
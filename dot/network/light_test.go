@@ -4,12 +4,14 @@
 package network
 
 import (
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/utils"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 
 	"github.com/libp2p/go-libp2p-core/peer"
 	"github.com/stretchr/testify/require"
@@ -24,15 +26,30 @@ func TestEncodeLightRequest(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, exp, enc)
 
+	// empty requests are not set when decoding
 	testLightRequest2 := NewLightRequest()
 	err = testLightRequest2.Decode(enc)
 	require.NoError(t, err)
-	require.Equal(t, testLightRequest, testLightRequest2)
+	require.Equal(t, &LightRequest{}, testLightRequest2)
+
+	testLightRequest3 := &LightRequest{
+		RemoteReadRequest: &RemoteReadRequest{
+			Block: common.Hash{1}.ToBytes(),
+			Keys:  [][]byte{{1, 2}},
+		},
+	}
+	enc, err = testLightRequest3.Encode()
+	require.NoError(t, err)
+
+	testLightRequest4 := NewLightRequest()
+	err = testLightRequest4.Decode(enc)
+	require.NoError(t, err)
+	require.Equal(t, testLightRequest3, testLightRequest4)
 }
 
 func TestEncodeLightResponse(t *testing.T) {
 	t.Parallel()
-	exp := common.MustHexToBytes("0x00000000000000")
+	exp := common.MustHexToBytes("0x0000000000000000")
 
 	testLightResponse := NewLightResponse()
 	enc, err := testLightResponse.Encode()
@@ -155,3 +172,117 @@ func TestHandleLightMessage_Response(t *testing.T) {
 	err = s.handleLightMsg(stream, msg)
 	require.Error(t, err, expectedErr, msg.String())
 }
+
+func TestHandleLightMessage_Provider(t *testing.T) {
+	t.Parallel()
+
+	block := common.Hash{1}
+	proof := [][]byte{{1, 2, 3}, {4, 5}}
+	encProof, err := scale.Marshal(proof)
+	require.NoError(t, err)
+
+	provider := new(MockLightProvider)
+	provider.On("GenerateCallProof", block, "Core_version", []byte{1}).Return(proof, nil)
+	provider.On("GetReadProofAt", block, [][]byte{{9}}).Return(block, proof, nil)
+	provider.On("GenerateChildReadProof", block, []byte(":child_storage:default:a"), [][]byte{{9}}).
+		Return(proof, nil)
+	header := types.NewEmptyHeader()
+	header.Number = big.NewInt(3)
+	provider.On("GenerateHeaderProof", uint32(3)).Return(header, proof, nil)
+
+	s := &Service{
+		lightProvider: provider,
+	}
+
+	callResp, err := s.remoteCallResp(&RemoteCallRequest{
+		Block:  block.ToBytes(),
+		Method: "Core_version",
+		Data:   []byte{1},
+	})
+	require.NoError(t, err)
+	require.Equal(t, encProof, callResp.Proof)
+
+	readResp, err := s.remoteReadResp(&RemoteReadRequest{
+		Block: block.ToBytes(),
+		Keys:  [][]byte{{9}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, encProof, readResp.Proof)
+
+	readResp, err = s.remoteReadChildResp(&RemoteReadChildRequest{
+		Block:      block.ToBytes(),
+		StorageKey: []byte(":child_storage:default:a"),
+		Keys:       [][]byte{{9}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, encProof, readResp.Proof)
+
+	encNumber, err := scale.Marshal(uint32(3))
+	require.NoError(t, err)
+	headerResp, err := s.remoteHeaderResp(&RemoteHeaderRequest{
+		Block: encNumber,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []*types.Header{header}, headerResp.Header)
+	require.Equal(t, encProof, headerResp.Proof)
+
+	_, err = s.remoteCallResp(&RemoteCallRequest{
+		Block: []byte{1, 2},
+	})
+	require.ErrorIs(t, err, errInvalidBlockHash)
+
+	_, err = s.remoteHeaderResp(&RemoteHeaderRequest{
+		Block: []byte{1},
+	})
+	require.ErrorIs(t, err, errInvalidBlockNumber)
+
+	_, err = remoteChangeResp(&RemoteChangesRequest{})
+	require.ErrorIs(t, err, errNoChangesTries)
+
+	provider.AssertExpectations(t)
+}
+
+func TestDoLightRequest(t *testing.T) {
+	config := &Config{
+		BasePath:    utils.NewTestBasePath(t, "nodeA"),
+		Port:        7001,
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+	s := createTestService(t, config)
+
+	configB := &Config{
+		BasePath:    utils.NewTestBasePath(t, "nodeB"),
+		Port:        7002,
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+	b := createTestService(t, configB)
+
+	block := common.Hash{1}
+	proof := [][]byte{{1, 2, 3}}
+	provider := new(MockLightProvider)
+	provider.On("GetReadProofAt", block, [][]byte{{9}}).Return(block, proof, nil)
+	b.SetLightProvider(provider)
+
+	addrInfoB := b.host.addrInfo()
+	err := s.host.connect(addrInfoB)
+	// retry connect if "failed to dial" error
+	if failedToDial(err) {
+		time.Sleep(TestBackoffTimeout)
+		err = s.host.connect(addrInfoB)
+	}
+	require.NoError(t, err)
+
+	resp, err := s.DoLightRequest(b.host.id(), &LightRequest{
+		RemoteReadRequest: &RemoteReadRequest{
+			Block: block.ToBytes(),
+			Keys:  [][]byte{{9}},
+		},
+	})
+	require.NoError(t, err)
+
+	encProof, err := scale.Marshal(proof)
+	require.NoError(t, err)
+	require.Equal(t, encProof, resp.RemoteReadResponse.Proof)
+}
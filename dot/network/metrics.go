@@ -0,0 +1,22 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"github.com/ChainSafe/gossamer/dot/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	requestsOutSuccess = metrics.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sub_libp2p_requests_out_success_total",
+		Help:    "For successful outgoing requests, time between a request's start and finish",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, []string{"protocol"})
+
+	requestsOutFailure = metrics.NewCounterVec(prometheus.CounterOpts{
+		Name: "sub_libp2p_requests_out_failure_total",
+		Help: "Number of outgoing requests that failed",
+	}, []string{"protocol", "reason"})
+)
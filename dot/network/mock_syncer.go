@@ -35,6 +35,29 @@ func (_m *MockSyncer) CreateBlockResponse(_a0 *BlockRequestMessage) (*BlockRespo
 	return r0, r1
 }
 
+// CreateStateResponse provides a mock function with given fields: _a0
+func (_m *MockSyncer) CreateStateResponse(_a0 *StateRequest) (*StateResponse, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *StateResponse
+	if rf, ok := ret.Get(0).(func(*StateRequest) *StateResponse); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*StateResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*StateRequest) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HandleBlockAnnounce provides a mock function with given fields: from, msg
 func (_m *MockSyncer) HandleBlockAnnounce(from peer.ID, msg *BlockAnnounceMessage) error {
 	ret := _m.Called(from, msg)
@@ -62,27 +62,43 @@ type handshakeReader struct {
 
 type notificationsProtocol struct {
 	protocolID               protocol.ID
+	setID                    int
 	getHandshake             HandshakeGetter
 	handshakeDecoder         HandshakeDecoder
 	handshakeValidator       HandshakeValidator
 	outboundHandshakeMutexes *sync.Map //map[peer.ID]*sync.Mutex
 	inboundHandshakeData     *sync.Map //map[peer.ID]*handshakeData
 	outboundHandshakeData    *sync.Map //map[peer.ID]*handshakeData
+	// setPeers holds the peers the peer set gave a slot to, for the protocols which don't
+	// use the block announces set, whose peers are all the connected peers.
+	setPeers *sync.Map //map[peer.ID]struct{}
 }
 
-func newNotificationsProtocol(protocolID protocol.ID, handshakeGetter HandshakeGetter,
+func newNotificationsProtocol(protocolID protocol.ID, setID int, handshakeGetter HandshakeGetter,
 	handshakeDecoder HandshakeDecoder, handshakeValidator HandshakeValidator) *notificationsProtocol {
 	return &notificationsProtocol{
 		protocolID:               protocolID,
+		setID:                    setID,
 		getHandshake:             handshakeGetter,
 		handshakeValidator:       handshakeValidator,
 		handshakeDecoder:         handshakeDecoder,
 		outboundHandshakeMutexes: new(sync.Map),
 		inboundHandshakeData:     new(sync.Map),
 		outboundHandshakeData:    new(sync.Map),
+		setPeers:                 new(sync.Map),
 	}
 }
 
+// hasSetPeer returns true if notifications can be exchanged with the peer over the protocol
+func (n *notificationsProtocol) hasSetPeer(pid peer.ID) bool {
+	if n.setID == blockAnnounceSetID || n.setPeers == nil {
+		return true
+	}
+
+	_, has := n.setPeers.Load(pid)
+	return has
+}
+
 func (n *notificationsProtocol) getInboundHandshakeData(pid peer.ID) (*handshakeData, bool) {
 	var (
 		data interface{}
@@ -186,6 +202,11 @@ func (s *Service) createNotificationsMessageHandler(
 			// ie it is an inbound stream and we only send the handshake over it.
 			// we do not send any other data over this stream, we would need to open a new outbound stream.
 			if _, has := info.getInboundHandshakeData(peer); !has {
+				// the peer set answers with an Accept or Reject message, in which case the stream gets reset
+				if !info.hasSetPeer(peer) {
+					s.host.cm.peerSetHandler.Incoming(info.setID, peer)
+				}
+
 				logger.Tracef("receiver: validating handshake using protocol %s", info.protocolID)
 
 				hsData := newHandshakeData(true, false, stream)
@@ -273,6 +294,11 @@ func (s *Service) sendData(peer peer.ID, hs Handshake, info *notificationsProtoc
 		return
 	}
 
+	if !info.hasSetPeer(peer) {
+		logger.Tracef("peer %s has no slot in the peer set of protocol %s", peer, info.protocolID)
+		return
+	}
+
 	if support, err := s.host.supportsProtocol(peer, info.protocolID); err != nil || !support {
 		s.host.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
 			Value:  peerset.BadProtocolValue,
@@ -30,6 +30,8 @@ const (
 
 	// the following are sub-protocols used by the node
 	syncID          = "/sync/2"
+	warpSyncID      = "/sync/gossamer-warp/1"  // not substrate's /sync/warp, the proofs hold the vote ancestry
+	stateID         = "/sync/gossamer-state/1" // not substrate's /state/2, which is protobuf encoded
 	lightID         = "/light/2"
 	blockAnnounceID = "/block-announces/1"
 	transactionsID  = "/transactions/1"
@@ -76,6 +78,8 @@ type Service struct {
 	blockState         BlockState
 	syncer             Syncer
 	transactionHandler TransactionHandler
+	warpSyncProvider   WarpSyncProvider
+	lightProvider      LightProvider
 
 	// Configuration options
 	noBootstrap bool
@@ -194,6 +198,8 @@ func (s *Service) Start() error {
 
 	s.host.registerStreamHandler(s.host.protocolID+syncID, s.handleSyncStream)
 	s.host.registerStreamHandler(s.host.protocolID+lightID, s.handleLightStream)
+	s.host.registerStreamHandler(s.host.protocolID+warpSyncID, s.handleWarpSyncStream)
+	s.host.registerStreamHandler(s.host.protocolID+stateID, s.handleStateStream)
 
 	// register block announce protocol
 	err := s.RegisterNotificationsProtocol(
@@ -235,18 +241,26 @@ func (s *Service) Start() error {
 
 	// this handles all new connections (incoming and outgoing)
 	// it creates a per-protocol mutex for sending outbound handshakes to the peer
+	// and makes the peer known to the peer sets of the notifications protocols
 	s.host.cm.connectHandler = func(peerID peer.ID) {
 		for _, prtl := range s.notificationsProtocols {
 			prtl.outboundHandshakeMutexes.Store(peerID, new(sync.Mutex))
+			if prtl.setID != blockAnnounceSetID {
+				s.host.cm.peerSetHandler.AddPeer(prtl.setID, peerID)
+			}
 		}
 	}
 
-	// when a peer gets disconnected, we should clear all handshake data we have for it.
+	// when a peer gets disconnected, we should clear all handshake data we have for it,
+	// and remove it from the peer sets of the notifications protocols unless it is reserved.
 	s.host.cm.disconnectHandler = func(peerID peer.ID) {
 		for _, prtl := range s.notificationsProtocols {
 			prtl.outboundHandshakeMutexes.Delete(peerID)
 			prtl.inboundHandshakeData.Delete(peerID)
 			prtl.outboundHandshakeData.Delete(peerID)
+			if prtl.setID != blockAnnounceSetID {
+				s.host.cm.peerSetHandler.RemovePeer(prtl.setID, peerID)
+			}
 		}
 	}
 
@@ -431,8 +445,9 @@ func (s *Service) sentBlockIntervalTelemetry() {
 }
 
 func (s *Service) handleConn(conn libp2pnetwork.Conn) {
-	// TODO: currently we only have one set so setID is 0, change this once we have more set in peerSet.
-	s.host.cm.peerSetHandler.Incoming(0, conn.RemotePeer())
+	// connections are governed by the block announces set, the peers of the other sets
+	// are accepted when they open a notifications substream.
+	s.host.cm.peerSetHandler.Incoming(blockAnnounceSetID, conn.RemotePeer())
 }
 
 // Stop closes running instances of the host and network services as well as
@@ -490,7 +505,8 @@ func (s *Service) RegisterNotificationsProtocol(
 		return errors.New("notifications protocol with message type already exists")
 	}
 
-	np := newNotificationsProtocol(protocolID, handshakeGetter, handshakeDecoder, handshakeValidator)
+	np := newNotificationsProtocol(protocolID, notificationsSetID(messageID), handshakeGetter,
+		handshakeDecoder, handshakeValidator)
 	s.notificationsProtocols[messageID] = np
 	decoder := createDecoder(np, handshakeDecoder, messageDecoder)
 	handlerWithValidate := s.createNotificationsMessageHandler(np, messageHandler, batchHandler)
@@ -668,6 +684,12 @@ func (s *Service) processMessage(msg peerset.Message) {
 		logger.Errorf("found empty peer id in peerset message")
 		return
 	}
+
+	if msg.SetID() != blockAnnounceSetID {
+		s.processNotificationsSetMessage(msg)
+		return
+	}
+
 	switch msg.Status {
 	case peerset.Connect:
 		addrInfo := s.host.h.Peerstore().PeerInfo(peerID)
@@ -696,6 +718,35 @@ func (s *Service) processMessage(msg peerset.Message) {
 	}
 }
 
+// processNotificationsSetMessage handles the messages of the peer sets of the notifications protocols,
+// which open and close the substreams of the protocol with the peer rather than the connection.
+// Connections are only opened and closed by the block announces set.
+func (s *Service) processNotificationsSetMessage(msg peerset.Message) {
+	s.notificationsMu.Lock()
+	defer s.notificationsMu.Unlock()
+
+	for _, prtl := range s.notificationsProtocols {
+		if prtl.setID != msg.SetID() {
+			continue
+		}
+
+		switch msg.Status {
+		case peerset.Connect, peerset.Accept:
+			prtl.setPeers.Store(msg.PeerID, struct{}{})
+		case peerset.Drop, peerset.Reject:
+			prtl.setPeers.Delete(msg.PeerID)
+			if hsData, has := prtl.getInboundHandshakeData(msg.PeerID); has {
+				prtl.inboundHandshakeData.Delete(msg.PeerID)
+				_ = hsData.stream.Reset()
+			}
+
+			if hsData, has := prtl.getOutboundHandshakeData(msg.PeerID); has && hsData.stream != nil {
+				closeOutboundStream(prtl, msg.PeerID, hsData.stream)
+			}
+		}
+	}
+}
+
 func (s *Service) startProcessingMsg() {
 	msgCh := s.host.cm.peerSetHandler.Messages()
 	for {
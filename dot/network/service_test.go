@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/libp2p/go-libp2p-core/peer"
 	mock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
@@ -330,6 +331,55 @@ func TestHandleConn(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNotificationsPeerSets(t *testing.T) {
+	configA := &Config{
+		BasePath:    utils.NewTestBasePath(t, "nodeA"),
+		Port:        7001,
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+
+	nodeA := createTestService(t, configA)
+
+	configB := &Config{
+		BasePath:    utils.NewTestBasePath(t, "nodeB"),
+		Port:        7002,
+		NoBootstrap: true,
+		NoMDNS:      true,
+	}
+
+	nodeB := createTestService(t, configB)
+
+	configC := &Config{
+		BasePath:     utils.NewTestBasePath(t, "nodeC"),
+		Port:         7003,
+		NoBootstrap:  true,
+		NoMDNS:       true,
+		ReservedOnly: true,
+	}
+
+	nodeC := createTestService(t, configC)
+
+	for _, addrInfo := range []peer.AddrInfo{nodeB.host.addrInfo(), nodeC.host.addrInfo()} {
+		err := nodeA.host.connect(addrInfo)
+		if failedToDial(err) {
+			time.Sleep(TestBackoffTimeout)
+			err = nodeA.host.connect(addrInfo)
+		}
+		require.NoError(t, err)
+	}
+
+	time.Sleep(time.Millisecond * 500)
+
+	// nodeA gets a slot in the transactions set of nodeB, the block announces set uses the connections
+	require.True(t, nodeB.notificationsProtocols[TransactionMsgType].hasSetPeer(nodeA.host.id()))
+	require.True(t, nodeB.notificationsProtocols[BlockAnnounceMsgType].hasSetPeer(nodeA.host.id()))
+
+	// nodeC only accepts its reserved peers
+	require.False(t, nodeC.notificationsProtocols[TransactionMsgType].hasSetPeer(nodeA.host.id()))
+	require.Equal(t, 0, nodeC.host.peerCount())
+}
+
 func TestSerivceIsMajorSyncMetrics(t *testing.T) {
 	mocksyncer := new(MockSyncer)
 
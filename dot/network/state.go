@@ -40,6 +40,9 @@ type Syncer interface {
 
 	// CreateBlockResponse is called upon receipt of a BlockRequestMessage to create the response
 	CreateBlockResponse(*BlockRequestMessage) (*BlockResponseMessage, error)
+
+	// CreateStateResponse is called upon receipt of a StateRequest to create the response
+	CreateStateResponse(*StateRequest) (*StateResponse, error)
 }
 
 //go:generate mockery --name TransactionHandler --structname MockTransactionHandler --case underscore --inpackage
@@ -50,6 +53,27 @@ type TransactionHandler interface {
 	TransactionsCount() int
 }
 
+// WarpSyncProvider is the interface used by the warp sync sub-protocol
+type WarpSyncProvider interface {
+	// GenerateWarpSyncProof returns a proof of the authority set changes that happened after the given finalised block
+	GenerateWarpSyncProof(begin common.Hash) (*WarpSyncProof, error)
+}
+
+//go:generate mockery --name LightProvider --structname MockLightProvider --case underscore --inpackage
+
+// LightProvider is the interface used by the light client sub-protocol to answer the requests of light clients
+type LightProvider interface {
+	// GenerateCallProof executes the runtime call against the state of the given block and returns
+	// the proof of the storage read by the call
+	GenerateCallProof(block common.Hash, method string, data []byte) ([][]byte, error)
+	// GetReadProofAt returns the proof of the given keys in the state of the given block
+	GetReadProofAt(block common.Hash, keys [][]byte) (common.Hash, [][]byte, error)
+	// GenerateChildReadProof returns the proof of the given keys in the child trie stored at the given key
+	GenerateChildReadProof(block common.Hash, storageKey []byte, keys [][]byte) ([][]byte, error)
+	// GenerateHeaderProof returns the canonical header at the given number and its CHT proof
+	GenerateHeaderProof(number uint32) (*types.Header, [][]byte, error)
+}
+
 // PeerSetHandler is the interface used by the connection manager to handle peerset.
 type PeerSetHandler interface {
 	Start()
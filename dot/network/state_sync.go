@@ -0,0 +1,184 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+var stateRequestTimeout = time.Second * 20
+
+var (
+	_ Message = &StateRequest{}
+	_ Message = &StateResponse{}
+)
+
+// StateRequest is sent to request the storage entries of the state trie at a block
+type StateRequest struct {
+	// Block is the hash of the block whose state is requested
+	Block common.Hash
+	// Start is empty to start from the first key of the top trie. Otherwise, it holds the last key
+	// received from the top trie and, if the response stopped in a child trie, the last key received from it.
+	Start [][]byte
+	// NoProof is true if the response doesn't need to include proofs of its entries
+	NoProof bool
+}
+
+// SubProtocol returns the state sub-protocol
+func (r *StateRequest) SubProtocol() string {
+	return stateID
+}
+
+// Encode returns the SCALE encoded StateRequest
+func (r *StateRequest) Encode() ([]byte, error) {
+	return scale.Marshal(*r)
+}
+
+// Decode decodes the SCALE encoded input to a StateRequest
+func (r *StateRequest) Decode(in []byte) error {
+	return scale.Unmarshal(in, r)
+}
+
+// String formats a StateRequest as a string
+func (r *StateRequest) String() string {
+	return fmt.Sprintf("StateRequest Block=%s Start=%x NoProof=%t", r.Block, r.Start, r.NoProof)
+}
+
+// StateEntry is a key-value pair of a state trie
+type StateEntry struct {
+	Key   []byte
+	Value []byte
+}
+
+// KeyValueStateEntry holds consecutive entries of the top trie or of a child trie
+type KeyValueStateEntry struct {
+	// ChildKey is the key of the child trie without the child storage key prefix. It is empty for the top trie.
+	ChildKey []byte
+	Entries  []StateEntry
+	// Complete is true if the entries include the last key of the trie
+	Complete bool
+	// Proof proves the entries against the root of the trie, unless no proof was requested
+	Proof [][]byte
+}
+
+// StateResponse is the response to a StateRequest
+type StateResponse struct {
+	Entries []KeyValueStateEntry
+}
+
+// SubProtocol returns the state sub-protocol
+func (r *StateResponse) SubProtocol() string {
+	return stateID
+}
+
+// Encode returns the SCALE encoded StateResponse
+func (r *StateResponse) Encode() ([]byte, error) {
+	return scale.Marshal(*r)
+}
+
+// Decode decodes the SCALE encoded input to a StateResponse
+func (r *StateResponse) Decode(in []byte) error {
+	return scale.Unmarshal(in, r)
+}
+
+// String formats a StateResponse as a string
+func (r *StateResponse) String() string {
+	return fmt.Sprintf("StateResponse Entries=%d", len(r.Entries))
+}
+
+// DoStateRequest sends a state request to the given peer.
+// If a response is received within a certain time period, it is returned,
+// otherwise an error is returned.
+func (s *Service) DoStateRequest(to peer.ID, req *StateRequest) (*StateResponse, error) {
+	fullStateID := s.host.protocolID + stateID
+
+	s.host.h.ConnManager().Protect(to, "")
+	defer s.host.h.ConnManager().Unprotect(to, "")
+
+	ctx, cancel := context.WithTimeout(s.ctx, stateRequestTimeout)
+	defer cancel()
+
+	stream, err := s.host.h.NewStream(ctx, to, fullStateID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	if err = s.host.writeToStream(stream, req); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, maxBlockResponseSize)
+	n, err := readStream(stream, buf)
+	if err != nil {
+		return nil, fmt.Errorf("read stream error: %w", err)
+	}
+
+	if n == 0 {
+		return nil, fmt.Errorf("received empty message")
+	}
+
+	resp := new(StateResponse)
+	if err = resp.Decode(buf[:n]); err != nil {
+		s.host.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.BadMessageValue,
+			Reason: peerset.BadMessageReason,
+		}, to)
+		return nil, fmt.Errorf("failed to decode state response: %w", err)
+	}
+
+	return resp, nil
+}
+
+// handleStateStream handles streams with the <protocol-id>/sync/gossamer-state/1 protocol ID
+func (s *Service) handleStateStream(stream libp2pnetwork.Stream) {
+	if stream == nil {
+		return
+	}
+
+	s.readStream(stream, decodeStateMessage, s.handleStateMessage)
+}
+
+func decodeStateMessage(in []byte, _ peer.ID, _ bool) (Message, error) {
+	msg := new(StateRequest)
+	err := msg.Decode(in)
+	return msg, err
+}
+
+// handleStateMessage handles inbound state streams, which only carry StateRequests
+func (s *Service) handleStateMessage(stream libp2pnetwork.Stream, msg Message) error {
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	req, ok := msg.(*StateRequest)
+	if !ok {
+		return nil
+	}
+
+	resp, err := s.syncer.CreateStateResponse(req)
+	if err != nil {
+		logger.Debugf("cannot create response for request: %s", err)
+		return nil
+	}
+
+	if err = s.host.writeToStream(stream, resp); err != nil {
+		logger.Debugf("failed to send StateResponse message to peer %s: %s", stream.Conn().RemotePeer(), err)
+		return err
+	}
+
+	return nil
+}
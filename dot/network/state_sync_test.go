@@ -0,0 +1,57 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeStateRequest(t *testing.T) {
+	req := &StateRequest{
+		Block:   common.Hash{0x01},
+		Start:   [][]byte{{1, 2}, {3}},
+		NoProof: true,
+	}
+
+	enc, err := req.Encode()
+	require.NoError(t, err)
+
+	res := new(StateRequest)
+	err = res.Decode(enc)
+	require.NoError(t, err)
+	require.Equal(t, req, res)
+}
+
+func TestEncodeStateResponse(t *testing.T) {
+	resp := &StateResponse{
+		Entries: []KeyValueStateEntry{
+			{
+				ChildKey: []byte{},
+				Entries: []StateEntry{
+					{Key: []byte("key"), Value: []byte("value")},
+				},
+				Proof: [][]byte{{1, 2, 3}},
+			},
+			{
+				ChildKey: []byte("child"),
+				Entries: []StateEntry{
+					{Key: []byte("childkey"), Value: []byte("childvalue")},
+				},
+				Complete: true,
+			},
+		},
+	}
+
+	enc, err := resp.Encode()
+	require.NoError(t, err)
+
+	res := new(StateResponse)
+	err = res.Decode(enc)
+	require.NoError(t, err)
+	require.Equal(t, resp, res)
+}
@@ -23,6 +23,7 @@ var (
 // otherwise an error is returned.
 func (s *Service) DoBlockRequest(to peer.ID, req *BlockRequestMessage) (*BlockResponseMessage, error) {
 	fullSyncID := s.host.protocolID + syncID
+	protocol := string(fullSyncID)
 
 	s.host.h.ConnManager().Protect(to, "")
 	defer s.host.h.ConnManager().Unprotect(to, "")
@@ -30,8 +31,10 @@ func (s *Service) DoBlockRequest(to peer.ID, req *BlockRequestMessage) (*BlockRe
 	ctx, cancel := context.WithTimeout(s.ctx, blockRequestTimeout)
 	defer cancel()
 
+	start := time.Now()
 	stream, err := s.host.h.NewStream(ctx, to, fullSyncID)
 	if err != nil {
+		requestsOutFailure.WithLabelValues(protocol, "dial-failure").Inc()
 		return nil, err
 	}
 
@@ -40,10 +43,18 @@ func (s *Service) DoBlockRequest(to peer.ID, req *BlockRequestMessage) (*BlockRe
 	}()
 
 	if err = s.host.writeToStream(stream, req); err != nil {
+		requestsOutFailure.WithLabelValues(protocol, "network-error").Inc()
 		return nil, err
 	}
 
-	return s.receiveBlockResponse(stream)
+	resp, err := s.receiveBlockResponse(stream)
+	if err != nil {
+		requestsOutFailure.WithLabelValues(protocol, "network-error").Inc()
+		return nil, err
+	}
+
+	requestsOutSuccess.WithLabelValues(protocol).Observe(time.Since(start).Seconds())
+	return resp, nil
 }
 
 func (s *Service) receiveBlockResponse(stream libp2pnetwork.Stream) (*BlockResponseMessage, error) {
@@ -0,0 +1,270 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+
+	libp2pnetwork "github.com/libp2p/go-libp2p-core/network"
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+var (
+	warpSyncRequestTimeout = time.Second * 10
+	errNoWarpSyncProvider  = errors.New("no warp sync provider set")
+)
+
+var (
+	_ Message = &WarpSyncProofRequest{}
+	_ Message = &WarpSyncProof{}
+)
+
+// WarpSyncProofRequest is sent to request a proof of the GRANDPA authority set changes
+// that happened after the given finalised block
+type WarpSyncProofRequest struct {
+	Begin common.Hash
+}
+
+// SubProtocol returns the warp sync sub-protocol
+func (r *WarpSyncProofRequest) SubProtocol() string {
+	return warpSyncID
+}
+
+// Encode returns the SCALE encoded WarpSyncProofRequest
+func (r *WarpSyncProofRequest) Encode() ([]byte, error) {
+	return scale.Marshal(*r)
+}
+
+// Decode decodes the SCALE encoded input to a WarpSyncProofRequest
+func (r *WarpSyncProofRequest) Decode(in []byte) error {
+	return scale.Unmarshal(in, r)
+}
+
+// String formats a WarpSyncProofRequest as a string
+func (r *WarpSyncProofRequest) String() string {
+	return fmt.Sprintf("WarpSyncProofRequest Begin=%s", r.Begin)
+}
+
+// WarpSyncFragment proves that a GRANDPA authority set finalised a block
+type WarpSyncFragment struct {
+	// Header is the header signalling the authority set change. For the last fragment of a finished proof,
+	// it is the latest finalised header, which may not signal a change.
+	Header *types.Header
+	// Justification is the GRANDPA justification, signed by the current authority set, of Header
+	// or of a block in Ancestry
+	Justification []byte
+	// Ancestry holds the headers between Header and the blocks voted for in Justification,
+	// ordered from parent to child
+	Ancestry []*types.Header
+}
+
+type encodedWarpSyncFragment struct {
+	Header        []byte
+	Justification []byte
+	Ancestry      [][]byte
+}
+
+type encodedWarpSyncProof struct {
+	Fragments  []encodedWarpSyncFragment
+	IsFinished bool
+}
+
+// WarpSyncProof is the response to a WarpSyncProofRequest. It holds one fragment for each authority set change,
+// ordered by set ID.
+type WarpSyncProof struct {
+	Fragments []*WarpSyncFragment
+	// IsFinished is true if the last fragment justifies the latest finalised block of the responder.
+	// Otherwise, another request starting from the last fragment is needed.
+	IsFinished bool
+}
+
+// SubProtocol returns the warp sync sub-protocol
+func (p *WarpSyncProof) SubProtocol() string {
+	return warpSyncID
+}
+
+// Encode returns the SCALE encoded WarpSyncProof
+func (p *WarpSyncProof) Encode() ([]byte, error) {
+	enc := encodedWarpSyncProof{
+		Fragments:  make([]encodedWarpSyncFragment, len(p.Fragments)),
+		IsFinished: p.IsFinished,
+	}
+
+	for i, f := range p.Fragments {
+		header, err := scale.Marshal(*f.Header)
+		if err != nil {
+			return nil, err
+		}
+
+		ancestry := make([][]byte, len(f.Ancestry))
+		for j, h := range f.Ancestry {
+			ancestry[j], err = scale.Marshal(*h)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		enc.Fragments[i] = encodedWarpSyncFragment{
+			Header:        header,
+			Justification: f.Justification,
+			Ancestry:      ancestry,
+		}
+	}
+
+	return scale.Marshal(enc)
+}
+
+// Decode decodes the SCALE encoded input to a WarpSyncProof
+func (p *WarpSyncProof) Decode(in []byte) error {
+	var enc encodedWarpSyncProof
+	err := scale.Unmarshal(in, &enc)
+	if err != nil {
+		return err
+	}
+
+	p.IsFinished = enc.IsFinished
+	p.Fragments = make([]*WarpSyncFragment, len(enc.Fragments))
+
+	for i, f := range enc.Fragments {
+		header, err := decodeHeader(f.Header)
+		if err != nil {
+			return err
+		}
+
+		ancestry := make([]*types.Header, len(f.Ancestry))
+		for j, h := range f.Ancestry {
+			ancestry[j], err = decodeHeader(h)
+			if err != nil {
+				return err
+			}
+		}
+
+		p.Fragments[i] = &WarpSyncFragment{
+			Header:        header,
+			Justification: f.Justification,
+			Ancestry:      ancestry,
+		}
+	}
+
+	return nil
+}
+
+// String formats a WarpSyncProof as a string
+func (p *WarpSyncProof) String() string {
+	return fmt.Sprintf("WarpSyncProof Fragments=%d IsFinished=%t", len(p.Fragments), p.IsFinished)
+}
+
+func decodeHeader(in []byte) (*types.Header, error) {
+	header := types.NewEmptyHeader()
+	err := scale.Unmarshal(in, header)
+	if err != nil {
+		return nil, err
+	}
+
+	return header, nil
+}
+
+// SetWarpSyncProvider sets the WarpSyncProvider used to answer warp sync requests
+func (s *Service) SetWarpSyncProvider(provider WarpSyncProvider) {
+	s.warpSyncProvider = provider
+}
+
+// DoWarpSyncRequest sends a warp sync proof request to the given peer.
+// If a response is received within a certain time period, it is returned,
+// otherwise an error is returned.
+func (s *Service) DoWarpSyncRequest(to peer.ID, req *WarpSyncProofRequest) (*WarpSyncProof, error) {
+	fullWarpSyncID := s.host.protocolID + warpSyncID
+
+	s.host.h.ConnManager().Protect(to, "")
+	defer s.host.h.ConnManager().Unprotect(to, "")
+
+	ctx, cancel := context.WithTimeout(s.ctx, warpSyncRequestTimeout)
+	defer cancel()
+
+	stream, err := s.host.h.NewStream(ctx, to, fullWarpSyncID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	if err = s.host.writeToStream(stream, req); err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, maxBlockResponseSize)
+	n, err := readStream(stream, buf)
+	if err != nil {
+		return nil, fmt.Errorf("read stream error: %w", err)
+	}
+
+	if n == 0 {
+		return nil, fmt.Errorf("received empty message")
+	}
+
+	proof := new(WarpSyncProof)
+	if err = proof.Decode(buf[:n]); err != nil {
+		s.host.cm.peerSetHandler.ReportPeer(peerset.ReputationChange{
+			Value:  peerset.BadMessageValue,
+			Reason: peerset.BadMessageReason,
+		}, to)
+		return nil, fmt.Errorf("failed to decode warp sync proof: %w", err)
+	}
+
+	return proof, nil
+}
+
+// handleWarpSyncStream handles streams with the <protocol-id>/sync/gossamer-warp/1 protocol ID
+func (s *Service) handleWarpSyncStream(stream libp2pnetwork.Stream) {
+	if stream == nil {
+		return
+	}
+
+	s.readStream(stream, decodeWarpSyncMessage, s.handleWarpSyncMessage)
+}
+
+func decodeWarpSyncMessage(in []byte, _ peer.ID, _ bool) (Message, error) {
+	msg := new(WarpSyncProofRequest)
+	err := msg.Decode(in)
+	return msg, err
+}
+
+// handleWarpSyncMessage handles inbound warp sync streams, which only carry WarpSyncProofRequests
+func (s *Service) handleWarpSyncMessage(stream libp2pnetwork.Stream, msg Message) error {
+	defer func() {
+		_ = stream.Close()
+	}()
+
+	req, ok := msg.(*WarpSyncProofRequest)
+	if !ok {
+		return nil
+	}
+
+	if s.warpSyncProvider == nil {
+		return errNoWarpSyncProvider
+	}
+
+	proof, err := s.warpSyncProvider.GenerateWarpSyncProof(req.Begin)
+	if err != nil {
+		logger.Debugf("cannot create warp sync proof for request: %s", err)
+		return nil
+	}
+
+	if err = s.host.writeToStream(stream, proof); err != nil {
+		logger.Debugf("failed to send WarpSyncProof message to peer %s: %s", stream.Conn().RemotePeer(), err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package network
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeWarpSyncProofRequest(t *testing.T) {
+	req := &WarpSyncProofRequest{
+		Begin: common.Hash{0x01},
+	}
+
+	enc, err := req.Encode()
+	require.NoError(t, err)
+
+	res := new(WarpSyncProofRequest)
+	err = res.Decode(enc)
+	require.NoError(t, err)
+	require.Equal(t, req, res)
+}
+
+func TestEncodeWarpSyncProof(t *testing.T) {
+	header := &types.Header{
+		ParentHash: common.Hash{0x02},
+		Number:     big.NewInt(7),
+		Digest:     types.NewDigest(),
+	}
+
+	child := &types.Header{
+		ParentHash: header.Hash(),
+		Number:     big.NewInt(8),
+		Digest:     types.NewDigest(),
+	}
+
+	proof := &WarpSyncProof{
+		Fragments: []*WarpSyncFragment{
+			{
+				Header:        header,
+				Justification: []byte{1, 2, 3},
+				Ancestry:      []*types.Header{child},
+			},
+		},
+		IsFinished: true,
+	}
+
+	enc, err := proof.Encode()
+	require.NoError(t, err)
+
+	res := new(WarpSyncProof)
+	err = res.Decode(enc)
+	require.NoError(t, err)
+	require.True(t, res.IsFinished)
+	require.Len(t, res.Fragments, 1)
+	require.Equal(t, header.Hash(), res.Fragments[0].Header.Hash())
+	require.Equal(t, []byte{1, 2, 3}, res.Fragments[0].Justification)
+	require.Len(t, res.Fragments[0].Ancestry, 1)
+	require.Equal(t, child.Hash(), res.Fragments[0].Ancestry[0].Hash())
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/ChainSafe/gossamer/dot/metrics"
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/rpc"
+	"github.com/ChainSafe/gossamer/dot/rpc/modules"
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/state/pruner"
 	"github.com/ChainSafe/gossamer/dot/telemetry"
@@ -28,6 +29,7 @@ import (
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/services"
+	"github.com/ChainSafe/gossamer/lib/signer"
 	"github.com/ChainSafe/gossamer/lib/utils"
 )
 
@@ -181,11 +183,25 @@ func NewNode(cfg *Config, ks *keystore.GlobalKeystore) (*Node, error) {
 
 	logger.Patch(log.SetLevel(cfg.Global.LogLvl))
 
-	// if authority node, should have at least 1 key in keystore
-	if cfg.Core.Roles == types.AuthorityRole && (ks.Babe.Size() == 0 || ks.Gran.Size() == 0) {
+	// light clients only sync and verify headers, they keep no state and produce no blocks
+	isLightClient := cfg.Core.Roles == types.LightClientRole
+
+	loaded, err := keystore.LoadSessionKeys(ks, cfg.Global.BasePath, []byte(cfg.Account.SessionKeysPassword))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load session keys: %w", err)
+	}
+	if loaded > 0 {
+		logger.Infof("loaded %d session keys", loaded)
+	}
+
+	// if authority node, should have at least 1 key in keystore, unless the keys are held by a signing server
+	if cfg.Core.Roles == types.AuthorityRole && cfg.Account.RemoteSigner == "" &&
+		(ks.Babe.Size() == 0 || ks.Gran.Size() == 0) {
 		return nil, ErrNoKeysProvided
 	}
 
+	sgn := createSigner(cfg, ks)
+
 	logger.Infof(
 		"🕸️ initialising node services with global configuration name %s, id %s and base path %s...",
 		cfg.Global.Name, cfg.Global.ID, cfg.Global.BasePath)
@@ -204,6 +220,11 @@ func NewNode(cfg *Config, ks *keystore.GlobalKeystore) (*Node, error) {
 		return nil, fmt.Errorf("failed to create state service: %s", err)
 	}
 
+	err = setSS58Format(stateSrvc)
+	if err != nil {
+		return nil, err
+	}
+
 	// check if network service is enabled
 	if enabled := networkServiceEnabled(cfg); enabled {
 		// create network service and append network service to node services
@@ -223,9 +244,11 @@ func NewNode(cfg *Config, ks *keystore.GlobalKeystore) (*Node, error) {
 		return nil, err
 	}
 
-	err = loadRuntime(cfg, ns, stateSrvc, ks, networkSrvc)
-	if err != nil {
-		return nil, err
+	if !isLightClient {
+		err = loadRuntime(cfg, ns, stateSrvc, ks, sgn, networkSrvc)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	ver, err := createBlockVerifier(stateSrvc)
@@ -245,13 +268,13 @@ func NewNode(cfg *Config, ks *keystore.GlobalKeystore) (*Node, error) {
 	}
 	nodeSrvcs = append(nodeSrvcs, coreSrvc)
 
-	fg, err := createGRANDPAService(cfg, stateSrvc, dh, ks.Gran, networkSrvc)
+	fg, err := createGRANDPAService(cfg, stateSrvc, dh, ks.Gran, sgn, networkSrvc)
 	if err != nil {
 		return nil, err
 	}
 	nodeSrvcs = append(nodeSrvcs, fg)
 
-	syncer, err := newSyncService(cfg, stateSrvc, fg, ver, coreSrvc, networkSrvc)
+	syncer, err := newSyncService(cfg, stateSrvc, fg, ver, coreSrvc, networkSrvc, dh)
 	if err != nil {
 		return nil, err
 	}
@@ -259,14 +282,22 @@ func NewNode(cfg *Config, ks *keystore.GlobalKeystore) (*Node, error) {
 	if networkSrvc != nil {
 		networkSrvc.SetSyncer(syncer)
 		networkSrvc.SetTransactionHandler(coreSrvc)
+		networkSrvc.SetWarpSyncProvider(fg)
+		if !isLightClient {
+			networkSrvc.SetLightProvider(coreSrvc)
+		}
 	}
 	nodeSrvcs = append(nodeSrvcs, syncer)
 
-	bp, err := createBABEService(cfg, stateSrvc, ks.Babe, coreSrvc)
-	if err != nil {
-		return nil, err
+	var bp modules.BlockProducerAPI
+	if !isLightClient {
+		babeSrvc, err := createBABEService(cfg, stateSrvc, ks.Babe, sgn, coreSrvc)
+		if err != nil {
+			return nil, err
+		}
+		nodeSrvcs = append(nodeSrvcs, babeSrvc)
+		bp = babeSrvc
 	}
-	nodeSrvcs = append(nodeSrvcs, bp)
 
 	sysSrvc, err := createSystemService(&cfg.System, stateSrvc)
 	if err != nil {
@@ -399,11 +430,12 @@ func (n *Node) Start() error {
 func (n *Node) Stop() {
 	// stop all node services
 	n.Services.StopAll()
+	telemetry.GetInstance().Stop()
 	n.wg.Done()
 }
 
 func loadRuntime(cfg *Config, ns *runtime.NodeStorage,
-	stateSrvc *state.Service, ks *keystore.GlobalKeystore,
+	stateSrvc *state.Service, ks *keystore.GlobalKeystore, sgn signer.Signer,
 	net *network.Service) error {
 	blocks := stateSrvc.Block.GetNonFinalisedBlocks()
 	runtimeCode := make(map[string]runtime.Instance)
@@ -424,7 +456,7 @@ func loadRuntime(cfg *Config, ns *runtime.NodeStorage,
 			continue
 		}
 
-		rt, err := createRuntime(cfg, *ns, stateSrvc, ks, net, code)
+		rt, err := createRuntime(cfg, *ns, stateSrvc, ks, sgn, net, code)
 		if err != nil {
 			return err
 		}
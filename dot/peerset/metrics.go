@@ -0,0 +1,14 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package peerset
+
+import (
+	"github.com/ChainSafe/gossamer/dot/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var reputationChanges = metrics.NewCounterVec(prometheus.CounterOpts{
+	Name: "sub_libp2p_peerset_reputation_changes_total",
+	Help: "Reputation changes reported for peers",
+}, []string{"reason"})
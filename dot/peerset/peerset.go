@@ -37,7 +37,7 @@ const (
 	removeReservedPeer
 	// setReservedPeers is for setting peerList in peerSet reserved peers
 	setReservedPeers
-	// setReservedOnly is for setting peerList in peerSet reserved peers only
+	// setReservedOnly is for enabling or disabling the reserved-only mode of a set
 	setReservedOnly
 	// reportPeer is for reporting peers if it misbehaves
 	reportPeer
@@ -86,6 +86,7 @@ type action struct {
 	setID         int
 	reputation    ReputationChange
 	peers         peer.IDSlice
+	reservedOnly  bool
 	resultPeersCh chan peer.IDSlice
 }
 
@@ -121,6 +122,11 @@ type Message struct {
 	PeerID peer.ID
 }
 
+// SetID returns the index of the set the message is about.
+func (m Message) SetID() int {
+	return int(m.setID)
+}
+
 // Reputation represents reputation value of the node
 type Reputation int32
 
@@ -164,10 +170,10 @@ func newReputationChange(value Reputation, reason string) ReputationChange {
 type PeerSet struct {
 	peerState *PeersState
 
-	reservedNode map[peer.ID]struct{}
-	// TODO: this will be useful for reserved only mode
-	// this is for future purpose if reserved-only flag is enabled (#1888).
-	isReservedOnly bool
+	// reserved nodes of each set.
+	reservedNode []map[peer.ID]struct{}
+	// if true for a set, only reserved nodes are dialled and accepted in the set.
+	isReservedOnly []bool
 	resultMsgCh    chan Message
 	// time when the PeerSet was created.
 	created time.Time
@@ -187,8 +193,7 @@ type config struct {
 	// maximum number of slot occupying nodes for outgoing connections.
 	maxOutPeers uint32
 
-	// TODO Use in future for reserved only peers
-	// if true, we only accept reservedNodes (#1888).
+	// if true, we only connect to and accept reservedNodes.
 	reservedOnly bool
 
 	// time duration for a peerSet to periodically call allocSlots.
@@ -210,12 +215,28 @@ func NewConfigSet(maxInPeers, maxOutPeers uint32, reservedOnly bool, allocTime t
 	}
 
 	return &ConfigSet{
-		// Why are we using an array of config in the set, when we are
-		// using just one config
 		Set: []*config{set},
 	}
 }
 
+// AddSet adds a set with its own slot limits to the config set and returns its index.
+// The periodic allocation time of the first set is used for every set.
+func (c *ConfigSet) AddSet(maxInPeers, maxOutPeers uint32, reservedOnly bool) int {
+	var allocTime time.Duration
+	if len(c.Set) > 0 {
+		allocTime = c.Set[0].periodicAllocTime
+	}
+
+	c.Set = append(c.Set, &config{
+		maxInPeers:        maxInPeers,
+		maxOutPeers:       maxOutPeers,
+		reservedOnly:      reservedOnly,
+		periodicAllocTime: allocTime,
+	})
+
+	return len(c.Set) - 1
+}
+
 func newPeerSet(cfg *ConfigSet) (*PeerSet, error) {
 	if len(cfg.Set) == 0 {
 		return nil, ErrConfigSetIsEmpty
@@ -226,16 +247,21 @@ func newPeerSet(cfg *ConfigSet) (*PeerSet, error) {
 		return nil, err
 	}
 
-	// TODO: currently we only have one set, change this once we have more (#1886).
-	cfgSet := cfg.Set[0]
+	reservedNode := make([]map[peer.ID]struct{}, len(cfg.Set))
+	isReservedOnly := make([]bool, len(cfg.Set))
+	for i, set := range cfg.Set {
+		reservedNode[i] = make(map[peer.ID]struct{})
+		isReservedOnly[i] = set.reservedOnly
+	}
+
 	now := time.Now()
 	ps := &PeerSet{
 		peerState:              peerState,
-		reservedNode:           make(map[peer.ID]struct{}),
-		isReservedOnly:         cfgSet.reservedOnly,
+		reservedNode:           reservedNode,
+		isReservedOnly:         isReservedOnly,
 		created:                now,
 		latestTimeUpdate:       now,
-		nextPeriodicAllocSlots: cfgSet.periodicAllocTime,
+		nextPeriodicAllocSlots: cfg.Set[0].periodicAllocTime,
 	}
 
 	return ps, nil
@@ -325,6 +351,7 @@ func (ps *PeerSet) reportPeer(change ReputationChange, peers ...peer.ID) error {
 			return err
 		}
 
+		reputationChanges.WithLabelValues(change.Reason).Inc()
 		rep := n.addReputation(change.Value)
 		ps.peerState.nodes[pid] = n
 		if rep >= BannedThresholdValue {
@@ -362,7 +389,7 @@ func (ps *PeerSet) allocSlots(setIdx int) error {
 	}
 
 	peerState := ps.peerState
-	for reservePeer := range ps.reservedNode {
+	for reservePeer := range ps.reservedNode[setIdx] {
 		status := peerState.peerStatus(setIdx, reservePeer)
 		switch status {
 		case connectedPeer:
@@ -395,7 +422,7 @@ func (ps *PeerSet) allocSlots(setIdx int) error {
 	}
 
 	// nothing more to do if we're in reserved mode.
-	if ps.isReservedOnly {
+	if ps.isReservedOnly[setIdx] {
 		return nil
 	}
 
@@ -429,14 +456,14 @@ func (ps *PeerSet) allocSlots(setIdx int) error {
 
 func (ps *PeerSet) addReservedPeers(setID int, peers ...peer.ID) error {
 	for _, peerID := range peers {
-		if _, ok := ps.reservedNode[peerID]; ok {
+		if _, ok := ps.reservedNode[setID][peerID]; ok {
 			logger.Debugf("peer %s already exists in peerSet", peerID)
 			return nil
 		}
 
 		ps.peerState.discover(setID, peerID)
 
-		ps.reservedNode[peerID] = struct{}{}
+		ps.reservedNode[setID][peerID] = struct{}{}
 		if err := ps.peerState.addNoSlotNode(setID, peerID); err != nil {
 			return fmt.Errorf("could not add to list of no-slot nodes: %w", err)
 		}
@@ -449,24 +476,23 @@ func (ps *PeerSet) addReservedPeers(setID int, peers ...peer.ID) error {
 
 func (ps *PeerSet) removeReservedPeers(setID int, peers ...peer.ID) error {
 	for _, peerID := range peers {
-		if _, ok := ps.reservedNode[peerID]; !ok {
+		if _, ok := ps.reservedNode[setID][peerID]; !ok {
 			logger.Debugf("peer %s doesn't exist in the peerSet", peerID)
 			return nil
 		}
 
-		delete(ps.reservedNode, peerID)
+		delete(ps.reservedNode[setID], peerID)
 		if err := ps.peerState.removeNoSlotNode(setID, peerID); err != nil {
 			return fmt.Errorf("could not remove from the list of no-slot nodes: %w", err)
 		}
 
 		// nothing more to do if not in reservedOnly mode.
-		if !ps.isReservedOnly {
-			return nil
+		if !ps.isReservedOnly[setID] {
+			continue
 		}
 
-		// reservedOnly mode is not yet implemented for future this code will help.
-		// If however the peerSet is in reserved-only mode, then non-reserved node peers needs to be
-		// disconnected.
+		// If however the set is in reserved-only mode, then the peer is no longer
+		// allowed in the set and needs to be disconnected.
 		if ps.peerState.peerStatus(setID, peerID) == connectedPeer {
 			err := ps.peerState.disconnect(setID, peerID)
 			if err != nil {
@@ -489,13 +515,13 @@ func (ps *PeerSet) setReservedPeer(setID int, peers ...peer.ID) error {
 	peerIDMap := make(map[peer.ID]struct{}, len(peers))
 	for _, pid := range peers {
 		peerIDMap[pid] = struct{}{}
-		if _, ok := ps.reservedNode[pid]; ok {
+		if _, ok := ps.reservedNode[setID][pid]; ok {
 			continue
 		}
 		toInsert = append(toInsert, pid)
 	}
 
-	for pid := range ps.reservedNode {
+	for pid := range ps.reservedNode[setID] {
 		if _, ok := peerIDMap[pid]; ok {
 			continue
 		}
@@ -509,6 +535,37 @@ func (ps *PeerSet) setReservedPeer(setID int, peers ...peer.ID) error {
 	return ps.removeReservedPeers(setID, toRemove...)
 }
 
+// setReservedOnly enables or disables the reserved-only mode of the set. When enabled,
+// the connected peers which aren't reserved are dropped, otherwise free slots are filled.
+func (ps *PeerSet) setReservedOnly(setID int, reservedOnly bool) error {
+	ps.isReservedOnly[setID] = reservedOnly
+	if !reservedOnly {
+		return ps.allocSlots(setID)
+	}
+
+	for _, pid := range ps.peerState.peers() {
+		if _, ok := ps.reservedNode[setID][pid]; ok {
+			continue
+		}
+
+		if ps.peerState.peerStatus(setID, pid) != connectedPeer {
+			continue
+		}
+
+		if err := ps.peerState.disconnect(setID, pid); err != nil {
+			return err
+		}
+
+		ps.resultMsgCh <- Message{
+			Status: Drop,
+			setID:  uint64(setID),
+			PeerID: pid,
+		}
+	}
+
+	return nil
+}
+
 func (ps *PeerSet) addPeer(setID int, peers peer.IDSlice) error {
 	for _, pid := range peers {
 		if ps.peerState.peerStatus(setID, pid) != unknownPeer {
@@ -525,7 +582,7 @@ func (ps *PeerSet) addPeer(setID int, peers peer.IDSlice) error {
 
 func (ps *PeerSet) removePeer(setID int, peers ...peer.ID) error {
 	for _, pid := range peers {
-		if _, ok := ps.reservedNode[pid]; ok {
+		if _, ok := ps.reservedNode[setID][pid]; ok {
 			logger.Debugf("peer %s is reserved and cannot be removed", pid)
 			return nil
 		}
@@ -563,10 +620,9 @@ func (ps *PeerSet) incoming(setID int, peers ...peer.ID) error {
 		return err
 	}
 
-	// This is for reserved only mode.
 	for _, pid := range peers {
-		if ps.isReservedOnly {
-			if _, ok := ps.reservedNode[pid]; !ok {
+		if ps.isReservedOnly[setID] {
+			if _, ok := ps.reservedNode[setID][pid]; !ok {
 				ps.resultMsgCh <- Message{
 					Status: Reject,
 					setID:  uint64(setID),
@@ -700,8 +756,7 @@ func (ps *PeerSet) doWork() {
 				// TODO: this is not used yet, might required to implement RPC Call for this.
 				err = ps.setReservedPeer(act.setID, act.peers...)
 			case setReservedOnly:
-				// TODO: not yet implemented (#1888)
-				err = fmt.Errorf("not implemented yet")
+				err = ps.setReservedOnly(act.setID, act.reservedOnly)
 			case reportPeer:
 				err = ps.reportPeer(act.reputation, act.peers...)
 			case addToPeerSet:
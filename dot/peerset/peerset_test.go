@@ -178,8 +178,84 @@ func TestSetReservePeer(t *testing.T) {
 	handler.SetReservedPeer(0, newRsrPeerSet...)
 	time.Sleep(200 * time.Millisecond)
 
-	require.Equal(t, len(newRsrPeerSet), len(ps.reservedNode))
+	require.Equal(t, len(newRsrPeerSet), len(ps.reservedNode[0]))
 	for _, p := range newRsrPeerSet {
-		require.Contains(t, ps.reservedNode, p)
+		require.Contains(t, ps.reservedNode[0], p)
 	}
 }
+
+func TestReservedOnly(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestPeerSet(t, 25, 25, []peer.ID{bootNode}, []peer.ID{reservedPeer}, true)
+	ps := handler.peerSet
+
+	// only the reserved peer is dialled.
+	require.Equal(t, 1, len(ps.resultMsgCh))
+	require.Equal(t, Message{Status: Connect, setID: 0, PeerID: reservedPeer}, <-ps.resultMsgCh)
+
+	handler.Incoming(0, incomingPeer)
+	checkMessageStatus(t, <-ps.resultMsgCh, Reject)
+
+	// the reserved peer is dropped once it is no longer reserved.
+	handler.RemoveReservedPeer(0, reservedPeer)
+	require.Equal(t, Message{Status: Drop, setID: 0, PeerID: reservedPeer}, <-ps.resultMsgCh)
+
+	// disabling the reserved-only mode fills the free slots.
+	handler.SetReservedOnly(0, false)
+	time.Sleep(time.Millisecond * 100)
+
+	require.Equal(t, 2, len(ps.resultMsgCh))
+	for len(ps.resultMsgCh) > 0 {
+		checkMessageStatus(t, <-ps.resultMsgCh, Connect)
+	}
+
+	handler.Incoming(0, incomingPeer)
+	checkMessageStatus(t, <-ps.resultMsgCh, Accept)
+
+	// enabling it again drops the peers which aren't reserved.
+	handler.SetReservedOnly(0, true)
+	time.Sleep(time.Millisecond * 100)
+
+	require.Equal(t, 3, len(ps.resultMsgCh))
+	for len(ps.resultMsgCh) > 0 {
+		checkMessageStatus(t, <-ps.resultMsgCh, Drop)
+	}
+}
+
+func TestMultipleSets(t *testing.T) {
+	t.Parallel()
+
+	cfg := NewConfigSet(1, 1, false, time.Second*2)
+	setID := cfg.AddSet(0, 1, true)
+	require.Equal(t, 1, setID)
+
+	handler, err := NewPeerSetHandler(cfg)
+	require.NoError(t, err)
+	handler.Start()
+	ps := handler.peerSet
+
+	handler.AddPeer(0, discovered1)
+	handler.AddPeer(setID, discovered1)
+	handler.AddReservedPeer(setID, reservedPeer)
+	time.Sleep(time.Millisecond * 100)
+
+	expectedMsgs := []Message{
+		{Status: Connect, setID: 0, PeerID: discovered1},
+		{Status: Connect, setID: 1, PeerID: reservedPeer},
+	}
+
+	require.Equal(t, len(expectedMsgs), len(ps.resultMsgCh))
+	for _, expected := range expectedMsgs {
+		msg := <-ps.resultMsgCh
+		require.Equal(t, expected, msg)
+		require.Equal(t, int(expected.setID), msg.SetID())
+	}
+
+	// each set has its own slots.
+	handler.Incoming(0, incomingPeer)
+	checkMessageStatus(t, <-ps.resultMsgCh, Accept)
+
+	handler.Incoming(setID, incomingPeer)
+	checkMessageStatus(t, <-ps.resultMsgCh, Reject)
+}
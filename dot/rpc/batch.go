@@ -0,0 +1,184 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/rpc/v2"
+	"github.com/gorilla/rpc/v2/json2"
+)
+
+// maxRequestSize is the maximum size of the body of a HTTP request
+const maxRequestSize = 10 * 1024 * 1024 // 10mb
+
+type wsRequestKey struct{}
+
+// isWebSocketRequest returns true if the request was received over a websocket connection
+func isWebSocketRequest(r *http.Request) bool {
+	_, ok := r.Context().Value(wsRequestKey{}).(bool)
+	return ok
+}
+
+// batchHandler serves JSON-RPC 2.0 requests, calling the rpc server once for each request of a batch.
+// It is also used to dispatch the calls received over websocket connections directly into the rpc server.
+type batchHandler struct {
+	rpcServer *rpc.Server
+}
+
+func newBatchHandler(rpcServer *rpc.Server) *batchHandler {
+	return &batchHandler{
+		rpcServer: rpcServer,
+	}
+}
+
+// ServeHTTP implements http.Handler
+func (b *batchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		b.rpcServer.ServeHTTP(w, r)
+		return
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil && len(data) == maxRequestSize {
+		rpc.WriteError(w, http.StatusRequestEntityTooLarge,
+			fmt.Sprintf("request too large, the maximum size is %d bytes", maxRequestSize))
+		return
+	}
+
+	if err != nil {
+		rpc.WriteError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !isBatch(data) {
+		r.Body = io.NopCloser(bytes.NewReader(data))
+		b.rpcServer.ServeHTTP(w, r)
+		return
+	}
+
+	res := b.serveBatch(r, data)
+	if len(res) == 0 {
+		// a batch of notifications gets no response
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if _, err = w.Write(res); err != nil {
+		logger.Debugf("failed to write batch response: %s", err)
+	}
+}
+
+// Dispatch executes the JSON-RPC request received over the websocket connection with the given
+// remote address. It returns the encoded response, which is empty for notifications.
+func (b *batchHandler) Dispatch(remoteAddr string, data []byte) []byte {
+	ctx := context.WithValue(context.Background(), wsRequestKey{}, true)
+	r, err := http.NewRequestWithContext(ctx, http.MethodPost, "/", nil)
+	if err != nil {
+		return nil
+	}
+
+	r.RemoteAddr = remoteAddr
+	return b.serve(r, data)
+}
+
+// serveBatch executes each request of the batch and returns the array of their responses
+func (b *batchHandler) serveBatch(r *http.Request, data []byte) []byte {
+	var reqs []json.RawMessage
+	if err := json.Unmarshal(data, &reqs); err != nil {
+		return encodeErrorResponse(json2.E_PARSE, err.Error())
+	}
+
+	if len(reqs) == 0 {
+		return encodeErrorResponse(json2.E_INVALID_REQ, "empty batch")
+	}
+
+	responses := make([]json.RawMessage, 0, len(reqs))
+	for _, req := range reqs {
+		if res := b.serve(r, req); len(res) > 0 {
+			responses = append(responses, res)
+		}
+	}
+
+	if len(responses) == 0 {
+		return nil
+	}
+
+	res, err := json.Marshal(responses)
+	if err != nil {
+		return encodeErrorResponse(json2.E_INTERNAL, err.Error())
+	}
+
+	return append(res, '\n')
+}
+
+// serve calls the rpc server with a copy of the request holding the given single JSON-RPC request
+func (b *batchHandler) serve(r *http.Request, data []byte) []byte {
+	req := r.Clone(r.Context())
+	req.Body = io.NopCloser(bytes.NewReader(data))
+	req.ContentLength = int64(len(data))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := newResponseBuffer()
+	b.rpcServer.ServeHTTP(w, req)
+	return bytes.TrimSpace(w.body.Bytes())
+}
+
+// isBatch returns true if the data holds a JSON array
+func isBatch(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '['
+}
+
+type errorResponse struct {
+	Version string       `json:"jsonrpc"`
+	Error   *json2.Error `json:"error"`
+	ID      interface{}  `json:"id"`
+}
+
+func encodeErrorResponse(code json2.ErrorCode, message string) []byte {
+	res, err := json.Marshal(&errorResponse{
+		Version: "2.0",
+		Error: &json2.Error{
+			Code:    code,
+			Message: message,
+		},
+	})
+	if err != nil {
+		return nil
+	}
+
+	return append(res, '\n')
+}
+
+// responseBuffer is a http.ResponseWriter keeping the response in memory
+type responseBuffer struct {
+	header http.Header
+	body   *bytes.Buffer
+}
+
+func newResponseBuffer() *responseBuffer {
+	return &responseBuffer{
+		header: make(http.Header),
+		body:   new(bytes.Buffer),
+	}
+}
+
+// Header implements http.ResponseWriter
+func (w *responseBuffer) Header() http.Header {
+	return w.header
+}
+
+// Write implements http.ResponseWriter
+func (w *responseBuffer) Write(b []byte) (int, error) {
+	return w.body.Write(b)
+}
+
+// WriteHeader implements http.ResponseWriter
+func (*responseBuffer) WriteHeader(int) {}
@@ -0,0 +1,112 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package rpc
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/rpc/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBatchHandler(t *testing.T) *batchHandler {
+	t.Helper()
+
+	s := rpc.NewServer()
+	err := s.RegisterService(new(mockService), "mockService")
+	require.NoError(t, err)
+	s.RegisterCodec(NewDotUpCodec(), "application/json")
+
+	return newBatchHandler(s)
+}
+
+func TestBatchHandler_ServeHTTP(t *testing.T) {
+	h := newTestBatchHandler(t)
+
+	const call = `{"jsonrpc":"2.0","method":"mockService_readArray","params":[["key"],[]],"id":%s}`
+	const result = `{"jsonrpc":"2.0","result":{"Key":["key"],"Bhash":[]},"id":%s}`
+
+	testCases := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			name:     "single request",
+			body:     strings.Replace(call, "%s", "1", 1),
+			expected: strings.Replace(result, "%s", "1", 1) + "\n",
+		},
+		{
+			name:     "single request with string id",
+			body:     strings.Replace(call, "%s", `"abc"`, 1),
+			expected: strings.Replace(result, "%s", `"abc"`, 1) + "\n",
+		},
+		{
+			name: "batch",
+			body: "[" + strings.Replace(call, "%s", "1", 1) + "," +
+				strings.Replace(call, "%s", `"two"`, 1) + "," +
+				`{"jsonrpc":"2.0","method":"mockService_unknown","params":[],"id":3}` + "]",
+			expected: "[" + strings.Replace(result, "%s", "1", 1) + "," +
+				strings.Replace(result, "%s", `"two"`, 1) + "," +
+				`{"jsonrpc":"2.0","error":{"code":-32000,"message":"rpc: can't find method \"mockService.Unknown\"",` +
+				`"data":null},"id":3}` + "]\n",
+		},
+		{
+			name:     "single request with null id",
+			body:     strings.Replace(call, "%s", "null", 1),
+			expected: strings.Replace(result, "%s", "null", 1) + "\n",
+		},
+		{
+			name:     "batch of notifications",
+			body:     `[{"jsonrpc":"2.0","method":"mockService_readArray","params":[["key"],[]]}]`,
+			expected: "",
+		},
+		{
+			name:     "empty batch",
+			body:     "[]",
+			expected: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"empty batch","data":null},"id":null}` + "\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+
+			w := newMockResponseWriter()
+			h.ServeHTTP(w, req)
+			require.Equal(t, tc.expected, w.Body)
+		})
+	}
+}
+
+func TestBatchHandler_ServeHTTP_TooLarge(t *testing.T) {
+	h := newTestBatchHandler(t)
+
+	body := "[" + strings.Repeat(" ", maxRequestSize) + "]"
+	req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+
+	w := newMockResponseWriter()
+	h.ServeHTTP(w, req)
+	require.Equal(t, http.StatusRequestEntityTooLarge, w.Status)
+	require.Equal(t, "request too large, the maximum size is 10485760 bytes", w.Body)
+}
+
+func TestBatchHandler_Dispatch(t *testing.T) {
+	h := newTestBatchHandler(t)
+
+	res := h.Dispatch("127.0.0.1:1234",
+		[]byte(`{"jsonrpc":"2.0","method":"mockService_readArray","params":[["key"],[]],"id":"abc"}`))
+	require.Equal(t, `{"jsonrpc":"2.0","result":{"Key":["key"],"Bhash":[]},"id":"abc"}`, string(res))
+
+	res = h.Dispatch("127.0.0.1:1234",
+		[]byte(`{"jsonrpc":"2.0","method":"mockService_readArray","params":[["key"],[]]}`))
+	require.Empty(t, res)
+}
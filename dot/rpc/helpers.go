@@ -60,6 +60,24 @@ func rpcValidator(cfg *HTTPServerConfig, validate *validator.Validate) func(r *r
 		}
 
 		isUnsafe := modules.IsUnsafe(rpcmethod)
+
+		// websocket connections are already restricted on upgrade, only unsafe calls need checking
+		if isWebSocketRequest(r.Request) {
+			if isUnsafe && !cfg.wsUnsafeEnabled() {
+				return fmt.Errorf("unsafe rpc method %s cannot be reachable", rpcmethod)
+			}
+
+			if err = validate.Struct(v); err != nil {
+				return err
+			}
+
+			if isUnsafe && !cfg.WSUnsafeExternal {
+				return LocalRequestOnly(r, v)
+			}
+
+			return nil
+		}
+
 		if isUnsafe && !cfg.rpcUnsafeEnabled() {
 			return fmt.Errorf("unsafe rpc method %s cannot be reachable", rpcmethod)
 		}
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"net"
 	"net/http"
-	"time"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
 	"github.com/ChainSafe/gossamer/dot/rpc/subscription"
@@ -24,6 +23,7 @@ import (
 type HTTPServer struct {
 	logger       *log.Logger
 	rpcServer    *rpc.Server // Actual RPC call handler
+	batchHandler *batchHandler
 	serverConfig *HTTPServerConfig
 	wsConns      []*subscription.WSConn
 }
@@ -79,9 +79,11 @@ func NewHTTPServer(cfg *HTTPServerConfig) *HTTPServer {
 	logger = log.NewFromGlobal(log.AddContext("pkg", "rpc"))
 	logger.Patch(log.SetLevel(cfg.LogLvl))
 
+	rpcServer := rpc.NewServer()
 	server := &HTTPServer{
 		logger:       logger,
-		rpcServer:    rpc.NewServer(),
+		rpcServer:    rpcServer,
+		batchHandler: newBatchHandler(rpcServer),
 		serverConfig: cfg,
 	}
 
@@ -117,7 +119,7 @@ func (h *HTTPServer) RegisterModules(mods []string) {
 		case "syncstate":
 			srvc = modules.NewSyncStateModule(h.serverConfig.SyncStateAPI)
 		case "payment":
-			srvc = modules.NewPaymentModule(h.serverConfig.BlockAPI)
+			srvc = modules.NewPaymentModule(h.serverConfig.BlockAPI, h.serverConfig.StorageAPI)
 		default:
 			h.logger.Warn("Unrecognised module: " + mod)
 			continue
@@ -142,7 +144,7 @@ func (h *HTTPServer) Start() error {
 
 	h.logger.Infof("Starting HTTP Server on host %s and port %d...", h.serverConfig.Host, h.serverConfig.RPCPort)
 	r := mux.NewRouter()
-	r.Handle("/", h.rpcServer)
+	r.Handle("/", h.batchHandler)
 
 	validate := validator.New()
 	// Add custom validator for `common.Hash`
@@ -228,14 +230,16 @@ func (h *HTTPServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// create wsConn
-	wsc := NewWSConn(ws, h.serverConfig)
+	wsc := NewWSConn(ws, h.serverConfig, h.batchHandler)
 	h.wsConns = append(h.wsConns, wsc)
 
 	go wsc.HandleComm()
 }
 
-// NewWSConn to create new WebSocket Connection struct
-func NewWSConn(conn *websocket.Conn, cfg *HTTPServerConfig) *subscription.WSConn {
+// NewWSConn to create new WebSocket Connection struct, the calls which aren't subscriptions
+// being dispatched to the given RPC dispatcher
+func NewWSConn(conn *websocket.Conn, cfg *HTTPServerConfig,
+	dispatcher subscription.RPCDispatcher) *subscription.WSConn {
 	c := &subscription.WSConn{
 		UnsafeEnabled: cfg.wsUnsafeEnabled(),
 		Wsconn:        conn,
@@ -244,10 +248,7 @@ func NewWSConn(conn *websocket.Conn, cfg *HTTPServerConfig) *subscription.WSConn
 		BlockAPI:      cfg.BlockAPI,
 		CoreAPI:       cfg.CoreAPI,
 		TxStateAPI:    cfg.TransactionQueueAPI,
-		RPCHost:       fmt.Sprintf("http://%s:%d/", cfg.Host, cfg.RPCPort),
-		HTTP: &http.Client{
-			Timeout: time.Second * 30,
-		},
+		RPC:           dispatcher,
 	}
 	return c
 }
@@ -31,8 +31,9 @@ type serverRequest struct {
 
 	// The request id. MUST be a string, number or null.
 	// Our implementation will not do type checking for id.
-	// It will be copied as it is.
-	ID *json.RawMessage `json:"id"`
+	// It will be copied as it is. It is empty for notifications,
+	// while a null id is kept as it is.
+	ID json.RawMessage `json:"id"`
 }
 
 // serverResponse represents a JSON-RPC response returned by the server.
@@ -51,7 +52,7 @@ type serverResponse struct {
 	Error *json2.Error `json:"error,omitempty"`
 
 	// This must be the same id as the request it is responding to.
-	ID *json.RawMessage `json:"id"`
+	ID json.RawMessage `json:"id"`
 }
 
 // ----------------------------------------------------------------------------
@@ -229,7 +230,7 @@ func (c CodecRequest) tryToMapIfNotAnErrorAlready(err error) error {
 func (c *CodecRequest) writeServerResponse(w http.ResponseWriter, res *serverResponse) {
 	// ID is null for notifications and they don't have a response, unless we couldn't even parse the JSON, in that
 	// case we can't know whether it was intended to be a notification
-	if c.request.ID != nil || isParseErrorResponse(res) {
+	if len(c.request.ID) > 0 || isParseErrorResponse(res) {
 		w.Header().Set("Content-Type", "application/json; charset=utf-8")
 		encoder := json.NewEncoder(c.encoder.Encode(w))
 		err := encoder.Encode(res)
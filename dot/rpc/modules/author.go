@@ -5,6 +5,7 @@ package modules
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
@@ -39,10 +40,10 @@ type Extrinsic struct {
 	Data string
 }
 
-// ExtrinsicOrHash is a type for Hash and Extrinsic array of bytes
+// ExtrinsicOrHash identifies an extrinsic either by its hash or by its hex-encoded bytes
 type ExtrinsicOrHash struct {
-	Hash      common.Hash
-	Extrinsic []byte
+	Hash      common.Hash `json:"hash"`
+	Extrinsic string      `json:"extrinsic"`
 }
 
 // ExtrinsicOrHashRequest is a array of ExtrinsicOrHash
@@ -57,8 +58,8 @@ type PendingExtrinsicsResponse []string
 // RemoveExtrinsicsResponse is a array of hash used to Remove extrinsics
 type RemoveExtrinsicsResponse []common.Hash
 
-// KeyRotateResponse is a byte array used to rotate
-type KeyRotateResponse []byte
+// KeyRotateResponse is the hex encoded public session keys returned by author_rotateKeys
+type KeyRotateResponse string
 
 // HasSessionKeyResponse is the response to the RPC call author_hasSessionKeys
 type HasSessionKeyResponse bool
@@ -146,12 +147,8 @@ func (am *AuthorModule) HasSessionKeys(r *http.Request, req *HasSessionKeyReques
 func (am *AuthorModule) InsertKey(r *http.Request, req *KeyInsertRequest, res *KeyInsertResponse) error {
 	keyReq := *req
 
-	keyBytes, err := common.HexToBytes(req.Seed)
-	if err != nil {
-		return err
-	}
-
-	keyPair, err := keystore.DecodeKeyPairFromHex(keyBytes, keystore.DetermineKeyType(keyReq.Type))
+	// the seed is a secret URI, of which a 0x prefixed hex encoded seed without derivation path is a special case
+	keyPair, err := keystore.DecodeKeyPairFromSecretURI(req.Seed, keystore.DetermineKeyType(keyReq.Type))
 	if err != nil {
 		return err
 	}
@@ -190,13 +187,48 @@ func (am *AuthorModule) PendingExtrinsics(r *http.Request, req *EmptyRequest, re
 	return nil
 }
 
-// RemoveExtrinsic Remove given extrinsic from the pool and temporarily ban it to prevent reimporting
-func (am *AuthorModule) RemoveExtrinsic(r *http.Request, _ *ExtrinsicOrHashRequest, _ *RemoveExtrinsicsResponse) error {
+// RemoveExtrinsic Remove given extrinsics from the pool, along with the extrinsics depending on them.
+// Removed extrinsics are not banned, they can be submitted again.
+func (am *AuthorModule) RemoveExtrinsic(r *http.Request, req *ExtrinsicOrHashRequest,
+	res *RemoveExtrinsicsResponse) error {
+	hashes := make([]common.Hash, len(*req))
+	for i, extOrHash := range *req {
+		if extOrHash.Extrinsic == "" {
+			if extOrHash.Hash.IsEmpty() {
+				return errExtrinsicOrHashRequired
+			}
+
+			hashes[i] = extOrHash.Hash
+			continue
+		}
+
+		extBytes, err := common.HexToBytes(extOrHash.Extrinsic)
+		if err != nil {
+			return err
+		}
+
+		hashes[i] = types.Extrinsic(extBytes).Hash()
+	}
+
+	removed := am.txStateAPI.EvictExtrinsics(hashes)
+	am.logger.Infof("removed %d extrinsics from the transaction pool", len(removed))
+
+	*res = removed
 	return nil
 }
 
-// RotateKeys Generate new session keys and returns the corresponding public keys
+// RotateKeys Generate new session keys and returns the corresponding public keys.
+// The node must be started with --session-keys-password for the keys to be persisted.
 func (am *AuthorModule) RotateKeys(r *http.Request, req *EmptyRequest, res *KeyRotateResponse) error {
+	keys, err := am.coreAPI.GenerateSessionKeys()
+	if errors.Is(err, keystore.ErrNoSessionKeysPassword) {
+		return fmt.Errorf("%w: restart the node with --session-keys-password to rotate keys", err)
+	}
+	if err != nil {
+		return err
+	}
+
+	*res = KeyRotateResponse(common.BytesToHex(keys))
 	return nil
 }
 
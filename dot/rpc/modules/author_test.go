@@ -313,6 +313,12 @@ func TestAuthorModule_InsertKey(t *testing.T) {
 	mockCoreAPIHappyGran := &mocks.CoreAPI{}
 	mockCoreAPIHappyGran.On("InsertKey", kp2, "gran").Return(nil)
 
+	alice, err := sr25519.NewKeypairFromSecretURI("//Alice")
+	require.NoError(t, err)
+
+	mockCoreAPIHappySecretURI := &mocks.CoreAPI{}
+	mockCoreAPIHappySecretURI.On("InsertKey", alice, "babe").Return(nil)
+
 	mockCoreAPIBadKey := &mocks.CoreAPI{}
 	mockCoreAPIBadKey.On("InsertKey", kp3, "babe").Return(nil)
 
@@ -362,6 +368,20 @@ func TestAuthorModule_InsertKey(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "happy path, secret URI",
+			fields: fields{
+				logger:  log.New(log.SetWriter(io.Discard)),
+				coreAPI: mockCoreAPIHappySecretURI,
+			},
+			args: args{
+				req: &KeyInsertRequest{
+					"babe",
+					"//Alice",
+					"0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+				},
+			},
+		},
 		{
 			name: "invalid key",
 			fields: fields{
@@ -489,3 +509,102 @@ func TestAuthorModule_HasKey(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthorModule_RotateKeys(t *testing.T) {
+	keys := common.MustHexToBytes("0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d")
+
+	coreMockAPIOk := new(mocks.CoreAPI)
+	coreMockAPIOk.On("GenerateSessionKeys").Return(keys, nil)
+
+	coreMockAPIErr := new(mocks.CoreAPI)
+	coreMockAPIErr.On("GenerateSessionKeys").Return(nil, errors.New("generate err"))
+
+	coreMockAPINoPassword := new(mocks.CoreAPI)
+	coreMockAPINoPassword.On("GenerateSessionKeys").
+		Return(nil, fmt.Errorf("cannot persist session keys: %w", keystore.ErrNoSessionKeysPassword))
+
+	tests := []struct {
+		name    string
+		coreAPI *mocks.CoreAPI
+		expErr  error
+		exp     KeyRotateResponse
+	}{
+		{
+			name:    "happy path",
+			coreAPI: coreMockAPIOk,
+			exp:     "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d",
+		},
+		{
+			name:    "GenerateSessionKeys err",
+			coreAPI: coreMockAPIErr,
+			expErr:  errors.New("generate err"),
+		},
+		{
+			name:    "no session keys password",
+			coreAPI: coreMockAPINoPassword,
+			expErr: errors.New("cannot persist session keys: no password to encrypt session keys with: " +
+				"restart the node with --session-keys-password to rotate keys"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			am := NewAuthorModule(log.New(log.SetWriter(io.Discard)), tt.coreAPI, nil)
+			var res KeyRotateResponse
+			err := am.RotateKeys(nil, nil, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
+
+func TestAuthorModule_RemoveExtrinsic(t *testing.T) {
+	ext := types.Extrinsic{1, 2, 3}
+	hash := common.Hash{0x01}
+
+	mockTxStateAPI := new(mocks.TransactionStateAPI)
+	mockTxStateAPI.On("EvictExtrinsics", []common.Hash{hash, ext.Hash()}).
+		Return([]common.Hash{hash, ext.Hash()})
+
+	tests := []struct {
+		name   string
+		req    ExtrinsicOrHashRequest
+		expErr error
+		exp    RemoveExtrinsicsResponse
+	}{
+		{
+			name: "hash and extrinsic",
+			req: ExtrinsicOrHashRequest{
+				{Hash: hash},
+				{Extrinsic: common.BytesToHex(ext)},
+			},
+			exp: RemoveExtrinsicsResponse{hash, ext.Hash()},
+		},
+		{
+			name:   "empty extrinsic or hash",
+			req:    ExtrinsicOrHashRequest{{}},
+			expErr: errExtrinsicOrHashRequired,
+		},
+		{
+			name:   "invalid extrinsic",
+			req:    ExtrinsicOrHashRequest{{Extrinsic: "0xzz"}},
+			expErr: errors.New("encoding/hex: invalid byte: U+007A 'z'"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			am := NewAuthorModule(log.New(log.SetWriter(io.Discard)), nil, mockTxStateAPI)
+			var res RemoveExtrinsicsResponse
+			err := am.RemoveExtrinsic(nil, &tt.req, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
+}
@@ -7,3 +7,5 @@ import "errors"
 
 // ErrSubscriptionTransport error sent when trying to access websocket subscriptions via http
 var ErrSubscriptionTransport = errors.New("subscriptions are not available on this transport")
+
+var errExtrinsicOrHashRequired = errors.New("either an extrinsic or its hash must be given")
@@ -237,6 +237,29 @@ func (_m *BlockAPI) GetRuntime(hash *common.Hash) (runtime.Instance, error) {
 	return r0, r1
 }
 
+// GetRuntimePool provides a mock function with given fields: hash
+func (_m *BlockAPI) GetRuntimePool(hash *common.Hash) (*runtime.InstancePool, error) {
+	ret := _m.Called(hash)
+
+	var r0 *runtime.InstancePool
+	if rf, ok := ret.Get(0).(func(*common.Hash) *runtime.InstancePool); ok {
+		r0 = rf(hash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*runtime.InstancePool)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*common.Hash) error); ok {
+		r1 = rf(hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HasJustification provides a mock function with given fields: hash
 func (_m *BlockAPI) HasJustification(hash common.Hash) (bool, error) {
 	ret := _m.Called(hash)
@@ -20,6 +20,29 @@ type CoreAPI struct {
 	mock.Mock
 }
 
+// CallRuntime provides a mock function with given fields: bhash, method, params
+func (_m *CoreAPI) CallRuntime(bhash *common.Hash, method string, params []byte) ([]byte, error) {
+	ret := _m.Called(bhash, method, params)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(*common.Hash, string, []byte) []byte); ok {
+		r0 = rf(bhash, method, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*common.Hash, string, []byte) error); ok {
+		r1 = rf(bhash, method, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DecodeSessionKeys provides a mock function with given fields: enc
 func (_m *CoreAPI) DecodeSessionKeys(enc []byte) ([]byte, error) {
 	ret := _m.Called(enc)
@@ -43,6 +66,29 @@ func (_m *CoreAPI) DecodeSessionKeys(enc []byte) ([]byte, error) {
 	return r0, r1
 }
 
+// GenerateSessionKeys provides a mock function with given fields:
+func (_m *CoreAPI) GenerateSessionKeys() ([]byte, error) {
+	ret := _m.Called()
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func() []byte); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMetadata provides a mock function with given fields: bhash
 func (_m *CoreAPI) GetMetadata(bhash *common.Hash) ([]byte, error) {
 	ret := _m.Called(bhash)
@@ -32,6 +32,22 @@ func (_m *TransactionStateAPI) AddToPool(_a0 *transaction.ValidTransaction) comm
 	return r0
 }
 
+// EvictExtrinsics provides a mock function with given fields: hashes
+func (_m *TransactionStateAPI) EvictExtrinsics(hashes []common.Hash) []common.Hash {
+	ret := _m.Called(hashes)
+
+	var r0 []common.Hash
+	if rf, ok := ret.Get(0).(func([]common.Hash) []common.Hash); ok {
+		r0 = rf(hashes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Hash)
+		}
+	}
+
+	return r0
+}
+
 // FreeStatusNotifierChannel provides a mock function with given fields: ch
 func (_m *TransactionStateAPI) FreeStatusNotifierChannel(ch chan transaction.Status) {
 	_m.Called(ch)
@@ -26,13 +26,15 @@ type PaymentQueryInfoResponse struct {
 
 // PaymentModule holds all the RPC implementation of polkadot payment rpc api
 type PaymentModule struct {
-	blockAPI BlockAPI
+	blockAPI   BlockAPI
+	storageAPI StorageAPI
 }
 
 // NewPaymentModule returns a pointer to PaymentModule
-func NewPaymentModule(blockAPI BlockAPI) *PaymentModule {
+func NewPaymentModule(blockAPI BlockAPI, storageAPI StorageAPI) *PaymentModule {
 	return &PaymentModule{
-		blockAPI: blockAPI,
+		blockAPI:   blockAPI,
+		storageAPI: storageAPI,
 	}
 }
 
@@ -45,11 +47,29 @@ func (p *PaymentModule) QueryInfo(_ *http.Request, req *PaymentQueryInfoRequest,
 		hash = *req.Hash
 	}
 
-	r, err := p.blockAPI.GetRuntime(&hash)
+	root, err := p.storageAPI.GetStateRootFromBlock(&hash)
 	if err != nil {
 		return err
 	}
 
+	ts, err := p.storageAPI.TrieState(root)
+	if err != nil {
+		return err
+	}
+
+	pool, err := p.blockAPI.GetRuntimePool(&hash)
+	if err != nil {
+		return err
+	}
+
+	r, err := pool.Get()
+	if err != nil {
+		return err
+	}
+	defer pool.Put(r)
+
+	r.SetContextStorage(ts)
+
 	ext, err := common.HexToBytes(req.Ext)
 	if err != nil {
 		return err
@@ -43,7 +43,8 @@ func TestPaymentQueryInfo(t *testing.T) {
 		blockAPIMock := new(mocks.BlockAPI)
 		blockAPIMock.On("BestBlockHash").Return(bestBlockHash)
 
-		blockAPIMock.On("GetRuntime", mock.AnythingOfType("*common.Hash")).Return(runtimeMock, nil)
+		blockAPIMock.On("GetRuntimePool", mock.AnythingOfType("*common.Hash")).
+			Return(newMockRuntimePool(t, runtimeMock), nil)
 
 		mod := &PaymentModule{
 			blockAPI: blockAPIMock,
@@ -61,7 +62,7 @@ func TestPaymentQueryInfo(t *testing.T) {
 
 		// should be called because req.Hash is nil
 		blockAPIMock.AssertCalled(t, "BestBlockHash")
-		blockAPIMock.AssertCalled(t, "GetRuntime", mock.AnythingOfType("*common.Hash"))
+		blockAPIMock.AssertCalled(t, "GetRuntimePool", mock.AnythingOfType("*common.Hash"))
 		runtimeMock.AssertCalled(t, "PaymentQueryInfo", mock.AnythingOfType("[]uint8"))
 	})
 
@@ -69,7 +70,7 @@ func TestPaymentQueryInfo(t *testing.T) {
 		blockAPIMock := new(mocks.BlockAPI)
 		blockAPIMock.On("BestBlockHash").Return(bestBlockHash)
 
-		blockAPIMock.On("GetRuntime", mock.AnythingOfType("*common.Hash")).
+		blockAPIMock.On("GetRuntimePool", mock.AnythingOfType("*common.Hash")).
 			Return(nil, errors.New("mocked problems"))
 
 		mod := &PaymentModule{
@@ -87,7 +88,7 @@ func TestPaymentQueryInfo(t *testing.T) {
 		require.Equal(t, res, PaymentQueryInfoResponse{})
 
 		blockAPIMock.AssertCalled(t, "BestBlockHash")
-		blockAPIMock.AssertCalled(t, "GetRuntime", mock.AnythingOfType("*common.Hash"))
+		blockAPIMock.AssertCalled(t, "GetRuntimePool", mock.AnythingOfType("*common.Hash"))
 	})
 
 	t.Run("When PaymentQueryInfo returns error", func(t *testing.T) {
@@ -95,7 +96,8 @@ func TestPaymentQueryInfo(t *testing.T) {
 		runtimeMock.On("PaymentQueryInfo", mock.AnythingOfType("[]uint8")).Return(nil, errors.New("mocked error"))
 
 		blockAPIMock := new(mocks.BlockAPI)
-		blockAPIMock.On("GetRuntime", mock.AnythingOfType("*common.Hash")).Return(runtimeMock, nil)
+		blockAPIMock.On("GetRuntimePool", mock.AnythingOfType("*common.Hash")).
+			Return(newMockRuntimePool(t, runtimeMock), nil)
 
 		mod := &PaymentModule{
 			blockAPI: blockAPIMock,
@@ -114,7 +116,7 @@ func TestPaymentQueryInfo(t *testing.T) {
 
 		// should be called because req.Hash is nil
 		blockAPIMock.AssertNotCalled(t, "BestBlockHash")
-		blockAPIMock.AssertCalled(t, "GetRuntime", mock.AnythingOfType("*common.Hash"))
+		blockAPIMock.AssertCalled(t, "GetRuntimePool", mock.AnythingOfType("*common.Hash"))
 		runtimeMock.AssertCalled(t, "PaymentQueryInfo", mock.AnythingOfType("[]uint8"))
 	})
 
@@ -123,7 +125,8 @@ func TestPaymentQueryInfo(t *testing.T) {
 		runtimeMock.On("PaymentQueryInfo", mock.AnythingOfType("[]uint8")).Return(nil, nil)
 
 		blockAPIMock := new(mocks.BlockAPI)
-		blockAPIMock.On("GetRuntime", mock.AnythingOfType("*common.Hash")).Return(runtimeMock, nil)
+		blockAPIMock.On("GetRuntimePool", mock.AnythingOfType("*common.Hash")).
+			Return(newMockRuntimePool(t, runtimeMock), nil)
 
 		mod := &PaymentModule{
 			blockAPI: blockAPIMock,
@@ -142,7 +145,7 @@ func TestPaymentQueryInfo(t *testing.T) {
 
 		// should be called because req.Hash is nil
 		blockAPIMock.AssertNotCalled(t, "BestBlockHash")
-		blockAPIMock.AssertCalled(t, "GetRuntime", mock.AnythingOfType("*common.Hash"))
+		blockAPIMock.AssertCalled(t, "GetRuntimePool", mock.AnythingOfType("*common.Hash"))
 		runtimeMock.AssertCalled(t, "PaymentQueryInfo", mock.AnythingOfType("[]uint8"))
 	})
 }
@@ -12,21 +12,46 @@ import (
 	"github.com/ChainSafe/gossamer/dot/rpc/modules/mocks"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
 	mocksruntime "github.com/ChainSafe/gossamer/lib/runtime/mocks"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func newMockRuntimePool(t *testing.T, rt *mocksruntime.Instance) *runtime.InstancePool {
+	rt.On("Clone").Return(rt, nil)
+
+	pool, err := runtime.NewInstancePool(rt, 1)
+	require.NoError(t, err)
+	return pool
+}
+
 func TestPaymentModule_QueryInfo(t *testing.T) {
 	testHash := common.NewHash([]byte{0x01, 0x02})
 	u, err := scale.NewUint128(new(big.Int).SetBytes([]byte{1, 2, 3, 4, 5, 6, 7, 8, 1, 2, 3, 4, 5, 6}))
 	require.NoError(t, err)
 
+	testRoot := common.Hash{0x03}
+	ts, err := rtstorage.NewTrieState(nil)
+	require.NoError(t, err)
+
+	storageAPIMock := new(mocks.StorageAPI)
+	storageAPIMock.On("GetStateRootFromBlock", &testHash).Return(&testRoot, nil)
+	storageAPIMock.On("TrieState", &testRoot).Return(ts, nil)
+
+	storageErrorAPIMock := new(mocks.StorageAPI)
+	storageErrorAPIMock.On("GetStateRootFromBlock", &testHash).Return(nil, errors.New("GetStateRootFromBlock error"))
+
 	runtimeMock := new(mocksruntime.Instance)
 	runtimeMock2 := new(mocksruntime.Instance)
 	runtimeErrorMock := new(mocksruntime.Instance)
+	for _, rt := range []*mocksruntime.Instance{runtimeMock, runtimeMock2, runtimeErrorMock} {
+		// the call must run against the state of the requested block
+		rt.On("SetContextStorage", ts)
+	}
 
 	blockAPIMock := new(mocks.BlockAPI)
 	blockAPIMock2 := new(mocks.BlockAPI)
@@ -34,13 +59,13 @@ func TestPaymentModule_QueryInfo(t *testing.T) {
 	blockErrorAPIMock2 := new(mocks.BlockAPI)
 
 	blockAPIMock.On("BestBlockHash").Return(testHash, nil)
-	blockAPIMock.On("GetRuntime", &testHash).Return(runtimeMock, nil)
+	blockAPIMock.On("GetRuntimePool", &testHash).Return(newMockRuntimePool(t, runtimeMock), nil)
 
-	blockAPIMock2.On("GetRuntime", &testHash).Return(runtimeMock2, nil)
+	blockAPIMock2.On("GetRuntimePool", &testHash).Return(newMockRuntimePool(t, runtimeMock2), nil)
 
-	blockErrorAPIMock1.On("GetRuntime", &testHash).Return(runtimeErrorMock, nil)
+	blockErrorAPIMock1.On("GetRuntimePool", &testHash).Return(newMockRuntimePool(t, runtimeErrorMock), nil)
 
-	blockErrorAPIMock2.On("GetRuntime", &testHash).Return(nil, errors.New("GetRuntime error"))
+	blockErrorAPIMock2.On("GetRuntimePool", &testHash).Return(nil, errors.New("GetRuntime error"))
 
 	runtimeMock.On("PaymentQueryInfo", common.MustHexToBytes("0x0000")).Return(nil, nil)
 	runtimeMock2.On("PaymentQueryInfo", common.MustHexToBytes("0x0000")).Return(&types.TransactionPaymentQueryInfo{
@@ -51,9 +76,10 @@ func TestPaymentModule_QueryInfo(t *testing.T) {
 	runtimeErrorMock.On("PaymentQueryInfo", common.MustHexToBytes("0x0000")).
 		Return(nil, errors.New("PaymentQueryInfo error"))
 
-	paymentModule := NewPaymentModule(blockAPIMock)
+	paymentModule := NewPaymentModule(blockAPIMock, storageAPIMock)
 	type fields struct {
-		blockAPI BlockAPI
+		blockAPI   BlockAPI
+		storageAPI StorageAPI
 	}
 	type args struct {
 		in0 *http.Request
@@ -70,6 +96,7 @@ func TestPaymentModule_QueryInfo(t *testing.T) {
 			name: "Nil Query Info",
 			fields: fields{
 				paymentModule.blockAPI,
+				paymentModule.storageAPI,
 			},
 			args: args{
 				req: &PaymentQueryInfoRequest{
@@ -83,6 +110,7 @@ func TestPaymentModule_QueryInfo(t *testing.T) {
 			name: "Not Nil Query Info",
 			fields: fields{
 				blockAPIMock2,
+				storageAPIMock,
 			},
 			args: args{
 				req: &PaymentQueryInfoRequest{
@@ -102,6 +130,7 @@ func TestPaymentModule_QueryInfo(t *testing.T) {
 			name: "Nil Hash",
 			fields: fields{
 				paymentModule.blockAPI,
+				paymentModule.storageAPI,
 			},
 			args: args{
 				req: &PaymentQueryInfoRequest{
@@ -114,6 +143,7 @@ func TestPaymentModule_QueryInfo(t *testing.T) {
 			name: "Invalid Ext",
 			fields: fields{
 				paymentModule.blockAPI,
+				paymentModule.storageAPI,
 			},
 			args: args{
 				req: &PaymentQueryInfoRequest{
@@ -126,6 +156,7 @@ func TestPaymentModule_QueryInfo(t *testing.T) {
 			name: "PaymentQueryInfo error",
 			fields: fields{
 				blockErrorAPIMock1,
+				storageAPIMock,
 			},
 			args: args{
 				req: &PaymentQueryInfoRequest{
@@ -139,6 +170,7 @@ func TestPaymentModule_QueryInfo(t *testing.T) {
 			name: "GetRuntime error",
 			fields: fields{
 				blockErrorAPIMock2,
+				storageAPIMock,
 			},
 			args: args{
 				req: &PaymentQueryInfoRequest{
@@ -148,11 +180,26 @@ func TestPaymentModule_QueryInfo(t *testing.T) {
 			},
 			expErr: errors.New("GetRuntime error"),
 		},
+		{
+			name: "GetStateRootFromBlock error",
+			fields: fields{
+				blockAPIMock2,
+				storageErrorAPIMock,
+			},
+			args: args{
+				req: &PaymentQueryInfoRequest{
+					Ext:  "0x0000",
+					Hash: &testHash,
+				},
+			},
+			expErr: errors.New("GetStateRootFromBlock error"),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			p := &PaymentModule{
-				blockAPI: tt.fields.blockAPI,
+				blockAPI:   tt.fields.blockAPI,
+				storageAPI: tt.fields.storageAPI,
 			}
 			res := PaymentQueryInfoResponse{}
 			err := p.QueryInfo(tt.args.in0, tt.args.req, &res)
@@ -164,4 +211,5 @@ func TestPaymentModule_QueryInfo(t *testing.T) {
 			assert.Equal(t, tt.exp, res)
 		})
 	}
+	runtimeMock2.AssertCalled(t, "SetContextStorage", ts)
 }
@@ -24,7 +24,7 @@ type StateGetReadProofRequest struct {
 // StateCallRequest holds json fields
 type StateCallRequest struct {
 	Method string       `json:"method"`
-	Data   []byte       `json:"data"`
+	Data   string       `json:"data"`
 	Block  *common.Hash `json:"block"`
 }
 
@@ -80,8 +80,8 @@ type StateStorageQueryRangeRequest struct {
 // StateStorageKeysQuery field to store storage keys
 type StateStorageKeysQuery [][]byte
 
-// StateCallResponse holds json fields
-type StateCallResponse []byte
+// StateCallResponse holds the hex encoded result of a runtime call
+type StateCallResponse string
 
 // StateKeysResponse field to store the state keys
 type StateKeysResponse [][]byte
@@ -211,10 +211,20 @@ func (sm *StateModule) GetPairs(_ *http.Request, req *StatePairRequest, res *Sta
 	return nil
 }
 
-// Call isn't implemented properly yet.
-func (sm *StateModule) Call(_ *http.Request, _ *StateCallRequest, _ *StateCallResponse) error {
-	_ = sm.networkAPI
-	_ = sm.storageAPI
+// Call executes the given runtime function at the given block's state and returns the hex encoded result.
+// If no block hash is provided, the best block's state is used. State changes made by the call are not persisted.
+func (sm *StateModule) Call(_ *http.Request, req *StateCallRequest, res *StateCallResponse) error {
+	params, err := common.HexToBytes(req.Data)
+	if err != nil {
+		return fmt.Errorf("cannot convert hex data %s to bytes: %w", req.Data, err)
+	}
+
+	ret, err := sm.coreAPI.CallRuntime(req.Block, req.Method, params)
+	if err != nil {
+		return err
+	}
+
+	*res = StateCallResponse(common.BytesToHex(ret))
 	return nil
 }
 
@@ -278,14 +278,66 @@ func TestStateModuleGetKeysPaged(t *testing.T) {
 	}
 }
 
-// Implement Tests once function is implemented
 func TestCall(t *testing.T) {
-	mockNetworkAPI := new(mocks.NetworkAPI)
-	mockStorageAPI := new(mocks.StorageAPI)
-	sm := NewStateModule(mockNetworkAPI, mockStorageAPI, nil)
+	hash := common.MustHexToHash("0x3aa96b0149b6ca3688878bdbd19464448624136398e3ce45b9e755d3ab61355a")
+	method := "AccountNonceApi_account_nonce"
+	params := []byte{1, 2, 3}
 
-	err := sm.Call(nil, nil, nil)
-	assert.NoError(t, err)
+	mockCoreAPI := new(mocks.CoreAPI)
+	mockCoreAPI.On("CallRuntime", &hash, method, params).Return([]byte{4, 5, 6}, nil)
+
+	mockCoreAPIErr := new(mocks.CoreAPI)
+	mockCoreAPIErr.On("CallRuntime", &hash, method, params).Return(nil, errors.New("CallRuntime Error"))
+
+	type args struct {
+		in0 *http.Request
+		req *StateCallRequest
+	}
+	tests := []struct {
+		name    string
+		coreAPI CoreAPI
+		args    args
+		expErr  error
+		exp     StateCallResponse
+	}{
+		{
+			name:    "OK Case",
+			coreAPI: mockCoreAPI,
+			args: args{
+				req: &StateCallRequest{Method: method, Data: "0x010203", Block: &hash},
+			},
+			exp: StateCallResponse("0x040506"),
+		},
+		{
+			name:    "Invalid Data",
+			coreAPI: mockCoreAPI,
+			args: args{
+				req: &StateCallRequest{Method: method, Data: "010203", Block: &hash},
+			},
+			expErr: errors.New("cannot convert hex data 010203 to bytes: could not byteify non 0x prefixed string"),
+		},
+		{
+			name:    "CallRuntime Error",
+			coreAPI: mockCoreAPIErr,
+			args: args{
+				req: &StateCallRequest{Method: method, Data: "0x010203", Block: &hash},
+			},
+			expErr: errors.New("CallRuntime Error"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sm := NewStateModule(nil, nil, tt.coreAPI)
+			res := StateCallResponse("")
+			err := sm.Call(tt.args.in0, tt.args.req, &res)
+			if tt.expErr != nil {
+				assert.EqualError(t, err, tt.expErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.exp, res)
+		})
+	}
 }
 
 func TestStateModuleGetMetadata(t *testing.T) {
@@ -6,6 +6,7 @@ package modules
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"math/big"
 	"net/http"
 	"strings"
@@ -160,7 +161,10 @@ func (sm *SystemModule) AccountNextIndex(r *http.Request, req *StringRequest, re
 	if req == nil || req.String == "" {
 		return errors.New("account address must be valid")
 	}
-	addressPubKey := crypto.PublicAddressToByteArray(common.Address(req.String))
+	addressPubKey, _, err := crypto.DecodeSS58(common.Address(req.String))
+	if err != nil {
+		return fmt.Errorf("invalid account address: %w", err)
+	}
 
 	// check pending transactions for extrinsics singed by addressPubKey
 	pending := sm.txStateAPI.Pending()
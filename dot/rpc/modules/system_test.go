@@ -202,7 +202,7 @@ func TestSystemModule_NodeRolesTest(t *testing.T) {
 
 func TestSystemModule_AccountNextIndex(t *testing.T) {
 	storageKeyHex := common.MustHexToBytes("0x26aa394eea5630e07c48ae0c9558cef7b99d880ec681799c0cf30e8886" +
-		"371da93116aec311d8421cece41129ffaac05aa7f9580382edb384b1b43cbcf3d1b1e7f1a1d232cf4139bd48eaafb9656da27d")
+		"371da94f9aea1afa791265fae359272badc1cf8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
 	signedExt := common.MustHexToBytes("0xad018400d43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e" +
 		"7a56da27d0146d0050619728683af4e9659bf202aeb2b8b13b48a875adb663f449f1a71453903546f3252193964185eb91" +
 		"c482cf95caf327db407d57ebda95046b5ef890187001000000108abcd")
@@ -250,6 +250,14 @@ func TestSystemModule_AccountNextIndex(t *testing.T) {
 			args:      args{},
 			expErr:    errors.New("account address must be valid"),
 		},
+		{
+			name:      "Invalid Checksum",
+			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPI, mockTxStateAPI, nil),
+			args: args{
+				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+			},
+			expErr: errors.New("invalid account address: invalid ss58 checksum"),
+		},
 		{
 			name:      "Found",
 			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPI, mockTxStateAPI, nil),
@@ -262,7 +270,7 @@ func TestSystemModule_AccountNextIndex(t *testing.T) {
 			name:      "Not found",
 			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPI, mockTxStateAPI, nil),
 			args: args{
-				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+				req: &StringRequest{String: "14E5nqKAp3oAJcmzgZhUD2RcptBeUBScxKHgJKU4HPNcKVf3"},
 			},
 			exp: U64Response(3),
 		},
@@ -270,7 +278,7 @@ func TestSystemModule_AccountNextIndex(t *testing.T) {
 			name:      "GetMetadata Err",
 			sysModule: NewSystemModule(nil, nil, mockCoreAPIErr, mockStorageAPI, mockTxStateAPI, nil),
 			args: args{
-				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+				req: &StringRequest{String: "14E5nqKAp3oAJcmzgZhUD2RcptBeUBScxKHgJKU4HPNcKVf3"},
 			},
 			expErr: errors.New("getMetadata error"),
 		},
@@ -278,7 +286,7 @@ func TestSystemModule_AccountNextIndex(t *testing.T) {
 			name:      "Magic Number Mismatch",
 			sysModule: NewSystemModule(nil, nil, mockCoreAPIMagicNumMismatch, mockStorageAPI, mockTxStateAPI, nil),
 			args: args{
-				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+				req: &StringRequest{String: "14E5nqKAp3oAJcmzgZhUD2RcptBeUBScxKHgJKU4HPNcKVf3"},
 			},
 			expErr: errors.New("magic number mismatch: expected 0x6174656d, found 0xe03056ea"),
 		},
@@ -286,7 +294,7 @@ func TestSystemModule_AccountNextIndex(t *testing.T) {
 			name:      "GetStorage Err",
 			sysModule: NewSystemModule(nil, nil, mockCoreAPI, mockStorageAPIErr, mockTxStateAPI, nil),
 			args: args{
-				req: &StringRequest{String: "5FrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+				req: &StringRequest{String: "14E5nqKAp3oAJcmzgZhUD2RcptBeUBScxKHgJKU4HPNcKVf3"},
 			},
 			expErr: errors.New("getStorage error"),
 		},
@@ -41,13 +41,13 @@ func newSubscriptionResponse(method string, subID uint32, result interface{}) Ba
 
 // ResponseJSON for json subscription responses
 type ResponseJSON struct {
-	Jsonrpc string  `json:"jsonrpc"`
-	Result  uint32  `json:"result"`
-	ID      float64 `json:"id"`
+	Jsonrpc string      `json:"jsonrpc"`
+	Result  uint32      `json:"result"`
+	ID      interface{} `json:"id"`
 }
 
 // NewSubscriptionResponseJSON builds a Response JSON object
-func NewSubscriptionResponseJSON(subID uint32, reqID float64) ResponseJSON {
+func NewSubscriptionResponseJSON(subID uint32, reqID interface{}) ResponseJSON {
 	return ResponseJSON{
 		Jsonrpc: "2.0",
 		Result:  subID,
@@ -57,12 +57,12 @@ func NewSubscriptionResponseJSON(subID uint32, reqID float64) ResponseJSON {
 
 // BooleanResponse for responses that return boolean values
 type BooleanResponse struct {
-	JSONRPC string  `json:"jsonrpc"`
-	Result  bool    `json:"result"`
-	ID      float64 `json:"id"`
+	JSONRPC string      `json:"jsonrpc"`
+	Result  bool        `json:"result"`
+	ID      interface{} `json:"id"`
 }
 
-func newBooleanResponseJSON(value bool, reqID float64) BooleanResponse {
+func newBooleanResponseJSON(value bool, reqID interface{}) BooleanResponse {
 	return BooleanResponse{
 		JSONRPC: "2.0",
 		Result:  value,
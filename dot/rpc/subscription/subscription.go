@@ -21,13 +21,14 @@ const (
 	grandpaSubscribeJustifications string = "grandpa_subscribeJustifications"
 )
 
-type setupListener func(reqid float64, params interface{}) (Listener, error)
+type setupListener func(reqID interface{}, params interface{}) (Listener, error)
 
 var (
 	errUknownParamSubscribeID = errors.New("invalid params format type")
 	errCannotParseID          = errors.New("could not parse param id")
 	errCannotFindListener     = errors.New("could not find listener")
 	errCannotFindUnsubsriber  = errors.New("could not find unsubsriber function")
+	errSubscriptionInBatch    = errors.New("subscriptions are not supported in batch requests")
 )
 
 func (c *WSConn) getSetupListener(method string) setupListener {
@@ -8,9 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 	"math/big"
-	"net/http"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -22,12 +20,14 @@ import (
 	"github.com/gorilla/websocket"
 )
 
-type httpclient interface {
-	Do(*http.Request) (*http.Response, error)
+// RPCDispatcher executes the JSON-RPC requests which aren't subscriptions
+type RPCDispatcher interface {
+	// Dispatch executes the request received from the given remote address and returns the
+	// encoded response, which is empty for notifications
+	Dispatch(remoteAddr string, data []byte) []byte
 }
 
 var errCannotReadFromWebsocket = errors.New("cannot read message from websocket")
-var errCannotUnmarshalMessage = errors.New("cannot unmarshal webasocket message data")
 var logger = log.NewFromGlobal(log.AddContext("pkg", "rpc/subscription"))
 
 // WSConn struct to hold WebSocket Connection references
@@ -41,114 +41,171 @@ type WSConn struct {
 	BlockAPI      modules.BlockAPI
 	CoreAPI       modules.CoreAPI
 	TxStateAPI    modules.TransactionStateAPI
-	RPCHost       string
-	HTTP          httpclient
+	RPC           RPCDispatcher
 }
 
-// readWebsocketMessage will read and parse the message data to a string->interface{} data
-func (c *WSConn) readWebsocketMessage() ([]byte, map[string]interface{}, error) {
-	_, mbytes, err := c.Wsconn.ReadMessage()
-	if err != nil {
-		logger.Debugf("websocket failed to read message: %s", err)
-		return nil, nil, errCannotReadFromWebsocket
-	}
+// request is a JSON-RPC request received over a websocket connection. The id is kept
+// as it is, since it can be a number, a string or null.
+type request struct {
+	Method string          `json:"method"`
+	Params interface{}     `json:"params"`
+	ID     json.RawMessage `json:"id"`
+}
 
-	logger.Tracef("websocket message received: %s", string(mbytes))
+// reqID returns the id of the request to be set in its response
+func (r *request) reqID() interface{} {
+	if len(r.ID) == 0 {
+		return nil
+	}
 
-	// determine if request is for subscribe method type
-	var msg map[string]interface{}
-	err = json.Unmarshal(mbytes, &msg)
+	return r.ID
+}
 
+// readWebsocketMessage will read the message data
+func (c *WSConn) readWebsocketMessage() ([]byte, error) {
+	_, mbytes, err := c.Wsconn.ReadMessage()
 	if err != nil {
-		logger.Debugf("websocket failed to unmarshal request message: %s", err)
-		return nil, nil, errCannotUnmarshalMessage
+		logger.Debugf("websocket failed to read message: %s", err)
+		return nil, errCannotReadFromWebsocket
 	}
 
-	return mbytes, msg, nil
+	logger.Tracef("websocket message received: %s", string(mbytes))
+	return mbytes, nil
 }
 
 //HandleComm handles messages received on websocket connections
 func (c *WSConn) HandleComm() {
 	for {
-		mbytes, msg, err := c.readWebsocketMessage()
+		mbytes, err := c.readWebsocketMessage()
 		if errors.Is(err, errCannotReadFromWebsocket) {
 			return
 		}
 
-		if errors.Is(err, errCannotUnmarshalMessage) {
-			c.safeSendError(0, big.NewInt(InvalidRequestCode), InvalidRequestMessage)
+		if isBatch(mbytes) {
+			c.handleBatch(mbytes)
 			continue
 		}
 
-		params := msg["params"]
-		reqid := msg["id"].(float64)
-		method := msg["method"].(string)
+		var req request
+		if err = json.Unmarshal(mbytes, &req); err != nil {
+			logger.Debugf("websocket failed to unmarshal request message: %s", err)
+			c.safeSendError(nil, big.NewInt(InvalidRequestCode), InvalidRequestMessage)
+			continue
+		}
 
-		logger.Debugf("ws method %s called with params %v", method, params)
+		c.handleRequest(mbytes, &req)
+	}
+}
 
-		if !strings.Contains(method, "_unsubscribe") && !strings.Contains(method, "_unwatch") {
-			setupListener := c.getSetupListener(method)
+func (c *WSConn) handleRequest(data []byte, req *request) {
+	reqid := req.reqID()
+	logger.Debugf("ws method %s called with params %v", req.Method, req.Params)
 
-			if setupListener == nil {
-				c.executeRPCCall(mbytes)
-				continue
-			}
+	if !isUnsubscribeMethod(req.Method) {
+		setupListener := c.getSetupListener(req.Method)
 
-			listener, err := setupListener(reqid, params)
-			if err != nil {
-				logger.Warnf("failed to create listener (method=%s): %s", method, err)
-				continue
-			}
+		if setupListener == nil {
+			c.executeRPCCall(data)
+			return
+		}
 
-			listener.Listen()
-			continue
+		listener, err := setupListener(reqid, req.Params)
+		if err != nil {
+			logger.Warnf("failed to create listener (method=%s): %s", req.Method, err)
+			return
 		}
 
-		listener, err := c.getUnsubListener(params)
+		listener.Listen()
+		return
+	}
 
-		if err != nil {
-			logger.Warnf("failed to get unsubscriber (method=%s): %s", method, err)
+	listener, err := c.getUnsubListener(req.Params)
 
-			if errors.Is(err, errUknownParamSubscribeID) || errors.Is(err, errCannotFindUnsubsriber) {
-				c.safeSendError(reqid, big.NewInt(InvalidRequestCode), InvalidRequestMessage)
-				continue
-			}
+	if err != nil {
+		logger.Warnf("failed to get unsubscriber (method=%s): %s", req.Method, err)
 
-			if errors.Is(err, errCannotParseID) || errors.Is(err, errCannotFindListener) {
-				c.safeSend(newBooleanResponseJSON(false, reqid))
-				continue
-			}
+		if errors.Is(err, errUknownParamSubscribeID) || errors.Is(err, errCannotFindUnsubsriber) {
+			c.safeSendError(reqid, big.NewInt(InvalidRequestCode), InvalidRequestMessage)
+			return
 		}
 
-		err = listener.Stop()
-		if err != nil {
-			logger.Warnf("failed to stop listener goroutine (method=%s): %s", method, err)
+		if errors.Is(err, errCannotParseID) || errors.Is(err, errCannotFindListener) {
 			c.safeSend(newBooleanResponseJSON(false, reqid))
+			return
 		}
+	}
 
-		c.safeSend(newBooleanResponseJSON(true, reqid))
-		continue
+	err = listener.Stop()
+	if err != nil {
+		logger.Warnf("failed to stop listener goroutine (method=%s): %s", req.Method, err)
+		c.safeSend(newBooleanResponseJSON(false, reqid))
 	}
+
+	c.safeSend(newBooleanResponseJSON(true, reqid))
 }
 
-func (c *WSConn) executeRPCCall(data []byte) {
-	request, err := c.prepareRequest(data)
-	if err != nil {
-		logger.Warnf("failed while preparing the request: %s", err)
+// handleBatch executes the requests of a batch and sends back the array of their responses.
+// Subscriptions aren't supported in batches, as their notifications are sent on their own.
+func (c *WSConn) handleBatch(data []byte) {
+	var reqs []json.RawMessage
+	if err := json.Unmarshal(data, &reqs); err != nil || len(reqs) == 0 {
+		c.safeSendError(nil, big.NewInt(InvalidRequestCode), InvalidRequestMessage)
 		return
 	}
 
-	var wsresponse interface{}
-	err = c.executeRequest(request, &wsresponse)
-	if err != nil {
-		logger.Warnf("problems while executing the request: %s", err)
+	responses := make([]interface{}, 0, len(reqs))
+	for _, raw := range reqs {
+		var req request
+		if err := json.Unmarshal(raw, &req); err != nil {
+			responses = append(responses, newErrorResponseJSON(nil, big.NewInt(InvalidRequestCode), InvalidRequestMessage))
+			continue
+		}
+
+		if isUnsubscribeMethod(req.Method) || c.getSetupListener(req.Method) != nil {
+			responses = append(responses, newErrorResponseJSON(req.reqID(), big.NewInt(InvalidRequestCode),
+				errSubscriptionInBatch.Error()))
+			continue
+		}
+
+		if res := c.dispatch(raw); len(res) > 0 {
+			responses = append(responses, json.RawMessage(res))
+		}
+	}
+
+	if len(responses) > 0 {
+		c.safeSend(responses)
+	}
+}
+
+func (c *WSConn) executeRPCCall(data []byte) {
+	res := c.dispatch(data)
+	if len(res) == 0 {
 		return
 	}
 
-	c.safeSend(wsresponse)
+	c.safeSend(json.RawMessage(res))
+}
+
+func (c *WSConn) dispatch(data []byte) []byte {
+	if c.RPC == nil {
+		logger.Warn("websocket RPC dispatcher not set")
+		return nil
+	}
+
+	return c.RPC.Dispatch(c.Wsconn.RemoteAddr().String(), data)
+}
+
+// isBatch returns true if the data holds a JSON array
+func isBatch(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '['
 }
 
-func (c *WSConn) initStorageChangeListener(reqID float64, params interface{}) (Listener, error) {
+func isUnsubscribeMethod(method string) bool {
+	return strings.Contains(method, "_unsubscribe") || strings.Contains(method, "_unwatch")
+}
+
+func (c *WSConn) initStorageChangeListener(reqID interface{}, params interface{}) (Listener, error) {
 	if c.StorageAPI == nil {
 		c.safeSendError(reqID, nil, "error StorageAPI not set")
 		return nil, fmt.Errorf("error StorageAPI not set")
@@ -194,7 +251,7 @@ func (c *WSConn) initStorageChangeListener(reqID float64, params interface{}) (L
 	return stgobs, nil
 }
 
-func (c *WSConn) initBlockListener(reqID float64, _ interface{}) (Listener, error) {
+func (c *WSConn) initBlockListener(reqID interface{}, _ interface{}) (Listener, error) {
 	bl := NewBlockListener(c)
 
 	if c.BlockAPI == nil {
@@ -216,7 +273,7 @@ func (c *WSConn) initBlockListener(reqID float64, _ interface{}) (Listener, erro
 	return bl, nil
 }
 
-func (c *WSConn) initBlockFinalizedListener(reqID float64, _ interface{}) (Listener, error) {
+func (c *WSConn) initBlockFinalizedListener(reqID interface{}, _ interface{}) (Listener, error) {
 	blockFinalizedListener := &BlockFinalizedListener{
 		cancel:        make(chan struct{}, 1),
 		done:          make(chan struct{}, 1),
@@ -244,7 +301,7 @@ func (c *WSConn) initBlockFinalizedListener(reqID float64, _ interface{}) (Liste
 	return blockFinalizedListener, nil
 }
 
-func (c *WSConn) initAllBlocksListerner(reqID float64, _ interface{}) (Listener, error) {
+func (c *WSConn) initAllBlocksListerner(reqID interface{}, _ interface{}) (Listener, error) {
 	listener := newAllBlockListener(c)
 
 	if c.BlockAPI == nil {
@@ -264,7 +321,7 @@ func (c *WSConn) initAllBlocksListerner(reqID float64, _ interface{}) (Listener,
 	return listener, nil
 }
 
-func (c *WSConn) initExtrinsicWatch(reqID float64, params interface{}) (Listener, error) {
+func (c *WSConn) initExtrinsicWatch(reqID interface{}, params interface{}) (Listener, error) {
 	pA := params.([]interface{})
 
 	if len(pA) != 1 {
@@ -313,7 +370,7 @@ func (c *WSConn) initExtrinsicWatch(reqID float64, params interface{}) (Listener
 	return extSubmitListener, err
 }
 
-func (c *WSConn) initRuntimeVersionListener(reqID float64, _ interface{}) (Listener, error) {
+func (c *WSConn) initRuntimeVersionListener(reqID interface{}, _ interface{}) (Listener, error) {
 	if c.CoreAPI == nil {
 		c.safeSendError(reqID, nil, "error CoreAPI not set")
 		return nil, fmt.Errorf("error CoreAPI not set")
@@ -344,7 +401,7 @@ func (c *WSConn) initRuntimeVersionListener(reqID float64, _ interface{}) (Liste
 	return rvl, nil
 }
 
-func (c *WSConn) initGrandpaJustificationListener(reqID float64, _ interface{}) (Listener, error) {
+func (c *WSConn) initGrandpaJustificationListener(reqID interface{}, _ interface{}) (Listener, error) {
 	if c.BlockAPI == nil {
 		c.safeSendError(reqID, nil, "error BlockAPI not set")
 		return nil, fmt.Errorf("error BlockAPI not set")
@@ -380,8 +437,12 @@ func (c *WSConn) safeSend(msg interface{}) {
 	}
 }
 
-func (c *WSConn) safeSendError(reqID float64, errorCode *big.Int, message string) {
-	res := &ErrorResponseJSON{
+func (c *WSConn) safeSendError(reqID interface{}, errorCode *big.Int, message string) {
+	c.safeSend(newErrorResponseJSON(reqID, errorCode, message))
+}
+
+func newErrorResponseJSON(reqID interface{}, errorCode *big.Int, message string) *ErrorResponseJSON {
+	return &ErrorResponseJSON{
 		Jsonrpc: "2.0",
 		Error: &ErrorMessageJSON{
 			Code:    errorCode,
@@ -389,65 +450,13 @@ func (c *WSConn) safeSendError(reqID float64, errorCode *big.Int, message string
 		},
 		ID: reqID,
 	}
-	c.mu.Lock()
-	defer c.mu.Unlock()
-	err := c.Wsconn.WriteJSON(res)
-	if err != nil {
-		logger.Debugf("error sending websocket message: %s", err)
-	}
-}
-
-func (c *WSConn) prepareRequest(b []byte) (*http.Request, error) {
-	buff := &bytes.Buffer{}
-	if _, err := buff.Write(b); err != nil {
-		logger.Warnf("failed to write message to buffer: %s", err)
-		return nil, err
-	}
-
-	req, err := http.NewRequest("POST", c.RPCHost, buff)
-	if err != nil {
-		logger.Warnf("failed request to rpc service: %s", err)
-		return nil, err
-	}
-
-	req.Header.Set("Content-Type", "application/json;")
-	return req, nil
-}
-
-func (c *WSConn) executeRequest(r *http.Request, d interface{}) error {
-	res, err := c.HTTP.Do(r)
-	if err != nil {
-		logger.Warnf("websocket error calling rpc: %s", err)
-		return err
-	}
-
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		logger.Warnf("error reading response body: %s", err)
-		return err
-	}
-
-	err = res.Body.Close()
-	if err != nil {
-		logger.Warnf("error closing response body: %s", err)
-		return err
-	}
-
-	err = json.Unmarshal(body, d)
-
-	if err != nil {
-		logger.Warnf("error unmarshal rpc response: %s", err)
-		return err
-	}
-
-	return nil
 }
 
 // ErrorResponseJSON json for error responses
 type ErrorResponseJSON struct {
 	Jsonrpc string            `json:"jsonrpc"`
 	Error   *ErrorMessageJSON `json:"error"`
-	ID      float64           `json:"id"`
+	ID      interface{}       `json:"id"`
 }
 
 // ErrorMessageJSON json for error messages
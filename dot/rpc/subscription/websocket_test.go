@@ -4,6 +4,7 @@
 package subscription
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/big"
 	"testing"
@@ -373,3 +374,67 @@ func TestSubscribeAllHeads(t *testing.T) {
 	require.NoError(t, l.Stop())
 	mockBlockAPI.On("FreeImportedBlockNotifierChannel", mock.AnythingOfType("chan *types.Block"))
 }
+
+type echoDispatcher struct{}
+
+func (echoDispatcher) Dispatch(_ string, data []byte) []byte {
+	var req request
+	if err := json.Unmarshal(data, &req); err != nil || len(req.ID) == 0 {
+		return nil
+	}
+
+	return []byte(fmt.Sprintf(`{"jsonrpc":"2.0","result":"%s","id":%s}`, req.Method, req.ID))
+}
+
+func TestWSConn_HandleComm_Dispatch(t *testing.T) {
+	wsconn, c, cancel := setupWSConn(t)
+	wsconn.Subscriptions = make(map[uint32]Listener)
+	wsconn.RPC = echoDispatcher{}
+	defer cancel()
+
+	go wsconn.HandleComm()
+	time.Sleep(time.Second * 2)
+
+	testCases := []struct {
+		call     string
+		expected string
+	}{
+		{
+			call:     `{"jsonrpc":"2.0","method":"system_name","params":[],"id":1}`,
+			expected: `{"jsonrpc":"2.0","result":"system_name","id":1}`,
+		},
+		{
+			call:     `{"jsonrpc":"2.0","method":"system_name","params":[],"id":"abc"}`,
+			expected: `{"jsonrpc":"2.0","result":"system_name","id":"abc"}`,
+		},
+		{
+			call:     `{"jsonrpc":"2.0","method":"state_unsubscribeStorage","params":[],"id":null}`,
+			expected: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid request"},"id":null}`,
+		},
+		{
+			call: `[{"jsonrpc":"2.0","method":"system_name","params":[],"id":1},` +
+				`{"jsonrpc":"2.0","method":"system_health","params":[]},` +
+				`{"jsonrpc":"2.0","method":"chain_subscribeNewHeads","params":[],"id":"sub"},` +
+				`1]`,
+			expected: `[{"jsonrpc":"2.0","result":"system_name","id":1},` +
+				`{"jsonrpc":"2.0","error":{"code":-32600,` +
+				`"message":"subscriptions are not supported in batch requests"},"id":"sub"},` +
+				`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid request"},"id":null}]`,
+		},
+		{
+			call:     `[]`,
+			expected: `{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid request"},"id":null}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := c.WriteMessage(websocket.TextMessage, []byte(tc.call))
+		require.NoError(t, err)
+
+		_, msg, err := c.ReadMessage()
+		require.NoError(t, err)
+		require.Equal(t, tc.expected+"\n", string(msg))
+	}
+
+	require.Len(t, wsconn.Subscriptions, 0)
+}
@@ -26,19 +26,27 @@ var testCalls = []struct {
 }{
 	{
 		call:     []byte(`{"jsonrpc":"2.0","method":"system_name","params":[],"id":1}`),
-		expected: []byte(`{"id":1,"jsonrpc":"2.0","result":"gossamer"}` + "\n")}, // working request
+		expected: []byte(`{"jsonrpc":"2.0","result":"gossamer","id":1}` + "\n")}, // working request
 	{
 		call: []byte(`{"jsonrpc":"2.0","method":"unknown","params":[],"id":1}`),
 		// unknown method
-		expected: []byte(`{"error":{` +
-			`"code":-32000,"data":null,` +
-			`"message":"rpc error method unknown not found"},` +
-			`"id":1,` +
-			`"jsonrpc":"2.0"}` + "\n")},
+		expected: []byte(`{"jsonrpc":"2.0","error":{` +
+			`"code":-32000,` +
+			`"message":"rpc error method unknown not found","data":null},` +
+			`"id":1}` + "\n")},
 	{
 		call: []byte{},
 		// empty request
-		expected: []byte(`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid request"},"id":0}` + "\n")},
+		expected: []byte(`{"jsonrpc":"2.0","error":{"code":-32600,"message":"Invalid request"},"id":null}` + "\n")},
+	{
+		call:     []byte(`{"jsonrpc":"2.0","method":"system_name","params":[],"id":"abc"}`),
+		expected: []byte(`{"jsonrpc":"2.0","result":"gossamer","id":"abc"}` + "\n")}, // string id
+	{
+		call: []byte(`[{"jsonrpc":"2.0","method":"system_name","params":[],"id":1},` +
+			`{"jsonrpc":"2.0","method":"chain_subscribeNewHeads","params":[],"id":2}]`),
+		expected: []byte(`[{"jsonrpc":"2.0","result":"gossamer","id":1},` +
+			`{"jsonrpc":"2.0","error":{"code":-32600,"message":"subscriptions are not supported in batch requests"},` +
+			`"id":2}]` + "\n")}, // batch
 	{
 		call:     []byte(`{"jsonrpc":"2.0","method":"chain_subscribeNewHeads","params":[],"id":3}`),
 		expected: []byte(`{"jsonrpc":"2.0","result":1,"id":3}` + "\n")},
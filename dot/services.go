@@ -13,6 +13,7 @@ import (
 
 	"github.com/ChainSafe/gossamer/dot/core"
 	"github.com/ChainSafe/gossamer/dot/digest"
+	"github.com/ChainSafe/gossamer/dot/light"
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/rpc"
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
@@ -25,13 +26,12 @@ import (
 	"github.com/ChainSafe/gossamer/lib/babe"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
-	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
-	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/runtime/life"
 	"github.com/ChainSafe/gossamer/lib/runtime/wasmer"
+	"github.com/ChainSafe/gossamer/lib/signer"
 	"github.com/ChainSafe/gossamer/lib/utils"
 )
 
@@ -81,8 +81,19 @@ func createRuntimeStorage(st *state.Service) (*runtime.NodeStorage, error) {
 	}, nil
 }
 
+// createSigner creates the signer of the node, which signs with the keys of the keystore, or else with the keys
+// of the signing server of the configuration
+func createSigner(cfg *Config, ks *keystore.GlobalKeystore) signer.Signer {
+	if cfg.Account.RemoteSigner == "" {
+		return signer.NewLocal(ks)
+	}
+
+	logger.Info("signing with the keys of signing server " + cfg.Account.RemoteSigner)
+	return signer.NewRemote(cfg.Account.RemoteSigner, cfg.Account.RemoteSignerToken)
+}
+
 func createRuntime(cfg *Config, ns runtime.NodeStorage, st *state.Service,
-	ks *keystore.GlobalKeystore, net *network.Service, code []byte) (
+	ks *keystore.GlobalKeystore, sgn signer.Signer, net *network.Service, code []byte) (
 	runtime.Instance, error) {
 	logger.Info("creating runtime with interpreter " + cfg.Core.WasmInterpreter + "...")
 
@@ -118,6 +129,7 @@ func createRuntime(cfg *Config, ns runtime.NodeStorage, st *state.Service,
 		}
 		rtCfg.Storage = ts
 		rtCfg.Keystore = ks
+		rtCfg.Signer = sgn
 		rtCfg.LogLvl = cfg.Log.RuntimeLvl
 		rtCfg.NodeStorage = ns
 		rtCfg.Network = net
@@ -135,6 +147,7 @@ func createRuntime(cfg *Config, ns runtime.NodeStorage, st *state.Service,
 		}
 		rtCfg.Storage = ts
 		rtCfg.Keystore = ks
+		rtCfg.Signer = sgn
 		rtCfg.LogLvl = cfg.Log.RuntimeLvl
 		rtCfg.NodeStorage = ns
 		rtCfg.Network = net
@@ -159,7 +172,8 @@ func asAuthority(authority bool) string {
 	return ""
 }
 
-func createBABEService(cfg *Config, st *state.Service, ks keystore.Keystore, cs *core.Service) (*babe.Service, error) {
+func createBABEService(cfg *Config, st *state.Service, ks keystore.Keystore, sgn signer.Signer,
+	cs *core.Service) (*babe.Service, error) {
 	logger.Info("creating BABE service" +
 		asAuthority(cfg.Core.BabeAuthority) + "...")
 
@@ -167,9 +181,13 @@ func createBABEService(cfg *Config, st *state.Service, ks keystore.Keystore, cs
 		return nil, ErrInvalidKeystoreType
 	}
 
-	kps := ks.Keypairs()
-	logger.Infof("keystore with keys %v", kps)
-	if len(kps) == 0 && cfg.Core.BabeAuthority {
+	pubs, err := sgn.PublicKeys(keystore.BabeName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get BABE keys: %w", err)
+	}
+
+	logger.Infof("keystore with keys %v", pubs)
+	if len(pubs) == 0 && cfg.Core.BabeAuthority {
 		return nil, ErrNoKeysProvided
 	}
 
@@ -180,15 +198,12 @@ func createBABEService(cfg *Config, st *state.Service, ks keystore.Keystore, cs
 		TransactionState:   st.Transaction,
 		EpochState:         st.Epoch,
 		BlockImportHandler: cs,
+		Signer:             sgn,
 		Authority:          cfg.Core.BabeAuthority,
 		IsDev:              cfg.Global.ID == "dev",
 		Lead:               cfg.Core.BABELead,
 	}
 
-	if cfg.Core.BabeAuthority {
-		bcfg.Keypair = kps[0].(*sr25519.Keypair)
-	}
-
 	// create new BABE service
 	bs, err := babe.NewService(bcfg)
 	if err != nil {
@@ -203,7 +218,7 @@ func createBABEService(cfg *Config, st *state.Service, ks keystore.Keystore, cs
 
 // createCoreService creates the core service from the provided core configuration
 func createCoreService(cfg *Config, ks *keystore.GlobalKeystore,
-	st *state.Service, net *network.Service, dh *digest.Handler) (
+	st *state.Service, net core.Network, dh *digest.Handler) (
 	*core.Service, error) {
 	logger.Debug("creating core service" +
 		asAuthority(cfg.Core.Roles == types.AuthorityRole) +
@@ -227,6 +242,8 @@ func createCoreService(cfg *Config, ks *keystore.GlobalKeystore,
 		StorageState:         st.Storage,
 		TransactionState:     st.Transaction,
 		Keystore:             ks,
+		KeystoreBasePath:     cfg.Global.BasePath,
+		KeystorePassword:     []byte(cfg.Account.SessionKeysPassword),
 		Network:              net,
 		DigestHandler:        dh,
 		CodeSubstitutes:      codeSubs,
@@ -272,6 +289,7 @@ func createNetworkService(cfg *Config, stateSrvc *state.Service) (*network.Servi
 		MaxPeers:          cfg.Network.MaxPeers,
 		PublishMetrics:    cfg.Global.PublishMetrics,
 		PersistentPeers:   cfg.Network.PersistentPeers,
+		ReservedOnly:      cfg.Network.ReservedOnly,
 		DiscoveryInterval: cfg.Network.DiscoveryInterval,
 		SlotDuration:      slotDuration,
 		PublicIP:          cfg.Network.PublicIP,
@@ -310,12 +328,30 @@ func createRPCService(cfg *Config, ns *runtime.NodeStorage, stateSrvc *state.Ser
 		return nil, fmt.Errorf("failed to create sync state service: %s", err)
 	}
 
+	var (
+		storageAPI modules.StorageAPI = stateSrvc.Storage
+		coreAPI    modules.CoreAPI    = coreSrvc
+	)
+
+	// light clients keep no state, storage queries and runtime calls are answered using proofs from full nodes
+	if cfg.Core.Roles == types.LightClientRole {
+		client := light.NewClient(stateSrvc.Block, networkSrvc, newLightInstanceBuilder(cfg))
+		storageAPI = &lightStorageAPI{
+			StorageState: stateSrvc.Storage,
+			client:       client,
+		}
+		coreAPI = &lightCoreAPI{
+			Service: coreSrvc,
+			client:  client,
+		}
+	}
+
 	rpcConfig := &rpc.HTTPServerConfig{
 		LogLvl:              cfg.Log.RPCLvl,
 		BlockAPI:            stateSrvc.Block,
-		StorageAPI:          stateSrvc.Storage,
+		StorageAPI:          storageAPI,
 		NetworkAPI:          networkSrvc,
-		CoreAPI:             coreSrvc,
+		CoreAPI:             coreAPI,
 		NodeStorage:         ns,
 		BlockProducerAPI:    bp,
 		BlockFinalityAPI:    finSrvc,
@@ -340,6 +376,21 @@ func createRPCService(cfg *Config, ns *runtime.NodeStorage, stateSrvc *state.Ser
 	return rpc.NewHTTPServer(rpcConfig), nil
 }
 
+// setSS58Format sets the SS58 address format of the chain, from its genesis properties, to encode addresses with
+func setSS58Format(stateSrvc *state.Service) error {
+	genesisData, err := stateSrvc.Base.LoadGenesisData()
+	if err != nil {
+		return fmt.Errorf("failed to load genesis data: %w", err)
+	}
+
+	format, err := genesisData.SS58Format()
+	if err != nil {
+		return err
+	}
+
+	return crypto.SetSS58Format(format)
+}
+
 // createSystemService creates a systemService for providing system related information
 func createSystemService(cfg *types.SystemInfo, stateSrvc *state.Service) (*system.Service, error) {
 	genesisData, err := stateSrvc.Base.LoadGenesisData()
@@ -352,13 +403,8 @@ func createSystemService(cfg *types.SystemInfo, stateSrvc *state.Service) (*syst
 
 // createGRANDPAService creates a new GRANDPA service
 func createGRANDPAService(cfg *Config, st *state.Service, dh *digest.Handler,
-	ks keystore.Keystore, net *network.Service) (*grandpa.Service, error) {
-	rt, err := st.Block.GetRuntime(nil)
-	if err != nil {
-		return nil, err
-	}
-
-	ad, err := rt.GrandpaAuthorities()
+	ks keystore.Keystore, sgn signer.Signer, net grandpa.Network) (*grandpa.Service, error) {
+	voters, err := grandpaVoters(cfg, st)
 	if err != nil {
 		return nil, err
 	}
@@ -367,9 +413,11 @@ func createGRANDPAService(cfg *Config, st *state.Service, dh *digest.Handler,
 		return nil, ErrInvalidKeystoreType
 	}
 
-	voters := types.NewGrandpaVotersFromAuthorities(ad)
+	keys, err := sgn.PublicKeys(keystore.GranName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get GRANDPA keys: %w", err)
+	}
 
-	keys := ks.Keypairs()
 	if len(keys) == 0 && cfg.Core.GrandpaAuthority {
 		return nil, errors.New("no ed25519 keys provided for GRANDPA")
 	}
@@ -380,16 +428,38 @@ func createGRANDPAService(cfg *Config, st *state.Service, dh *digest.Handler,
 		GrandpaState:  st.Grandpa,
 		DigestHandler: dh,
 		Voters:        voters,
+		Signer:        sgn,
 		Authority:     cfg.Core.GrandpaAuthority,
 		Network:       net,
 		Interval:      cfg.Core.GrandpaInterval,
 	}
 
-	if cfg.Core.GrandpaAuthority {
-		gsCfg.Keypair = keys[0].(*ed25519.Keypair)
+	return grandpa.NewService(gsCfg)
+}
+
+// grandpaVoters returns the current GRANDPA voters. Light clients have no runtime loaded,
+// so they use the voters of the current set tracked by the grandpa state.
+func grandpaVoters(cfg *Config, st *state.Service) ([]types.GrandpaVoter, error) {
+	if cfg.Core.Roles == types.LightClientRole {
+		setID, err := st.Grandpa.GetCurrentSetID()
+		if err != nil {
+			return nil, err
+		}
+
+		return st.Grandpa.GetAuthorities(setID)
 	}
 
-	return grandpa.NewService(gsCfg)
+	rt, err := st.Block.GetRuntime(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ad, err := rt.GrandpaAuthorities()
+	if err != nil {
+		return nil, err
+	}
+
+	return types.NewGrandpaVotersFromAuthorities(ad), nil
 }
 
 func createBlockVerifier(st *state.Service) (*babe.VerificationManager, error) {
@@ -402,7 +472,7 @@ func createBlockVerifier(st *state.Service) (*babe.VerificationManager, error) {
 }
 
 func newSyncService(cfg *Config, st *state.Service, fg sync.FinalityGadget,
-	verifier *babe.VerificationManager, cs *core.Service, net *network.Service) (
+	verifier *babe.VerificationManager, cs *core.Service, net *network.Service, dh *digest.Handler) (
 	*sync.Service, error) {
 	slotDuration, err := st.Epoch.GetSlotDuration()
 	if err != nil {
@@ -414,6 +484,7 @@ func newSyncService(cfg *Config, st *state.Service, fg sync.FinalityGadget,
 		Network:            net,
 		BlockState:         st.Block,
 		StorageState:       st.Storage,
+		EpochState:         st.Epoch,
 		TransactionState:   st.Transaction,
 		FinalityGadget:     fg,
 		BabeVerifier:       verifier,
@@ -421,6 +492,9 @@ func newSyncService(cfg *Config, st *state.Service, fg sync.FinalityGadget,
 		MinPeers:           cfg.Network.MinPeers,
 		MaxPeers:           cfg.Network.MaxPeers,
 		SlotDuration:       slotDuration,
+		WarpSync:           cfg.Core.WarpSync,
+		HeadersOnly:        cfg.Core.Roles == types.LightClientRole,
+		DigestHandler:      dh,
 	}
 
 	return sync.NewService(syncCfg)
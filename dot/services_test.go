@@ -14,6 +14,7 @@ import (
 	"github.com/ChainSafe/gossamer/internal/pprof"
 	"github.com/ChainSafe/gossamer/lib/grandpa"
 	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/signer"
 	"github.com/ChainSafe/gossamer/lib/utils"
 
 	"github.com/gorilla/websocket"
@@ -126,7 +127,7 @@ func TestCreateSyncService(t *testing.T) {
 	coreSrvc, err := createCoreService(cfg, ks, stateSrvc, &network.Service{}, dh)
 	require.NoError(t, err)
 
-	_, err = newSyncService(cfg, stateSrvc, &grandpa.Service{}, ver, coreSrvc, &network.Service{})
+	_, err = newSyncService(cfg, stateSrvc, &grandpa.Service{}, ver, coreSrvc, &network.Service{}, nil)
 	require.NoError(t, err)
 }
 
@@ -182,7 +183,7 @@ func TestCreateRPCService(t *testing.T) {
 
 	ns, err := createRuntimeStorage(stateSrvc)
 	require.NoError(t, err)
-	err = loadRuntime(cfg, ns, stateSrvc, ks, networkSrvc)
+	err = loadRuntime(cfg, ns, stateSrvc, ks, signer.NewLocal(ks), networkSrvc)
 	require.NoError(t, err)
 
 	dh, err := createDigestHandler(stateSrvc)
@@ -224,7 +225,7 @@ func TestCreateBABEService(t *testing.T) {
 
 	ns, err := createRuntimeStorage(stateSrvc)
 	require.NoError(t, err)
-	err = loadRuntime(cfg, ns, stateSrvc, ks, &network.Service{})
+	err = loadRuntime(cfg, ns, stateSrvc, ks, signer.NewLocal(ks), &network.Service{})
 	require.NoError(t, err)
 
 	dh, err := createDigestHandler(stateSrvc)
@@ -233,7 +234,7 @@ func TestCreateBABEService(t *testing.T) {
 	coreSrvc, err := createCoreService(cfg, ks, stateSrvc, &network.Service{}, dh)
 	require.NoError(t, err)
 
-	bs, err := createBABEService(cfg, stateSrvc, ks.Babe, coreSrvc)
+	bs, err := createBABEService(cfg, stateSrvc, ks.Babe, signer.NewLocal(ks), coreSrvc)
 	require.NoError(t, err)
 	require.NotNil(t, bs)
 }
@@ -264,7 +265,7 @@ func TestCreateGrandpaService(t *testing.T) {
 	ns, err := createRuntimeStorage(stateSrvc)
 	require.NoError(t, err)
 
-	err = loadRuntime(cfg, ns, stateSrvc, ks, &network.Service{})
+	err = loadRuntime(cfg, ns, stateSrvc, ks, signer.NewLocal(ks), &network.Service{})
 	require.NoError(t, err)
 
 	dh, err := createDigestHandler(stateSrvc)
@@ -273,7 +274,7 @@ func TestCreateGrandpaService(t *testing.T) {
 	networkSrvc, err := createNetworkService(cfg, stateSrvc)
 	require.NoError(t, err)
 
-	gs, err := createGRANDPAService(cfg, stateSrvc, dh, ks.Gran, networkSrvc)
+	gs, err := createGRANDPAService(cfg, stateSrvc, dh, ks.Gran, signer.NewLocal(ks), networkSrvc)
 	require.NoError(t, err)
 	require.NotNil(t, gs)
 }
@@ -337,7 +338,7 @@ func TestNewWebSocketServer(t *testing.T) {
 
 	ns, err := createRuntimeStorage(stateSrvc)
 	require.NoError(t, err)
-	err = loadRuntime(cfg, ns, stateSrvc, ks, networkSrvc)
+	err = loadRuntime(cfg, ns, stateSrvc, ks, signer.NewLocal(ks), networkSrvc)
 	require.NoError(t, err)
 
 	dh, err := createDigestHandler(stateSrvc)
@@ -381,3 +382,15 @@ func Test_createPprofService(t *testing.T) {
 
 	require.NotNil(t, service)
 }
+
+func Test_createSigner(t *testing.T) {
+	t.Parallel()
+
+	ks := keystore.NewGlobalKeystore()
+	cfg := &Config{}
+	require.Equal(t, signer.NewLocal(ks), createSigner(cfg, ks))
+
+	cfg.Account.RemoteSigner = "http://127.0.0.1:9955/"
+	cfg.Account.RemoteSignerToken = "secret"
+	require.Equal(t, signer.NewRemote("http://127.0.0.1:9955", "secret"), createSigner(cfg, ks))
+}
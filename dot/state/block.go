@@ -60,6 +60,11 @@ type BlockState struct {
 	runtimeUpdateSubscriptionsLock sync.RWMutex
 	runtimeUpdateSubscriptions     map[uint32]chan<- runtime.Version
 
+	// runtimePools holds the instance pools of the runtimes of the block tree, by runtime. Blocks share
+	// the same runtime instance until the runtime code changes, so there is one pool per runtime code.
+	runtimePools     map[runtime.Instance]*runtime.InstancePool
+	runtimePoolsLock sync.Mutex
+
 	pruneKeyCh chan *types.Header
 }
 
@@ -74,6 +79,7 @@ func NewBlockState(db chaindb.Database) (*BlockState, error) {
 		finalised:                  make(map[chan *types.FinalisationInfo]struct{}),
 		pruneKeyCh:                 make(chan *types.Header, pruneKeyBufferSize),
 		runtimeUpdateSubscriptions: make(map[uint32]chan<- runtime.Version),
+		runtimePools:               make(map[runtime.Instance]*runtime.InstancePool),
 	}
 
 	gh, err := bs.db.Get(headerHashKey(0))
@@ -105,6 +111,7 @@ func NewBlockStateFromGenesis(db chaindb.Database, header *types.Header) (*Block
 		finalised:                  make(map[chan *types.FinalisationInfo]struct{}),
 		pruneKeyCh:                 make(chan *types.Header, pruneKeyBufferSize),
 		runtimeUpdateSubscriptions: make(map[uint32]chan<- runtime.Version),
+		runtimePools:               make(map[runtime.Instance]*runtime.InstancePool),
 		genesisHash:                header.Hash(),
 		lastFinalised:              header.Hash(),
 	}
@@ -472,6 +479,17 @@ func (bs *BlockState) BestBlockHash() common.Hash {
 	return bs.bt.DeepestBlockHash()
 }
 
+// SetForkChoiceRule sets the rule the block tree uses to choose the best block
+func (bs *BlockState) SetForkChoiceRule(rule blocktree.ForkChoiceRule) {
+	bs.bt.SetForkChoiceRule(rule)
+}
+
+// SetBestBlockHint restricts the best block to the descendants of the given block,
+// passing the zero hash clears the hint
+func (bs *BlockState) SetBestBlockHint(hash common.Hash) error {
+	return bs.bt.SetBestBlockHint(hash)
+}
+
 // BestBlockHeader returns the block header of the current head of the chain
 func (bs *BlockState) BestBlockHeader() (*types.Header, error) {
 	return bs.GetHeader(bs.BestBlockHash())
@@ -631,6 +649,7 @@ func (bs *BlockState) HandleRuntimeChanges(newState *rtstorage.TrieState,
 
 	rtCfg.Storage = newState
 	rtCfg.Keystore = rt.Keystore()
+	rtCfg.Signer = rt.Signer()
 	rtCfg.NodeStorage = rt.NodeStorage()
 	rtCfg.Network = rt.NetworkService()
 	rtCfg.CodeHash = currCodeHash
@@ -677,6 +696,64 @@ func (bs *BlockState) StoreRuntime(hash common.Hash, rt runtime.Instance) {
 	bs.bt.StoreRuntime(hash, rt)
 }
 
+// GetRuntimePool returns the pool of instances of the runtime of the given block, or of the best block if the
+// hash is nil. The pool is created with runtime.DefaultPoolSize clones of the runtime on first use. Instances
+// checked out of the pool make runtime calls concurrently, without waiting on the runtime returned by GetRuntime.
+// If the runtime cannot be cloned, the pool holds the runtime itself, which is shared with GetRuntime callers.
+func (bs *BlockState) GetRuntimePool(hash *common.Hash) (*runtime.InstancePool, error) {
+	rt, err := bs.GetRuntime(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	bs.runtimePoolsLock.Lock()
+	defer bs.runtimePoolsLock.Unlock()
+
+	pool, has := bs.runtimePools[rt]
+	if has {
+		return pool, nil
+	}
+
+	pool, err = runtime.NewInstancePool(rt, runtime.DefaultPoolSize)
+	if errors.Is(err, runtime.ErrCloneNotSupported) {
+		pool = runtime.NewSharedInstancePool(rt)
+	} else if err != nil {
+		return nil, err
+	}
+
+	bs.runtimePools[rt] = pool
+	return pool, nil
+}
+
+// pruneRuntimePools closes the instance pools of the runtimes no longer used by any block of the block tree
+func (bs *BlockState) pruneRuntimePools() {
+	bs.runtimePoolsLock.Lock()
+	defer bs.runtimePoolsLock.Unlock()
+
+	if len(bs.runtimePools) == 0 {
+		return
+	}
+
+	inUse := make(map[runtime.Instance]struct{})
+	for _, hash := range bs.bt.GetAllBlocks() {
+		rt, err := bs.bt.GetBlockRuntime(hash)
+		if err != nil {
+			continue
+		}
+
+		inUse[rt] = struct{}{}
+	}
+
+	for rt, pool := range bs.runtimePools {
+		if _, has := inUse[rt]; has {
+			continue
+		}
+
+		pool.Close()
+		delete(bs.runtimePools, rt)
+	}
+}
+
 // GetNonFinalisedBlocks get all the blocks in the blocktree
 func (bs *BlockState) GetNonFinalisedBlocks() []common.Hash {
 	return bs.bt.GetAllBlocks()
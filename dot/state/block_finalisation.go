@@ -7,9 +7,12 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math/big"
+	"sync"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/common"
 )
 
@@ -145,6 +148,8 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 	}
 
 	pruned := bs.bt.Prune(hash)
+	bs.pruneRuntimePools()
+
 	for _, hash := range pruned {
 		block, has := bs.getAndDeleteUnfinalisedBlock(hash)
 		if !has {
@@ -185,6 +190,75 @@ func (bs *BlockState) SetFinalisedHash(hash common.Hash, round, setID uint64) er
 	return nil
 }
 
+// ImportWarpSyncHeader sets a header proven final by a warp sync proof as the latest finalised block.
+// The header becomes the root of the block tree, and any unfinalised blocks are discarded.
+// Its ancestors aren't imported, so they remain unknown to the node.
+func (bs *BlockState) ImportWarpSyncHeader(header *types.Header, justification []byte, round, setID uint64) error {
+	bs.Lock()
+	defer bs.Unlock()
+
+	hash := header.Hash()
+
+	if err := bs.SetHeader(header); err != nil {
+		return err
+	}
+
+	if err := bs.SetJustification(hash, justification); err != nil {
+		return err
+	}
+
+	if err := bs.setArrivalTime(hash, time.Now()); err != nil {
+		return err
+	}
+
+	if err := bs.db.Put(headerHashKey(header.Number.Uint64()), hash.ToBytes()); err != nil {
+		return err
+	}
+
+	if err := bs.db.Put(finalisedHashKey(round, setID), hash[:]); err != nil {
+		return fmt.Errorf("failed to set finalised hash key: %w", err)
+	}
+
+	if err := bs.setHighestRoundAndSetID(round, setID); err != nil {
+		return fmt.Errorf("failed to set highest round and set ID: %w", err)
+	}
+
+	bs.resetBlockTree(header)
+	return nil
+}
+
+// RevertWarpSyncHeader sets the given finalised header, which must be the latest finalised block before
+// a warp sync, back as the latest finalised block, for the blocks after it to be synced and executed instead.
+// The header becomes the root of the block tree again, and any unfinalised blocks are discarded.
+func (bs *BlockState) RevertWarpSyncHeader(header *types.Header, round, setID uint64) error {
+	bs.Lock()
+	defer bs.Unlock()
+
+	if err := bs.db.Put(highestRoundAndSetIDKey, roundAndSetIDToBytes(round, setID)); err != nil {
+		return fmt.Errorf("failed to set highest round and set ID: %w", err)
+	}
+
+	bs.resetBlockTree(header)
+	return nil
+}
+
+// resetBlockTree replaces the block tree with a block tree rooted at the given finalised header
+func (bs *BlockState) resetBlockTree(header *types.Header) {
+	hash := header.Hash()
+
+	// keep the runtime of the previous chain, state sync upgrades it if the code has changed since
+	rt, rtErr := bs.bt.GetBlockRuntime(bs.bt.DeepestBlockHash())
+
+	bs.bt = blocktree.NewBlockTreeFromRoot(header)
+	if rtErr == nil {
+		bs.bt.StoreRuntime(hash, rt)
+	}
+	bs.pruneRuntimePools()
+
+	bs.unfinalisedBlocks = new(sync.Map)
+	bs.lastFinalised = hash
+}
+
 func (bs *BlockState) handleFinalisedBlock(curr common.Hash) error {
 	if curr.Equal(bs.lastFinalised) {
 		return nil
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 
 	"github.com/stretchr/testify/require"
 )
@@ -145,3 +146,60 @@ func TestSetFinalisedHash_setFirstSlotOnFinalisation(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, firstSlot, res)
 }
+
+func TestBlockState_ImportWarpSyncHeader(t *testing.T) {
+	bs := newTestBlockState(t, testGenesisHeader)
+
+	header := &types.Header{
+		ParentHash: common.Hash{0x01},
+		Number:     big.NewInt(100),
+		Digest:     types.NewDigest(),
+	}
+
+	err := bs.ImportWarpSyncHeader(header, []byte{1, 2, 3}, 7, 2)
+	require.NoError(t, err)
+
+	fin, err := bs.GetHighestFinalisedHeader()
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), fin.Hash())
+
+	round, setID, err := bs.GetHighestRoundAndSetID()
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), round)
+	require.Equal(t, uint64(2), setID)
+
+	hash, err := bs.GetHashByNumber(big.NewInt(100))
+	require.NoError(t, err)
+	require.Equal(t, header.Hash(), hash)
+
+	justification, err := bs.GetJustification(header.Hash())
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, justification)
+	require.Equal(t, header.Hash(), bs.BestBlockHash())
+}
+
+func TestBlockState_RevertWarpSyncHeader(t *testing.T) {
+	bs := newTestBlockState(t, testGenesisHeader)
+
+	header := &types.Header{
+		ParentHash: common.Hash{0x01},
+		Number:     big.NewInt(100),
+		Digest:     types.NewDigest(),
+	}
+
+	err := bs.ImportWarpSyncHeader(header, []byte{1, 2, 3}, 7, 2)
+	require.NoError(t, err)
+
+	err = bs.RevertWarpSyncHeader(testGenesisHeader, 0, 0)
+	require.NoError(t, err)
+
+	fin, err := bs.GetHighestFinalisedHeader()
+	require.NoError(t, err)
+	require.Equal(t, testGenesisHeader.Hash(), fin.Hash())
+
+	round, setID, err := bs.GetHighestRoundAndSetID()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), round)
+	require.Equal(t, uint64(0), setID)
+	require.Equal(t, testGenesisHeader.Hash(), bs.BestBlockHash())
+}
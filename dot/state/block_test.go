@@ -10,6 +10,8 @@ import (
 
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	runtimemocks "github.com/ChainSafe/gossamer/lib/runtime/mocks"
 	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 
@@ -530,3 +532,81 @@ func TestNumberIsFinalised(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, fin)
 }
+
+func TestGetRuntimePool(t *testing.T) {
+	bs := newTestBlockState(t, testGenesisHeader)
+
+	newMockInstance := func() *runtimemocks.Instance {
+		rt := new(runtimemocks.Instance)
+		rt.On("Clone").Return(func() runtime.Instance {
+			clone := new(runtimemocks.Instance)
+			clone.On("Stop")
+			return clone
+		}, nil)
+		return rt
+	}
+
+	genesisRuntime := newMockInstance()
+	bs.StoreRuntime(bs.GenesisHash(), genesisRuntime)
+
+	pool, err := bs.GetRuntimePool(nil)
+	require.NoError(t, err)
+
+	genesisHash := bs.GenesisHash()
+	samePool, err := bs.GetRuntimePool(&genesisHash)
+	require.NoError(t, err)
+	require.Same(t, pool, samePool)
+	genesisRuntime.AssertNumberOfCalls(t, "Clone", runtime.DefaultPoolSize)
+
+	header := &types.Header{
+		ParentHash: genesisHash,
+		Number:     big.NewInt(1),
+		Digest:     types.NewDigest(),
+	}
+
+	err = bs.AddBlock(&types.Block{
+		Header: *header,
+		Body:   *types.NewBody([]types.Extrinsic{}),
+	})
+	require.NoError(t, err)
+
+	// the runtime code changed at block 1
+	upgradedRuntime := newMockInstance()
+	bs.StoreRuntime(header.Hash(), upgradedRuntime)
+
+	hash := header.Hash()
+	upgradedPool, err := bs.GetRuntimePool(&hash)
+	require.NoError(t, err)
+	require.NotSame(t, pool, upgradedPool)
+
+	// the pool of the genesis runtime is closed once no block uses it anymore
+	bs.bt.Prune(hash)
+	bs.pruneRuntimePools()
+
+	_, err = pool.Get()
+	require.ErrorIs(t, err, runtime.ErrPoolClosed)
+
+	in, err := upgradedPool.Get()
+	require.NoError(t, err)
+	upgradedPool.Put(in)
+}
+
+func TestGetRuntimePool_CloneNotSupported(t *testing.T) {
+	bs := newTestBlockState(t, testGenesisHeader)
+
+	rt := new(runtimemocks.Instance)
+	rt.On("Clone").Return(nil, runtime.ErrCloneNotSupported)
+	bs.StoreRuntime(bs.GenesisHash(), rt)
+
+	pool, err := bs.GetRuntimePool(nil)
+	require.NoError(t, err)
+
+	// the pool holds the runtime itself
+	in, err := pool.Get()
+	require.NoError(t, err)
+	require.Same(t, rt, in)
+	pool.Put(in)
+
+	pool.Close()
+	rt.AssertNotCalled(t, "Stop")
+}
@@ -12,6 +12,8 @@ import (
 
 	"github.com/ChainSafe/chaindb"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
@@ -305,6 +307,95 @@ func (s *EpochState) HasConfigData(epoch uint64) (bool, error) {
 	return s.db.Has(configDataKey(epoch))
 }
 
+// ImportEpochs sets the data and the configuration of the current and the next BABE epochs of the given state,
+// along with the current epoch and the first slot the epochs are counted from. The current epoch is returned by
+// BabeApi_current_epoch, called with the given runtime, and the next epoch is read from the BABE storage.
+// It is used when the state of a block is imported without its ancestors, whose digests announced the epochs.
+func (s *EpochState) ImportEpochs(ts *rtstorage.TrieState, rt runtime.Instance) error {
+	rt.SetContextStorage(ts)
+	enc, err := rt.Exec(runtime.BabeAPICurrentEpoch, []byte{})
+	if err != nil {
+		return fmt.Errorf("failed to get current epoch: %w", err)
+	}
+
+	current, err := runtime.DecodeBabeEpoch(enc)
+	if err != nil {
+		return fmt.Errorf("failed to decode current epoch: %w", err)
+	}
+
+	if current.StartSlot < current.EpochIndex*s.epochLength {
+		return fmt.Errorf("start slot %d of epoch %d is before the first slot", current.StartSlot, current.EpochIndex)
+	}
+
+	// the epochs are counted from the slot of block 1, which started epoch 0
+	if err = s.baseState.storeFirstSlot(current.StartSlot - current.EpochIndex*s.epochLength); err != nil {
+		return err
+	}
+
+	if err = s.SetCurrentEpoch(current.EpochIndex); err != nil {
+		return err
+	}
+
+	auths, err := types.BABEAuthorityRawToAuthority(current.Authorities)
+	if err != nil {
+		return err
+	}
+
+	err = s.SetEpochData(current.EpochIndex, &types.EpochData{
+		Authorities: auths,
+		Randomness:  current.Randomness,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = s.SetConfigData(current.EpochIndex, &types.ConfigData{
+		C1:             current.C1,
+		C2:             current.C2,
+		SecondarySlots: current.AllowedSlots,
+	})
+	if err != nil {
+		return err
+	}
+
+	enc = ts.Get(runtime.BABENextAuthoritiesKey())
+	if enc == nil {
+		return errors.New("next epoch authorities not found in state")
+	}
+
+	var nextAuthsRaw []types.AuthorityRaw
+	if err = scale.Unmarshal(enc, &nextAuthsRaw); err != nil {
+		return fmt.Errorf("failed to decode next epoch authorities: %w", err)
+	}
+
+	nextAuths, err := types.BABEAuthorityRawToAuthority(nextAuthsRaw)
+	if err != nil {
+		return err
+	}
+
+	next := &types.EpochData{
+		Authorities: nextAuths,
+	}
+	copy(next.Randomness[:], ts.Get(runtime.BABENextRandomnessKey()))
+
+	if err = s.SetEpochData(current.EpochIndex+1, next); err != nil {
+		return err
+	}
+
+	// the configuration of the next epoch is only stored if it changes
+	enc = ts.Get(runtime.BABENextEpochConfigKey())
+	if enc == nil {
+		return nil
+	}
+
+	nextConfig := new(types.ConfigData)
+	if err = scale.Unmarshal(enc, nextConfig); err != nil {
+		return fmt.Errorf("failed to decode next epoch configuration: %w", err)
+	}
+
+	return s.SetConfigData(current.EpochIndex+1, nextConfig)
+}
+
 // GetStartSlotForEpoch returns the first slot in the given epoch.
 // If 0 is passed as the epoch, it returns the start slot for the current epoch.
 func (s *EpochState) GetStartSlotForEpoch(epoch uint64) (uint64, error) {
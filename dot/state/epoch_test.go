@@ -11,6 +11,9 @@ import (
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	runtimemocks "github.com/ChainSafe/gossamer/lib/runtime/mocks"
+	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 
 	"github.com/stretchr/testify/require"
@@ -223,3 +226,83 @@ func TestEpochState_GetEpochFromTime(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, uint64(99), epoch)
 }
+
+func TestEpochState_ImportEpochs(t *testing.T) {
+	s := newEpochStateFromGenesis(t)
+
+	keyring, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	alice := keyring.Alice().Public().(*sr25519.PublicKey).AsBytes()
+	bob := keyring.Bob().Public().(*sr25519.PublicKey).AsBytes()
+
+	current := runtime.BabeEpoch{
+		EpochIndex:   3,
+		StartSlot:    1000 + 3*genesisBABEConfig.EpochLength,
+		Duration:     genesisBABEConfig.EpochLength,
+		Authorities:  []types.AuthorityRaw{{Key: alice, Weight: 1}},
+		Randomness:   [types.RandomnessLength]byte{1},
+		C1:           1,
+		C2:           4,
+		AllowedSlots: 1,
+	}
+	enc, err := scale.Marshal(current)
+	require.NoError(t, err)
+
+	ts, err := rtstorage.NewTrieState(nil)
+	require.NoError(t, err)
+
+	rt := new(runtimemocks.Instance)
+	rt.On("SetContextStorage", ts)
+	rt.On("Exec", runtime.BabeAPICurrentEpoch, []byte{}).Return(enc, nil)
+
+	// the next epoch data is read from the state
+	err = s.ImportEpochs(ts, rt)
+	require.Error(t, err)
+
+	nextAuthorities := []types.AuthorityRaw{{Key: bob, Weight: 1}}
+	enc, err = scale.Marshal(nextAuthorities)
+	require.NoError(t, err)
+	ts.Set(runtime.BABENextAuthoritiesKey(), enc)
+	ts.Set(runtime.BABENextRandomnessKey(), []byte{2})
+
+	err = s.ImportEpochs(ts, rt)
+	require.NoError(t, err)
+
+	epoch, err := s.GetCurrentEpoch()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), epoch)
+
+	start, err := s.GetStartSlotForEpoch(3)
+	require.NoError(t, err)
+	require.Equal(t, current.StartSlot, start)
+
+	data, err := s.GetEpochData(3)
+	require.NoError(t, err)
+	require.Equal(t, current.Authorities, types.AuthoritiesToRaw(data.Authorities))
+	require.Equal(t, current.Randomness, data.Randomness)
+
+	data, err = s.GetEpochData(4)
+	require.NoError(t, err)
+	require.Equal(t, nextAuthorities, types.AuthoritiesToRaw(data.Authorities))
+	require.Equal(t, [types.RandomnessLength]byte{2}, data.Randomness)
+
+	cfg, err := s.GetConfigData(3)
+	require.NoError(t, err)
+	require.Equal(t, &types.ConfigData{C1: 1, C2: 4, SecondarySlots: 1}, cfg)
+
+	// the configuration of the next epoch is only stored if it changes
+	has, err := s.HasConfigData(4)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	enc, err = scale.Marshal(types.ConfigData{C1: 1, C2: 2, SecondarySlots: 2})
+	require.NoError(t, err)
+	ts.Set(runtime.BABENextEpochConfigKey(), enc)
+
+	err = s.ImportEpochs(ts, rt)
+	require.NoError(t, err)
+
+	cfg, err = s.GetConfigData(4)
+	require.NoError(t, err)
+	require.Equal(t, &types.ConfigData{C1: 1, C2: 2, SecondarySlots: 2}, cfg)
+}
@@ -6,6 +6,7 @@ package state
 import (
 	"encoding/binary"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ChainSafe/chaindb"
@@ -106,6 +107,21 @@ func (s *GrandpaState) setCurrentSetID(setID uint64) error {
 	return s.db.Put(currentSetIDKey, buf)
 }
 
+// SetCurrentSetID sets the current set ID back to a previous set ID, for the authority set changes
+// after it to be applied again as their blocks are imported
+func (s *GrandpaState) SetCurrentSetID(setID uint64) error {
+	curr, err := s.GetCurrentSetID()
+	if err != nil {
+		return err
+	}
+
+	if setID > curr {
+		return fmt.Errorf("cannot set current set ID %d after set ID %d", setID, curr)
+	}
+
+	return s.setCurrentSetID(setID)
+}
+
 // GetCurrentSetID retrieves the current set ID
 func (s *GrandpaState) GetCurrentSetID() (uint64, error) {
 	id, err := s.db.Get(currentSetIDKey)
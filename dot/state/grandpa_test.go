@@ -69,6 +69,25 @@ func TestGrandpaState_IncrementSetID(t *testing.T) {
 	require.Equal(t, genesisSetID+1, setID)
 }
 
+func TestGrandpaState_SetCurrentSetID(t *testing.T) {
+	db := NewInMemoryDB(t)
+	gs, err := NewGrandpaStateFromGenesis(db, testAuths)
+	require.NoError(t, err)
+
+	err = gs.IncrementSetID()
+	require.NoError(t, err)
+
+	err = gs.SetCurrentSetID(genesisSetID + 2)
+	require.Error(t, err)
+
+	err = gs.SetCurrentSetID(genesisSetID)
+	require.NoError(t, err)
+
+	setID, err := gs.GetCurrentSetID()
+	require.NoError(t, err)
+	require.Equal(t, genesisSetID, setID)
+}
+
 func TestGrandpaState_GetSetIDByBlockNumber(t *testing.T) {
 	db := NewInMemoryDB(t)
 	gs, err := NewGrandpaStateFromGenesis(db, testAuths)
@@ -168,6 +168,22 @@ func (s *StorageState) TrieState(root *common.Hash) (*rtstorage.TrieState, error
 	return next, nil
 }
 
+// ImportTrie writes a trie downloaded by state sync to the database and caches it
+func (s *StorageState) ImportTrie(t *trie.Trie) error {
+	root, err := t.Hash()
+	if err != nil {
+		return err
+	}
+
+	if err = t.Store(s.db); err != nil {
+		return fmt.Errorf("failed to store trie with root %s: %w", root, err)
+	}
+
+	_, _ = s.tries.LoadOrStore(root, t)
+	logger.Debugf("imported trie with root %s", root)
+	return nil
+}
+
 // LoadFromDB loads an encoded trie from the DB where the key is `root`
 func (s *StorageState) LoadFromDB(root common.Hash) (*trie.Trie, error) {
 	t := trie.NewEmptyTrie()
@@ -145,6 +145,24 @@ func TestStorage_LoadFromDB(t *testing.T) {
 	require.Equal(t, 3, len(entries))
 }
 
+func TestStorage_ImportTrie(t *testing.T) {
+	storage := newTestStorageState(t)
+
+	tr := trie.NewEmptyTrie()
+	tr.Put([]byte("key1"), []byte("value1"))
+	tr.Put([]byte("key2"), []byte("value2"))
+
+	err := storage.ImportTrie(tr)
+	require.NoError(t, err)
+
+	root := tr.MustHash()
+	storage.tries.Delete(root)
+
+	data, err := storage.GetStorage(&root, []byte("key2"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), data)
+}
+
 func syncMapLen(m *sync.Map) int {
 	l := 0
 	m.Range(func(_, _ interface{}) bool {
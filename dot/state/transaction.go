@@ -4,6 +4,8 @@
 package state
 
 import (
+	"errors"
+	"math"
 	"sync"
 
 	"github.com/ChainSafe/gossamer/dot/telemetry"
@@ -13,10 +15,22 @@ import (
 	"github.com/ChainSafe/gossamer/lib/transaction"
 )
 
-// TransactionState represents the queue of transactions
+// TransactionState represents the queue of transactions. Transactions are added to the pool as future
+// transactions and are moved to the ready queue by PromoteReady once every tag they require is provided.
 type TransactionState struct {
 	queue *transaction.PriorityQueue
 	pool  *transaction.Pool
+	// txMu serialises the changes spanning both the queue and the pool, such as moving transactions from one
+	// to the other or evicting a transaction along with its dependents.
+	txMu sync.Mutex
+
+	// mu guards the fields below. bestNumber is the number of the latest block passed to PruneStale,
+	// validUntil maps transaction hashes to the last block number they are valid for, and includedTags
+	// holds the tags provided by transactions included in blocks that are required by transactions in the pool.
+	mu           sync.Mutex
+	bestNumber   uint64
+	validUntil   map[common.Hash]uint64
+	includedTags map[string]struct{}
 
 	// notifierChannels are used to notify transaction status. It maps a channel to
 	// hex string of the extrinsic it is supposed to notify about.
@@ -29,14 +43,40 @@ func NewTransactionState() *TransactionState {
 	return &TransactionState{
 		queue:            transaction.NewPriorityQueue(),
 		pool:             transaction.NewPool(),
+		validUntil:       make(map[common.Hash]uint64),
+		includedTags:     make(map[string]struct{}),
 		notifierChannels: make(map[chan transaction.Status]string),
 	}
 }
 
-// Push pushes a transaction to the queue, ordered by priority
+// Push pushes a transaction to the queue, ordered by priority. Queued transactions providing the same tags
+// with a lower priority are replaced, and their watchers are notified with the Usurped status.
 func (s *TransactionState) Push(vt *transaction.ValidTransaction) (common.Hash, error) {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	return s.push(vt)
+}
+
+func (s *TransactionState) push(vt *transaction.ValidTransaction) (common.Hash, error) {
+	hash, replaced, err := s.queue.Replace(vt)
+	if err != nil && !errors.Is(err, transaction.ErrTransactionExists) {
+		return hash, err
+	}
+
 	s.notifyStatus(vt.Extrinsic, transaction.Ready)
-	return s.queue.Push(vt)
+	if err != nil {
+		return hash, err
+	}
+
+	s.trackLongevity(hash, vt)
+
+	for _, r := range replaced {
+		s.forgetLongevity(r.Extrinsic.Hash())
+		s.notifyStatus(r.Extrinsic, transaction.Usurped)
+	}
+
+	return hash, nil
 }
 
 // Pop removes and returns the head of the queue
@@ -51,6 +91,9 @@ func (s *TransactionState) Peek() *transaction.ValidTransaction {
 
 // Pending returns the current transactions in the queue and pool
 func (s *TransactionState) Pending() []*transaction.ValidTransaction {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
 	return append(s.queue.Pending(), s.pool.Transactions()...)
 }
 
@@ -59,10 +102,31 @@ func (s *TransactionState) PendingInPool() []*transaction.ValidTransaction {
 	return s.pool.Transactions()
 }
 
-// RemoveExtrinsic removes an extrinsic from the queue and pool
+// RemoveExtrinsic removes an extrinsic that was included in a block from the queue and pool.
+// The tags it provides are considered provided by PromoteReady until no transaction in the pool requires them.
 func (s *TransactionState) RemoveExtrinsic(ext types.Extrinsic) {
-	s.pool.Remove(ext.Hash())
-	s.queue.RemoveExtrinsic(ext)
+	hash := ext.Hash()
+
+	s.txMu.Lock()
+	vt := s.pool.Get(hash)
+	s.pool.Remove(hash)
+	if queued := s.queue.RemoveExtrinsic(ext); queued != nil {
+		vt = queued
+	}
+	s.txMu.Unlock()
+
+	s.forgetLongevity(hash)
+
+	if vt == nil || vt.Validity == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tag := range vt.Validity.Provides {
+		s.includedTags[string(tag)] = struct{}{}
+	}
 }
 
 // RemoveExtrinsicFromPool removes an extrinsic from the pool
@@ -70,11 +134,87 @@ func (s *TransactionState) RemoveExtrinsicFromPool(ext types.Extrinsic) {
 	s.pool.Remove(ext.Hash())
 }
 
+// EvictExtrinsics removes the extrinsics with the given hashes from the queue and pool, along with
+// every transaction that requires a tag provided by a removed transaction. Watchers of removed
+// extrinsics are notified with the Dropped status. It returns the hashes of all removed extrinsics.
+func (s *TransactionState) EvictExtrinsics(hashes []common.Hash) []common.Hash {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	pending := make(map[common.Hash]*transaction.ValidTransaction)
+	for _, vt := range append(s.queue.Pending(), s.pool.Transactions()...) {
+		pending[vt.Extrinsic.Hash()] = vt
+	}
+
+	var (
+		removed  []common.Hash
+		provided = make(map[string]struct{})
+	)
+
+	remove := func(hash common.Hash, vt *transaction.ValidTransaction) {
+		delete(pending, hash)
+		s.pool.Remove(hash)
+		s.queue.RemoveExtrinsic(vt.Extrinsic)
+		s.forgetLongevity(hash)
+		s.notifyStatus(vt.Extrinsic, transaction.Dropped)
+		removed = append(removed, hash)
+
+		if vt.Validity == nil {
+			return
+		}
+
+		for _, tag := range vt.Validity.Provides {
+			provided[string(tag)] = struct{}{}
+		}
+	}
+
+	for _, hash := range hashes {
+		vt, has := pending[hash]
+		if !has {
+			continue
+		}
+
+		remove(hash, vt)
+	}
+
+	// evict the dependents of removed transactions until no transaction requires a removed tag
+	for evicted := true; evicted; {
+		evicted = false
+		for hash, vt := range pending {
+			if !requiresAny(vt, provided) {
+				continue
+			}
+
+			remove(hash, vt)
+			evicted = true
+		}
+	}
+
+	return removed
+}
+
+func requiresAny(vt *transaction.ValidTransaction, tags map[string]struct{}) bool {
+	if vt.Validity == nil {
+		return false
+	}
+
+	for _, tag := range vt.Validity.Requires {
+		if _, has := tags[string(tag)]; has {
+			return true
+		}
+	}
+
+	return false
+}
+
 // AddToPool adds a transaction to the pool
 func (s *TransactionState) AddToPool(vt *transaction.ValidTransaction) common.Hash {
 	s.notifyStatus(vt.Extrinsic, transaction.Future)
 
+	s.txMu.Lock()
 	hash := s.pool.Insert(vt)
+	s.trackLongevity(hash, vt)
+	s.txMu.Unlock()
 
 	if err := telemetry.GetInstance().SendMessage(
 		telemetry.NewTxpoolImportTM(uint(s.queue.Len()), uint(s.pool.Len())),
@@ -85,6 +225,143 @@ func (s *TransactionState) AddToPool(vt *transaction.ValidTransaction) common.Ha
 	return hash
 }
 
+// PromoteReady moves the transactions in the pool whose required tags are all provided, either by transactions
+// in the queue or by transactions included in blocks, to the queue. Transactions that lose a conflict with a
+// queued transaction providing the same tag, or that have no validity, are removed and notified with the Dropped
+// status. Tags provided by included transactions are forgotten once no transaction in the pool requires them.
+func (s *TransactionState) PromoteReady() {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	s.mu.Lock()
+	included := s.includedTags
+	s.includedTags = make(map[string]struct{})
+	s.mu.Unlock()
+
+	for promoted := true; promoted; {
+		promoted = false
+		for _, vt := range s.pool.Transactions() {
+			if vt.Validity != nil && !s.isReady(vt, included) {
+				continue
+			}
+
+			hash := vt.Extrinsic.Hash()
+			s.pool.Remove(hash)
+
+			_, err := s.push(vt)
+			switch {
+			case err == nil:
+				promoted = true
+				logger.Tracef("moved transaction %s to queue", hash)
+			case errors.Is(err, transaction.ErrTransactionExists):
+			default:
+				s.forgetLongevity(hash)
+				s.notifyStatus(vt.Extrinsic, transaction.Dropped)
+				logger.Debugf("dropped transaction %s: %s", hash, err)
+			}
+		}
+	}
+
+	// keep the included tags still required by transactions in the pool for the next call
+	required := make(map[string]struct{})
+	for _, vt := range s.pool.Transactions() {
+		for _, tag := range vt.Validity.Requires {
+			required[string(tag)] = struct{}{}
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for tag := range included {
+		if _, has := required[tag]; has {
+			s.includedTags[tag] = struct{}{}
+		}
+	}
+}
+
+// isReady returns true if the required tags of a transaction with a validity are all provided
+func (s *TransactionState) isReady(vt *transaction.ValidTransaction, included map[string]struct{}) bool {
+	for _, tag := range vt.Validity.Requires {
+		if _, has := included[string(tag)]; has {
+			continue
+		}
+
+		if !s.queue.Provides(tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// PruneStale removes the transactions in the queue and pool whose longevity has expired at the given
+// block number, and notifies their watchers with the Invalid status. It returns the hashes of the
+// removed transactions.
+func (s *TransactionState) PruneStale(number uint64) []common.Hash {
+	s.mu.Lock()
+	s.bestNumber = number
+
+	var stale []common.Hash
+	for hash, until := range s.validUntil {
+		if until >= number {
+			continue
+		}
+
+		stale = append(stale, hash)
+		delete(s.validUntil, hash)
+	}
+	s.mu.Unlock()
+
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+
+	var pruned []common.Hash
+	for _, hash := range stale {
+		vt := s.pool.Get(hash)
+		s.pool.Remove(hash)
+		if queued := s.queue.Remove(hash); queued != nil {
+			vt = queued
+		}
+
+		if vt == nil {
+			continue
+		}
+
+		s.notifyStatus(vt.Extrinsic, transaction.Invalid)
+		pruned = append(pruned, hash)
+	}
+
+	return pruned
+}
+
+// trackLongevity records the last block number a new transaction is valid for
+func (s *TransactionState) trackLongevity(hash common.Hash, vt *transaction.ValidTransaction) {
+	if vt.Validity == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, has := s.validUntil[hash]; has {
+		return
+	}
+
+	until := uint64(math.MaxUint64)
+	if vt.Validity.Longevity < until-s.bestNumber {
+		until = s.bestNumber + vt.Validity.Longevity
+	}
+
+	s.validUntil[hash] = until
+}
+
+func (s *TransactionState) forgetLongevity(hash common.Hash) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.validUntil, hash)
+}
+
 // GetStatusNotifierChannel creates and returns a status notifier channel.
 func (s *TransactionState) GetStatusNotifierChannel(ext types.Extrinsic) chan transaction.Status {
 	s.notifierLock.Lock()
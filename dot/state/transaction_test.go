@@ -4,6 +4,7 @@
 package state
 
 import (
+	"math"
 	"math/rand"
 	"sort"
 	"testing"
@@ -116,3 +117,187 @@ func TestTransactionState_NotifierChannels(t *testing.T) {
 	require.Equal(t, expectedFutureCount, futureCount)
 	require.Equal(t, expectedReadyCount, readyCount)
 }
+
+func TestTransactionState_EvictExtrinsics(t *testing.T) {
+	ts := NewTransactionState()
+
+	// b depends on a, c depends on b, d is unrelated
+	txs := []*transaction.ValidTransaction{
+		{
+			Extrinsic: []byte("a"),
+			Validity:  &transaction.Validity{Priority: 1, Provides: [][]byte{{1}}},
+		},
+		{
+			Extrinsic: []byte("b"),
+			Validity:  &transaction.Validity{Priority: 1, Requires: [][]byte{{1}}, Provides: [][]byte{{2}}},
+		},
+		{
+			Extrinsic: []byte("c"),
+			Validity:  &transaction.Validity{Priority: 1, Requires: [][]byte{{2}}},
+		},
+		{
+			Extrinsic: []byte("d"),
+			Validity:  &transaction.Validity{Priority: 1},
+		},
+	}
+
+	_, err := ts.Push(txs[0])
+	require.NoError(t, err)
+	for _, tx := range txs[1:] {
+		ts.AddToPool(tx)
+	}
+
+	notifierChannel := ts.GetStatusNotifierChannel(txs[2].Extrinsic)
+	defer ts.FreeStatusNotifierChannel(notifierChannel)
+
+	removed := ts.EvictExtrinsics([]common.Hash{txs[0].Extrinsic.Hash(), {0xff}})
+	require.ElementsMatch(t, []common.Hash{
+		txs[0].Extrinsic.Hash(),
+		txs[1].Extrinsic.Hash(),
+		txs[2].Extrinsic.Hash(),
+	}, removed)
+
+	require.Equal(t, []*transaction.ValidTransaction{txs[3]}, ts.Pending())
+	require.Equal(t, transaction.Dropped, <-notifierChannel)
+}
+
+func TestTransactionState_PromoteReady(t *testing.T) {
+	ts := NewTransactionState()
+
+	// b requires a tag provided by a, c requires a tag that is never provided
+	txs := []*transaction.ValidTransaction{
+		{
+			Extrinsic: []byte("a"),
+			Validity:  &transaction.Validity{Priority: 1, Provides: [][]byte{{1}}, Longevity: 64},
+		},
+		{
+			Extrinsic: []byte("b"),
+			Validity:  &transaction.Validity{Priority: 2, Requires: [][]byte{{1}}, Longevity: 64},
+		},
+		{
+			Extrinsic: []byte("c"),
+			Validity:  &transaction.Validity{Priority: 3, Requires: [][]byte{{2}}, Longevity: 64},
+		},
+	}
+
+	for _, tx := range txs {
+		ts.AddToPool(tx)
+	}
+
+	ts.PromoteReady()
+	require.Equal(t, []*transaction.ValidTransaction{txs[2]}, ts.PendingInPool())
+	require.Equal(t, txs[0], ts.Pop())
+	require.Equal(t, txs[1], ts.Pop())
+	require.Nil(t, ts.Pop())
+
+	// c becomes ready once a transaction providing its required tag is included in a block
+	ts.AddToPool(&transaction.ValidTransaction{
+		Extrinsic: []byte("d"),
+		Validity:  &transaction.Validity{Priority: 1, Provides: [][]byte{{2}}},
+	})
+	ts.RemoveExtrinsic([]byte("d"))
+
+	ts.PromoteReady()
+	require.Empty(t, ts.PendingInPool())
+	require.Equal(t, txs[2], ts.Pop())
+}
+
+func TestTransactionState_PromoteReady_IncludedTags(t *testing.T) {
+	ts := NewTransactionState()
+
+	// e requires the tags provided by f and g, which are included in different blocks
+	e := &transaction.ValidTransaction{
+		Extrinsic: []byte("e"),
+		Validity:  &transaction.Validity{Priority: 1, Requires: [][]byte{{3}, {4}}},
+	}
+	ts.AddToPool(e)
+
+	include := func(ext string, tag byte) {
+		ts.AddToPool(&transaction.ValidTransaction{
+			Extrinsic: []byte(ext),
+			Validity:  &transaction.Validity{Priority: 1, Provides: [][]byte{{tag}}},
+		})
+		ts.RemoveExtrinsic([]byte(ext))
+	}
+
+	include("f", 3)
+
+	ts.PromoteReady()
+	require.Equal(t, []*transaction.ValidTransaction{e}, ts.PendingInPool())
+	require.Equal(t, map[string]struct{}{string([]byte{3}): {}}, ts.includedTags)
+
+	include("g", 4)
+
+	ts.PromoteReady()
+	require.Empty(t, ts.PendingInPool())
+	require.Equal(t, e, ts.Pop())
+
+	// the tags are forgotten once no transaction in the pool requires them
+	require.Empty(t, ts.includedTags)
+}
+
+func TestTransactionState_NilValidity(t *testing.T) {
+	ts := NewTransactionState()
+
+	vt := &transaction.ValidTransaction{Extrinsic: []byte("a")}
+	_, err := ts.Push(vt)
+	require.ErrorIs(t, err, transaction.ErrNilValidity)
+
+	ts.AddToPool(vt)
+	ts.PromoteReady()
+	require.Empty(t, ts.Pending())
+}
+
+func TestTransactionState_Usurped(t *testing.T) {
+	ts := NewTransactionState()
+
+	low := &transaction.ValidTransaction{
+		Extrinsic: []byte("low"),
+		Validity:  &transaction.Validity{Priority: 1, Provides: [][]byte{{1}}},
+	}
+	high := &transaction.ValidTransaction{
+		Extrinsic: []byte("high"),
+		Validity:  &transaction.Validity{Priority: 2, Provides: [][]byte{{1}}},
+	}
+
+	notifierChannel := ts.GetStatusNotifierChannel(low.Extrinsic)
+	defer ts.FreeStatusNotifierChannel(notifierChannel)
+
+	_, err := ts.Push(low)
+	require.NoError(t, err)
+	require.Equal(t, transaction.Ready, <-notifierChannel)
+
+	_, err = ts.Push(high)
+	require.NoError(t, err)
+	require.Equal(t, transaction.Usurped, <-notifierChannel)
+	require.Equal(t, []*transaction.ValidTransaction{high}, ts.Pending())
+
+	_, err = ts.Push(low)
+	require.ErrorIs(t, err, transaction.ErrTooLowPriority)
+}
+
+func TestTransactionState_PruneStale(t *testing.T) {
+	ts := NewTransactionState()
+
+	short := &transaction.ValidTransaction{
+		Extrinsic: []byte("short"),
+		Validity:  &transaction.Validity{Priority: 1, Longevity: 2},
+	}
+	long := &transaction.ValidTransaction{
+		Extrinsic: []byte("long"),
+		Validity:  &transaction.Validity{Priority: 1, Longevity: math.MaxUint64},
+	}
+
+	ts.PruneStale(10)
+	ts.AddToPool(short)
+	ts.AddToPool(long)
+	ts.PromoteReady()
+
+	notifierChannel := ts.GetStatusNotifierChannel(short.Extrinsic)
+	defer ts.FreeStatusNotifierChannel(notifierChannel)
+
+	require.Empty(t, ts.PruneStale(12))
+	require.Equal(t, []common.Hash{short.Extrinsic.Hash()}, ts.PruneStale(13))
+	require.Equal(t, transaction.Invalid, <-notifierChannel)
+	require.Equal(t, []*transaction.ValidTransaction{long}, ts.Pending())
+}
@@ -0,0 +1,87 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/internal/log"
+)
+
+// BlockImporter imports blocks which are not received from peers, such as the blocks of an exported block file.
+// The blocks are verified and handled in the same way as the blocks received while syncing: their BABE header is
+// verified, they are executed and handled by the BlockImportHandler, and their justification is verified by the
+// FinalityGadget.
+type BlockImporter struct {
+	chainProcessor *chainProcessor
+}
+
+// NewBlockImporter returns a new BlockImporter. The network of the configuration is not used, since the blocks
+// are not requested from peers.
+func NewBlockImporter(cfg *Config) (*BlockImporter, error) {
+	if cfg.BlockState == nil {
+		return nil, errNilBlockState
+	}
+
+	if cfg.StorageState == nil {
+		return nil, errNilStorageState
+	}
+
+	if cfg.FinalityGadget == nil {
+		return nil, errNilFinalityGadget
+	}
+
+	if cfg.TransactionState == nil {
+		return nil, errNilTransactionState
+	}
+
+	if cfg.BabeVerifier == nil {
+		return nil, errNilVerifier
+	}
+
+	if cfg.BlockImportHandler == nil {
+		return nil, errNilBlockImportHandler
+	}
+
+	logger.Patch(log.SetLevel(cfg.LogLvl))
+
+	// the blocks are imported in order by the caller, so the queues of the processor are not used
+	chainProcessor := newChainProcessor(nil, nil,
+		cfg.BlockState, cfg.StorageState, cfg.TransactionState,
+		cfg.BabeVerifier, cfg.FinalityGadget, cfg.BlockImportHandler,
+		false, nil)
+
+	return &BlockImporter{
+		chainProcessor: chainProcessor,
+	}, nil
+}
+
+// ImportBlock verifies and imports the block of the block data, whose parent must have been imported. Blocks which
+// have already been imported are skipped. The justification of the block data, if any, is verified and stored once
+// the block is imported, and an error is returned if it is invalid.
+func (bi *BlockImporter) ImportBlock(bd *types.BlockData) error {
+	if bd == nil {
+		return ErrNilBlockData
+	}
+
+	if bd.Header == nil || bd.Body == nil {
+		return errors.New("block data must hold a header and a body")
+	}
+
+	// the chain processor only logs invalid justifications, so the justification is imported separately
+	block := *bd
+	block.Justification = nil
+
+	err := bi.chainProcessor.processBlockData(&block)
+	if err != nil {
+		return err
+	}
+
+	if bd.Justification == nil || len(*bd.Justification) == 0 {
+		return nil
+	}
+
+	return bi.chainProcessor.importJustification(bd.Header, *bd.Justification)
+}
@@ -0,0 +1,126 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/sync/mocks"
+	"github.com/ChainSafe/gossamer/dot/types"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBlockImporter(t *testing.T) {
+	_, err := NewBlockImporter(&Config{})
+	require.Equal(t, errNilBlockState, err)
+
+	syncer := newTestSyncer(t)
+	cp := syncer.chainProcessor.(*chainProcessor)
+
+	// the network is not required
+	_, err = NewBlockImporter(&Config{
+		BlockState:         cp.blockState,
+		StorageState:       cp.storageState,
+		TransactionState:   cp.transactionState,
+		BabeVerifier:       cp.babeVerifier,
+		FinalityGadget:     cp.finalityGadget,
+		BlockImportHandler: cp.blockImportHandler,
+	})
+	require.NoError(t, err)
+}
+
+func TestBlockImporter_ImportBlock(t *testing.T) {
+	syncer := newTestSyncer(t)
+	responder := newTestSyncer(t)
+
+	parent, err := responder.blockState.(*state.BlockState).BestBlockHeader()
+	require.NoError(t, err)
+
+	rt, err := responder.blockState.GetRuntime(nil)
+	require.NoError(t, err)
+
+	var blocks []*types.Block
+	for i := 0; i < 3; i++ {
+		block := BuildBlock(t, rt, parent, nil)
+		err = responder.blockState.AddBlock(block)
+		require.NoError(t, err)
+		parent = &block.Header
+		blocks = append(blocks, block)
+	}
+
+	badJustification, justification := []byte{0xba, 0xd}, []byte{1, 2, 3}
+	fg := new(mocks.FinalityGadget)
+	fg.On("VerifyBlockJustification", blocks[2].Header.Hash(), badJustification).
+		Return(errors.New("invalid justification"))
+	fg.On("VerifyBlockJustification", blocks[2].Header.Hash(), justification).Return(nil)
+
+	cp := syncer.chainProcessor.(*chainProcessor)
+	bi, err := NewBlockImporter(&Config{
+		BlockState:         cp.blockState,
+		StorageState:       cp.storageState,
+		TransactionState:   cp.transactionState,
+		BabeVerifier:       cp.babeVerifier,
+		FinalityGadget:     fg,
+		BlockImportHandler: cp.blockImportHandler,
+	})
+	require.NoError(t, err)
+
+	err = bi.ImportBlock(&types.BlockData{Hash: blocks[0].Header.Hash(), Header: &blocks[0].Header})
+	require.Error(t, err)
+
+	// the parent of the block has not been imported
+	err = bi.ImportBlock(&types.BlockData{
+		Hash:   blocks[1].Header.Hash(),
+		Header: &blocks[1].Header,
+		Body:   &blocks[1].Body,
+	})
+	require.ErrorIs(t, err, errFailedToGetParent)
+
+	for _, block := range blocks {
+		err = bi.ImportBlock(&types.BlockData{
+			Hash:   block.Header.Hash(),
+			Header: &block.Header,
+			Body:   &block.Body,
+		})
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, blocks[2].Header.Hash(), syncer.blockState.BestBlockHash())
+
+	// importing a block again is a no-op
+	err = bi.ImportBlock(&types.BlockData{
+		Hash:   blocks[2].Header.Hash(),
+		Header: &blocks[2].Header,
+		Body:   &blocks[2].Body,
+	})
+	require.NoError(t, err)
+
+	// invalid justifications fail the import
+	err = bi.ImportBlock(&types.BlockData{
+		Hash:          blocks[2].Header.Hash(),
+		Header:        &blocks[2].Header,
+		Body:          &blocks[2].Body,
+		Justification: &badJustification,
+	})
+	require.EqualError(t, err, "failed to verify justification: invalid justification")
+
+	has, err := syncer.blockState.(*state.BlockState).HasJustification(blocks[2].Header.Hash())
+	require.NoError(t, err)
+	require.False(t, has)
+
+	err = bi.ImportBlock(&types.BlockData{
+		Hash:          blocks[2].Header.Hash(),
+		Header:        &blocks[2].Header,
+		Body:          &blocks[2].Body,
+		Justification: &justification,
+	})
+	require.NoError(t, err)
+
+	res, err := syncer.blockState.(*state.BlockState).GetJustification(blocks[2].Header.Hash())
+	require.NoError(t, err)
+	require.Equal(t, justification, res)
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/dot/types"
@@ -39,12 +40,18 @@ type chainProcessor struct {
 	babeVerifier       BabeVerifier
 	finalityGadget     FinalityGadget
 	blockImportHandler BlockImportHandler
+
+	// headersOnly is set for light clients, which import verified headers without executing blocks.
+	// The consensus digests of the imported headers are then handled by the digestHandler.
+	headersOnly   bool
+	digestHandler DigestHandler
 }
 
 func newChainProcessor(readyBlocks *blockQueue, pendingBlocks DisjointBlockSet,
 	blockState BlockState, storageState StorageState,
 	transactionState TransactionState, babeVerifier BabeVerifier,
-	finalityGadget FinalityGadget, blockImportHandler BlockImportHandler) *chainProcessor {
+	finalityGadget FinalityGadget, blockImportHandler BlockImportHandler,
+	headersOnly bool, digestHandler DigestHandler) *chainProcessor {
 	ctx, cancel := context.WithCancel(context.Background())
 
 	return &chainProcessor{
@@ -58,6 +65,8 @@ func newChainProcessor(readyBlocks *blockQueue, pendingBlocks DisjointBlockSet,
 		babeVerifier:       babeVerifier,
 		finalityGadget:     finalityGadget,
 		blockImportHandler: blockImportHandler,
+		headersOnly:        headersOnly,
+		digestHandler:      digestHandler,
 	}
 }
 
@@ -87,10 +96,7 @@ func (s *chainProcessor) processReadyBlocks() {
 
 			// depending on the error, we might want to save this block for later
 			if errors.Is(err, errFailedToGetParent) {
-				if err := s.pendingBlocks.addBlock(&types.Block{
-					Header: *bd.Header,
-					Body:   *bd.Body,
-				}); err != nil {
+				if err := addPendingBlock(s.pendingBlocks, bd.Header, bd.Body); err != nil {
 					logger.Debugf("failed to re-add block to pending blocks: %s", err)
 				}
 			}
@@ -106,6 +112,10 @@ func (s *chainProcessor) processBlockData(bd *types.BlockData) error {
 		return ErrNilBlockData
 	}
 
+	if s.headersOnly {
+		return s.processHeaderData(bd)
+	}
+
 	hasHeader, _ := s.blockState.HasHeader(bd.Hash)
 	hasBody, _ := s.blockState.HasBlockBody(bd.Hash)
 	if hasHeader && hasBody {
@@ -186,6 +196,32 @@ func (s *chainProcessor) processBlockData(bd *types.BlockData) error {
 	return nil
 }
 
+// processHeaderData processes the BlockData of a light client, which only holds headers and justifications.
+func (s *chainProcessor) processHeaderData(bd *types.BlockData) error {
+	if bd.Header == nil {
+		return nil
+	}
+
+	hasHeader, _ := s.blockState.HasHeader(bd.Hash)
+	if !hasHeader {
+		if err := s.handleHeader(bd.Header); err != nil {
+			return err
+		}
+
+		if err := s.importHeader(bd.Header); err != nil {
+			logger.Errorf("failed to import header number %s: %s", bd.Header.Number, err)
+			return err
+		}
+	}
+
+	if bd.Justification != nil {
+		logger.Debugf("handling Justification for block number %s with hash %s...", bd.Number(), bd.Hash)
+		s.handleJustification(bd.Header, *bd.Justification)
+	}
+
+	return nil
+}
+
 // handleHeader handles headers included in BlockResponses
 func (s *chainProcessor) handleHeader(header *types.Header) error {
 	err := s.babeVerifier.VerifyBlock(header)
@@ -209,6 +245,8 @@ func (s *chainProcessor) handleBlock(block *types.Block) error {
 		return errors.New("block or body is nil")
 	}
 
+	start := time.Now()
+
 	parent, err := s.blockState.GetHeader(block.Header.ParentHash)
 	if err != nil {
 		return fmt.Errorf("%w: %s", errFailedToGetParent, err)
@@ -228,10 +266,16 @@ func (s *chainProcessor) handleBlock(block *types.Block) error {
 	}
 
 	hash := parent.Hash()
-	rt, err := s.blockState.GetRuntime(&hash)
+	pool, err := s.blockState.GetRuntimePool(&hash)
+	if err != nil {
+		return err
+	}
+
+	rt, err := pool.Get()
 	if err != nil {
 		return err
 	}
+	defer pool.Put(rt)
 
 	rt.SetContextStorage(ts)
 
@@ -244,6 +288,7 @@ func (s *chainProcessor) handleBlock(block *types.Block) error {
 		return err
 	}
 
+	blockImportTime.Observe(time.Since(start).Seconds())
 	logger.Debugf("🔗 imported block number %s with hash %s", block.Header.Number, block.Header.Hash())
 
 	blockHash := block.Header.Hash()
@@ -258,22 +303,64 @@ func (s *chainProcessor) handleBlock(block *types.Block) error {
 	return nil
 }
 
+// importHeader imports a verified header without executing its block. Light clients keep no block bodies,
+// so the header is stored with an empty body.
+func (s *chainProcessor) importHeader(header *types.Header) error {
+	start := time.Now()
+
+	hasParent, err := s.blockState.HasHeader(header.ParentHash)
+	if err != nil || !hasParent {
+		return fmt.Errorf("%w: %s", errFailedToGetParent, header.ParentHash)
+	}
+
+	err = s.blockState.AddBlock(&types.Block{
+		Header: *header,
+		Body:   types.Body{},
+	})
+	if err != nil {
+		return err
+	}
+
+	s.digestHandler.HandleDigests(header)
+
+	blockImportTime.Observe(time.Since(start).Seconds())
+	logger.Debugf("🔗 imported header number %s with hash %s", header.Number, header.Hash())
+
+	hash := header.Hash()
+	err = telemetry.GetInstance().SendMessage(telemetry.NewBlockImportTM(
+		&hash,
+		header.Number,
+		"NetworkInitialSync"))
+	if err != nil {
+		logger.Debugf("problem sending block.import telemetry message: %s", err)
+	}
+
+	return nil
+}
+
 func (s *chainProcessor) handleJustification(header *types.Header, justification []byte) {
 	if len(justification) == 0 || header == nil {
 		return
 	}
 
+	err := s.importJustification(header, justification)
+	if err != nil {
+		logger.Warnf("failed to import block number %s and hash %s justification: %s", header.Number, header.Hash(), err)
+	}
+}
+
+// importJustification verifies the justification of the given header, which finalises it, and stores it
+func (s *chainProcessor) importJustification(header *types.Header, justification []byte) error {
 	err := s.finalityGadget.VerifyBlockJustification(header.Hash(), justification)
 	if err != nil {
-		logger.Warnf("failed to verify block number %s and hash %s justification: %s", header.Number, header.Hash(), err)
-		return
+		return fmt.Errorf("failed to verify justification: %w", err)
 	}
 
 	err = s.blockState.SetJustification(header.Hash(), justification)
 	if err != nil {
-		logger.Errorf("failed tostore justification: %s", err)
-		return
+		return fmt.Errorf("failed to store justification: %w", err)
 	}
 
 	logger.Infof("🔨 finalised block number %s with hash %s", header.Number, header.Hash())
+	return nil
 }
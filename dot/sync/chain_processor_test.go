@@ -11,10 +11,12 @@ import (
 
 	"github.com/ChainSafe/gossamer/dot/network"
 	"github.com/ChainSafe/gossamer/dot/state"
+	syncmocks "github.com/ChainSafe/gossamer/dot/sync/mocks"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common/variadic"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -249,3 +251,59 @@ func TestChainProcessor_processReadyBlocks_errFailedToGetParent(t *testing.T) {
 	time.Sleep(time.Millisecond * 100)
 	require.True(t, processor.pendingBlocks.hasBlock(header.Hash()))
 }
+
+func TestChainProcessor_processBlockData_headersOnly(t *testing.T) {
+	parent := types.NewEmptyHeader()
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     big.NewInt(1),
+		Digest:     types.NewDigest(),
+	}
+
+	bs := new(syncmocks.BlockState)
+	bs.On("HasHeader", header.Hash()).Return(false, nil)
+	bs.On("HasHeader", parent.Hash()).Return(true, nil)
+	bs.On("AddBlock", &types.Block{Header: *header, Body: types.Body{}}).Return(nil)
+
+	verifier := new(syncmocks.BabeVerifier)
+	verifier.On("VerifyBlock", header).Return(nil)
+
+	dh := new(syncmocks.DigestHandler)
+	dh.On("HandleDigests", header)
+
+	processor := newChainProcessor(newBlockQueue(maxResponseSize), newDisjointBlockSet(pendingBlocksLimit),
+		bs, nil, nil, verifier, nil, nil, true, dh)
+
+	err := processor.processBlockData(&types.BlockData{
+		Hash:   header.Hash(),
+		Header: header,
+	})
+	require.NoError(t, err)
+
+	bs.AssertExpectations(t)
+	verifier.AssertExpectations(t)
+	dh.AssertExpectations(t)
+}
+
+func TestChainProcessor_processBlockData_headersOnly_invalidSeal(t *testing.T) {
+	header := &types.Header{
+		Number: big.NewInt(1),
+		Digest: types.NewDigest(),
+	}
+
+	bs := new(syncmocks.BlockState)
+	bs.On("HasHeader", header.Hash()).Return(false, nil)
+
+	verifier := new(syncmocks.BabeVerifier)
+	verifier.On("VerifyBlock", header).Return(errors.New("invalid seal"))
+
+	processor := newChainProcessor(newBlockQueue(maxResponseSize), newDisjointBlockSet(pendingBlocksLimit),
+		bs, nil, nil, verifier, nil, nil, true, new(syncmocks.DigestHandler))
+
+	err := processor.processBlockData(&types.BlockData{
+		Hash:   header.Hash(),
+		Header: header,
+	})
+	require.ErrorIs(t, err, ErrInvalidBlock)
+	bs.AssertNotCalled(t, "AddBlock", mock.Anything)
+}
@@ -146,6 +146,9 @@ type chainSync struct {
 	minPeers         int
 	maxWorkerRetries uint16
 	slotDuration     time.Duration
+
+	// headersOnly is set for light clients, which never request block bodies
+	headersOnly bool
 }
 
 type chainSyncConfig struct {
@@ -155,6 +158,7 @@ type chainSyncConfig struct {
 	pendingBlocks      DisjointBlockSet
 	minPeers, maxPeers int
 	slotDuration       time.Duration
+	headersOnly        bool
 }
 
 func newChainSync(cfg *chainSyncConfig) *chainSync {
@@ -178,6 +182,7 @@ func newChainSync(cfg *chainSyncConfig) *chainSync {
 		minPeers:         cfg.minPeers,
 		maxWorkerRetries: uint16(cfg.maxPeers),
 		slotDuration:     cfg.slotDuration,
+		headersOnly:      cfg.headersOnly,
 	}
 }
 
@@ -633,6 +638,12 @@ func (cs *chainSync) dispatchWorker(w *worker) {
 		return
 	}
 
+	if cs.headersOnly {
+		for _, req := range reqs {
+			req.RequestedData &^= network.RequestedDataBody
+		}
+	}
+
 	for _, req := range reqs {
 		// TODO: if we find a good peer, do sync with them, right now it re-selects a peer each time (#1399)
 		if err := cs.doSync(req, w.peersTried); err != nil {
@@ -659,6 +670,7 @@ func (cs *chainSync) doSync(req *network.BlockRequestMessage, peersTried map[pee
 	// TODO: use scoring to determine what peer to try to sync from first (#1399)
 	idx, _ := rand.Int(rand.Reader, big.NewInt(int64(len(peers))))
 	who := peers[idx.Int64()]
+	start := time.Now()
 	resp, err := cs.network.DoBlockRequest(who, req)
 	if err != nil {
 		return &workerError{
@@ -667,6 +679,8 @@ func (cs *chainSync) doSync(req *network.BlockRequestMessage, peersTried map[pee
 		}
 	}
 
+	blockRequestTime.WithLabelValues(who.String()).Observe(time.Since(start).Seconds())
+
 	if resp == nil {
 		return &workerError{
 			err: errNilResponse,
@@ -698,6 +712,19 @@ func (cs *chainSync) doSync(req *network.BlockRequestMessage, peersTried map[pee
 	return nil
 }
 
+// addPendingBlock adds the block to the pending blocks set, or only its header if the body is nil,
+// as is the case for light clients
+func addPendingBlock(pendingBlocks DisjointBlockSet, header *types.Header, body *types.Body) error {
+	if body == nil {
+		return pendingBlocks.addHeader(header)
+	}
+
+	return pendingBlocks.addBlock(&types.Block{
+		Header: *header,
+		Body:   *body,
+	})
+}
+
 func handleReadyBlock(bd *types.BlockData, pendingBlocks DisjointBlockSet, readyBlocks *blockQueue) {
 	// see if there are any descendents in the pending queue that are now ready to be processed,
 	// as we have just become aware of their parent block
@@ -828,10 +855,7 @@ func (cs *chainSync) validateResponse(req *network.BlockRequestMessage,
 			}
 
 			// parent unknown, add to pending blocks
-			if err := cs.pendingBlocks.addBlock(&types.Block{
-				Header: *curr,
-				Body:   *bd.Body,
-			}); err != nil {
+			if err := addPendingBlock(cs.pendingBlocks, curr, bd.Body); err != nil {
 				return err
 			}
 
@@ -849,10 +873,7 @@ func (cs *chainSync) validateResponse(req *network.BlockRequestMessage,
 		if !prev.Hash().Equal(curr.ParentHash) || curr.Number.Cmp(big.NewInt(0).Add(prev.Number, big.NewInt(1))) != 0 {
 			// the response is missing some blocks, place blocks from curr onwards into pending blocks set
 			for _, bd := range resp.BlockData[i:] {
-				if err := cs.pendingBlocks.addBlock(&types.Block{
-					Header: *curr,
-					Body:   *bd.Body,
-				}); err != nil {
+				if err := addPendingBlock(cs.pendingBlocks, curr, bd.Body); err != nil {
 					return err
 				}
 
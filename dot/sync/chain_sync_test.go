@@ -635,6 +635,37 @@ func TestChainSync_validateResponse_firstBlock(t *testing.T) {
 	require.NotNil(t, bd.justification)
 }
 
+func TestChainSync_validateResponse_firstBlock_headersOnly(t *testing.T) {
+	cs, _ := newTestChainSync(t)
+	bs := new(syncmocks.BlockState)
+	bs.On("HasHeader", mock.AnythingOfType("common.Hash")).Return(false, nil)
+	cs.blockState = bs
+
+	req := &network.BlockRequestMessage{
+		RequestedData: network.RequestedDataHeader + network.RequestedDataJustification,
+	}
+
+	header := &types.Header{
+		Number: big.NewInt(2),
+	}
+
+	resp := &network.BlockResponseMessage{
+		BlockData: []*types.BlockData{
+			{
+				Hash:   header.Hash(),
+				Header: header,
+			},
+		},
+	}
+
+	err := cs.validateResponse(req, resp, "")
+	require.ErrorIs(t, err, errUnknownParent)
+	bd := cs.pendingBlocks.getBlock(header.Hash())
+	require.NotNil(t, bd)
+	require.NotNil(t, bd.header)
+	require.Nil(t, bd.body)
+}
+
 func TestChainSync_doSync(t *testing.T) {
 	cs, readyBlocks := newTestChainSync(t)
 
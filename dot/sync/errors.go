@@ -16,6 +16,8 @@ var (
 	errNilNetwork            = errors.New("cannot have nil Network")
 	errNilFinalityGadget     = errors.New("cannot have nil FinalityGadget")
 	errNilTransactionState   = errors.New("cannot have nil TransactionState")
+	errNilDigestHandler      = errors.New("cannot have nil DigestHandler when syncing headers only")
+	errNilEpochState         = errors.New("cannot have nil EpochState when warp syncing with state sync")
 
 	// ErrNilBlockData is returned when trying to process a BlockResponseMessage with nil BlockData
 	ErrNilBlockData = errors.New("got nil BlockData")
@@ -28,10 +30,23 @@ var (
 
 	// ErrInvalidBlockRequest is returned when an invalid block request is received
 	ErrInvalidBlockRequest        = errors.New("invalid block request")
+	errHeadersOnly                = errors.New("light clients do not serve block requests")
 	errInvalidRequestDirection    = errors.New("invalid request direction")
 	errRequestStartTooHigh        = errors.New("request start number is higher than our best block")
 	errFailedToGetEndHashAncestor = errors.New("failed to get ancestor of end block")
 
+	errWarpSyncNoProgress  = errors.New("warp sync proof does not go beyond our finalised block")
+	errWarpSyncUnavailable = errors.New("no peer provided a warp sync proof")
+
+	// state sync errors
+	errInvalidStateRequest  = errors.New("invalid state request")
+	errChildTrieNotFound    = errors.New("child trie not found")
+	errInvalidStateResponse = errors.New("invalid state response")
+	errInvalidStateProof    = errors.New("invalid state proof")
+	errStateRootMismatch    = errors.New("state trie root does not match block state root")
+	errStateSyncFailed      = errors.New("no peer provided a state matching the block state root")
+	errStateSyncUnavailable = errors.New("no peer provided the state entries")
+
 	// chainSync errors
 	errEmptyBlockData               = errors.New("empty block data")
 	errNilBlockData                 = errors.New("block data is nil")
@@ -13,6 +13,7 @@ import (
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/libp2p/go-libp2p-core/peer"
 )
 
@@ -41,12 +42,15 @@ type BlockState interface {
 	GetHashByNumber(*big.Int) (common.Hash, error)
 	GetBlockByHash(common.Hash) (*types.Block, error)
 	GetRuntime(*common.Hash) (runtime.Instance, error)
+	GetRuntimePool(*common.Hash) (*runtime.InstancePool, error)
 	StoreRuntime(common.Hash, runtime.Instance)
 	GetHighestFinalisedHeader() (*types.Header, error)
+	GetHighestRoundAndSetID() (uint64, uint64, error)
 	GetFinalisedNotifierChannel() chan *types.FinalisationInfo
 	GetHeaderByNumber(num *big.Int) (*types.Header, error)
 	GetAllBlocksAtNumber(num *big.Int) ([]common.Hash, error)
 	IsDescendantOf(parent, child common.Hash) (bool, error)
+	HandleRuntimeChanges(newState *rtstorage.TrieState, rt runtime.Instance, bHash common.Hash) error
 }
 
 // StorageState is the interface for the storage state
@@ -54,6 +58,7 @@ type StorageState interface {
 	TrieState(root *common.Hash) (*rtstorage.TrieState, error)
 	LoadCodeHash(*common.Hash) (common.Hash, error)
 	SetSyncing(bool)
+	ImportTrie(t *trie.Trie) error
 	sync.Locker
 }
 
@@ -75,11 +80,35 @@ type BabeVerifier interface {
 	VerifyBlock(header *types.Header) error
 }
 
+//go:generate mockery --name DigestHandler --structname DigestHandler --case underscore --keeptree
+
+// DigestHandler is the interface for the consensus digest handler
+type DigestHandler interface {
+	HandleDigests(header *types.Header)
+}
+
+//go:generate mockery --name EpochState --structname EpochState --case underscore --keeptree
+
+// EpochState is the interface for the BABE epoch state
+type EpochState interface {
+	// ImportEpochs sets the data and the configuration of the current and the next BABE epochs of the given
+	// state, which was imported without the digests announcing the epochs.
+	ImportEpochs(ts *rtstorage.TrieState, rt runtime.Instance) error
+}
+
 //go:generate mockery --name FinalityGadget --structname FinalityGadget --case underscore --keeptree
 
 // FinalityGadget implements justification verification functionality
 type FinalityGadget interface {
 	VerifyBlockJustification(common.Hash, []byte) error
+
+	// ImportWarpSyncProof verifies a warp sync proof against the tracked authority sets,
+	// records the authority set changes it proves and returns the latest finalised header.
+	ImportWarpSyncProof(proof *network.WarpSyncProof) (*types.Header, error)
+
+	// RevertWarpSync sets the given header, finalised at the given round and set ID, back as the latest
+	// finalised block, undoing the warp sync proofs imported since.
+	RevertWarpSync(header *types.Header, round, setID uint64) error
 }
 
 //go:generate mockery --name BlockImportHandler --structname BlockImportHandler --case underscore --keeptree
@@ -98,6 +127,16 @@ type Network interface {
 	// it is returned, otherwise an error is returned.
 	DoBlockRequest(to peer.ID, req *network.BlockRequestMessage) (*network.BlockResponseMessage, error)
 
+	// DoWarpSyncRequest sends a warp sync proof request to the given peer.
+	// If a response is received within a certain time period,
+	// it is returned, otherwise an error is returned.
+	DoWarpSyncRequest(to peer.ID, req *network.WarpSyncProofRequest) (*network.WarpSyncProof, error)
+
+	// DoStateRequest sends a state request to the given peer.
+	// If a response is received within a certain time period,
+	// it is returned, otherwise an error is returned.
+	DoStateRequest(to peer.ID, req *network.StateRequest) (*network.StateResponse, error)
+
 	// Peers returns a list of currently connected peers
 	Peers() []common.PeerInfo
 
@@ -19,6 +19,10 @@ const (
 
 // CreateBlockResponse creates a block response message from a block request message
 func (s *Service) CreateBlockResponse(req *network.BlockRequestMessage) (*network.BlockResponseMessage, error) {
+	if s.headersOnly {
+		return nil, errHeadersOnly
+	}
+
 	switch req.Direction {
 	case network.Ascending:
 		return s.handleAscendingRequest(req)
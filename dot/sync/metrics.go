@@ -0,0 +1,23 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"github.com/ChainSafe/gossamer/dot/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	blockImportTime = metrics.NewHistogram(prometheus.HistogramOpts{
+		Name:    "block_verification_and_import_time",
+		Help:    "Time taken to execute and import blocks received from the network",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+	})
+
+	blockRequestTime = metrics.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "sync_block_request_duration_seconds",
+		Help:    "Round trip time of the block requests sent to each peer",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+	}, []string{"peer"})
+)
@@ -10,6 +10,8 @@ import (
 
 	runtime "github.com/ChainSafe/gossamer/lib/runtime"
 
+	storage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+
 	types "github.com/ChainSafe/gossamer/dot/types"
 )
 
@@ -322,6 +324,34 @@ func (_m *BlockState) GetHighestFinalisedHeader() (*types.Header, error) {
 	return r0, r1
 }
 
+// GetHighestRoundAndSetID provides a mock function with given fields:
+func (_m *BlockState) GetHighestRoundAndSetID() (uint64, uint64, error) {
+	ret := _m.Called()
+
+	var r0 uint64
+	if rf, ok := ret.Get(0).(func() uint64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	var r1 uint64
+	if rf, ok := ret.Get(1).(func() uint64); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetJustification provides a mock function with given fields: _a0
 func (_m *BlockState) GetJustification(_a0 common.Hash) ([]byte, error) {
 	ret := _m.Called(_a0)
@@ -414,6 +444,29 @@ func (_m *BlockState) GetRuntime(_a0 *common.Hash) (runtime.Instance, error) {
 	return r0, r1
 }
 
+// GetRuntimePool provides a mock function with given fields: _a0
+func (_m *BlockState) GetRuntimePool(_a0 *common.Hash) (*runtime.InstancePool, error) {
+	ret := _m.Called(_a0)
+
+	var r0 *runtime.InstancePool
+	if rf, ok := ret.Get(0).(func(*common.Hash) *runtime.InstancePool); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*runtime.InstancePool)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*common.Hash) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // HasBlockBody provides a mock function with given fields: hash
 func (_m *BlockState) HasBlockBody(hash common.Hash) (bool, error) {
 	ret := _m.Called(hash)
@@ -435,6 +488,20 @@ func (_m *BlockState) HasBlockBody(hash common.Hash) (bool, error) {
 	return r0, r1
 }
 
+// HandleRuntimeChanges provides a mock function with given fields: newState, rt, bHash
+func (_m *BlockState) HandleRuntimeChanges(newState *storage.TrieState, rt runtime.Instance, bHash common.Hash) error {
+	ret := _m.Called(newState, rt, bHash)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*storage.TrieState, runtime.Instance, common.Hash) error); ok {
+		r0 = rf(newState, rt, bHash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // HasHeader provides a mock function with given fields: hash
 func (_m *BlockState) HasHeader(hash common.Hash) (bool, error) {
 	ret := _m.Called(hash)
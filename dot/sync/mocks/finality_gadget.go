@@ -5,6 +5,10 @@ package mocks
 import (
 	common "github.com/ChainSafe/gossamer/lib/common"
 	mock "github.com/stretchr/testify/mock"
+
+	network "github.com/ChainSafe/gossamer/dot/network"
+
+	types "github.com/ChainSafe/gossamer/dot/types"
 )
 
 // FinalityGadget is an autogenerated mock type for the FinalityGadget type
@@ -12,6 +16,43 @@ type FinalityGadget struct {
 	mock.Mock
 }
 
+// ImportWarpSyncProof provides a mock function with given fields: proof
+func (_m *FinalityGadget) ImportWarpSyncProof(proof *network.WarpSyncProof) (*types.Header, error) {
+	ret := _m.Called(proof)
+
+	var r0 *types.Header
+	if rf, ok := ret.Get(0).(func(*network.WarpSyncProof) *types.Header); ok {
+		r0 = rf(proof)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Header)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*network.WarpSyncProof) error); ok {
+		r1 = rf(proof)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevertWarpSync provides a mock function with given fields: header, round, setID
+func (_m *FinalityGadget) RevertWarpSync(header *types.Header, round uint64, setID uint64) error {
+	ret := _m.Called(header, round, setID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*types.Header, uint64, uint64) error); ok {
+		r0 = rf(header, round, setID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // VerifyBlockJustification provides a mock function with given fields: _a0, _a1
 func (_m *FinalityGadget) VerifyBlockJustification(_a0 common.Hash, _a1 []byte) error {
 	ret := _m.Called(_a0, _a1)
@@ -0,0 +1,506 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+var (
+	// maxStateResponseSize is the size of the entries after which a state response is sent,
+	// leaving room for the proofs within the maximum message size
+	maxStateResponseSize   = 1024 * 1024 * 2 // 2mb
+	stateSyncRetryInterval = time.Second * 5
+	// stateSyncMaxRetries is the number of times the state is downloaded again after not matching
+	// the state root of the target block, before the state sync is given up
+	stateSyncMaxRetries = 3
+	// stateSyncMaxIdleRetries is the number of times peers are asked for state entries without any progress
+	// before the state sync is given up
+	stateSyncMaxIdleRetries = 12
+)
+
+// CreateStateResponse creates a state response from a state request. The response holds the entries
+// following the start key of the request, child trie entries being sent right after their key in the top trie.
+func (s *Service) CreateStateResponse(req *network.StateRequest) (*network.StateResponse, error) {
+	header, err := s.blockState.GetHeader(req.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	ts, err := s.storageState.TrieState(&header.StateRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	return newStateResponse(ts.Trie(), req.Start, req.NoProof)
+}
+
+func newStateResponse(top *trie.Trie, start [][]byte, noProof bool) (*network.StateResponse, error) {
+	if len(start) > 2 {
+		return nil, errInvalidStateRequest
+	}
+
+	b := &stateResponseBuilder{
+		top:     top,
+		noProof: noProof,
+		resp:    new(network.StateResponse),
+	}
+
+	var topStart []byte
+	if len(start) > 0 {
+		topStart = start[0]
+	}
+
+	if len(start) == 2 {
+		if !bytes.HasPrefix(topStart, trie.ChildStorageKeyPrefix) {
+			return nil, errInvalidStateRequest
+		}
+
+		complete, err := b.addChildEntries(topStart[len(trie.ChildStorageKeyPrefix):], start[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if !complete {
+			return b.resp, nil
+		}
+	}
+
+	if _, err := b.addEntries(b.top, nil, topStart); err != nil {
+		return nil, err
+	}
+
+	return b.resp, nil
+}
+
+type stateResponseBuilder struct {
+	top     *trie.Trie
+	noProof bool
+	size    int
+	resp    *network.StateResponse
+}
+
+func (b *stateResponseBuilder) full() bool {
+	return b.size >= maxStateResponseSize
+}
+
+func (b *stateResponseBuilder) addChildEntries(childKey, start []byte) (bool, error) {
+	child, err := b.top.GetChild(childKey)
+	if err != nil {
+		return false, err
+	}
+
+	if child == nil {
+		return false, fmt.Errorf("%w: %x", errChildTrieNotFound, childKey)
+	}
+
+	return b.addEntries(child, childKey, start)
+}
+
+// addEntries adds the entries of the trie that come after the start key to the response, until it is full.
+// It returns true if the entries include the last key of the trie.
+func (b *stateResponseBuilder) addEntries(t *trie.Trie, childKey, start []byte) (bool, error) {
+	entry := network.KeyValueStateEntry{
+		ChildKey: childKey,
+		Complete: true,
+	}
+
+	for key := t.NextKey(start); len(key) > 0; key = t.NextKey(key) {
+		value := t.Get(key)
+		entry.Entries = append(entry.Entries, network.StateEntry{
+			Key:   key,
+			Value: value,
+		})
+		b.size += len(key) + len(value)
+
+		if childKey == nil && bytes.HasPrefix(key, trie.ChildStorageKeyPrefix) {
+			complete, err := b.addChildEntries(key[len(trie.ChildStorageKeyPrefix):], nil)
+			if err != nil {
+				return false, err
+			}
+
+			if !complete {
+				entry.Complete = false
+				break
+			}
+		}
+
+		if b.full() {
+			entry.Complete = len(t.NextKey(key)) == 0
+			break
+		}
+	}
+
+	if !b.noProof && len(entry.Entries) > 0 {
+		keys := make([][]byte, len(entry.Entries))
+		for i, e := range entry.Entries {
+			keys[i] = e.Key
+		}
+
+		proof, err := trie.GenerateProofFromTrie(t, keys)
+		if err != nil {
+			return false, err
+		}
+
+		entry.Proof = proof
+	}
+
+	b.resp.Entries = append(b.resp.Entries, entry)
+	return entry.Complete, nil
+}
+
+// stateSyncer downloads the state trie of a block from peers, so that blocks can be executed
+// on top of it without executing its ancestors
+type stateSyncer struct {
+	ctx          context.Context
+	cancel       context.CancelFunc
+	blockState   BlockState
+	storageState StorageState
+	epochState   EpochState
+	network      Network
+
+	// badPeers holds the peers that sent a state not matching the state root of the target block
+	badPeers map[peer.ID]struct{}
+}
+
+func newStateSyncer(bs BlockState, ss StorageState, es EpochState, net Network) *stateSyncer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &stateSyncer{
+		ctx:          ctx,
+		cancel:       cancel,
+		blockState:   bs,
+		storageState: ss,
+		epochState:   es,
+		network:      net,
+		badPeers:     make(map[peer.ID]struct{}),
+	}
+}
+
+// stateSyncProgress holds the tries being downloaded and the start of the next state request
+type stateSyncProgress struct {
+	target   *types.Header
+	top      *trie.Trie
+	children map[string]*trie.Trie
+	start    [][]byte
+	complete bool
+	// peers holds the peers whose entries were added to the tries
+	peers map[peer.ID]struct{}
+	// responses is the number of responses added to the tries
+	responses int
+}
+
+// sync downloads the state trie of the given block, stores it in the StorageState and
+// sets up the runtime of the block. If the downloaded state doesn't match the state root of the block,
+// the peers that sent it are reported and the state is downloaded again from the other peers, up to
+// stateSyncMaxRetries times.
+func (s *stateSyncer) sync(target *types.Header) error {
+	logger.Infof("starting state sync at block %s with hash %s", target.Number, target.Hash())
+
+	for retries := 0; ; retries++ {
+		err := s.download(target)
+		if !errors.Is(err, errStateRootMismatch) {
+			return err
+		}
+
+		if retries == stateSyncMaxRetries {
+			return fmt.Errorf("%w: %s", errStateSyncFailed, err)
+		}
+
+		logger.Warnf("downloading state of block %s again: %s", target.Hash(), err)
+
+		select {
+		case <-s.ctx.Done():
+			return ErrServiceStopped
+		case <-time.After(stateSyncRetryInterval):
+		}
+	}
+}
+
+// download downloads the state trie of the given block from peers and imports it
+func (s *stateSyncer) download(target *types.Header) error {
+	progress := &stateSyncProgress{
+		target:   target,
+		top:      trie.NewEmptyTrie(),
+		children: make(map[string]*trie.Trie),
+		peers:    make(map[peer.ID]struct{}),
+	}
+
+	for retries := 0; !progress.complete; retries++ {
+		if retries > stateSyncMaxIdleRetries {
+			return errStateSyncUnavailable
+		}
+
+		responses := progress.responses
+		for _, info := range s.network.Peers() {
+			select {
+			case <-s.ctx.Done():
+				return ErrServiceStopped
+			default:
+			}
+
+			who, err := peer.Decode(info.PeerID)
+			if err != nil {
+				continue
+			}
+
+			if _, bad := s.badPeers[who]; bad {
+				continue
+			}
+
+			if err = s.syncFromPeer(who, progress); err != nil {
+				logger.Debugf("failed to state sync from peer %s: %s", who, err)
+				continue
+			}
+
+			if progress.complete {
+				break
+			}
+		}
+
+		if progress.complete {
+			break
+		}
+
+		if progress.responses > responses {
+			retries = 0
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return ErrServiceStopped
+		case <-time.After(stateSyncRetryInterval):
+		}
+	}
+
+	err := s.importState(progress)
+	if errors.Is(err, errStateRootMismatch) {
+		// the entries are proven, so some peers left entries out
+		for who := range progress.peers {
+			s.network.ReportPeer(peerset.ReputationChange{
+				Value:  peerset.BadMessageValue,
+				Reason: peerset.BadMessageReason,
+			}, who)
+			s.badPeers[who] = struct{}{}
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	logger.Infof("state sync finished at block %s with hash %s", target.Number, target.Hash())
+	return nil
+}
+
+// syncFromPeer requests state entries from the given peer until the state is complete
+func (s *stateSyncer) syncFromPeer(who peer.ID, progress *stateSyncProgress) error {
+	for !progress.complete {
+		select {
+		case <-s.ctx.Done():
+			return ErrServiceStopped
+		default:
+		}
+
+		resp, err := s.network.DoStateRequest(who, &network.StateRequest{
+			Block: progress.target.Hash(),
+			Start: progress.start,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err = progress.handleResponse(resp); err != nil {
+			s.network.ReportPeer(peerset.ReputationChange{
+				Value:  peerset.BadMessageValue,
+				Reason: peerset.BadMessageReason,
+			}, who)
+			return err
+		}
+
+		progress.peers[who] = struct{}{}
+		progress.responses++
+	}
+
+	return nil
+}
+
+// handleResponse verifies the entries of the response and adds them to the tries being downloaded.
+// Top trie entries are handled first, as they hold the roots of the child tries.
+func (p *stateSyncProgress) handleResponse(resp *network.StateResponse) error {
+	var (
+		top   *network.KeyValueStateEntry
+		child *network.KeyValueStateEntry
+	)
+
+	for i := range resp.Entries {
+		entry := &resp.Entries[i]
+		if len(entry.ChildKey) == 0 {
+			if top != nil {
+				return errInvalidStateResponse
+			}
+
+			top = entry
+			continue
+		}
+
+		if !entry.Complete {
+			if child != nil {
+				return errInvalidStateResponse
+			}
+
+			child = entry
+		}
+	}
+
+	if top != nil {
+		if err := verifyStateEntry(top, p.target.StateRoot); err != nil {
+			return err
+		}
+
+		for _, e := range top.Entries {
+			p.top.Put(e.Key, e.Value)
+		}
+	}
+
+	for i := range resp.Entries {
+		entry := &resp.Entries[i]
+		if len(entry.ChildKey) == 0 {
+			continue
+		}
+
+		key := append(append([]byte{}, trie.ChildStorageKeyPrefix...), entry.ChildKey...)
+		root := p.top.Get(key)
+		if len(root) != common.HashLength {
+			return fmt.Errorf("%w: unknown child trie %x", errInvalidStateResponse, entry.ChildKey)
+		}
+
+		if err := verifyStateEntry(entry, common.BytesToHash(root)); err != nil {
+			return err
+		}
+
+		t, has := p.children[string(entry.ChildKey)]
+		if !has {
+			t = trie.NewEmptyTrie()
+			p.children[string(entry.ChildKey)] = t
+		}
+
+		for _, e := range entry.Entries {
+			t.Put(e.Key, e.Value)
+		}
+	}
+
+	switch {
+	case child != nil:
+		if len(child.Entries) == 0 {
+			return errInvalidStateResponse
+		}
+
+		key := append(append([]byte{}, trie.ChildStorageKeyPrefix...), child.ChildKey...)
+		p.start = [][]byte{key, child.Entries[len(child.Entries)-1].Key}
+	case top == nil:
+		return errInvalidStateResponse
+	case top.Complete:
+		p.complete = true
+	default:
+		if len(top.Entries) == 0 {
+			return errInvalidStateResponse
+		}
+
+		p.start = [][]byte{top.Entries[len(top.Entries)-1].Key}
+	}
+
+	return nil
+}
+
+func verifyStateEntry(entry *network.KeyValueStateEntry, root common.Hash) error {
+	if len(entry.Entries) == 0 {
+		return nil
+	}
+
+	items := make([]trie.Pair, len(entry.Entries))
+	for i, e := range entry.Entries {
+		items[i] = trie.Pair{Key: e.Key, Value: e.Value}
+	}
+
+	ok, err := trie.VerifyProof(entry.Proof, root[:], items)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errInvalidStateProof, err)
+	}
+
+	if !ok {
+		return errInvalidStateProof
+	}
+
+	return nil
+}
+
+// importState checks the downloaded tries against the state root of the target block and stores them.
+// The runtime of the target block is upgraded if its code differs from the code of the current runtime,
+// and the BABE epochs of the target block are read from its state, so that the following blocks can be verified.
+func (s *stateSyncer) importState(progress *stateSyncProgress) error {
+	for key, child := range progress.children {
+		if err := progress.top.PutChild([]byte(key), child); err != nil {
+			return err
+		}
+	}
+
+	root, err := progress.top.Hash()
+	if err != nil {
+		return err
+	}
+
+	if root != progress.target.StateRoot {
+		return fmt.Errorf("%w: expected %s, got %s", errStateRootMismatch, progress.target.StateRoot, root)
+	}
+
+	s.storageState.Lock()
+	defer s.storageState.Unlock()
+
+	if err = s.storageState.ImportTrie(progress.top); err != nil {
+		return err
+	}
+
+	ts, err := s.storageState.TrieState(&root)
+	if err != nil {
+		return err
+	}
+
+	hash := progress.target.Hash()
+	rt, err := s.blockState.GetRuntime(&hash)
+	if err != nil {
+		return err
+	}
+
+	if err = s.blockState.HandleRuntimeChanges(ts, rt, hash); err != nil {
+		return err
+	}
+
+	// the runtime is replaced if the code has changed
+	rt, err = s.blockState.GetRuntime(&hash)
+	if err != nil {
+		return err
+	}
+
+	if err = s.epochState.ImportEpochs(ts, rt); err != nil {
+		return fmt.Errorf("failed to import BABE epochs: %w", err)
+	}
+
+	return nil
+}
+
+func (s *stateSyncer) stop() {
+	s.cancel()
+}
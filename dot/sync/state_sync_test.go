@@ -0,0 +1,205 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	syncmocks "github.com/ChainSafe/gossamer/dot/sync/mocks"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStateTrie(t *testing.T) *trie.Trie {
+	t.Helper()
+
+	top := trie.NewEmptyTrie()
+	for i := 0; i < 100; i++ {
+		top.Put([]byte(fmt.Sprintf("key%03d", i)), make([]byte, 64))
+	}
+
+	child := trie.NewEmptyTrie()
+	for i := 0; i < 50; i++ {
+		child.Put([]byte(fmt.Sprintf("childkey%03d", i)), []byte(fmt.Sprintf("childvalue%03d", i)))
+	}
+
+	require.NoError(t, top.PutChild([]byte("child"), child))
+	return top
+}
+
+func newTestStateSyncProgress(t *testing.T, top *trie.Trie) *stateSyncProgress {
+	t.Helper()
+
+	return &stateSyncProgress{
+		target: &types.Header{
+			StateRoot: top.MustHash(),
+		},
+		top:      trie.NewEmptyTrie(),
+		children: make(map[string]*trie.Trie),
+	}
+}
+
+func TestStateSync_PagedResponses(t *testing.T) {
+	defer func(size int) {
+		maxStateResponseSize = size
+	}(maxStateResponseSize)
+	maxStateResponseSize = 512
+
+	top := newTestStateTrie(t)
+	progress := newTestStateSyncProgress(t, top)
+
+	var requests int
+	for !progress.complete {
+		resp, err := newStateResponse(top, progress.start, false)
+		require.NoError(t, err)
+		require.NoError(t, progress.handleResponse(resp))
+
+		requests++
+		require.Less(t, requests, 100)
+	}
+
+	require.Greater(t, requests, 1)
+	require.Len(t, progress.children, 1)
+
+	for key, child := range progress.children {
+		require.NoError(t, progress.top.PutChild([]byte(key), child))
+	}
+
+	require.Equal(t, top.MustHash(), progress.top.MustHash())
+}
+
+func TestStateSync_InvalidProof(t *testing.T) {
+	top := newTestStateTrie(t)
+	progress := newTestStateSyncProgress(t, top)
+
+	resp, err := newStateResponse(top, nil, false)
+	require.NoError(t, err)
+
+	for i := range resp.Entries {
+		if len(resp.Entries[i].ChildKey) == 0 {
+			resp.Entries[i].Entries[0].Value = []byte("invalid")
+		}
+	}
+
+	err = progress.handleResponse(resp)
+	require.ErrorIs(t, err, errInvalidStateProof)
+}
+
+func TestStateSync_InvalidRequest(t *testing.T) {
+	top := newTestStateTrie(t)
+
+	_, err := newStateResponse(top, [][]byte{[]byte("key000"), []byte("key001")}, false)
+	require.ErrorIs(t, err, errInvalidStateRequest)
+
+	_, err = newStateResponse(top, [][]byte{{1}, {2}, {3}}, false)
+	require.ErrorIs(t, err, errInvalidStateRequest)
+}
+
+func newTestStatePeer(t *testing.T) peer.ID {
+	t.Helper()
+
+	_, pub, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	who, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+	return who
+}
+
+// newTestIncompleteStateResponse returns a state response claiming the state is complete after its first page
+func newTestIncompleteStateResponse(t *testing.T, top *trie.Trie) *network.StateResponse {
+	t.Helper()
+
+	resp, err := newStateResponse(top, nil, false)
+	require.NoError(t, err)
+	require.Len(t, resp.Entries, 1)
+	require.False(t, resp.Entries[0].Complete)
+	resp.Entries[0].Complete = true
+	return resp
+}
+
+func TestStateSyncer_Sync_RetriesRootMismatch(t *testing.T) {
+	defer func(size int, interval time.Duration) {
+		maxStateResponseSize, stateSyncRetryInterval = size, interval
+	}(maxStateResponseSize, stateSyncRetryInterval)
+	maxStateResponseSize, stateSyncRetryInterval = 512, time.Millisecond
+
+	top := trie.NewEmptyTrie()
+	for i := 0; i < 100; i++ {
+		top.Put([]byte(fmt.Sprintf("key%03d", i)), make([]byte, 64))
+	}
+
+	badPeer, goodPeer := newTestStatePeer(t), newTestStatePeer(t)
+
+	net := new(syncmocks.Network)
+	net.On("Peers").Return([]common.PeerInfo{{PeerID: badPeer.String()}, {PeerID: goodPeer.String()}})
+	s := newStateSyncer(new(syncmocks.BlockState), nil, nil, net)
+
+	req := mock.AnythingOfType("*network.StateRequest")
+	net.On("DoStateRequest", badPeer, req).Return(newTestIncompleteStateResponse(t, top), nil).Once()
+	net.On("ReportPeer", peerset.ReputationChange{
+		Value:  peerset.BadMessageValue,
+		Reason: peerset.BadMessageReason,
+	}, badPeer).Once()
+	net.On("DoStateRequest", goodPeer, req).Return(nil, errors.New("timeout")).Run(func(mock.Arguments) {
+		s.stop()
+	}).Once()
+
+	err := s.sync(&types.Header{Number: big.NewInt(1), StateRoot: top.MustHash(), Digest: types.NewDigest()})
+	require.ErrorIs(t, err, ErrServiceStopped)
+	// the state is downloaded again from the other peers only
+	net.AssertExpectations(t)
+}
+
+func TestStateSyncer_Sync_GivesUp(t *testing.T) {
+	defer func(size int, interval time.Duration, retries, idleRetries int) {
+		maxStateResponseSize, stateSyncRetryInterval = size, interval
+		stateSyncMaxRetries, stateSyncMaxIdleRetries = retries, idleRetries
+	}(maxStateResponseSize, stateSyncRetryInterval, stateSyncMaxRetries, stateSyncMaxIdleRetries)
+	maxStateResponseSize, stateSyncRetryInterval = 512, time.Millisecond
+	stateSyncMaxRetries, stateSyncMaxIdleRetries = 1, 2
+
+	top := trie.NewEmptyTrie()
+	for i := 0; i < 100; i++ {
+		top.Put([]byte(fmt.Sprintf("key%03d", i)), make([]byte, 64))
+	}
+	target := &types.Header{Number: big.NewInt(1), StateRoot: top.MustHash(), Digest: types.NewDigest()}
+
+	peers := []peer.ID{newTestStatePeer(t), newTestStatePeer(t)}
+
+	net := new(syncmocks.Network)
+	net.On("Peers").Return([]common.PeerInfo{{PeerID: peers[0].String()}, {PeerID: peers[1].String()}})
+	for _, who := range peers {
+		net.On("DoStateRequest", who, mock.AnythingOfType("*network.StateRequest")).
+			Return(newTestIncompleteStateResponse(t, top), nil).Once()
+		net.On("ReportPeer", mock.AnythingOfType("peerset.ReputationChange"), who).Once()
+	}
+
+	err := newStateSyncer(new(syncmocks.BlockState), nil, nil, net).sync(target)
+	require.ErrorIs(t, err, errStateSyncFailed)
+	net.AssertExpectations(t)
+
+	// no peer answers state requests
+	net = new(syncmocks.Network)
+	net.On("Peers").Return([]common.PeerInfo{{PeerID: peers[0].String()}})
+	net.On("DoStateRequest", peers[0], mock.AnythingOfType("*network.StateRequest")).
+		Return(nil, errors.New("protocol not supported"))
+
+	err = newStateSyncer(new(syncmocks.BlockState), nil, nil, net).sync(target)
+	require.ErrorIs(t, err, errStateSyncUnavailable)
+	net.AssertNumberOfCalls(t, "DoStateRequest", stateSyncMaxIdleRetries+1)
+}
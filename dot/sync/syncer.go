@@ -4,6 +4,7 @@
 package sync
 
 import (
+	"errors"
 	"math/big"
 	"time"
 
@@ -21,7 +22,11 @@ type Service struct {
 	blockState     BlockState
 	chainSync      ChainSync
 	chainProcessor ChainProcessor
+	storageState   StorageState
 	network        Network
+	warpSyncer     *warpSyncer
+	stateSyncer    *stateSyncer
+	headersOnly    bool
 }
 
 // Config is the configuration for the sync Service.
@@ -30,12 +35,22 @@ type Config struct {
 	Network            Network
 	BlockState         BlockState
 	StorageState       StorageState
+	EpochState         EpochState
 	FinalityGadget     FinalityGadget
 	TransactionState   TransactionState
 	BlockImportHandler BlockImportHandler
 	BabeVerifier       BabeVerifier
 	MinPeers, MaxPeers int
 	SlotDuration       time.Duration
+	// WarpSync enables jumping to the latest finalised block using GRANDPA warp sync proofs before syncing.
+	// The state of the block is then downloaded from peers using state sync, unless HeadersOnly is set, and
+	// the BABE epochs of the block are read from its state into the EpochState, which is then required.
+	WarpSync bool
+	// HeadersOnly enables light client syncing. Only block headers and justifications are requested from peers,
+	// and headers are imported once their BABE seal is verified, without executing the blocks.
+	HeadersOnly bool
+	// DigestHandler handles the consensus digests of the imported headers. It is required if HeadersOnly is set.
+	DigestHandler DigestHandler
 }
 
 // NewService returns a new *sync.Service
@@ -68,6 +83,14 @@ func NewService(cfg *Config) (*Service, error) {
 		return nil, errNilBlockImportHandler
 	}
 
+	if cfg.HeadersOnly && cfg.DigestHandler == nil {
+		return nil, errNilDigestHandler
+	}
+
+	if cfg.WarpSync && !cfg.HeadersOnly && cfg.EpochState == nil {
+		return nil, errNilEpochState
+	}
+
 	logger.Patch(log.SetLevel(cfg.LogLvl))
 
 	readyBlocks := newBlockQueue(maxResponseSize * 30)
@@ -81,30 +104,97 @@ func NewService(cfg *Config) (*Service, error) {
 		minPeers:      cfg.MinPeers,
 		maxPeers:      cfg.MaxPeers,
 		slotDuration:  cfg.SlotDuration,
+		headersOnly:   cfg.HeadersOnly,
 	}
 
 	chainSync := newChainSync(csCfg)
 	chainProcessor := newChainProcessor(readyBlocks, pendingBlocks,
 		cfg.BlockState, cfg.StorageState, cfg.TransactionState,
-		cfg.BabeVerifier, cfg.FinalityGadget, cfg.BlockImportHandler)
+		cfg.BabeVerifier, cfg.FinalityGadget, cfg.BlockImportHandler,
+		cfg.HeadersOnly, cfg.DigestHandler)
+
+	var (
+		ws *warpSyncer
+		ss *stateSyncer
+	)
+
+	if cfg.WarpSync {
+		ws = newWarpSyncer(cfg.BlockState, cfg.Network, cfg.FinalityGadget)
+	}
+
+	if cfg.WarpSync && !cfg.HeadersOnly {
+		ss = newStateSyncer(cfg.BlockState, cfg.StorageState, cfg.EpochState, cfg.Network)
+	}
 
 	return &Service{
 		blockState:     cfg.BlockState,
 		chainSync:      chainSync,
 		chainProcessor: chainProcessor,
+		storageState:   cfg.StorageState,
 		network:        cfg.Network,
+		warpSyncer:     ws,
+		stateSyncer:    ss,
+		headersOnly:    cfg.HeadersOnly,
 	}, nil
 }
 
-// Start begins the chainSync and chainProcessor modules. It begins syncing in bootstrap mode
+// Start begins the chainSync and chainProcessor modules. It begins syncing in bootstrap mode,
+// unless warp sync is enabled. In that case, it first warp syncs to the latest finalised block and downloads
+// its state, and then syncs the blocks that follow it, usually in tip mode as the block is near the head.
+// Light clients skip downloading the state. If the warp sync or the state sync fails, it falls back to syncing
+// every block.
 func (s *Service) Start() error {
-	go s.chainSync.start()
 	go s.chainProcessor.start()
+
+	if s.warpSyncer == nil {
+		go s.chainSync.start()
+		return nil
+	}
+
+	go func() {
+		target, err := s.warpSyncer.sync()
+		if errors.Is(err, ErrServiceStopped) {
+			return
+		}
+
+		if err != nil {
+			logger.Warnf("failed to warp sync, syncing every block instead: %s", err)
+			s.chainSync.start()
+			return
+		}
+
+		if s.stateSyncer != nil {
+			// blocks can't be executed without the state of their parent, so the blocks following the
+			// finalised block before the warp sync are synced instead if the state can't be downloaded
+			err = s.stateSyncer.sync(target)
+			if errors.Is(err, ErrServiceStopped) {
+				return
+			}
+
+			if err != nil {
+				logger.Warnf("failed to state sync, syncing every block instead: %s", err)
+				if err = s.warpSyncer.revert(); err != nil {
+					logger.Errorf("failed to revert warp sync, cannot sync blocks: %s", err)
+					return
+				}
+			}
+		}
+
+		s.chainSync.start()
+	}()
 	return nil
 }
 
 // Stop stops the chainSync and chainProcessor modules
 func (s *Service) Stop() error {
+	if s.warpSyncer != nil {
+		s.warpSyncer.stop()
+	}
+
+	if s.stateSyncer != nil {
+		s.stateSyncer.stop()
+	}
+
 	s.chainSync.stop()
 	s.chainProcessor.stop()
 	return nil
@@ -0,0 +1,159 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"context"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/peerset"
+	"github.com/ChainSafe/gossamer/dot/types"
+
+	"github.com/libp2p/go-libp2p-core/peer"
+)
+
+var (
+	warpSyncRetryInterval = time.Second * 5
+	// warpSyncMaxRetries is the number of times peers are asked for proofs without any progress
+	// before the warp sync is given up
+	warpSyncMaxRetries = 12
+)
+
+// warpSyncer downloads and imports GRANDPA warp sync proofs, so that the node can jump to the latest
+// finalised block instead of executing every block from its current finalised block onwards
+type warpSyncer struct {
+	ctx            context.Context
+	cancel         context.CancelFunc
+	blockState     BlockState
+	network        Network
+	finalityGadget FinalityGadget
+
+	// start is the latest finalised block before the warp sync, finalised at startRound and startSetID
+	start      *types.Header
+	startRound uint64
+	startSetID uint64
+}
+
+func newWarpSyncer(bs BlockState, net Network, fg FinalityGadget) *warpSyncer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &warpSyncer{
+		ctx:            ctx,
+		cancel:         cancel,
+		blockState:     bs,
+		network:        net,
+		finalityGadget: fg,
+	}
+}
+
+// sync requests warp sync proofs from peers, starting from the highest finalised block, until a finished proof
+// is imported. It returns the header of the latest finalised block once it's done. If peers stop providing proofs,
+// it gives up and returns the latest finalised header proven so far, or errWarpSyncUnavailable if none was.
+func (w *warpSyncer) sync() (*types.Header, error) {
+	begin, err := w.blockState.GetHighestFinalisedHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	w.start = begin
+	w.startRound, w.startSetID, err = w.blockState.GetHighestRoundAndSetID()
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("starting warp sync from block %s with hash %s", begin.Number, begin.Hash())
+
+	for retries := 0; retries <= warpSyncMaxRetries; retries++ {
+		for _, info := range w.network.Peers() {
+			select {
+			case <-w.ctx.Done():
+				return nil, ErrServiceStopped
+			default:
+			}
+
+			who, err := peer.Decode(info.PeerID)
+			if err != nil {
+				continue
+			}
+
+			var (
+				finished bool
+				previous = begin.Hash()
+			)
+
+			begin, finished, err = w.syncFromPeer(who, begin)
+			if begin.Hash() != previous {
+				retries = 0
+			}
+
+			if err != nil {
+				logger.Debugf("failed to warp sync from peer %s: %s", who, err)
+				continue
+			}
+
+			if finished {
+				logger.Infof("warp sync finished at block %s with hash %s", begin.Number, begin.Hash())
+				return begin, nil
+			}
+		}
+
+		select {
+		case <-w.ctx.Done():
+			return nil, ErrServiceStopped
+		case <-time.After(warpSyncRetryInterval):
+		}
+	}
+
+	if begin.Hash() == w.start.Hash() {
+		return nil, errWarpSyncUnavailable
+	}
+
+	logger.Warnf("warp sync stopped at block %s with hash %s, no peer proves the blocks finalised after it",
+		begin.Number, begin.Hash())
+	return begin, nil
+}
+
+// revert sets the latest finalised block before the warp sync back as the latest finalised block,
+// so that the blocks after it can be synced and executed instead
+func (w *warpSyncer) revert() error {
+	return w.finalityGadget.RevertWarpSync(w.start, w.startRound, w.startSetID)
+}
+
+// syncFromPeer imports warp sync proofs from the given peer until it has sent a finished proof.
+// It returns the latest finalised header, which is returned even if an error occurs, and whether
+// the warp sync is finished.
+func (w *warpSyncer) syncFromPeer(who peer.ID, begin *types.Header) (*types.Header, bool, error) {
+	for {
+		proof, err := w.network.DoWarpSyncRequest(who, &network.WarpSyncProofRequest{
+			Begin: begin.Hash(),
+		})
+		if err != nil {
+			return begin, false, err
+		}
+
+		header, err := w.finalityGadget.ImportWarpSyncProof(proof)
+		if err != nil {
+			w.network.ReportPeer(peerset.ReputationChange{
+				Value:  peerset.BadJustificationValue,
+				Reason: peerset.BadJustificationReason,
+			}, who)
+			return begin, false, err
+		}
+
+		if proof.IsFinished {
+			return header, true, nil
+		}
+
+		if header.Hash() == begin.Hash() {
+			// the peer can't prove anything beyond our finalised block
+			return begin, false, errWarpSyncNoProgress
+		}
+
+		begin = header
+	}
+}
+
+func (w *warpSyncer) stop() {
+	w.cancel()
+}
@@ -0,0 +1,283 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sync
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/state"
+	"github.com/ChainSafe/gossamer/dot/state/pruner"
+	syncmocks "github.com/ChainSafe/gossamer/dot/sync/mocks"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/babe"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/runtime"
+	runtimemocks "github.com/ChainSafe/gossamer/lib/runtime/mocks"
+	"github.com/ChainSafe/gossamer/lib/trie"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+
+	"github.com/libp2p/go-libp2p-core/crypto"
+	"github.com/libp2p/go-libp2p-core/peer"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestWarpSyncer(t *testing.T) (*warpSyncer, peer.ID, *types.Header) {
+	t.Helper()
+
+	_, pub, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+
+	who, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+
+	genesis := &types.Header{
+		Number: big.NewInt(0),
+		Digest: types.NewDigest(),
+	}
+
+	bs := new(syncmocks.BlockState)
+	bs.On("GetHighestFinalisedHeader").Return(genesis, nil)
+	bs.On("GetHighestRoundAndSetID").Return(uint64(0), uint64(0), nil)
+
+	net := new(syncmocks.Network)
+	net.On("Peers").Return([]common.PeerInfo{{PeerID: who.String()}})
+
+	return newWarpSyncer(bs, net, new(syncmocks.FinalityGadget)), who, genesis
+}
+
+func TestWarpSyncer_Sync(t *testing.T) {
+	w, who, genesis := newTestWarpSyncer(t)
+
+	target := &types.Header{
+		ParentHash: genesis.Hash(),
+		Number:     big.NewInt(1),
+		Digest:     types.NewDigest(),
+	}
+
+	proof := &network.WarpSyncProof{IsFinished: true}
+	w.network.(*syncmocks.Network).On("DoWarpSyncRequest", who, &network.WarpSyncProofRequest{
+		Begin: genesis.Hash(),
+	}).Return(proof, nil)
+	w.finalityGadget.(*syncmocks.FinalityGadget).On("ImportWarpSyncProof", proof).Return(target, nil)
+
+	res, err := w.sync()
+	require.NoError(t, err)
+	require.Equal(t, target, res)
+
+	// the warp sync is reverted to the finalised block it started from
+	w.finalityGadget.(*syncmocks.FinalityGadget).On("RevertWarpSync", genesis, uint64(0), uint64(0)).Return(nil)
+	err = w.revert()
+	require.NoError(t, err)
+	w.finalityGadget.(*syncmocks.FinalityGadget).AssertCalled(t, "RevertWarpSync", genesis, uint64(0), uint64(0))
+}
+
+func TestWarpSyncer_Sync_InvalidProof(t *testing.T) {
+	w, who, _ := newTestWarpSyncer(t)
+
+	proof := &network.WarpSyncProof{IsFinished: true}
+	w.network.(*syncmocks.Network).On("DoWarpSyncRequest", who, mock.AnythingOfType("*network.WarpSyncProofRequest")).
+		Return(proof, nil)
+	w.finalityGadget.(*syncmocks.FinalityGadget).On("ImportWarpSyncProof", proof).
+		Return(nil, errors.New("invalid proof"))
+	w.network.(*syncmocks.Network).On("ReportPeer", mock.AnythingOfType("peerset.ReputationChange"), who).
+		Run(func(mock.Arguments) { w.stop() })
+
+	_, err := w.sync()
+	require.ErrorIs(t, err, ErrServiceStopped)
+	w.network.(*syncmocks.Network).AssertCalled(t, "ReportPeer", mock.AnythingOfType("peerset.ReputationChange"), who)
+}
+
+func TestWarpSyncer_Sync_GivesUp(t *testing.T) {
+	retryInterval, maxRetries := warpSyncRetryInterval, warpSyncMaxRetries
+	warpSyncRetryInterval, warpSyncMaxRetries = time.Millisecond, 2
+	t.Cleanup(func() {
+		warpSyncRetryInterval, warpSyncMaxRetries = retryInterval, maxRetries
+	})
+
+	w, who, genesis := newTestWarpSyncer(t)
+	w.network.(*syncmocks.Network).On("DoWarpSyncRequest", who, &network.WarpSyncProofRequest{
+		Begin: genesis.Hash(),
+	}).Return(nil, errors.New("protocol not supported"))
+
+	_, err := w.sync()
+	require.ErrorIs(t, err, errWarpSyncUnavailable)
+
+	// the latest proven header is returned if the peers stop providing proofs part way
+	w, who, genesis = newTestWarpSyncer(t)
+
+	target := &types.Header{
+		ParentHash: genesis.Hash(),
+		Number:     big.NewInt(1),
+		Digest:     types.NewDigest(),
+	}
+
+	proof := &network.WarpSyncProof{}
+	w.network.(*syncmocks.Network).On("DoWarpSyncRequest", who, &network.WarpSyncProofRequest{
+		Begin: genesis.Hash(),
+	}).Return(proof, nil)
+	w.network.(*syncmocks.Network).On("DoWarpSyncRequest", who, &network.WarpSyncProofRequest{
+		Begin: target.Hash(),
+	}).Return(nil, errors.New("timeout"))
+	w.finalityGadget.(*syncmocks.FinalityGadget).On("ImportWarpSyncProof", proof).Return(target, nil)
+
+	res, err := w.sync()
+	require.NoError(t, err)
+	require.Equal(t, target, res)
+}
+
+// newTestBabeHeader returns a header of a block produced by the given BABE authority in a secondary slot
+func newTestBabeHeader(t *testing.T, kp *sr25519.Keypair, parent *types.Header, slot uint64) *types.Header {
+	t.Helper()
+
+	preDigest, err := types.NewBabeSecondaryPlainPreDigest(0, slot).ToPreRuntimeDigest()
+	require.NoError(t, err)
+
+	digest := types.NewDigest()
+	require.NoError(t, digest.Add(*preDigest))
+
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     big.NewInt(0).Add(parent.Number, big.NewInt(1)),
+		Digest:     digest,
+	}
+
+	// the header hash is not computed with Hash, which would keep the hash of the header without the seal
+	enc, err := scale.Marshal(*header)
+	require.NoError(t, err)
+	hash, err := common.Blake2bHash(enc)
+	require.NoError(t, err)
+
+	sig, err := kp.Sign(hash[:])
+	require.NoError(t, err)
+
+	err = header.Digest.Add(types.SealDigest{
+		ConsensusEngineID: types.BabeEngineID,
+		Data:              sig,
+	})
+	require.NoError(t, err)
+	return header
+}
+
+func TestWarpSync_ImportBlocks(t *testing.T) {
+	const epochLength = 10
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	nextKp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	genesis := &types.Header{
+		Number:    big.NewInt(0),
+		StateRoot: trie.EmptyHash,
+		Digest:    types.NewDigest(),
+	}
+
+	db := state.NewInMemoryDB(t)
+	bs, err := state.NewBlockStateFromGenesis(db, genesis)
+	require.NoError(t, err)
+	ss, err := state.NewStorageState(db, bs, trie.NewEmptyTrie(), pruner.Config{})
+	require.NoError(t, err)
+	es, err := state.NewEpochStateFromGenesis(db, bs, &types.BabeConfiguration{
+		SlotDuration: 1000,
+		EpochLength:  epochLength,
+		C1:           1,
+		C2:           4,
+	})
+	require.NoError(t, err)
+
+	// the state of the target block is in epoch 5, which started at slot 1050
+	code := []byte("code")
+	nextAuthorities, err := scale.Marshal([]types.AuthorityRaw{{Key: nextKp.Public().(*sr25519.PublicKey).AsBytes(),
+		Weight: 1}})
+	require.NoError(t, err)
+
+	top := trie.NewEmptyTrie()
+	top.Put(common.CodeKey, code)
+	top.Put(runtime.BABENextAuthoritiesKey(), nextAuthorities)
+	top.Put(runtime.BABENextRandomnessKey(), []byte{2})
+
+	currentEpoch, err := scale.Marshal(runtime.BabeEpoch{
+		EpochIndex:   5,
+		StartSlot:    1050,
+		Duration:     epochLength,
+		Authorities:  []types.AuthorityRaw{{Key: kp.Public().(*sr25519.PublicKey).AsBytes(), Weight: 1}},
+		Randomness:   [types.RandomnessLength]byte{1},
+		C1:           1,
+		C2:           4,
+		AllowedSlots: 1,
+	})
+	require.NoError(t, err)
+
+	codeHash, err := common.Blake2bHash(code)
+	require.NoError(t, err)
+
+	rt := new(runtimemocks.Instance)
+	rt.On("GetCodeHash").Return(codeHash)
+	rt.On("SetContextStorage", mock.AnythingOfType("*storage.TrieState"))
+	rt.On("Exec", runtime.BabeAPICurrentEpoch, []byte{}).Return(currentEpoch, nil)
+	bs.StoreRuntime(genesis.Hash(), rt)
+
+	preDigest, err := types.NewBabeSecondaryPlainPreDigest(0, 1055).ToPreRuntimeDigest()
+	require.NoError(t, err)
+	digest := types.NewDigest()
+	require.NoError(t, digest.Add(*preDigest))
+	target, err := types.NewHeader(common.Hash{1}, top.MustHash(), common.Hash{}, big.NewInt(100), digest)
+	require.NoError(t, err)
+
+	_, pub, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(t, err)
+	who, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+
+	proof := &network.WarpSyncProof{IsFinished: true}
+	resp, err := newStateResponse(top, nil, false)
+	require.NoError(t, err)
+
+	net := new(syncmocks.Network)
+	net.On("Peers").Return([]common.PeerInfo{{PeerID: who.String()}})
+	net.On("DoWarpSyncRequest", who, &network.WarpSyncProofRequest{Begin: genesis.Hash()}).Return(proof, nil)
+	net.On("DoStateRequest", who, mock.AnythingOfType("*network.StateRequest")).Return(resp, nil)
+
+	fg := new(syncmocks.FinalityGadget)
+	fg.On("ImportWarpSyncProof", proof).Return(target, nil).Run(func(mock.Arguments) {
+		require.NoError(t, bs.ImportWarpSyncHeader(target, []byte("justification"), 1, 1))
+	})
+
+	res, err := newWarpSyncer(bs, net, fg).sync()
+	require.NoError(t, err)
+	require.Equal(t, target, res)
+
+	err = newStateSyncer(bs, ss, es, net).sync(target)
+	require.NoError(t, err)
+
+	// the blocks following the target block are verified against the epochs read from its state
+	verifier, err := babe.NewVerificationManager(bs, es)
+	require.NoError(t, err)
+
+	block := &types.Block{
+		Header: *newTestBabeHeader(t, kp, target, 1058),
+		Body:   types.Body{},
+	}
+	require.NoError(t, verifier.VerifyBlock(&block.Header))
+	require.NoError(t, bs.AddBlock(block))
+
+	next := &types.Block{
+		Header: *newTestBabeHeader(t, nextKp, &block.Header, 1061),
+		Body:   types.Body{},
+	}
+	require.NoError(t, verifier.VerifyBlock(&next.Header))
+	require.NoError(t, bs.AddBlock(next))
+
+	// the authority of the current epoch cannot produce blocks in the next epoch
+	invalid := newTestBabeHeader(t, kp, &block.Header, 1062)
+	require.ErrorIs(t, verifier.VerifyBlock(invalid), babe.ErrBadSignature)
+}
@@ -0,0 +1,183 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/gorilla/websocket"
+)
+
+// telemetryConnection holds the connection to a single telemetry endpoint. Messages are
+// buffered on a bounded queue and written by a single goroutine, which (re)connects to the
+// endpoint with exponential backoff whenever the connection is lost. The system.connected
+// handshake is sent first on every new connection.
+type telemetryConnection struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	endpoint  string
+	verbosity int
+	queue     chan []byte
+	dialer    *websocket.Dialer
+	logger    log.LeveledLogger
+
+	// handshake is the latest system.connected message, handshakeSet is notified when it changes
+	handshakeLock sync.Mutex
+	handshake     []byte
+	handshakeSet  chan struct{}
+
+	minRetryDelay time.Duration
+	maxRetryDelay time.Duration
+}
+
+func newTelemetryConnection(endpoint string, verbosity, queueSize int,
+	minRetryDelay, maxRetryDelay time.Duration, logger log.LeveledLogger) *telemetryConnection {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &telemetryConnection{
+		ctx:           ctx,
+		cancel:        cancel,
+		endpoint:      endpoint,
+		verbosity:     verbosity,
+		queue:         make(chan []byte, queueSize),
+		dialer:        websocket.DefaultDialer,
+		logger:        logger,
+		handshakeSet:  make(chan struct{}, 1),
+		minRetryDelay: minRetryDelay,
+		maxRetryDelay: maxRetryDelay,
+	}
+}
+
+// accepts returns true if a message of the given verbosity level should be sent to the endpoint
+func (c *telemetryConnection) accepts(verbosity int) bool {
+	return verbosity <= c.verbosity
+}
+
+// enqueue adds the message to the connection queue. The message is dropped if the queue is full.
+func (c *telemetryConnection) enqueue(msg []byte) bool {
+	select {
+	case c.queue <- msg:
+		return true
+	default:
+		c.logger.Debugf("telemetry queue for %s is full, dropping message", c.endpoint)
+		return false
+	}
+}
+
+// setHandshake sets the system.connected message sent on every new connection, and sends it
+// on the current connection
+func (c *telemetryConnection) setHandshake(msg []byte) {
+	c.handshakeLock.Lock()
+	c.handshake = msg
+	c.handshakeLock.Unlock()
+
+	select {
+	case c.handshakeSet <- struct{}{}:
+	default:
+	}
+}
+
+func (c *telemetryConnection) getHandshake() []byte {
+	c.handshakeLock.Lock()
+	defer c.handshakeLock.Unlock()
+	return c.handshake
+}
+
+// run connects to the endpoint and writes the queued messages, reconnecting when the
+// connection fails. A message whose write failed is written again on the next connection.
+// It returns once the connection is stopped.
+func (c *telemetryConnection) run() {
+	var pending []byte
+	for {
+		wsconn := c.connect()
+		if wsconn == nil {
+			return
+		}
+
+		c.logger.Debugf("connected to telemetry endpoint %s", c.endpoint)
+
+		pending = c.serve(wsconn, pending)
+
+		if err := wsconn.Close(); err != nil {
+			c.logger.Debugf("issue closing telemetry connection to %s: %s", c.endpoint, err)
+		}
+	}
+}
+
+// serve writes the handshake, the pending message and then the queued messages to the connection,
+// until a write fails or the connection is stopped. It returns the message whose write failed, if any.
+func (c *telemetryConnection) serve(wsconn *websocket.Conn, pending []byte) []byte {
+	// the handshake is written below, a notification of a handshake set before is stale
+	select {
+	case <-c.handshakeSet:
+	default:
+	}
+
+	if handshake := c.getHandshake(); handshake != nil {
+		if !c.write(wsconn, handshake) {
+			return pending
+		}
+	}
+
+	for {
+		if pending != nil {
+			if !c.write(wsconn, pending) {
+				return pending
+			}
+			pending = nil
+		}
+
+		select {
+		case <-c.ctx.Done():
+			return nil
+		case <-c.handshakeSet:
+			if !c.write(wsconn, c.getHandshake()) {
+				return nil
+			}
+		case pending = <-c.queue:
+		}
+	}
+}
+
+func (c *telemetryConnection) write(wsconn *websocket.Conn, msg []byte) bool {
+	err := wsconn.WriteMessage(websocket.TextMessage, msg)
+	if err != nil {
+		c.logger.Debugf("issue while sending telemetry message to %s: %s", c.endpoint, err)
+		return false
+	}
+
+	return true
+}
+
+// connect dials the endpoint until it succeeds, doubling the delay between attempts
+// up to maxRetryDelay. It returns nil if the connection is stopped.
+func (c *telemetryConnection) connect() *websocket.Conn {
+	delay := c.minRetryDelay
+	for {
+		wsconn, _, err := c.dialer.DialContext(c.ctx, c.endpoint, nil)
+		if err == nil {
+			return wsconn
+		}
+
+		c.logger.Debugf("issue connecting to telemetry endpoint %s, retrying in %s: %s", c.endpoint, delay, err)
+
+		select {
+		case <-c.ctx.Done():
+			return nil
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > c.maxRetryDelay {
+			delay = c.maxRetryDelay
+		}
+	}
+}
+
+// stop closes the connection and stops the goroutine writing to it
+func (c *telemetryConnection) stop() {
+	c.cancel()
+}
@@ -0,0 +1,183 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package telemetry
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/genesis"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer starts a websocket server sending the type of each received message on the
+// returned channel. The server closes each connection after receiving closeAfter messages,
+// or never if closeAfter is zero.
+func newTestServer(t *testing.T, closeAfter int) (endpoint string, msgTypes chan string) {
+	t.Helper()
+
+	msgTypes = make(chan string, 16)
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+
+		for received := 0; closeAfter == 0 || received < closeAfter; received++ {
+			_, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			var m map[string]interface{}
+			if err = json.Unmarshal(msg, &m); err != nil {
+				return
+			}
+			msgTypes <- m["msg"].(string)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return "ws" + strings.TrimPrefix(server.URL, "http"), msgTypes
+}
+
+func newTestHandler() *Handler {
+	h := newHandler()
+	h.minRetryDelay = time.Millisecond * 10
+	h.maxRetryDelay = time.Millisecond * 100
+	return h
+}
+
+func receiveMsgType(t *testing.T, msgTypes chan string) string {
+	t.Helper()
+
+	select {
+	case msgType := <-msgTypes:
+		return msgType
+	case <-time.After(time.Second * 5):
+		t.Fatal("timeout waiting for telemetry message")
+		return ""
+	}
+}
+
+func TestHandler_SendMessage_Verbosity(t *testing.T) {
+	infoEndpoint, infoMsgs := newTestServer(t, 0)
+	debugEndpoint, debugMsgs := newTestServer(t, 0)
+
+	h := newTestHandler()
+	h.AddConnections([]*genesis.TelemetryEndpoint{
+		{Endpoint: infoEndpoint, Verbosity: substrateInfo},
+		{Endpoint: debugEndpoint, Verbosity: consensusDebug},
+	})
+
+	hash := common.Hash{1}
+	require.NoError(t, h.SendMessage(NewAfgReceivedPrevoteTM(hash, "1", "")))
+	require.NoError(t, h.SendMessage(NewAfgAuthoritySetTM("id", "1", "[]")))
+	require.NoError(t, h.SendMessage(NewBlockImportTM(&hash, big.NewInt(1), "Own")))
+
+	require.Equal(t, afgReceivedPrevoteMsg, receiveMsgType(t, debugMsgs))
+	require.Equal(t, afgAuthoritySetMsg, receiveMsgType(t, debugMsgs))
+	require.Equal(t, blockImportMsg, receiveMsgType(t, debugMsgs))
+
+	require.Equal(t, blockImportMsg, receiveMsgType(t, infoMsgs))
+	select {
+	case msgType := <-infoMsgs:
+		t.Fatalf("unexpected message %s", msgType)
+	case <-time.After(time.Millisecond * 100):
+	}
+}
+
+func TestHandler_Reconnect(t *testing.T) {
+	endpoint, msgTypes := newTestServer(t, 1)
+
+	h := newTestHandler()
+	h.AddConnections([]*genesis.TelemetryEndpoint{
+		{Endpoint: endpoint, Verbosity: substrateInfo},
+	})
+
+	hash := common.Hash{1}
+	require.NoError(t, h.SendMessage(NewBlockImportTM(&hash, big.NewInt(1), "Own")))
+	require.Equal(t, blockImportMsg, receiveMsgType(t, msgTypes))
+
+	// the server closed the connection, messages are sent again once the handler reconnects
+	require.Eventually(t, func() bool {
+		err := h.SendMessage(NewNotifyFinalizedTM(hash, "1"))
+		require.NoError(t, err)
+
+		select {
+		case msgType := <-msgTypes:
+			return msgType == notifyFinalizedMsg
+		case <-time.After(time.Millisecond * 50):
+			return false
+		}
+	}, time.Second*5, time.Millisecond*10)
+}
+
+func TestHandler_Reconnect_Handshake(t *testing.T) {
+	endpoint, msgTypes := newTestServer(t, 2)
+
+	h := newTestHandler()
+	h.AddConnections([]*genesis.TelemetryEndpoint{
+		{Endpoint: endpoint, Verbosity: substrateInfo},
+	})
+
+	hash := common.Hash{1}
+	require.NoError(t, h.SendMessage(NewSystemConnectedTM(false, "chain", &hash, "system", "node", "peer", "0", "0")))
+	require.Equal(t, systemConnectedMsg, receiveMsgType(t, msgTypes))
+
+	require.NoError(t, h.SendMessage(NewBlockImportTM(&hash, big.NewInt(1), "Own")))
+	require.Equal(t, blockImportMsg, receiveMsgType(t, msgTypes))
+
+	// the server closed the connection, the handshake is sent first on the new connection
+	require.Eventually(t, func() bool {
+		err := h.SendMessage(NewNotifyFinalizedTM(hash, "1"))
+		require.NoError(t, err)
+
+		select {
+		case msgType := <-msgTypes:
+			return msgType == systemConnectedMsg
+		case <-time.After(time.Millisecond * 50):
+			return false
+		}
+	}, time.Second*5, time.Millisecond*10)
+}
+
+func TestTelemetryConnection_Stop(t *testing.T) {
+	conn := newTelemetryConnection("ws://127.0.0.1:0", substrateInfo, 2,
+		time.Millisecond, time.Millisecond, log.NewFromGlobal(log.AddContext("pkg", "telemetry")))
+
+	done := make(chan struct{})
+	go func() {
+		conn.run()
+		close(done)
+	}()
+
+	conn.stop()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timeout waiting for the connection to stop")
+	}
+}
+
+func TestTelemetryConnection_Enqueue(t *testing.T) {
+	conn := newTelemetryConnection("ws://127.0.0.1:0", substrateInfo, 2,
+		time.Second, time.Second, log.NewFromGlobal(log.AddContext("pkg", "telemetry")))
+
+	require.True(t, conn.enqueue([]byte("1")))
+	require.True(t, conn.enqueue([]byte("2")))
+	require.False(t, conn.enqueue([]byte("3")))
+	require.Len(t, conn.queue, 2)
+}
@@ -5,13 +5,11 @@ package telemetry
 
 import (
 	"encoding/json"
-	"errors"
 	"sync"
 	"time"
 
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/genesis"
-	"github.com/gorilla/websocket"
 )
 
 // telemetry message types
@@ -34,28 +32,45 @@ const (
 	txPoolImportMsg = "txpool.import"
 )
 
-type telemetryConnection struct {
-	wsconn    *websocket.Conn
-	verbosity int
-	sync.Mutex
+// telemetry verbosity levels, matching the ones used by substrate
+const (
+	substrateInfo  = 0
+	consensusInfo  = 1
+	consensusDebug = 5
+)
+
+// messageVerbosity holds the verbosity level of each message type. A message is only sent to
+// the endpoints with a verbosity greater than or equal to the level of its type.
+var messageVerbosity = map[string]int{
+	afgAuthoritySetMsg:           consensusInfo,
+	afgFinalizedBlocksUpToMsg:    consensusInfo,
+	afgReceivedCommitMsg:         consensusDebug,
+	afgReceivedPrecommitMsg:      consensusDebug,
+	afgReceivedPrevoteMsg:        consensusDebug,
+	blockImportMsg:               substrateInfo,
+	notifyFinalizedMsg:           substrateInfo,
+	preparedBlockForProposingMsg: consensusInfo,
+	systemConnectedMsg:           substrateInfo,
+	systemIntervalMsg:            substrateInfo,
+	txPoolImportMsg:              substrateInfo,
 }
 
 // Handler struct for holding telemetry related things
 type Handler struct {
-	msg                chan Message
-	connections        []*telemetryConnection
-	log                log.LeveledLogger
-	sendMessageTimeout time.Duration
-	maxRetries         int
-	retryDelay         time.Duration
+	sync.RWMutex
+	connections   []*telemetryConnection
+	log           log.LeveledLogger
+	queueSize     int
+	minRetryDelay time.Duration
+	maxRetryDelay time.Duration
 }
 
 // Instance interface that telemetry handler instance needs to implement
 type Instance interface {
 	AddConnections(conns []*genesis.TelemetryEndpoint)
 	SendMessage(msg Message) error
-	startListening()
 	Initialise(enabled bool)
+	Stop()
 }
 
 var (
@@ -67,9 +82,9 @@ var (
 )
 
 const (
-	defaultMessageTimeout = time.Second
-	defaultMaxRetries     = 5
-	defaultRetryDelay     = time.Second * 15
+	defaultQueueSize     = 256
+	defaultMinRetryDelay = time.Second
+	defaultMaxRetryDelay = time.Minute
 )
 
 // GetInstance singleton pattern to for accessing TelemetryHandler
@@ -77,14 +92,7 @@ func GetInstance() Instance {
 	if handlerInstance == nil {
 		once.Do(
 			func() {
-				handlerInstance = &Handler{
-					msg:                make(chan Message, 256),
-					log:                log.NewFromGlobal(log.AddContext("pkg", "telemetry")),
-					sendMessageTimeout: defaultMessageTimeout,
-					maxRetries:         defaultMaxRetries,
-					retryDelay:         defaultRetryDelay,
-				}
-				go handlerInstance.startListening()
+				handlerInstance = newHandler()
 			})
 	}
 	if !enabled {
@@ -94,6 +102,15 @@ func GetInstance() Instance {
 	return handlerInstance
 }
 
+func newHandler() *Handler {
+	return &Handler{
+		log:           log.NewFromGlobal(log.AddContext("pkg", "telemetry")),
+		queueSize:     defaultQueueSize,
+		minRetryDelay: defaultMinRetryDelay,
+		maxRetryDelay: defaultMaxRetryDelay,
+	}
+}
+
 // Initialise function to set if telemetry is enabled
 func (h *Handler) Initialise(e bool) {
 	initilised.Do(
@@ -102,58 +119,64 @@ func (h *Handler) Initialise(e bool) {
 		})
 }
 
-// AddConnections adds the given telemetry endpoint as listeners that will receive telemetry data
+// AddConnections adds the given telemetry endpoints as listeners that will receive telemetry data.
+// The endpoints are connected to in the background and reconnected to whenever the connection is lost.
 func (h *Handler) AddConnections(conns []*genesis.TelemetryEndpoint) {
+	h.Lock()
+	defer h.Unlock()
+
 	for _, v := range conns {
-		for connAttempts := 0; connAttempts < h.maxRetries; connAttempts++ {
-			c, _, err := websocket.DefaultDialer.Dial(v.Endpoint, nil)
-			if err != nil {
-				h.log.Debugf("issue adding telemetry connection: %s", err)
-				time.Sleep(h.retryDelay)
-				continue
-			}
-			h.connections = append(h.connections, &telemetryConnection{
-				wsconn:    c,
-				verbosity: v.Verbosity,
-			})
-			break
-		}
+		conn := newTelemetryConnection(v.Endpoint, v.Verbosity, h.queueSize,
+			h.minRetryDelay, h.maxRetryDelay, h.log)
+		h.connections = append(h.connections, conn)
+		go conn.run()
 	}
 }
 
-// SendMessage sends Message to connected telemetry listeners
+// SendMessage sends Message to the connected telemetry listeners accepting its verbosity level.
+// It never blocks: the message is dropped for the listeners whose queue is full. A system.connected
+// message is the handshake of the connections, it is sent again whenever a listener is reconnected.
 func (h *Handler) SendMessage(msg Message) error {
-	t := time.NewTicker(h.sendMessageTimeout)
-	defer t.Stop()
-	select {
-	case h.msg <- msg:
+	verbosity := messageVerbosity[msg.messageType()]
+
+	h.RLock()
+	defer h.RUnlock()
+
+	var msgBytes []byte
+	for _, conn := range h.connections {
+		if !conn.accepts(verbosity) {
+			continue
+		}
 
-	case <-t.C:
-		return errors.New("timeout sending message")
+		if msgBytes == nil {
+			var err error
+			msgBytes, err = h.msgToJSON(msg)
+			if err != nil {
+				return err
+			}
+		}
+
+		if msg.messageType() == systemConnectedMsg {
+			conn.setHandshake(msgBytes)
+			continue
+		}
+
+		conn.enqueue(msgBytes)
 	}
+
 	return nil
 }
 
-func (h *Handler) startListening() {
-	for {
-		msg := <-h.msg
-		go func() {
-			msgBytes, err := h.msgToJSON(msg)
-			if err != nil {
-				h.log.Debugf("issue decoding telemetry message: %s", err)
-				return
-			}
-			for _, conn := range h.connections {
-				conn.Lock()
-				defer conn.Unlock()
-
-				err = conn.wsconn.WriteMessage(websocket.TextMessage, msgBytes)
-				if err != nil {
-					h.log.Debugf("issue while sending telemetry message: %s", err)
-				}
-			}
-		}()
+// Stop closes the connections to the telemetry listeners
+func (h *Handler) Stop() {
+	h.Lock()
+	defer h.Unlock()
+
+	for _, conn := range h.connections {
+		conn.stop()
 	}
+
+	h.connections = nil
 }
 
 func (h *Handler) msgToJSON(message Message) ([]byte, error) {
@@ -191,8 +214,6 @@ type NoopHandler struct {
 // Initialise function to set if telemetry is enabled
 func (h *NoopHandler) Initialise(enabled bool) {}
 
-func (h *NoopHandler) startListening() {}
-
 // SendMessage no op for telemetry send message function
 func (h *NoopHandler) SendMessage(msg Message) error {
 	return nil
@@ -200,3 +221,6 @@ func (h *NoopHandler) SendMessage(msg Message) error {
 
 // AddConnections no op for telemetry add connections function
 func (h *NoopHandler) AddConnections(conns []*genesis.TelemetryEndpoint) {}
+
+// Stop no op for telemetry stop function
+func (h *NoopHandler) Stop() {}
@@ -35,7 +35,7 @@ func TestMain(m *testing.M) {
 	var testEndpoints []*genesis.TelemetryEndpoint
 	var testEndpoint1 = &genesis.TelemetryEndpoint{
 		Endpoint:  "ws://127.0.0.1:8001/",
-		Verbosity: 0,
+		Verbosity: consensusDebug,
 	}
 	GetInstance().AddConnections(append(testEndpoints, testEndpoint1))
 
@@ -46,8 +46,12 @@ func TestMain(m *testing.M) {
 
 func TestHandler_SendMulti(t *testing.T) {
 	expected := [][]byte{
-		[]byte(`{"authority":false,"chain":"chain","genesis_hash":"0x91b171bb158e2d3848fa23a9f1c25182fb8e20313b2c1eb49219da7a70ce90c3","implementation":"systemName","msg":"system.connected","name":"nodeName","network_id":"netID","startup_time":"startTime","ts":`), //nolint:lll
-		[]byte(`{"best":"0x07b749b6e20fd5f1159153a2e790235018621dd06072a62bcd25e8576f6ff5e6","height":2,"msg":"block.import","origin":"NetworkInitialSync","ts":`),                                                                                                      //nolint:lll
+		[]byte(`{"authority":false,"chain":"chain",` +
+			`"genesis_hash":"0x91b171bb158e2d3848fa23a9f1c25182fb8e20313b2c1eb49219da7a70ce90c3",` +
+			`"implementation":"systemName","msg":"system.connected","name":"nodeName","network_id":"netID",` +
+			`"startup_time":"startTime","ts":`),
+		[]byte(`{"best":"0x07b749b6e20fd5f1159153a2e790235018621dd06072a62bcd25e8576f6ff5e6","height":2,` +
+			`"msg":"block.import","origin":"NetworkInitialSync","ts":`),
 		[]byte(`{"bandwidth_download":2,"bandwidth_upload":3,"msg":"system.interval","peers":1,"ts":`),
 		[]byte(`{"best":"0x07b749b6e20fd5f1159153a2e790235018621dd06072a62bcd25e8576f6ff5e6","finalized_hash":"0x687197c11b4cf95374159843e7f46fbcd63558db981aaef01a8bac2a44a1d6b2","finalized_height":32256,"height":32375,"msg":"system.interval","ts":`), //nolint:lll
 		[]byte(`{"best":"0x07b749b6e20fd5f1159153a2e790235018621dd06072a62bcd25e8576f6ff5e6","height":"32375","msg":"notify.finalized","ts":`),                                                                                                             //nolint:lll
@@ -137,7 +141,7 @@ func TestHandler_SendMulti(t *testing.T) {
 }
 
 func TestListenerConcurrency(t *testing.T) {
-	const qty = 1000
+	const qty = defaultQueueSize
 	var wg sync.WaitGroup
 	wg.Add(qty)
 
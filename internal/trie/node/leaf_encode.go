@@ -80,6 +80,19 @@ func (l *Leaf) ScaleEncodeHash() (encoding []byte, err error) {
 }
 
 func (l *Leaf) hash(writer io.Writer) (err error) {
+	l.encodingMu.RLock()
+	hashOnly := !l.dirty && l.encoding == nil && l.hashDigest != nil
+	l.encodingMu.RUnlock()
+	if hashOnly {
+		// the leaf stubs the child of a decoded branch and only its hash is known,
+		// which is the case for the nodes missing from the proof of a partial trie
+		_, err = writer.Write(l.hashDigest)
+		if err != nil {
+			return fmt.Errorf("cannot write hash of leaf to buffer: %w", err)
+		}
+		return nil
+	}
+
 	encodingBuffer := pools.EncodingBuffers.Get().(*bytes.Buffer)
 	encodingBuffer.Reset()
 	defer pools.EncodingBuffers.Put(encodingBuffer)
@@ -202,6 +202,15 @@ func Test_Leaf_hash(t *testing.T) {
 		wrappedErr error
 		errMessage string
 	}{
+		"hash only leaf success": {
+			leaf: &Leaf{
+				hashDigest: []byte{1, 2, 3},
+			},
+			writeCall: true,
+			write: writeCall{
+				written: []byte{1, 2, 3},
+			},
+		},
 		"small leaf buffer write error": {
 			leaf: &Leaf{
 				encoding: []byte{1, 2, 3},
@@ -15,7 +15,9 @@ import (
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/signer"
 
 	ethmetrics "github.com/ethereum/go-ethereum/metrics"
 )
@@ -42,8 +44,9 @@ type Service struct {
 
 	blockImportHandler BlockImportHandler
 
-	// BABE authority keypair
-	keypair *sr25519.Keypair // TODO: change to BABE keystore (#1864)
+	// BABE authority key and the signer holding its private key
+	authorityKey *sr25519.PublicKey
+	signer       signer.Signer
 
 	// Epoch configuration data
 	slotDuration time.Duration
@@ -65,6 +68,7 @@ type ServiceConfig struct {
 	EpochState         EpochState
 	BlockImportHandler BlockImportHandler
 	Keypair            *sr25519.Keypair
+	Signer             signer.Signer
 	Runtime            runtime.Instance
 	AuthData           []types.Authority
 	IsDev              bool
@@ -74,10 +78,6 @@ type ServiceConfig struct {
 
 // NewService returns a new Babe Service using the provided VRF keys and runtime
 func NewService(cfg *ServiceConfig) (*Service, error) {
-	if cfg.Keypair == nil && cfg.Authority {
-		return nil, errors.New("cannot create BABE service as authority; no keypair provided")
-	}
-
 	if cfg.BlockState == nil {
 		return nil, errNilBlockState
 	}
@@ -100,7 +100,6 @@ func NewService(cfg *ServiceConfig) (*Service, error) {
 		blockState:         cfg.BlockState,
 		storageState:       cfg.StorageState,
 		epochState:         cfg.EpochState,
-		keypair:            cfg.Keypair,
 		transactionState:   cfg.TransactionState,
 		slotToProof:        make(map[uint64]*VrfOutputAndProof),
 		pause:              make(chan struct{}),
@@ -110,6 +109,13 @@ func NewService(cfg *ServiceConfig) (*Service, error) {
 		lead:               cfg.Lead,
 	}
 
+	if cfg.Authority || cfg.Keypair != nil {
+		err := babeService.setupSigner(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create BABE service as authority: %w", err)
+		}
+	}
+
 	epoch, err := cfg.EpochState.GetCurrentEpoch()
 	if err != nil {
 		return nil, err
@@ -306,7 +312,7 @@ func (b *Service) getAuthorityIndex(Authorities []types.Authority) (uint32, erro
 		return 0, ErrNotAuthority
 	}
 
-	pub := b.keypair.Public()
+	pub := b.authorityKey
 
 	for i, auth := range Authorities {
 		if bytes.Equal(pub.Encode(), auth.Key.Encode()) {
@@ -317,6 +323,47 @@ func (b *Service) getAuthorityIndex(Authorities []types.Authority) (uint32, erro
 	return 0, fmt.Errorf("key not in BABE authority data")
 }
 
+// setupSigner sets the signer of the service and its authority key, which is the key of the keypair of the
+// configuration, or else the first BABE key of the signer. Without signer, the service signs with the keypair.
+func (b *Service) setupSigner(cfg *ServiceConfig) error {
+	b.signer = cfg.Signer
+	if cfg.Keypair != nil {
+		b.authorityKey = cfg.Keypair.Public().(*sr25519.PublicKey)
+		if b.signer != nil {
+			return nil
+		}
+
+		local, err := signer.NewLocalFromKeypair(keystore.BabeName, cfg.Keypair)
+		if err != nil {
+			return err
+		}
+
+		b.signer = local
+		return nil
+	}
+
+	if b.signer == nil {
+		return errors.New("no keypair or signer provided")
+	}
+
+	pubs, err := b.signer.PublicKeys(keystore.BabeName)
+	if err != nil {
+		return fmt.Errorf("cannot get BABE keys of signer: %w", err)
+	}
+
+	if len(pubs) == 0 {
+		return errNoBABEAuthorityKeyProvided
+	}
+
+	pub, ok := pubs[0].(*sr25519.PublicKey)
+	if !ok {
+		return errors.New("BABE authority key is not of type sr25519")
+	}
+
+	b.authorityKey = pub
+	return nil
+}
+
 func (b *Service) getSlotDuration() time.Duration {
 	return b.slotDuration
 }
@@ -419,7 +466,11 @@ func (b *Service) invokeBlockAuthoring() error {
 						"not authorized to produce a block in slot %d, at epoch %d with %d slots in this epoch",
 						slotNum, epoch, slotNum-epochStartSlot)
 					continue
-				} else if err != nil {
+				}
+
+				slotsClaimed.Inc()
+				if err != nil {
+					slotsMissed.Inc()
 					logger.Warnf("failed to handle slot %d: %s", slotNum, err)
 					continue
 				}
@@ -527,11 +578,14 @@ func (b *Service) handleSlot(epoch, slotNum uint64) error {
 
 	rt.SetContextStorage(ts)
 
+	start := time.Now()
 	block, err := b.buildBlock(parent, currentSlot, rt)
 	if err != nil {
 		return err
 	}
 
+	blockConstructionTime.Observe(time.Since(start).Seconds())
+
 	logger.Infof(
 		"built block %d with hash %s, state root %s, epoch %d and slot %d",
 		block.Header.Number, block.Header.Hash(), block.Header.StateRoot, epoch, slotNum)
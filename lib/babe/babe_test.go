@@ -348,8 +348,8 @@ func TestService_GetAuthorityIndex(t *testing.T) {
 	}
 
 	bs := &Service{
-		keypair:   kpA,
-		authority: true,
+		authorityKey: pubA,
+		authority:    true,
 	}
 
 	idx, err := bs.getAuthorityIndex(authData)
@@ -357,8 +357,8 @@ func TestService_GetAuthorityIndex(t *testing.T) {
 	require.Equal(t, uint32(0), idx)
 
 	bs = &Service{
-		keypair:   kpB,
-		authority: true,
+		authorityKey: pubB,
+		authority:    true,
 	}
 
 	idx, err = bs.getAuthorityIndex(authData)
@@ -13,7 +13,9 @@ import (
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/signer"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	ethmetrics "github.com/ethereum/go-ethereum/metrics"
@@ -27,7 +29,8 @@ const (
 // construct a block for this slot with the given parent
 func (b *Service) buildBlock(parent *types.Header, slot Slot, rt runtime.Instance) (*types.Block, error) {
 	builder, err := NewBlockBuilder(
-		b.keypair,
+		b.signer,
+		b.authorityKey,
 		b.transactionState,
 		b.blockState,
 		b.slotToProof,
@@ -57,7 +60,8 @@ func (b *Service) buildBlock(parent *types.Header, slot Slot, rt runtime.Instanc
 
 // BlockBuilder builds blocks.
 type BlockBuilder struct {
-	keypair               *sr25519.Keypair
+	signer                signer.Signer
+	authorityKey          *sr25519.PublicKey
 	transactionState      TransactionState
 	blockState            BlockState
 	slotToProof           map[uint64]*VrfOutputAndProof
@@ -65,9 +69,12 @@ type BlockBuilder struct {
 }
 
 // NewBlockBuilder creates a new block builder.
-func NewBlockBuilder(kp *sr25519.Keypair, ts TransactionState,
+func NewBlockBuilder(s signer.Signer, pub *sr25519.PublicKey, ts TransactionState,
 	bs BlockState, sp map[uint64]*VrfOutputAndProof,
 	authidx uint32) (*BlockBuilder, error) {
+	if s == nil || pub == nil {
+		return nil, errors.New("cannot create block builder; signer or authority key is nil")
+	}
 	if ts == nil {
 		return nil, errors.New("cannot create block builder; transaction state is nil")
 	}
@@ -79,7 +86,8 @@ func NewBlockBuilder(kp *sr25519.Keypair, ts TransactionState,
 	}
 
 	bb := &BlockBuilder{
-		keypair:               kp,
+		signer:                s,
+		authorityKey:          pub,
 		transactionState:      ts,
 		blockState:            bs,
 		slotToProof:           sp,
@@ -168,7 +176,7 @@ func (b *BlockBuilder) buildBlock(parent *types.Header, slot Slot, rt runtime.In
 	return block, nil
 }
 
-// buildBlockSeal creates the seal for the block header.
+// buildBlockSeal creates the seal for the block header, which holds the BABE pre-runtime digest of its slot.
 // the seal consists of the ConsensusEngineID and a signature of the encoded block header.
 func (b *BlockBuilder) buildBlockSeal(header *types.Header) (*types.SealDigest, error) {
 	encHeader, err := scale.Marshal(*header)
@@ -181,7 +189,12 @@ func (b *BlockBuilder) buildBlockSeal(header *types.Header) (*types.SealDigest,
 		return nil, err
 	}
 
-	sig, err := b.keypair.Sign(hash[:])
+	eq, err := signer.NewBabeSealEquivocation(header)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := b.signer.Sign(keystore.BabeName, b.authorityKey, hash[:], eq)
 	if err != nil {
 		return nil, err
 	}
@@ -36,7 +36,8 @@ func TestSeal(t *testing.T) {
 	babeService := createTestService(t, cfg)
 
 	builder, _ := NewBlockBuilder(
-		babeService.keypair,
+		babeService.signer,
+		babeService.authorityKey,
 		babeService.transactionState,
 		babeService.blockState,
 		babeService.slotToProof,
@@ -46,7 +47,13 @@ func TestSeal(t *testing.T) {
 	zeroHash, err := common.HexToHash("0x00")
 	require.NoError(t, err)
 
-	header, err := types.NewHeader(zeroHash, zeroHash, zeroHash, big.NewInt(0), types.NewDigest())
+	preDigest, err := types.NewBabeSecondaryPlainPreDigest(0, 1).ToPreRuntimeDigest()
+	require.NoError(t, err)
+
+	digest := types.NewDigest()
+	require.NoError(t, digest.Add(*preDigest))
+
+	header, err := types.NewHeader(zeroHash, zeroHash, zeroHash, big.NewInt(0), digest)
 	require.NoError(t, err)
 
 	encHeader, err := scale.Marshal(*header)
@@ -152,7 +159,8 @@ func TestBuildBlock_ok(t *testing.T) {
 	babeService.epochData.threshold = maxThreshold
 
 	builder, _ := NewBlockBuilder(
-		babeService.keypair,
+		babeService.signer,
+		babeService.authorityKey,
 		babeService.transactionState,
 		babeService.blockState,
 		babeService.slotToProof,
@@ -203,7 +211,8 @@ func TestApplyExtrinsic(t *testing.T) {
 	babeService.epochData.threshold = maxThreshold
 
 	builder, _ := NewBlockBuilder(
-		babeService.keypair,
+		babeService.signer,
+		babeService.authorityKey,
 		babeService.transactionState,
 		babeService.blockState,
 		babeService.slotToProof,
@@ -261,7 +270,7 @@ func TestApplyExtrinsic(t *testing.T) {
 	require.NoError(t, err)
 
 	ext := createTestExtrinsic(t, rt, parentHash, 0)
-	_, err = rt.ValidateTransaction(append([]byte{byte(types.TxnExternal)}, ext...))
+	_, err = rt.ValidateTransaction(append([]byte{byte(types.TxnExternal)}, ext...), parentHash)
 	require.NoError(t, err)
 
 	digest2 := types.NewDigest()
@@ -347,7 +356,7 @@ func TestBuildAndApplyExtrinsic(t *testing.T) {
 	encoder := cscale.NewEncoder(&extEnc)
 	ext.Encode(*encoder)
 
-	txVal, err := rt.ValidateTransaction(append([]byte{byte(types.TxnLocal)}, extEnc.Bytes()...))
+	txVal, err := rt.ValidateTransaction(append([]byte{byte(types.TxnLocal)}, extEnc.Bytes()...), parentHash)
 	require.NoError(t, err)
 
 	vtx := transaction.NewValidTransaction(extEnc.Bytes(), txVal)
@@ -11,6 +11,8 @@ import (
 
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/signer"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/gtank/merlin"
 )
@@ -20,10 +22,15 @@ import (
 var babeVRFPrefix = []byte("substrate-babe-vrf")
 
 func makeTranscript(randomness Randomness, slot, epoch uint64) *merlin.Transcript {
-	t := merlin.NewTranscript("BABE") //string(types.BabeEngineID[:])
-	crypto.AppendUint64(t, []byte("slot number"), slot)
-	crypto.AppendUint64(t, []byte("current epoch"), epoch)
-	t.AppendMessage([]byte("chain randomness"), randomness[:])
+	return makeTranscriptData(randomness, slot, epoch).Merlin()
+}
+
+// makeTranscriptData returns the data of the VRF transcript of a slot, which can be sent to a signer
+func makeTranscriptData(randomness Randomness, slot, epoch uint64) *crypto.VRFTranscript {
+	t := crypto.NewVRFTranscript("BABE") //string(types.BabeEngineID[:])
+	t.AppendUint64("slot number", slot)
+	t.AppendUint64("current epoch", epoch)
+	t.AppendMessage("chain randomness", randomness[:])
 	return t
 }
 
@@ -34,19 +41,20 @@ func makeTranscript(randomness Randomness, slot, epoch uint64) *merlin.Transcrip
 func claimPrimarySlot(randomness Randomness,
 	slot, epoch uint64,
 	threshold *scale.Uint128,
-	keypair *sr25519.Keypair,
+	s signer.Signer,
+	pub *sr25519.PublicKey,
 ) (*VrfOutputAndProof, error) {
-	transcript := makeTranscript(randomness, slot, epoch)
+	transcript := makeTranscriptData(randomness, slot, epoch)
 
-	out, proof, err := keypair.VrfSign(transcript)
+	out, proof, err := s.VrfSign(keystore.BabeName, pub, transcript)
 	if err != nil {
 		return nil, err
 	}
 
 	logger.Tracef("claimPrimarySlot pub=%s slot=%d epoch=%d output=0x%x proof=0x%x",
-		keypair.Public().Hex(), slot, epoch, out, proof)
+		pub.Hex(), slot, epoch, out, proof)
 
-	ok, err := checkPrimaryThreshold(randomness, slot, epoch, out, threshold, keypair.Public().(*sr25519.PublicKey))
+	ok, err := checkPrimaryThreshold(randomness, slot, epoch, out, threshold, pub)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compare with threshold, %w", err)
 	}
@@ -179,6 +179,7 @@ func (b *Service) runLottery(slot, epoch uint64) (*VrfOutputAndProof, error) {
 		slot,
 		epoch,
 		b.epochData.threshold,
-		b.keypair,
+		b.signer,
+		b.authorityKey,
 	)
 }
@@ -9,7 +9,6 @@ import (
 
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
-	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 
 	"github.com/stretchr/testify/require"
 )
@@ -50,7 +49,7 @@ func TestInitiateEpoch_Epoch1(t *testing.T) {
 	threshold := bs.epochData.threshold
 
 	auth := types.Authority{
-		Key:    bs.keypair.Public().(*sr25519.PublicKey),
+		Key:    bs.authorityKey,
 		Weight: 1,
 	}
 
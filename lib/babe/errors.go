@@ -57,16 +57,17 @@ var (
 	// ErrNotAuthority is returned when trying to perform authority functions when not an authority
 	ErrNotAuthority = errors.New("node is not an authority")
 
-	errNilBlockImportHandler    = errors.New("cannot have nil BlockImportHandler")
-	errNilBlockState            = errors.New("cannot have nil BlockState")
-	errNilEpochState            = errors.New("cannot have nil EpochState")
-	errNilStorageState          = errors.New("storage state is nil")
-	errNilParentHeader          = errors.New("parent header is nil")
-	errInvalidResult            = errors.New("invalid error value")
-	errNoEpochData              = errors.New("no epoch data found for upcoming epoch")
-	errFirstBlockTimeout        = errors.New("timed out waiting for first block")
-	errChannelClosed            = errors.New("block notifier channel was closed")
-	errOverPrimarySlotThreshold = errors.New("cannot claim slot, over primary threshold")
+	errNilBlockImportHandler      = errors.New("cannot have nil BlockImportHandler")
+	errNilBlockState              = errors.New("cannot have nil BlockState")
+	errNilEpochState              = errors.New("cannot have nil EpochState")
+	errNilStorageState            = errors.New("storage state is nil")
+	errNilParentHeader            = errors.New("parent header is nil")
+	errInvalidResult              = errors.New("invalid error value")
+	errNoEpochData                = errors.New("no epoch data found for upcoming epoch")
+	errFirstBlockTimeout          = errors.New("timed out waiting for first block")
+	errChannelClosed              = errors.New("block notifier channel was closed")
+	errOverPrimarySlotThreshold   = errors.New("cannot claim slot, over primary threshold")
+	errNoBABEAuthorityKeyProvided = errors.New("no BABE authority key provided")
 
 	other         Other
 	invalidCustom InvalidCustom
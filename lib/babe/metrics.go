@@ -0,0 +1,27 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package babe
+
+import (
+	"github.com/ChainSafe/gossamer/dot/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	slotsClaimed = metrics.NewCounter(prometheus.CounterOpts{
+		Name: "babe_slots_claimed_total",
+		Help: "Slots for which we are authorised to produce a block",
+	})
+
+	slotsMissed = metrics.NewCounter(prometheus.CounterOpts{
+		Name: "babe_slots_missed_total",
+		Help: "Claimed slots for which we failed to produce a block",
+	})
+
+	blockConstructionTime = metrics.NewHistogram(prometheus.HistogramOpts{
+		Name:    "proposer_block_constructed",
+		Help:    "Time taken to build a new block",
+		Buckets: prometheus.ExponentialBuckets(0.001, 2, 16),
+	})
+)
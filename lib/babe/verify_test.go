@@ -295,7 +295,8 @@ func TestVerifyPimarySlotWinner(t *testing.T) {
 	babeService.epochData.authorityIndex = 0
 
 	builder, _ := NewBlockBuilder(
-		babeService.keypair,
+		babeService.signer,
+		babeService.authorityKey,
 		babeService.transactionState,
 		babeService.blockState,
 		babeService.slotToProof,
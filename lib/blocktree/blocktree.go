@@ -23,15 +23,18 @@ type BlockTree struct {
 	root   *node
 	leaves *leafMap
 	sync.RWMutex
-	runtime *sync.Map // map[Hash]runtime.Instance
+	runtime    *sync.Map // map[Hash]runtime.Instance
+	forkChoice ForkChoiceRule
+	bestHint   Hash // block the best chain must contain, zero if unset
 }
 
 // NewEmptyBlockTree creates a BlockTree with a nil head
 func NewEmptyBlockTree() *BlockTree {
 	return &BlockTree{
-		root:    nil,
-		leaves:  newEmptyLeafMap(),
-		runtime: &sync.Map{},
+		root:       nil,
+		leaves:     newEmptyLeafMap(),
+		runtime:    &sync.Map{},
+		forkChoice: BABEForkChoiceRule,
 	}
 }
 
@@ -47,9 +50,10 @@ func NewBlockTreeFromRoot(root *types.Header) *BlockTree {
 	}
 
 	return &BlockTree{
-		root:    n,
-		leaves:  newLeafMap(n),
-		runtime: &sync.Map{},
+		root:       n,
+		leaves:     newLeafMap(n),
+		runtime:    &sync.Map{},
+		forkChoice: BABEForkChoiceRule,
 	}
 }
 
@@ -84,6 +88,11 @@ func (bt *BlockTree) AddBlock(header *types.Header, arrivalTime time.Time) error
 		arrivalTime: arrivalTime,
 	}
 
+	n.primarySlots = parent.primarySlots
+	if isPrimarySlotBlock(header) {
+		n.primarySlots++
+	}
+
 	parent.addChild(n)
 	bt.leaves.replace(parent, n)
 	return nil
@@ -160,6 +169,10 @@ func (bt *BlockTree) Prune(finalised Hash) (pruned []Hash) {
 		bt.runtime.Delete(hash)
 	}
 
+	if bt.bestHint != (Hash{}) && bt.getNode(bt.bestHint) == nil {
+		bt.bestHint = Hash{}
+	}
+
 	return pruned
 }
 
@@ -230,13 +243,51 @@ func (bt *BlockTree) SubBlockchain(start, end Hash) ([]Hash, error) {
 
 }
 
-// deepestLeaf returns the deepest leaf in the block tree.
+// SetForkChoiceRule sets the rule used to choose the best block. By default, BABEForkChoiceRule is used.
+func (bt *BlockTree) SetForkChoiceRule(rule ForkChoiceRule) {
+	bt.Lock()
+	defer bt.Unlock()
+	bt.forkChoice = rule
+}
+
+// SetBestBlockHint restricts the best block to the descendants of the given block, for example
+// when the digest handler requires the chain holding a GRANDPA forced change to be followed.
+// The hint is cleared once the block is pruned. Passing the zero hash clears the hint.
+func (bt *BlockTree) SetBestBlockHint(hash Hash) error {
+	bt.Lock()
+	defer bt.Unlock()
+
+	if hash != (Hash{}) && bt.getNode(hash) == nil {
+		return ErrNodeNotFound
+	}
+
+	bt.bestHint = hash
+	return nil
+}
+
+// deepestLeaf returns the leaf preferred by the fork choice rule. Since the root of the block tree
+// is the last finalised block, the returned leaf always descends from it.
 func (bt *BlockTree) deepestLeaf() *node {
-	return bt.leaves.deepestLeaf()
+	rule := bt.forkChoice
+	if rule == nil {
+		rule = BABEForkChoiceRule
+	}
+
+	var hint *node
+	if bt.bestHint != (Hash{}) {
+		hint = bt.getNode(bt.bestHint)
+	}
+
+	if best := bt.leaves.bestLeaf(rule, hint); best != nil || hint == nil {
+		return best
+	}
+
+	return bt.leaves.bestLeaf(rule, nil)
 }
 
-// DeepestBlockHash returns the hash of the deepest block in the blocktree
-// If there is multiple deepest blocks, it returns the one with the earliest arrival time.
+// DeepestBlockHash returns the hash of the best block in the blocktree according to its fork choice rule.
+// By default, it is the block whose chain contains the most BABE primary slot blocks. If there are
+// multiple such blocks, it returns the deepest one, and then the one with the earliest arrival time.
 func (bt *BlockTree) DeepestBlockHash() Hash {
 	bt.RLock()
 	defer bt.RUnlock()
@@ -245,7 +296,7 @@ func (bt *BlockTree) DeepestBlockHash() Hash {
 		return Hash{}
 	}
 
-	deepest := bt.leaves.deepestLeaf()
+	deepest := bt.deepestLeaf()
 	if deepest == nil {
 		return Hash{}
 	}
@@ -326,7 +377,7 @@ func (bt *BlockTree) GetHashByNumber(num *big.Int) (common.Hash, error) {
 	bt.RLock()
 	defer bt.RUnlock()
 
-	deepest := bt.leaves.deepestLeaf()
+	deepest := bt.deepestLeaf()
 	if deepest.number.Cmp(num) == -1 {
 		return common.Hash{}, ErrNumGreaterThanHighest
 	}
@@ -375,7 +426,10 @@ func (bt *BlockTree) DeepCopy() *BlockTree {
 	bt.RLock()
 	defer bt.RUnlock()
 
-	btCopy := &BlockTree{}
+	btCopy := &BlockTree{
+		forkChoice: bt.forkChoice,
+		bestHint:   bt.bestHint,
+	}
 
 	if bt.root == nil {
 		return btCopy
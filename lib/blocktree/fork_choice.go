@@ -0,0 +1,63 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package blocktree
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+)
+
+// ChainHead describes a leaf of the block tree and the chain leading to it
+type ChainHead struct {
+	Hash   Hash
+	Number *big.Int
+	// PrimarySlots is the number of blocks in the chain authored in a BABE primary slot
+	PrimarySlots uint64
+	ArrivalTime  time.Time
+}
+
+// ForkChoiceRule reports whether the chain ending in a is preferred over the chain ending in b
+type ForkChoiceRule func(a, b *ChainHead) bool
+
+// LongestChainRule prefers the longest chain. If two chains have the same length,
+// it prefers the one whose head arrived first.
+func LongestChainRule(a, b *ChainHead) bool {
+	if cmp := a.Number.Cmp(b.Number); cmp != 0 {
+		return cmp > 0
+	}
+
+	return a.ArrivalTime.Before(b.ArrivalTime)
+}
+
+// BABEForkChoiceRule prefers the chain with the most blocks authored in BABE primary slots,
+// as Substrate does. If two chains have the same number of primary blocks, it falls back to LongestChainRule.
+func BABEForkChoiceRule(a, b *ChainHead) bool {
+	if a.PrimarySlots != b.PrimarySlots {
+		return a.PrimarySlots > b.PrimarySlots
+	}
+
+	return LongestChainRule(a, b)
+}
+
+// isPrimarySlotBlock returns true if the header contains a BABE primary pre-digest
+func isPrimarySlotBlock(header *types.Header) bool {
+	for _, d := range header.Digest.Types {
+		preDigest, ok := d.Value().(types.PreRuntimeDigest)
+		if !ok || preDigest.ConsensusEngineID != types.BabeEngineID {
+			continue
+		}
+
+		babePreDigest, err := types.DecodeBabePreDigest(preDigest.Data)
+		if err != nil {
+			return false
+		}
+
+		_, ok = babePreDigest.(types.BabePrimaryPreDigest)
+		return ok
+	}
+
+	return false
+}
@@ -0,0 +1,133 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package blocktree
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestBabeHeader(t *testing.T, parent *types.Header, slot uint64, primary bool) *types.Header {
+	t.Helper()
+
+	var (
+		preDigest *types.PreRuntimeDigest
+		err       error
+	)
+
+	if primary {
+		preDigest, err = types.NewBabePrimaryPreDigest(0, slot, [32]byte{}, [64]byte{}).ToPreRuntimeDigest()
+	} else {
+		preDigest, err = types.NewBabeSecondaryPlainPreDigest(0, slot).ToPreRuntimeDigest()
+	}
+	require.NoError(t, err)
+
+	digest := types.NewDigest()
+	require.NoError(t, digest.Add(*preDigest))
+
+	return &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     big.NewInt(0).Add(parent.Number, big.NewInt(1)),
+		Digest:     digest,
+	}
+}
+
+// createForkedBlockTree creates a tree with a fork of three secondary slot blocks
+// and a fork of two blocks, one of which was authored in a primary slot
+func createForkedBlockTree(t *testing.T) (bt *BlockTree, secondary, primary []common.Hash) {
+	t.Helper()
+
+	bt = NewBlockTreeFromRoot(testHeader)
+
+	parent := testHeader
+	for i := uint64(1); i <= 3; i++ {
+		header := newTestBabeHeader(t, parent, i, false)
+		require.NoError(t, bt.AddBlock(header, time.Unix(int64(i), 0)))
+		secondary = append(secondary, header.Hash())
+		parent = header
+	}
+
+	parent = testHeader
+	for i := uint64(1); i <= 2; i++ {
+		header := newTestBabeHeader(t, parent, i+10, i == 2)
+		require.NoError(t, bt.AddBlock(header, time.Unix(int64(i+10), 0)))
+		primary = append(primary, header.Hash())
+		parent = header
+	}
+
+	return bt, secondary, primary
+}
+
+func TestBlockTree_ForkChoiceRule(t *testing.T) {
+	bt, secondary, primary := createForkedBlockTree(t)
+
+	require.Equal(t, primary[1], bt.DeepestBlockHash())
+
+	hash, err := bt.GetHashByNumber(big.NewInt(1))
+	require.NoError(t, err)
+	require.Equal(t, primary[0], hash)
+
+	bt.SetForkChoiceRule(LongestChainRule)
+	require.Equal(t, secondary[2], bt.DeepestBlockHash())
+}
+
+func TestBlockTree_SetBestBlockHint(t *testing.T) {
+	bt, secondary, primary := createForkedBlockTree(t)
+
+	err := bt.SetBestBlockHint(common.Hash{0xff})
+	require.ErrorIs(t, err, ErrNodeNotFound)
+
+	err = bt.SetBestBlockHint(secondary[0])
+	require.NoError(t, err)
+	require.Equal(t, secondary[2], bt.DeepestBlockHash())
+
+	// the hint is dropped once it is pruned by finalisation
+	bt.Prune(primary[0])
+	require.Equal(t, Hash{}, bt.bestHint)
+	require.Equal(t, primary[1], bt.DeepestBlockHash())
+
+	err = bt.SetBestBlockHint(Hash{})
+	require.NoError(t, err)
+}
+
+func TestBABEForkChoiceRule(t *testing.T) {
+	now := time.Now()
+
+	testCases := []struct {
+		name     string
+		a, b     *ChainHead
+		expected bool
+	}{
+		{
+			name:     "more primary slots",
+			a:        &ChainHead{Number: big.NewInt(1), PrimarySlots: 1, ArrivalTime: now},
+			b:        &ChainHead{Number: big.NewInt(2), PrimarySlots: 0, ArrivalTime: now},
+			expected: true,
+		},
+		{
+			name:     "same primary slots, longer chain",
+			a:        &ChainHead{Number: big.NewInt(3), PrimarySlots: 1, ArrivalTime: now},
+			b:        &ChainHead{Number: big.NewInt(2), PrimarySlots: 1, ArrivalTime: now},
+			expected: true,
+		},
+		{
+			name:     "same primary slots and length, later arrival",
+			a:        &ChainHead{Number: big.NewInt(2), PrimarySlots: 1, ArrivalTime: now.Add(time.Second)},
+			b:        &ChainHead{Number: big.NewInt(2), PrimarySlots: 1, ArrivalTime: now},
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.expected, BABEForkChoiceRule(tc.a, tc.b))
+		})
+	}
+}
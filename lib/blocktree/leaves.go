@@ -5,7 +5,6 @@ package blocktree
 
 import (
 	"errors"
-	"math/big"
 	"sync"
 
 	"github.com/ChainSafe/gossamer/lib/common"
@@ -56,48 +55,45 @@ func (lm *leafMap) replace(oldNode, newNode *node) {
 	lm.store(newNode.hash, newNode)
 }
 
-// DeepestLeaf searches the stored leaves to the find the one with the greatest number.
-// If there are two leaves with the same number, choose the one with the earliest arrival time.
-func (lm *leafMap) deepestLeaf() *node {
+// bestLeaf searches the stored leaves descending from the given node for the one preferred by the
+// fork choice rule. The previously chosen leaf is kept unless a strictly better leaf is found.
+func (lm *leafMap) bestLeaf(better ForkChoiceRule, ancestor *node) *node {
 	lm.RLock()
 	defer lm.RUnlock()
 
-	max := big.NewInt(-1)
+	var (
+		best       *node
+		currentSet bool
+	)
 
-	var deepest *node
 	lm.smap.Range(func(h, n interface{}) bool {
 		if n == nil {
 			return true
 		}
 
 		node := n.(*node)
+		if ancestor != nil && !node.isDescendantOf(ancestor) {
+			return true
+		}
+
+		if node == lm.currentDeepestLeaf {
+			currentSet = true
+		}
 
-		if max.Cmp(node.number) < 0 {
-			max = node.number
-			deepest = node
-		} else if max.Cmp(node.number) == 0 && node.arrivalTime.Before(deepest.arrivalTime) {
-			deepest = node
+		if best == nil || better(node.chainHead(), best.chainHead()) {
+			best = node
 		}
 
 		return true
 	})
 
-	if lm.currentDeepestLeaf != nil {
-		if lm.currentDeepestLeaf.hash == deepest.hash {
-			return lm.currentDeepestLeaf
-		}
+	if best == nil {
+		return nil
+	}
 
-		// update the current deepest leaf if the found deepest has a greater number or
-		// if the current and the found deepest has the same number however the current
-		// arrived later then the found deepest
-		if deepest.number.Cmp(lm.currentDeepestLeaf.number) == 1 {
-			lm.currentDeepestLeaf = deepest
-		} else if deepest.number.Cmp(lm.currentDeepestLeaf.number) == 0 &&
-			deepest.arrivalTime.Before(lm.currentDeepestLeaf.arrivalTime) {
-			lm.currentDeepestLeaf = deepest
-		}
-	} else {
-		lm.currentDeepestLeaf = deepest
+	// update the current deepest leaf if it is no longer a candidate or if the found leaf is preferred to it
+	if !currentSet || better(best.chainHead(), lm.currentDeepestLeaf.chainHead()) {
+		lm.currentDeepestLeaf = best
 	}
 
 	return lm.currentDeepestLeaf
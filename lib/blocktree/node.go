@@ -19,6 +19,8 @@ type node struct {
 	children    []*node     // Nodes of children blocks
 	number      *big.Int    // block number
 	arrivalTime time.Time   // Arrival time of the block
+	// number of blocks authored in a BABE primary slot from the root up to and including this block
+	primarySlots uint64
 }
 
 // addChild appends Node to n's list of children
@@ -31,6 +33,16 @@ func (n *node) string() string {
 	return fmt.Sprintf("{hash: %s, number: %s, arrivalTime: %s}", n.hash.String(), n.number, n.arrivalTime)
 }
 
+// chainHead returns the description of the chain ending in n used by fork choice rules
+func (n *node) chainHead() *ChainHead {
+	return &ChainHead{
+		Hash:         n.hash,
+		Number:       n.number,
+		PrimarySlots: n.primarySlots,
+		ArrivalTime:  n.arrivalTime,
+	}
+}
+
 // createTree adds all the nodes children to the existing printable tree.
 // Note: this is strictly for BlockTree.String()
 func (n *node) createTree(tree gotree.Tree) {
@@ -180,6 +192,7 @@ func (n *node) deepCopy(parent *node) *node {
 	nCopy := new(node)
 	nCopy.hash = n.hash
 	nCopy.arrivalTime = n.arrivalTime
+	nCopy.primarySlots = n.primarySlots
 
 	if n.number != nil {
 		nCopy.number = new(big.Int).Set(n.number)
@@ -0,0 +1,88 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+// Package cht implements the canonical hash tries (CHT) used to prove to light clients that a header
+// is part of the canonical chain. A CHT is a trie mapping each block number of a range of Size
+// finalised blocks to the hash of the canonical block at that number.
+package cht
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+)
+
+// Size is the number of blocks covered by a CHT
+const Size = 2048
+
+var (
+	// ErrGenesisBlock is returned when the CHT of the genesis block is requested, it isn't part of any CHT
+	ErrGenesisBlock = errors.New("genesis block is not part of a CHT")
+	// ErrInvalidHashCount is returned when a CHT is built from a number of hashes other than Size
+	ErrInvalidHashCount = fmt.Errorf("a CHT must be built from %d block hashes", Size)
+)
+
+// Number returns the number of the CHT holding the given block
+func Number(block uint32) (uint32, error) {
+	if block == 0 {
+		return 0, ErrGenesisBlock
+	}
+
+	return (block - 1) / Size, nil
+}
+
+// BlockRange returns the first and last block numbers covered by the given CHT
+func BlockRange(chtNumber uint32) (first, last uint32) {
+	first = chtNumber*Size + 1
+	return first, first + Size - 1
+}
+
+// Key returns the key of the given block number in a CHT
+func Key(block uint32) []byte {
+	key := make([]byte, 4)
+	binary.LittleEndian.PutUint32(key, block)
+	return key
+}
+
+// BuildTrie returns the CHT of the given number, built from the hashes of the blocks it covers
+// ordered by block number
+func BuildTrie(chtNumber uint32, hashes []common.Hash) (*trie.Trie, error) {
+	if len(hashes) != Size {
+		return nil, ErrInvalidHashCount
+	}
+
+	first, _ := BlockRange(chtNumber)
+
+	t := trie.NewEmptyTrie()
+	for i, hash := range hashes {
+		t.Put(Key(first+uint32(i)), hash.ToBytes())
+	}
+
+	return t, nil
+}
+
+// GenerateProof returns the proof of the hash of the given block in the CHT
+func GenerateProof(t *trie.Trie, block uint32) ([][]byte, error) {
+	return trie.GenerateProofFromTrie(t, [][]byte{Key(block)})
+}
+
+// VerifyProof checks that the proof shows the given hash to be the canonical block hash at the
+// given number in the CHT with the given root
+func VerifyProof(root common.Hash, block uint32, hash common.Hash, proof [][]byte) error {
+	ok, err := trie.VerifyProof(proof, root.ToBytes(), []trie.Pair{{
+		Key:   Key(block),
+		Value: hash.ToBytes(),
+	}})
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return fmt.Errorf("invalid CHT proof for block %d", block)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package cht
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNumber(t *testing.T) {
+	_, err := Number(0)
+	require.ErrorIs(t, err, ErrGenesisBlock)
+
+	for block, expected := range map[uint32]uint32{1: 0, Size: 0, Size + 1: 1, 3 * Size: 2} {
+		num, err := Number(block)
+		require.NoError(t, err)
+		require.Equal(t, expected, num)
+	}
+
+	first, last := BlockRange(1)
+	require.Equal(t, uint32(Size+1), first)
+	require.Equal(t, uint32(2*Size), last)
+}
+
+func TestGenerateAndVerifyProof(t *testing.T) {
+	_, err := BuildTrie(1, make([]common.Hash, Size-1))
+	require.ErrorIs(t, err, ErrInvalidHashCount)
+
+	hashes := make([]common.Hash, Size)
+	for i := range hashes {
+		hashes[i] = common.Hash{byte(i), byte(i >> 8), 1}
+	}
+
+	tr, err := BuildTrie(1, hashes)
+	require.NoError(t, err)
+	root, err := tr.Hash()
+	require.NoError(t, err)
+
+	block := uint32(Size + 10)
+	proof, err := GenerateProof(tr, block)
+	require.NoError(t, err)
+	require.NotEmpty(t, proof)
+
+	err = VerifyProof(root, block, hashes[9], proof)
+	require.NoError(t, err)
+
+	err = VerifyProof(root, block, hashes[10], proof)
+	require.Error(t, err)
+}
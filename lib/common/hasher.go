@@ -12,6 +12,21 @@ import (
 	"golang.org/x/crypto/sha3"
 )
 
+// Blake2b8 returns the 64-bit blake2b hash of the input data
+func Blake2b8(in []byte) ([]byte, error) {
+	h, err := blake2b.New(8, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = h.Write(in)
+	if err != nil {
+		return nil, err
+	}
+
+	return h.Sum(nil), nil
+}
+
 // Blake2b128 returns the 128-bit blake2b hash of the input data
 func Blake2b128(in []byte) ([]byte, error) {
 	h, err := blake2b.New(16, nil)
@@ -156,6 +156,33 @@ func NewPublicKey(in []byte) (*PublicKey, error) {
 	return &pub, nil
 }
 
+// NewKeypairFromSecretURI returns the ed25519 keypair derived from the given secret URI, such as //Alice.
+// Only hard junctions are supported, each of them deriving the seed of the next key.
+func NewKeypairFromSecretURI(suri string) (*Keypair, error) {
+	s, err := crypto.ParseSecretURI(suri)
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := s.Seed()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, j := range s.Junctions {
+		if !j.Hard {
+			return nil, fmt.Errorf("%w: soft derivation is not supported for ed25519 keys", crypto.ErrInvalidSecretURI)
+		}
+
+		seed, err = crypto.HardDeriveSeed("Ed25519HDKD", seed, j.ChainCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewKeypairFromSeed(seed)
+}
+
 // NewPrivateKey returns an ed25519 private key that consists of the input bytes
 // Input length must be 64 bytes
 func NewPrivateKey(in []byte) (*PrivateKey, error) {
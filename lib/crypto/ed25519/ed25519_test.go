@@ -160,3 +160,18 @@ func TestVerifySignature(t *testing.T) {
 	}
 
 }
+
+func TestNewKeypairFromSecretURI(t *testing.T) {
+	kp, err := NewKeypairFromSecretURI("//Alice")
+	require.NoError(t, err)
+	require.Equal(t, "0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee", kp.Public().Hex())
+
+	root, err := NewKeypairFromSecretURI(crypto.DevPhrase)
+	require.NoError(t, err)
+	expected, err := NewKeypairFromMnenomic(crypto.DevPhrase, "")
+	require.NoError(t, err)
+	require.Equal(t, expected.Public().Hex(), root.Public().Hex())
+
+	_, err = NewKeypairFromSecretURI("//Alice/soft")
+	require.ErrorIs(t, err, crypto.ErrInvalidSecretURI)
+}
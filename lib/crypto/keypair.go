@@ -6,9 +6,7 @@ package crypto
 import (
 	"github.com/ChainSafe/gossamer/lib/common"
 
-	"github.com/btcsuite/btcutil/base58"
 	bip39 "github.com/cosmos/go-bip39"
-	"golang.org/x/crypto/blake2b"
 )
 
 // KeyType str
@@ -52,29 +50,25 @@ type PrivateKey interface {
 	Hex() string
 }
 
-var ss58Prefix = []byte("SS58PRE")
-
-// PublicKeyToAddress returns an ss58 address given a PublicKey
+// PublicKeyToAddress returns the ss58 address of the given PublicKey for the SS58 address format of the chain,
+// or an empty address if the public key cannot be encoded
 // see: https://github.com/paritytech/substrate/wiki/External-Address-Format-(SS58)
 // also see: https://github.com/paritytech/substrate/blob/master/primitives/core/src/crypto.rs#L275
 func PublicKeyToAddress(pub PublicKey) common.Address {
-	enc := append([]byte{42}, pub.Encode()...)
-	hasher, err := blake2b.New(64, nil)
-	if err != nil {
-		return ""
-	}
-	_, err = hasher.Write(append(ss58Prefix, enc...))
+	addr, err := EncodeSS58(pub.Encode(), SS58Format())
 	if err != nil {
 		return ""
 	}
-	checksum := hasher.Sum(nil)
-	return common.Address(base58.Encode(append(enc, checksum[:2]...)))
+	return addr
 }
 
-// PublicAddressToByteArray returns []byte address for given PublicKey Address
+// PublicAddressToByteArray returns []byte address for given PublicKey Address, or nil if the address is invalid
 func PublicAddressToByteArray(add common.Address) []byte {
-	k := base58.Decode(string(add))
-	return k[1:33]
+	pub, _, err := DecodeSS58(add)
+	if err != nil {
+		return nil
+	}
+	return pub
 }
 
 // NewBIP39Mnemonic returns a new BIP39-compatible mnemonic
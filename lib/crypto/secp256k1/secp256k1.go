@@ -102,6 +102,38 @@ func NewKeypairFromPrivate(priv *PrivateKey) (*Keypair, error) {
 	}, nil
 }
 
+// NewKeypairFromSecretURI returns the secp256k1 keypair derived from the given secret URI, such as //Alice.
+// Only hard junctions are supported, each of them deriving the seed of the next key.
+func NewKeypairFromSecretURI(suri string) (*Keypair, error) {
+	s, err := crypto.ParseSecretURI(suri)
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := s.Seed()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, j := range s.Junctions {
+		if !j.Hard {
+			return nil, fmt.Errorf("%w: soft derivation is not supported for secp256k1 keys", crypto.ErrInvalidSecretURI)
+		}
+
+		seed, err = crypto.HardDeriveSeed("Secp256k1HDKD", seed, j.ChainCode)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	priv, err := NewPrivateKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKeypairFromPrivate(priv)
+}
+
 // NewPrivateKey will return a PrivateKey for a []byte
 func NewPrivateKey(in []byte) (*PrivateKey, error) {
 	if len(in) != PrivateKeyLength {
@@ -201,3 +201,12 @@ func TestVerifySignature(t *testing.T) {
 	}
 
 }
+
+func TestNewKeypairFromSecretURI(t *testing.T) {
+	kp, err := NewKeypairFromSecretURI("//Alice")
+	require.NoError(t, err)
+	require.Equal(t, "0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1", kp.Public().Hex())
+
+	_, err = NewKeypairFromSecretURI("//Alice/soft")
+	require.ErrorIs(t, err, crypto.ErrInvalidSecretURI)
+}
@@ -13,6 +13,7 @@ import (
 
 	sr25519 "github.com/ChainSafe/go-schnorrkel"
 	"github.com/gtank/merlin"
+	"golang.org/x/crypto/blake2b"
 )
 
 const (
@@ -159,6 +160,52 @@ func NewKeypairFromMnenomic(mnemonic, password string) (*Keypair, error) {
 	}, nil
 }
 
+// NewKeypairFromSecretURI returns the sr25519 keypair derived from the given secret URI, such as //Alice.
+// Hard junctions derive a mini secret key from the secret key, and soft junctions derive the key simply.
+func NewKeypairFromSecretURI(suri string) (*Keypair, error) {
+	s, err := crypto.ParseSecretURI(suri)
+	if err != nil {
+		return nil, err
+	}
+
+	seed, err := s.Seed()
+	if err != nil {
+		return nil, err
+	}
+
+	buf := [SeedLength]byte{}
+	copy(buf[:], seed)
+	msc, err := sr25519.NewMiniSecretKeyFromRaw(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	priv := msc.ExpandEd25519()
+	for _, j := range s.Junctions {
+		if j.Hard {
+			msc, _, err = priv.HardDeriveMiniSecretKey([]byte{}, j.ChainCode)
+			if err != nil {
+				return nil, err
+			}
+
+			priv = msc.ExpandEd25519()
+			continue
+		}
+
+		ek, err := sr25519.DeriveKeySimple(priv, []byte{}, j.ChainCode)
+		if err != nil {
+			return nil, err
+		}
+
+		priv, err = ek.Secret()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return NewKeypair(priv)
+}
+
 // NewPrivateKey creates a new private key using the input bytes
 func NewPrivateKey(in []byte) (*PrivateKey, error) {
 	if len(in) != PrivateKeyLength {
@@ -276,6 +323,57 @@ func (k *PrivateKey) Decode(in []byte) error {
 	return k.key.Decode(b)
 }
 
+// EncodeEd25519 returns the 64-byte encoding of the private key in the ed25519 compatible format of schnorrkel,
+// used by polkadot.js, which is the key multiplied by the cofactor followed by the nonce. Since the nonce of a
+// private key is not kept, it is derived from the key; it is only used as randomness when signing.
+func (k *PrivateKey) EncodeEd25519() []byte {
+	if k.key == nil {
+		return nil
+	}
+
+	key := k.key.Encode()
+	nonce := blake2b.Sum256(key[:])
+	multiplyScalarBytesByCofactor(&key)
+	return append(key[:], nonce[:]...)
+}
+
+// NewPrivateKeyFromEd25519Bytes returns the private key encoded in the ed25519 compatible format of schnorrkel,
+// which is the key multiplied by the cofactor followed by the nonce
+func NewPrivateKeyFromEd25519Bytes(in []byte) (*PrivateKey, error) {
+	if len(in) != 2*PrivateKeyLength {
+		return nil, errors.New("input to sr25519 ed25519 bytes decode is not 64 bytes")
+	}
+
+	key := [PrivateKeyLength]byte{}
+	copy(key[:], in[:PrivateKeyLength])
+	divideScalarBytesByCofactor(&key)
+
+	nonce := [32]byte{}
+	copy(nonce[:], in[PrivateKeyLength:])
+
+	return &PrivateKey{key: sr25519.NewSecretKey(key, nonce)}, nil
+}
+
+// multiplyScalarBytesByCofactor multiplies the little endian scalar by the cofactor 8
+func multiplyScalarBytesByCofactor(scalar *[PrivateKeyLength]byte) {
+	var high byte
+	for i := range scalar {
+		r := scalar[i] & 0xe0
+		scalar[i] = scalar[i]<<3 + high
+		high = r >> 5
+	}
+}
+
+// divideScalarBytesByCofactor divides the little endian scalar by the cofactor 8
+func divideScalarBytesByCofactor(scalar *[PrivateKeyLength]byte) {
+	var low byte
+	for i := len(scalar) - 1; i >= 0; i-- {
+		r := scalar[i] & 0x07
+		scalar[i] = scalar[i]>>3 + low
+		low = r << 5
+	}
+}
+
 // Hex returns the private key as a '0x' prefixed hex string
 func (k *PrivateKey) Hex() string {
 	enc := k.Encode()
@@ -5,11 +5,14 @@ package sr25519
 
 import (
 	"crypto/rand"
+	"crypto/sha512"
 	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/ChainSafe/gossamer/lib/crypto"
+
+	sr25519 "github.com/ChainSafe/go-schnorrkel"
 	bip39 "github.com/cosmos/go-bip39"
 	"github.com/gtank/merlin"
 	"github.com/stretchr/testify/require"
@@ -182,3 +185,71 @@ func TestVerifySignature(t *testing.T) {
 	}
 
 }
+
+func TestNewKeypairFromSecretURI(t *testing.T) {
+	alice, err := NewKeypairFromSecretURI("//Alice")
+	require.NoError(t, err)
+	require.Equal(t, "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d", alice.Public().Hex())
+
+	root, err := NewKeypairFromSecretURI(crypto.DevPhrase)
+	require.NoError(t, err)
+	expected, err := NewKeypairFromMnenomic(crypto.DevPhrase, "")
+	require.NoError(t, err)
+	require.Equal(t, expected.Public().Hex(), root.Public().Hex())
+
+	seed := make([]byte, 32)
+	_, err = rand.Read(seed)
+	require.NoError(t, err)
+	kp, err := NewKeypairFromSecretURI(fmt.Sprintf("0x%x", seed))
+	require.NoError(t, err)
+	expected, err = NewKeypairFromSeed(seed)
+	require.NoError(t, err)
+	require.Equal(t, expected.Public().Hex(), kp.Public().Hex())
+
+	// a soft junction can be derived from the public key alone
+	soft, err := NewKeypairFromSecretURI("//Alice/soft")
+	require.NoError(t, err)
+	j, err := crypto.NewDeriveJunction("soft", false)
+	require.NoError(t, err)
+	ek, err := sr25519.DeriveKeySimple(alice.public.key, []byte{}, j.ChainCode)
+	require.NoError(t, err)
+	pub, err := ek.Public()
+	require.NoError(t, err)
+	require.Equal(t, pub.Encode(), soft.public.key.Encode())
+
+	msg := []byte("helloworld")
+	sig, err := soft.Sign(msg)
+	require.NoError(t, err)
+	ok, err := soft.Public().Verify(msg, sig)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = NewKeypairFromSecretURI("0x1234//Alice")
+	require.ErrorIs(t, err, crypto.ErrInvalidSecretURI)
+}
+
+func TestPrivateKey_EncodeEd25519(t *testing.T) {
+	seed := make([]byte, 32)
+	_, err := rand.Read(seed)
+	require.NoError(t, err)
+
+	kp, err := NewKeypairFromSeed(seed)
+	require.NoError(t, err)
+
+	// the ed25519 expansion of a mini secret key is the clamped sha512 hash of the mini secret key,
+	// divided by the cofactor
+	enc := kp.private.EncodeEd25519()
+	require.Len(t, enc, 64)
+	h := sha512.Sum512(seed)
+	h[0] &= 248
+	h[31] &= 63
+	h[31] |= 64
+	require.Equal(t, h[:32], enc[:32])
+
+	priv, err := NewPrivateKeyFromEd25519Bytes(enc)
+	require.NoError(t, err)
+	require.Equal(t, kp.private.Encode(), priv.Encode())
+
+	_, err = NewPrivateKeyFromEd25519Bytes(enc[:32])
+	require.Error(t, err)
+}
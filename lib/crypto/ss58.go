@@ -0,0 +1,163 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package crypto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+
+	"github.com/btcsuite/btcutil/base58"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// DefaultSS58Format is the SS58 address format of the generic substrate chains
+	DefaultSS58Format uint16 = 42
+	// MaxSS58Format is the highest SS58 address format, which is encoded with two bytes
+	MaxSS58Format uint16 = 16383
+
+	ss58ChecksumLength = 2
+)
+
+var (
+	// ErrInvalidSS58Format is returned when an SS58 address format is out of range or reserved
+	ErrInvalidSS58Format = errors.New("invalid ss58 format")
+	// ErrInvalidSS58Length is returned when an SS58 address does not hold a 32 or 33 byte payload
+	ErrInvalidSS58Length = errors.New("invalid ss58 address length")
+	// ErrInvalidSS58Checksum is returned when the checksum of an SS58 address does not match its content
+	ErrInvalidSS58Checksum = errors.New("invalid ss58 checksum")
+
+	ss58Prefix = []byte("SS58PRE")
+
+	// ss58Format is the SS58 address format of the chain, used to encode addresses
+	ss58Format = uint32(DefaultSS58Format)
+)
+
+// SetSS58Format sets the SS58 address format used to encode the addresses of public keys,
+// which is the ss58Format of the properties of the chain genesis
+func SetSS58Format(format uint16) error {
+	if !isValidSS58Format(format) {
+		return fmt.Errorf("%w: %d", ErrInvalidSS58Format, format)
+	}
+
+	atomic.StoreUint32(&ss58Format, uint32(format))
+	return nil
+}
+
+// SS58Format returns the SS58 address format used to encode the addresses of public keys
+func SS58Format() uint16 {
+	return uint16(atomic.LoadUint32(&ss58Format))
+}
+
+// EncodeSS58 returns the SS58 address of the given 32 byte public key, or 33 byte ECDSA public key, for the
+// given address format.
+// see: https://docs.substrate.io/v3/advanced/ss58/
+func EncodeSS58(payload []byte, format uint16) (common.Address, error) {
+	if !isValidSS58Format(format) {
+		return "", fmt.Errorf("%w: %d", ErrInvalidSS58Format, format)
+	}
+
+	if len(payload) != 32 && len(payload) != 33 {
+		return "", fmt.Errorf("%w: payload of %d bytes", ErrInvalidSS58Length, len(payload))
+	}
+
+	var prefix []byte
+	if format < 64 {
+		prefix = []byte{byte(format)}
+	} else {
+		// the six lower bits of the first byte hold the bits 2 to 7 of the format, the
+		// two upper bits of the second byte the bits 0 and 1 and its six lower bits the bits 8 to 13
+		prefix = []byte{
+			byte((format&0xfc)>>2) | 0x40,
+			byte(format>>8) | byte((format&0x03)<<6),
+		}
+	}
+
+	enc := make([]byte, 0, len(prefix)+len(payload)+ss58ChecksumLength)
+	enc = append(enc, prefix...)
+	enc = append(enc, payload...)
+
+	checksum, err := ss58Checksum(enc)
+	if err != nil {
+		return "", err
+	}
+
+	return common.Address(base58.Encode(append(enc, checksum...))), nil
+}
+
+// DecodeSS58 returns the public key encoded in the given SS58 address and the address format.
+// It returns an error if the address is malformed or its checksum does not match.
+func DecodeSS58(addr common.Address) (payload []byte, format uint16, err error) {
+	data := base58.Decode(string(addr))
+	if len(data) == 0 {
+		return nil, 0, fmt.Errorf("%w: cannot decode base58 address", ErrInvalidSS58Length)
+	}
+
+	prefixLength := 1
+	switch {
+	case data[0] < 64:
+		format = uint16(data[0])
+	case data[0] < 128:
+		if len(data) < 2 {
+			return nil, 0, ErrInvalidSS58Length
+		}
+
+		lower := (data[0] << 2) | (data[1] >> 6)
+		upper := data[1] & 0x3f
+		format = uint16(lower) | uint16(upper)<<8
+		prefixLength = 2
+	default:
+		return nil, 0, fmt.Errorf("%w: prefix byte 0x%x", ErrInvalidSS58Format, data[0])
+	}
+
+	if !isValidSS58Format(format) {
+		return nil, 0, fmt.Errorf("%w: %d", ErrInvalidSS58Format, format)
+	}
+
+	payloadLength := len(data) - prefixLength - ss58ChecksumLength
+	if payloadLength != 32 && payloadLength != 33 {
+		return nil, 0, fmt.Errorf("%w: payload of %d bytes", ErrInvalidSS58Length, payloadLength)
+	}
+
+	checksumStart := len(data) - ss58ChecksumLength
+	checksum, err := ss58Checksum(data[:checksumStart])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if !bytes.Equal(checksum, data[checksumStart:]) {
+		return nil, 0, ErrInvalidSS58Checksum
+	}
+
+	return data[prefixLength:checksumStart], format, nil
+}
+
+// ss58Checksum returns the checksum of the given SS58 address prefix and payload
+func ss58Checksum(data []byte) ([]byte, error) {
+	hasher, err := blake2b.New(64, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = hasher.Write(ss58Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = hasher.Write(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return hasher.Sum(nil)[:ss58ChecksumLength], nil
+}
+
+// isValidSS58Format returns whether the given SS58 address format can be used. Formats 46 and 47 are reserved.
+func isValidSS58Format(format uint16) bool {
+	return format <= MaxSS58Format && format != 46 && format != 47
+}
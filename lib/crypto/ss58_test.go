@@ -0,0 +1,115 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package crypto_test
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeSS58(t *testing.T) {
+	// public key of the Bob development account
+	pub := common.MustHexToBytes("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
+
+	testCases := []struct {
+		format uint16
+		addr   common.Address
+	}{
+		{0, "14E5nqKAp3oAJcmzgZhUD2RcptBeUBScxKHgJKU4HPNcKVf3"},
+		{2, "FoQJpPyadYccjavVdTWxpxU7rUEaYhfLCPwXgkfD6Zat9QP"},
+		{42, "5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty"},
+	}
+
+	for _, test := range testCases {
+		addr, err := crypto.EncodeSS58(pub, test.format)
+		require.NoError(t, err)
+		require.Equal(t, test.addr, addr)
+
+		dec, format, err := crypto.DecodeSS58(test.addr)
+		require.NoError(t, err)
+		require.Equal(t, pub, dec)
+		require.Equal(t, test.format, format)
+	}
+}
+
+func TestEncodeDecodeSS58_TwoBytePrefix(t *testing.T) {
+	pub := common.MustHexToBytes("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
+
+	for _, f := range []uint16{64, 69, 255, 256, 1337, crypto.MaxSS58Format} {
+		addr, err := crypto.EncodeSS58(pub, f)
+		require.NoError(t, err)
+
+		dec, format, err := crypto.DecodeSS58(addr)
+		require.NoError(t, err)
+		require.Equal(t, pub, dec)
+		require.Equal(t, f, format)
+	}
+}
+
+func TestEncodeDecodeSS58_ECDSA(t *testing.T) {
+	kp, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+
+	pub := kp.Public().Encode()
+	require.Len(t, pub, 33)
+
+	addr, err := crypto.EncodeSS58(pub, 0)
+	require.NoError(t, err)
+
+	dec, format, err := crypto.DecodeSS58(addr)
+	require.NoError(t, err)
+	require.Equal(t, pub, dec)
+	require.Equal(t, uint16(0), format)
+}
+
+func TestEncodeDecodeSS58_Errors(t *testing.T) {
+	pub := common.MustHexToBytes("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48")
+
+	_, err := crypto.EncodeSS58(pub, 46)
+	require.ErrorIs(t, err, crypto.ErrInvalidSS58Format)
+
+	_, err = crypto.EncodeSS58(pub, crypto.MaxSS58Format+1)
+	require.ErrorIs(t, err, crypto.ErrInvalidSS58Format)
+
+	_, err = crypto.EncodeSS58(pub[:20], 0)
+	require.ErrorIs(t, err, crypto.ErrInvalidSS58Length)
+
+	// the last character of the Bob address is modified
+	_, _, err = crypto.DecodeSS58("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694tz")
+	require.ErrorIs(t, err, crypto.ErrInvalidSS58Checksum)
+
+	_, _, err = crypto.DecodeSS58("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92Uhj")
+	require.ErrorIs(t, err, crypto.ErrInvalidSS58Length)
+
+	_, _, err = crypto.DecodeSS58("")
+	require.ErrorIs(t, err, crypto.ErrInvalidSS58Length)
+}
+
+func TestSetSS58Format(t *testing.T) {
+	defer func() {
+		require.NoError(t, crypto.SetSS58Format(crypto.DefaultSS58Format))
+	}()
+
+	pub, err := sr25519.NewPublicKey(
+		common.MustHexToBytes("0x8eaf04151687736326c9fea17e25fc5287613693c912909cb226aa4794f26a48"))
+	require.NoError(t, err)
+
+	require.Equal(t, crypto.DefaultSS58Format, crypto.SS58Format())
+	require.Equal(t, common.Address("5FHneW46xGXgs5mUiveU4sbTyGBzmstUspZC92UhjJM694ty"), pub.Address())
+
+	err = crypto.SetSS58Format(0)
+	require.NoError(t, err)
+	require.Equal(t, common.Address("14E5nqKAp3oAJcmzgZhUD2RcptBeUBScxKHgJKU4HPNcKVf3"), pub.Address())
+	require.Equal(t, pub.Encode(), crypto.PublicAddressToByteArray(pub.Address()))
+
+	err = crypto.SetSS58Format(47)
+	require.ErrorIs(t, err, crypto.ErrInvalidSS58Format)
+	require.Equal(t, uint16(0), crypto.SS58Format())
+}
@@ -0,0 +1,144 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package crypto
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+
+	"github.com/ChainSafe/go-schnorrkel"
+	"golang.org/x/crypto/blake2b"
+)
+
+// DevPhrase is the mnemonic of the development accounts, used by the secret URIs without phrase such as //Alice
+const DevPhrase = "bottom drive obey lake curtain smoke basket hold race lonely fit walk"
+
+// ChainCodeLength is the length of the chain code of a derivation junction
+const ChainCodeLength = 32
+
+// ErrInvalidSecretURI is returned when a secret URI cannot be parsed
+var ErrInvalidSecretURI = errors.New("invalid secret URI")
+
+var (
+	secretURIRegex = regexp.MustCompile(`^([\d\w ]+)?((?://?[^/]+)*)(?:///(.*))?$`)
+	junctionRegex  = regexp.MustCompile(`/(/?[^/]+)`)
+)
+
+// DeriveJunction is a step of a key derivation path. A hard junction, written //<code> in a secret URI, cannot
+// be derived from the public key, whereas a soft junction, written /<code>, can.
+type DeriveJunction struct {
+	ChainCode [ChainCodeLength]byte
+	Hard      bool
+}
+
+// NewDeriveJunction returns the junction for the given code. A numeric code is encoded as a little endian uint64
+// and any other code as a SCALE string, which is hashed with blake2b-256 if it does not fit in a chain code.
+func NewDeriveJunction(code string, hard bool) (DeriveJunction, error) {
+	var (
+		enc []byte
+		err error
+	)
+
+	if n, parseErr := strconv.ParseUint(code, 10, 64); parseErr == nil {
+		enc, err = scale.Marshal(n)
+	} else {
+		enc, err = scale.Marshal(code)
+	}
+	if err != nil {
+		return DeriveJunction{}, err
+	}
+
+	j := DeriveJunction{Hard: hard}
+	if len(enc) > ChainCodeLength {
+		h, err := common.Blake2bHash(enc)
+		if err != nil {
+			return DeriveJunction{}, err
+		}
+		copy(j.ChainCode[:], h[:])
+	} else {
+		copy(j.ChainCode[:], enc)
+	}
+
+	return j, nil
+}
+
+// SecretURI is a parsed secret URI of the form <phrase>//<hard>/<soft>///<password>, where the phrase is either
+// a mnemonic or a 0x prefixed hex encoded 32 byte seed. The phrase defaults to DevPhrase.
+// see: https://docs.substrate.io/v3/tools/subkey/#hd-key-derivation
+type SecretURI struct {
+	Phrase    string
+	Junctions []DeriveJunction
+	Password  string
+}
+
+// ParseSecretURI parses the given secret URI
+func ParseSecretURI(suri string) (*SecretURI, error) {
+	matches := secretURIRegex.FindStringSubmatch(suri)
+	if matches == nil {
+		return nil, ErrInvalidSecretURI
+	}
+
+	s := &SecretURI{
+		Phrase:   matches[1],
+		Password: matches[3],
+	}
+
+	if s.Phrase == "" {
+		s.Phrase = DevPhrase
+	}
+
+	for _, m := range junctionRegex.FindAllStringSubmatch(matches[2], -1) {
+		code := m[1]
+		hard := strings.HasPrefix(code, "/")
+		j, err := NewDeriveJunction(strings.TrimPrefix(code, "/"), hard)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSecretURI, err)
+		}
+
+		s.Junctions = append(s.Junctions, j)
+	}
+
+	return s, nil
+}
+
+// Seed returns the 32 byte seed of the root key of the secret URI. The password is only used with mnemonics.
+func (s *SecretURI) Seed() ([]byte, error) {
+	if strings.HasPrefix(s.Phrase, "0x") {
+		seed, err := common.HexToBytes(s.Phrase)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidSecretURI, err)
+		}
+
+		if len(seed) != 32 {
+			return nil, fmt.Errorf("%w: seed is not 32 bytes long", ErrInvalidSecretURI)
+		}
+
+		return seed, nil
+	}
+
+	seed, err := schnorrkel.SeedFromMnemonic(s.Phrase, s.Password)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidSecretURI, err)
+	}
+
+	return seed[:32], nil
+}
+
+// HardDeriveSeed returns the seed of the hard junction of the given seed, for the key types whose keys are
+// derived from their seed, with the given derivation domain, such as "Ed25519HDKD".
+func HardDeriveSeed(domain string, seed []byte, cc [ChainCodeLength]byte) ([]byte, error) {
+	enc, err := scale.Marshal(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	h := blake2b.Sum256(append(append(enc, seed...), cc[:]...))
+	return h[:], nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package crypto
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDeriveJunction(t *testing.T) {
+	j, err := NewDeriveJunction("Alice", true)
+	require.NoError(t, err)
+	require.True(t, j.Hard)
+	require.Equal(t, append([]byte{5 << 2}, []byte("Alice")...), j.ChainCode[:6])
+	require.Equal(t, make([]byte, 26), j.ChainCode[6:])
+
+	j, err = NewDeriveJunction("1", false)
+	require.NoError(t, err)
+	require.False(t, j.Hard)
+	require.Equal(t, [ChainCodeLength]byte{1}, j.ChainCode)
+
+	// codes longer than a chain code are hashed
+	long := "this code is longer than thirty two bytes"
+	j, err = NewDeriveJunction(long, false)
+	require.NoError(t, err)
+	expected, err := common.Blake2bHash(append([]byte{byte(len(long) << 2)}, long...))
+	require.NoError(t, err)
+	require.Equal(t, [ChainCodeLength]byte(expected), j.ChainCode)
+}
+
+func TestParseSecretURI(t *testing.T) {
+	alice, err := NewDeriveJunction("Alice", true)
+	require.NoError(t, err)
+	one, err := NewDeriveJunction("1", false)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		suri     string
+		expected *SecretURI
+	}{
+		{"", &SecretURI{Phrase: DevPhrase}},
+		{"//Alice", &SecretURI{Phrase: DevPhrase, Junctions: []DeriveJunction{alice}}},
+		{"//Alice/1///pass", &SecretURI{Phrase: DevPhrase, Junctions: []DeriveJunction{alice, one}, Password: "pass"}},
+		{DevPhrase + "///pass", &SecretURI{Phrase: DevPhrase, Password: "pass"}},
+		{"0x1234//Alice", &SecretURI{Phrase: "0x1234", Junctions: []DeriveJunction{alice}}},
+	}
+
+	for _, test := range testCases {
+		s, err := ParseSecretURI(test.suri)
+		require.NoError(t, err, test.suri)
+		require.Equal(t, test.expected, s, test.suri)
+	}
+
+	_, err = ParseSecretURI("bad!phrase")
+	require.ErrorIs(t, err, ErrInvalidSecretURI)
+}
+
+func TestSecretURI_Seed(t *testing.T) {
+	s, err := ParseSecretURI(common.BytesToHex(make([]byte, 32)))
+	require.NoError(t, err)
+	seed, err := s.Seed()
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, 32), seed)
+
+	s, err = ParseSecretURI("0x1234")
+	require.NoError(t, err)
+	_, err = s.Seed()
+	require.ErrorIs(t, err, ErrInvalidSecretURI)
+
+	s, err = ParseSecretURI("not a mnemonic")
+	require.NoError(t, err)
+	_, err = s.Seed()
+	require.ErrorIs(t, err, ErrInvalidSecretURI)
+
+	withPassword, err := ParseSecretURI("///pass")
+	require.NoError(t, err)
+	seed, err = withPassword.Seed()
+	require.NoError(t, err)
+
+	s, err = ParseSecretURI("")
+	require.NoError(t, err)
+	devSeed, err := s.Seed()
+	require.NoError(t, err)
+	require.NotEqual(t, devSeed, seed)
+}
@@ -15,3 +15,41 @@ func AppendUint64(t *merlin.Transcript, label []byte, n uint64) {
 	binary.LittleEndian.PutUint64(buf, n)
 	t.AppendMessage(label, buf)
 }
+
+// VRFTranscriptItem is a labelled message of a VRF transcript
+type VRFTranscriptItem struct {
+	Label   string `json:"label"`
+	Message []byte `json:"message"`
+}
+
+// VRFTranscript holds the data of a merlin transcript, so that it can be sent to a signer which evaluates the VRF
+type VRFTranscript struct {
+	Label string              `json:"label"`
+	Items []VRFTranscriptItem `json:"items"`
+}
+
+// NewVRFTranscript returns a new VRF transcript with the given label
+func NewVRFTranscript(label string) *VRFTranscript {
+	return &VRFTranscript{Label: label}
+}
+
+// AppendMessage appends a message to the transcript using the given label
+func (t *VRFTranscript) AppendMessage(label string, msg []byte) {
+	t.Items = append(t.Items, VRFTranscriptItem{Label: label, Message: msg})
+}
+
+// AppendUint64 appends a uint64 to the transcript using the given label
+func (t *VRFTranscript) AppendUint64(label string, n uint64) {
+	buf := make([]byte, 8)
+	binary.LittleEndian.PutUint64(buf, n)
+	t.AppendMessage(label, buf)
+}
+
+// Merlin returns the merlin transcript of the transcript data
+func (t *VRFTranscript) Merlin() *merlin.Transcript {
+	mt := merlin.NewTranscript(t.Label)
+	for _, item := range t.Items {
+		mt.AppendMessage([]byte(item.Label), item.Message)
+	}
+	return mt
+}
@@ -0,0 +1,33 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package crypto
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gtank/merlin"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVRFTranscript_Merlin(t *testing.T) {
+	expected := merlin.NewTranscript("BABE")
+	AppendUint64(expected, []byte("slot number"), 77)
+	expected.AppendMessage([]byte("chain randomness"), []byte{1, 2, 3})
+
+	data := NewVRFTranscript("BABE")
+	data.AppendUint64("slot number", 77)
+	data.AppendMessage("chain randomness", []byte{1, 2, 3})
+
+	// the transcript data is sent to remote signers as JSON
+	enc, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	decoded := new(VRFTranscript)
+	err = json.Unmarshal(enc, decoded)
+	require.NoError(t, err)
+	require.Equal(t, data, decoded)
+
+	require.Equal(t, expected.ExtractBytes([]byte("test"), 32), decoded.Merlin().ExtractBytes([]byte("test"), 32))
+}
@@ -4,7 +4,11 @@
 package genesis
 
 import (
+	"fmt"
+	"math"
+
 	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
 )
 
 // Genesis stores the data parsed from the genesis configuration file
@@ -94,6 +98,23 @@ func (g *Genesis) ToRaw() error {
 	return nil
 }
 
+// SS58Format returns the SS58 address format of the chain, set by the ss58Format property,
+// or crypto.DefaultSS58Format if the property is not set
+func (d *Data) SS58Format() (uint16, error) {
+	v, has := d.Properties["ss58Format"]
+	if !has || v == nil {
+		return crypto.DefaultSS58Format, nil
+	}
+
+	// numbers of the json properties are decoded as float64
+	f, ok := v.(float64)
+	if !ok || f < 0 || f > float64(crypto.MaxSS58Format) || f != math.Trunc(f) {
+		return 0, fmt.Errorf("%w: %v", crypto.ErrInvalidSS58Format, v)
+	}
+
+	return uint16(f), nil
+}
+
 func interfaceToTelemetryEndpoint(endpoints []interface{}) []*TelemetryEndpoint {
 	var res []*TelemetryEndpoint
 	for _, v := range endpoints {
@@ -6,6 +6,8 @@ package genesis
 import (
 	"testing"
 
+	"github.com/ChainSafe/gossamer/lib/crypto"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -50,3 +52,27 @@ func TestInterfaceToTelemetryEndpoint(t *testing.T) {
 		require.Equal(t, test.expected, res)
 	}
 }
+
+func TestData_SS58Format(t *testing.T) {
+	format, err := TestGenesis.GenesisData().SS58Format()
+	require.NoError(t, err)
+	require.Equal(t, uint16(0), format)
+
+	data := &Data{}
+	format, err = data.SS58Format()
+	require.NoError(t, err)
+	require.Equal(t, crypto.DefaultSS58Format, format)
+
+	data.Properties = map[string]interface{}{"ss58Format": float64(2)}
+	format, err = data.SS58Format()
+	require.NoError(t, err)
+	require.Equal(t, uint16(2), format)
+
+	data.Properties = map[string]interface{}{"ss58Format": "2"}
+	_, err = data.SS58Format()
+	require.ErrorIs(t, err, crypto.ErrInvalidSS58Format)
+
+	data.Properties = map[string]interface{}{"ss58Format": float64(16384)}
+	_, err = data.SS58Format()
+	require.ErrorIs(t, err, crypto.ErrInvalidSS58Format)
+}
@@ -20,6 +20,8 @@ import (
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/signer"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
@@ -44,7 +46,8 @@ type Service struct {
 	blockState     BlockState
 	grandpaState   GrandpaState
 	digestHandler  DigestHandler
-	keypair        *ed25519.Keypair // TODO: change to grandpa keystore (#1870)
+	authorityKey   *ed25519.PublicKey // GRANDPA authority key, whose private key is held by the signer
+	signer         signer.Signer
 	mapLock        sync.Mutex
 	chanLock       sync.Mutex
 	roundLock      sync.Mutex
@@ -85,6 +88,7 @@ type Config struct {
 	Network       Network
 	Voters        []Voter
 	Keypair       *ed25519.Keypair
+	Signer        signer.Signer
 	Authority     bool
 	Interval      time.Duration
 }
@@ -103,19 +107,24 @@ func NewService(cfg *Config) (*Service, error) {
 		return nil, ErrNilDigestHandler
 	}
 
-	if cfg.Keypair == nil && cfg.Authority {
-		return nil, ErrNilKeypair
-	}
-
 	if cfg.Network == nil {
 		return nil, ErrNilNetwork
 	}
 
 	logger.Patch(log.SetLevel(cfg.LogLvl))
 
-	var pub string
-	if cfg.Authority {
-		pub = cfg.Keypair.Public().Hex()
+	var (
+		pub          string
+		authorityKey *ed25519.PublicKey
+		sgn          signer.Signer
+	)
+	if cfg.Authority || cfg.Keypair != nil {
+		var err error
+		authorityKey, sgn, err = setupSigner(cfg)
+		if err != nil {
+			return nil, err
+		}
+		pub = authorityKey.Hex()
 	}
 
 	logger.Debugf(
@@ -152,7 +161,8 @@ func NewService(cfg *Config) (*Service, error) {
 		blockState:         cfg.BlockState,
 		grandpaState:       cfg.GrandpaState,
 		digestHandler:      cfg.DigestHandler,
-		keypair:            cfg.Keypair,
+		authorityKey:       authorityKey,
+		signer:             sgn,
 		authority:          cfg.Authority,
 		prevotes:           new(sync.Map),
 		precommits:         new(sync.Map),
@@ -178,6 +188,45 @@ func NewService(cfg *Config) (*Service, error) {
 	return s, nil
 }
 
+// setupSigner returns the authority key of the service and the signer holding its private key. The authority key
+// is the key of the keypair of the configuration, or else the first GRANDPA key of the signer. Without signer, the
+// service signs with the keypair.
+func setupSigner(cfg *Config) (*ed25519.PublicKey, signer.Signer, error) {
+	if cfg.Keypair != nil {
+		pub := cfg.Keypair.Public().(*ed25519.PublicKey)
+		if cfg.Signer != nil {
+			return pub, cfg.Signer, nil
+		}
+
+		local, err := signer.NewLocalFromKeypair(keystore.GranName, cfg.Keypair)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return pub, local, nil
+	}
+
+	if cfg.Signer == nil {
+		return nil, nil, ErrNilKeypair
+	}
+
+	pubs, err := cfg.Signer.PublicKeys(keystore.GranName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot get GRANDPA keys of signer: %w", err)
+	}
+
+	if len(pubs) == 0 {
+		return nil, nil, ErrNilKeypair
+	}
+
+	pub, ok := pubs[0].(*ed25519.PublicKey)
+	if !ok {
+		return nil, nil, errors.New("GRANDPA authority key is not of type ed25519")
+	}
+
+	return pub, cfg.Signer, nil
+}
+
 // Start begins the GRANDPA finality service
 func (s *Service) Start() error {
 	// if we're not an authority, we don't need to worry about the voting process.
@@ -267,11 +316,11 @@ func (s *Service) updateAuthorities() error {
 }
 
 func (s *Service) publicKeyBytes() ed25519.PublicKeyBytes {
-	return s.keypair.Public().(*ed25519.PublicKey).AsBytes()
+	return s.authorityKey.AsBytes()
 }
 
 func (s *Service) sendTelemetryAuthoritySet() {
-	authorityID := s.keypair.Public().Hex()
+	authorityID := s.authorityKey.Hex()
 	authorities := make([]string, len(s.state.voters))
 	for i, voter := range s.state.voters {
 		authorities[i] = fmt.Sprint(voter.ID)
@@ -373,6 +422,7 @@ func (s *Service) initiate() error {
 			return err
 		}
 
+		start := time.Now()
 		err = s.playGrandpaRound()
 		if err == ErrServicePaused {
 			logger.Info("service paused")
@@ -386,6 +436,9 @@ func (s *Service) initiate() error {
 			continue
 		}
 
+		roundDuration.Observe(time.Since(start).Seconds())
+		roundGauge.Set(float64(s.state.round))
+
 		if s.ctx.Err() != nil {
 			return errors.New("context cancelled")
 		}
@@ -415,7 +468,7 @@ func (s *Service) handleIsPrimary() (bool, error) {
 
 	// if primary, broadcast the best final candidate from the previous round
 	// otherwise, do nothing
-	if !bytes.Equal(primary.Key.Encode(), s.keypair.Public().Encode()) {
+	if !bytes.Equal(primary.Key.Encode(), s.authorityKey.Encode()) {
 		return false, nil
 	}
 
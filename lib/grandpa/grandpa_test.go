@@ -21,6 +21,7 @@ import (
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/lib/runtime/wasmer"
+	"github.com/ChainSafe/gossamer/lib/signer"
 	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/ChainSafe/gossamer/lib/utils"
 
@@ -111,6 +112,42 @@ func newTestService(t *testing.T) (*Service, *state.Service) {
 	return gs, st
 }
 
+// setTestKeypair sets the authority key of the service, which then signs with the given keypair
+func setTestKeypair(t *testing.T, s *Service, kp *ed25519.Keypair) {
+	local, err := signer.NewLocalFromKeypair(keystore.GranName, kp)
+	require.NoError(t, err)
+
+	s.authorityKey = kp.Public().(*ed25519.PublicKey)
+	s.signer = local
+}
+
+func TestNewService_Signer(t *testing.T) {
+	st := newTestState(t)
+	net := newTestNetwork(t)
+
+	local, err := signer.NewLocalFromKeypair(keystore.GranName, kr.Bob())
+	require.NoError(t, err)
+
+	cfg := &Config{
+		BlockState:    st.Block,
+		GrandpaState:  st.Grandpa,
+		DigestHandler: NewMockDigestHandler(),
+		Voters:        voters,
+		Signer:        local,
+		Authority:     true,
+		Network:       net,
+		Interval:      time.Second,
+	}
+
+	gs, err := NewService(cfg)
+	require.NoError(t, err)
+	require.Equal(t, kr.Bob().Public(), gs.authorityKey)
+
+	cfg.Signer = signer.NewLocal(keystore.NewGlobalKeystore())
+	_, err = NewService(cfg)
+	require.Equal(t, ErrNilKeypair, err)
+}
+
 func TestUpdateAuthorities(t *testing.T) {
 	gs, _ := newTestService(t)
 	err := gs.updateAuthorities()
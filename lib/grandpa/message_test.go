@@ -10,7 +10,6 @@ import (
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
-	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 
 	"github.com/stretchr/testify/require"
@@ -85,7 +84,7 @@ func TestVoteMessageToConsensusMessage(t *testing.T) {
 			Stage:       precommit,
 			Hash:        v.Hash,
 			Number:      v.Number,
-			AuthorityID: gs.keypair.Public().(*ed25519.PublicKey).AsBytes(),
+			AuthorityID: gs.authorityKey.AsBytes(),
 		},
 	}
 
@@ -103,7 +102,7 @@ func TestVoteMessageToConsensusMessage(t *testing.T) {
 			Stage:       prevote,
 			Hash:        v.Hash,
 			Number:      v.Number,
-			AuthorityID: gs.keypair.Public().(*ed25519.PublicKey).AsBytes(),
+			AuthorityID: gs.authorityKey.AsBytes(),
 		},
 	}
 
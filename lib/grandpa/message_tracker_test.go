@@ -28,10 +28,10 @@ func TestMessageTracker_ValidateMessage(t *testing.T) {
 		Number: big.NewInt(77),
 	}
 
-	gs.keypair = kr.Alice().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Alice().(*ed25519.Keypair))
 	_, msg, err := gs.createSignedVoteAndVoteMessage(NewVoteFromHeader(fake), prevote)
 	require.NoError(t, err)
-	gs.keypair = kr.Bob().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Bob().(*ed25519.Keypair))
 
 	expected := &networkVoteMessage{
 		msg: msg,
@@ -60,10 +60,10 @@ func TestMessageTracker_SendMessage(t *testing.T) {
 		Number:     big.NewInt(4),
 	}
 
-	gs.keypair = kr.Alice().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Alice().(*ed25519.Keypair))
 	_, msg, err := gs.createSignedVoteAndVoteMessage(NewVoteFromHeader(next), prevote)
 	require.NoError(t, err)
-	gs.keypair = kr.Bob().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Bob().(*ed25519.Keypair))
 
 	expected := &networkVoteMessage{
 		msg: msg,
@@ -106,10 +106,10 @@ func TestMessageTracker_ProcessMessage(t *testing.T) {
 		Number:     big.NewInt(4),
 	}
 
-	gs.keypair = kr.Alice().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Alice().(*ed25519.Keypair))
 	_, msg, err := gs.createSignedVoteAndVoteMessage(NewVoteFromHeader(next), prevote)
 	require.NoError(t, err)
-	gs.keypair = kr.Bob().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Bob().(*ed25519.Keypair))
 
 	expected := &networkVoteMessage{
 		msg: msg,
@@ -151,11 +151,11 @@ func TestMessageTracker_MapInsideMap(t *testing.T) {
 	_, ok := gs.tracker.voteMessages[hash]
 	require.False(t, ok)
 
-	gs.keypair = kr.Alice().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Alice().(*ed25519.Keypair))
 	authorityID := kr.Alice().Public().(*ed25519.PublicKey).AsBytes()
 	_, msg, err := gs.createSignedVoteAndVoteMessage(NewVoteFromHeader(header), prevote)
 	require.NoError(t, err)
-	gs.keypair = kr.Bob().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Bob().(*ed25519.Keypair))
 
 	gs.tracker.addVote(&networkVoteMessage{
 		msg: msg,
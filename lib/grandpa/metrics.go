@@ -0,0 +1,22 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"github.com/ChainSafe/gossamer/dot/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	roundGauge = metrics.NewGauge(prometheus.GaugeOpts{
+		Name: "finality_grandpa_round",
+		Help: "Highest completed GRANDPA round",
+	})
+
+	roundDuration = metrics.NewHistogram(prometheus.HistogramOpts{
+		Name:    "finality_grandpa_round_duration_seconds",
+		Help:    "Time taken to complete a GRANDPA round",
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 12),
+	})
+)
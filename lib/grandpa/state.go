@@ -39,13 +39,19 @@ type BlockState interface {
 	GetHashByNumber(num *big.Int) (common.Hash, error)
 	BestBlockNumber() (*big.Int, error)
 	GetHighestRoundAndSetID() (uint64, uint64, error)
+	ImportWarpSyncHeader(header *types.Header, justification []byte, round, setID uint64) error
+	RevertWarpSyncHeader(header *types.Header, round, setID uint64) error
 }
 
 // GrandpaState is the interface required by grandpa into the grandpa state
 type GrandpaState interface { //nolint:revive
 	GetCurrentSetID() (uint64, error)
+	SetCurrentSetID(setID uint64) error
 	GetAuthorities(setID uint64) ([]types.GrandpaVoter, error)
 	GetSetIDByBlockNumber(num *big.Int) (uint64, error)
+	GetSetIDChange(setID uint64) (*big.Int, error)
+	SetNextChange(authorities []types.GrandpaVoter, number *big.Int) error
+	IncrementSetID() error
 	SetLatestRound(round uint64) error
 	GetLatestRound() (uint64, error)
 	SetPrevotes(round, setID uint64, data []SignedVote) error
@@ -12,6 +12,8 @@ import (
 	"github.com/ChainSafe/gossamer/dot/telemetry"
 	"github.com/ChainSafe/gossamer/lib/blocktree"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/signer"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -94,7 +96,12 @@ func (s *Service) createSignedVoteAndVoteMessage(vote *Vote, stage Subround) (*S
 		return nil, nil, err
 	}
 
-	sig, err := s.keypair.Sign(msg)
+	eq, err := voteEquivocation(stage, s.state.setID, s.state.round)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sig, err := s.signer.Sign(keystore.GranName, s.authorityKey, msg, eq)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -102,7 +109,7 @@ func (s *Service) createSignedVoteAndVoteMessage(vote *Vote, stage Subround) (*S
 	pc := &SignedVote{
 		Vote:        *vote,
 		Signature:   ed25519.NewSignatureBytes(sig),
-		AuthorityID: s.keypair.Public().(*ed25519.PublicKey).AsBytes(),
+		AuthorityID: s.authorityKey.AsBytes(),
 	}
 
 	sm := &SignedMessage{
@@ -110,7 +117,7 @@ func (s *Service) createSignedVoteAndVoteMessage(vote *Vote, stage Subround) (*S
 		Hash:        pc.Vote.Hash,
 		Number:      pc.Vote.Number,
 		Signature:   ed25519.NewSignatureBytes(sig),
-		AuthorityID: s.keypair.Public().(*ed25519.PublicKey).AsBytes(),
+		AuthorityID: s.authorityKey.AsBytes(),
 	}
 
 	vm := &VoteMessage{
@@ -122,6 +129,21 @@ func (s *Service) createSignedVoteAndVoteMessage(vote *Vote, stage Subround) (*S
 	return pc, vm, nil
 }
 
+// voteEquivocation returns the equivocation of a vote of the given subround, so that the signer does not sign two
+// different votes of the same subround of a round
+func voteEquivocation(stage Subround, setID, round uint64) (*signer.Equivocation, error) {
+	switch stage {
+	case prevote:
+		return signer.NewGrandpaEquivocation(signer.GrandpaPrevoteKind, setID, round), nil
+	case precommit:
+		return signer.NewGrandpaEquivocation(signer.GrandpaPrecommitKind, setID, round), nil
+	case primaryProposal:
+		return signer.NewGrandpaEquivocation(signer.GrandpaPrimaryKind, setID, round), nil
+	default:
+		return nil, ErrUnsupportedSubround
+	}
+}
+
 // validateMessage validates a VoteMessage and adds it to the current votes
 // it returns the resulting vote if validated, error otherwise
 func (s *Service) validateMessage(from peer.ID, m *VoteMessage) (*Vote, error) {
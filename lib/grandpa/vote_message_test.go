@@ -5,13 +5,17 @@ package grandpa
 
 import (
 	"math/big"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/state"
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/signer"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/stretchr/testify/require"
 )
 
@@ -178,10 +182,10 @@ func TestValidateMessage_Valid(t *testing.T) {
 	h, err := st.Block.BestBlockHeader()
 	require.NoError(t, err)
 
-	gs.keypair = kr.Alice().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Alice().(*ed25519.Keypair))
 	_, msg, err := gs.createSignedVoteAndVoteMessage(NewVoteFromHeader(h), prevote)
 	require.NoError(t, err)
-	gs.keypair = kr.Bob().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Bob().(*ed25519.Keypair))
 
 	vote, err := gs.validateMessage("", msg)
 	require.NoError(t, err)
@@ -212,10 +216,10 @@ func TestValidateMessage_InvalidSignature(t *testing.T) {
 	h, err := st.Block.BestBlockHeader()
 	require.NoError(t, err)
 
-	gs.keypair = kr.Alice().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Alice().(*ed25519.Keypair))
 	_, msg, err := gs.createSignedVoteAndVoteMessage(NewVoteFromHeader(h), prevote)
 	require.NoError(t, err)
-	gs.keypair = kr.Bob().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Bob().(*ed25519.Keypair))
 
 	msg.Message.Signature[63] = 0
 
@@ -246,10 +250,10 @@ func TestValidateMessage_SetIDMismatch(t *testing.T) {
 	h, err := st.Block.BestBlockHeader()
 	require.NoError(t, err)
 
-	gs.keypair = kr.Alice().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Alice().(*ed25519.Keypair))
 	_, msg, err := gs.createSignedVoteAndVoteMessage(NewVoteFromHeader(h), prevote)
 	require.NoError(t, err)
-	gs.keypair = kr.Bob().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Bob().(*ed25519.Keypair))
 
 	gs.state.setID = 1
 
@@ -293,10 +297,10 @@ func TestValidateMessage_Equivocation(t *testing.T) {
 		Vote: *voteA,
 	})
 
-	gs.keypair = kr.Alice().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Alice().(*ed25519.Keypair))
 	_, msg, err := gs.createSignedVoteAndVoteMessage(voteB, prevote)
 	require.NoError(t, err)
-	gs.keypair = kr.Bob().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Bob().(*ed25519.Keypair))
 
 	_, err = gs.validateMessage("", msg)
 	require.Equal(t, ErrEquivocation, err, gs.prevotes)
@@ -328,10 +332,10 @@ func TestValidateMessage_BlockDoesNotExist(t *testing.T) {
 		Number: big.NewInt(77),
 	}
 
-	gs.keypair = kr.Alice().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Alice().(*ed25519.Keypair))
 	_, msg, err := gs.createSignedVoteAndVoteMessage(NewVoteFromHeader(fake), prevote)
 	require.NoError(t, err)
-	gs.keypair = kr.Bob().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Bob().(*ed25519.Keypair))
 
 	_, err = gs.validateMessage("", msg)
 	require.Equal(t, err, ErrBlockDoesNotExist)
@@ -366,14 +370,67 @@ func TestValidateMessage_IsNotDescendant(t *testing.T) {
 	gs.head, err = gs.blockState.GetHeader(leaves[0])
 	require.NoError(t, err)
 
-	gs.keypair = kr.Alice().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Alice().(*ed25519.Keypair))
 	vote, err := NewVoteFromHash(leaves[1], gs.blockState)
 	require.NoError(t, err)
 
 	_, msg, err := gs.createSignedVoteAndVoteMessage(vote, prevote)
 	require.NoError(t, err)
-	gs.keypair = kr.Bob().(*ed25519.Keypair)
+	setTestKeypair(t, gs, kr.Bob().(*ed25519.Keypair))
 
 	_, err = gs.validateMessage("", msg)
 	require.Equal(t, errInvalidVoteBlock, err, gs.prevotes)
 }
+
+func TestVoteEquivocation(t *testing.T) {
+	eq, err := voteEquivocation(prevote, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, signer.NewGrandpaEquivocation(signer.GrandpaPrevoteKind, 1, 2), eq)
+
+	eq, err = voteEquivocation(precommit, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, signer.NewGrandpaEquivocation(signer.GrandpaPrecommitKind, 1, 2), eq)
+
+	eq, err = voteEquivocation(primaryProposal, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, signer.NewGrandpaEquivocation(signer.GrandpaPrimaryKind, 1, 2), eq)
+
+	_, err = voteEquivocation(Subround(3), 1, 2)
+	require.Equal(t, ErrUnsupportedSubround, err)
+}
+
+func TestFullVote_SigningServer(t *testing.T) {
+	kr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+
+	ks := keystore.NewGlobalKeystore()
+	require.NoError(t, ks.Gran.Insert(kr.Alice()))
+
+	guard, err := signer.NewEquivocationGuard("")
+	require.NoError(t, err)
+
+	srv, err := signer.NewServer(signer.NewLocal(ks), "secret", guard)
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	// the signing server derives the equivocation of votes from their encoding
+	remote := signer.NewRemote(ts.URL, "secret")
+	vote := func(hash common.Hash) []byte {
+		msg, err := scale.Marshal(FullVote{
+			Stage: precommit,
+			Vote:  Vote{Hash: hash, Number: 1},
+			Round: 2,
+			SetID: 1,
+		})
+		require.NoError(t, err)
+		return msg
+	}
+
+	_, err = remote.Sign(keystore.GranName, kr.Alice().Public(), vote(common.Hash{1}), nil)
+	require.NoError(t, err)
+
+	_, err = remote.Sign(keystore.GranName, kr.Alice().Public(), vote(common.Hash{2}), nil)
+	require.ErrorIs(t, err, signer.ErrEquivocation)
+}
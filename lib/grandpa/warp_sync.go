@@ -0,0 +1,438 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// maxWarpSyncProofFragments is the maximum number of authority set changes proven by a single warp sync proof
+const maxWarpSyncProofFragments = 128
+
+var (
+	errWarpSyncBeginNotFinalised = errors.New("warp sync proof must begin at a finalised block")
+	errNoAuthoritySetChange      = errors.New("warp sync fragment header does not signal an authority set change")
+	errInvalidWarpSyncAncestry   = errors.New("warp sync fragment ancestry is not a chain")
+	errWarpSyncTargetNotFound    = errors.New("warp sync fragment does not contain justified block")
+)
+
+// GenerateWarpSyncProof returns a proof of the authority set changes that happened after the given finalised block.
+// Each fragment of the proof holds the header signalling a change and the justification of the first block
+// finalised once the change is enacted. If the latest finalised block comes after the last change, a final
+// fragment proves it and the proof is finished.
+func (s *Service) GenerateWarpSyncProof(begin common.Hash) (*network.WarpSyncProof, error) {
+	beginHeader, err := s.blockState.GetHeader(begin)
+	if err != nil {
+		return nil, err
+	}
+
+	finalised, err := s.highestFinalisedHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	canonical, err := s.blockState.GetHashByNumber(beginHeader.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	if beginHeader.Number.Cmp(finalised.Number) > 0 || canonical != begin {
+		return nil, errWarpSyncBeginNotFinalised
+	}
+
+	setID, err := s.grandpaState.GetSetIDByBlockNumber(beginHeader.Number)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get set ID from block number: %w", err)
+	}
+
+	currSetID, err := s.grandpaState.GetCurrentSetID()
+	if err != nil {
+		return nil, err
+	}
+
+	proof := new(network.WarpSyncProof)
+	lastProven := beginHeader.Number
+
+	for ; setID < currSetID; setID++ {
+		if len(proof.Fragments) == maxWarpSyncProofFragments {
+			return proof, nil
+		}
+
+		changeAt, err := s.grandpaState.GetSetIDChange(setID + 1)
+		if err != nil {
+			return nil, fmt.Errorf("cannot get block number of set ID change: %w", err)
+		}
+
+		if changeAt.Cmp(finalised.Number) > 0 {
+			break
+		}
+
+		fragment, target, err := s.createWarpSyncFragment(beginHeader.Number, changeAt, finalised.Number)
+		if err != nil {
+			return nil, err
+		}
+
+		proof.Fragments = append(proof.Fragments, fragment)
+		lastProven = target
+	}
+
+	if finalised.Number.Cmp(lastProven) <= 0 {
+		// there is nothing to prove beyond the last fragment, the requester will find out in its next request
+		proof.IsFinished = len(proof.Fragments) == 0
+		return proof, nil
+	}
+
+	justification, err := s.blockState.GetJustification(finalised.Hash())
+	if err != nil {
+		// the latest finalised block can't be proven, so let the requester continue from the last change
+		proof.IsFinished = len(proof.Fragments) == 0
+		return proof, nil //nolint:nilerr
+	}
+
+	ancestry, err := s.warpSyncAncestry(finalised.Number, justification)
+	if err != nil {
+		return nil, err
+	}
+
+	proof.Fragments = append(proof.Fragments, &network.WarpSyncFragment{
+		Header:        finalised,
+		Justification: justification,
+		Ancestry:      ancestry,
+	})
+	proof.IsFinished = true
+	return proof, nil
+}
+
+// createWarpSyncFragment creates the fragment proving the authority set change enacted at the given block number.
+// It also returns the number of the justified block.
+func (s *Service) createWarpSyncFragment(begin, changeAt, finalised *big.Int) (
+	*network.WarpSyncFragment, *big.Int, error) {
+	// find the header signalling the change, which is the enacting block or one of its ancestors
+	var signal *types.Header
+	for num := new(big.Int).Set(changeAt); num.Cmp(begin) > 0; num.Sub(num, big.NewInt(1)) {
+		header, err := s.blockState.GetHeaderByNumber(num)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if _, _, err = findGrandpaChange(header); err == nil {
+			signal = header
+			break
+		}
+	}
+
+	if signal == nil {
+		return nil, nil, fmt.Errorf("cannot find authority set change signal for change at block %s", changeAt)
+	}
+
+	// the first block finalised from the change onwards is justified by the previous authority set
+	for num := new(big.Int).Set(changeAt); num.Cmp(finalised) <= 0; num.Add(num, big.NewInt(1)) {
+		hash, err := s.blockState.GetHashByNumber(num)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		justification, err := s.blockState.GetJustification(hash)
+		if err != nil {
+			continue
+		}
+
+		ancestry, err := s.warpSyncAncestry(signal.Number, justification)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &network.WarpSyncFragment{
+			Header:        signal,
+			Justification: justification,
+			Ancestry:      ancestry,
+		}, num, nil
+	}
+
+	return nil, nil, fmt.Errorf("cannot find justification for authority set change at block %s", changeAt)
+}
+
+// warpSyncAncestry returns the canonical headers after the given block number up to the highest block
+// voted for in the justification
+func (s *Service) warpSyncAncestry(from *big.Int, justification []byte) ([]*types.Header, error) {
+	fj := Justification{}
+	err := scale.Unmarshal(justification, &fj)
+	if err != nil {
+		return nil, err
+	}
+
+	highest := fj.Commit.Number
+	for _, pc := range fj.Commit.Precommits {
+		if pc.Vote.Number <= highest {
+			continue
+		}
+
+		canonical, err := s.blockState.GetHashByNumber(big.NewInt(int64(pc.Vote.Number)))
+		if err != nil || canonical != pc.Vote.Hash {
+			continue
+		}
+
+		highest = pc.Vote.Number
+	}
+
+	var ancestry []*types.Header
+	for num := from.Uint64() + 1; num <= uint64(highest); num++ {
+		header, err := s.blockState.GetHeaderByNumber(new(big.Int).SetUint64(num))
+		if err != nil {
+			return nil, err
+		}
+
+		ancestry = append(ancestry, header)
+	}
+
+	return ancestry, nil
+}
+
+func (s *Service) highestFinalisedHeader() (*types.Header, error) {
+	round, setID, err := s.blockState.GetHighestRoundAndSetID()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.blockState.GetFinalisedHeader(round, setID)
+}
+
+type authoritySetChange struct {
+	voters []types.GrandpaVoter
+	at     *big.Int
+}
+
+// ImportWarpSyncProof verifies the fragments of a warp sync proof against the authority set currently tracked
+// in the GrandpaState. If every fragment is valid, the authority set changes they prove are recorded and the last
+// justified block is set as the latest finalised block. It returns the latest finalised header.
+func (s *Service) ImportWarpSyncProof(proof *network.WarpSyncProof) (*types.Header, error) {
+	setID, err := s.grandpaState.GetCurrentSetID()
+	if err != nil {
+		return nil, err
+	}
+
+	voters, err := s.grandpaState.GetAuthorities(setID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get authorities for set ID: %w", err)
+	}
+
+	var (
+		changes   []authoritySetChange
+		target    *types.Header
+		fj        *Justification
+		justified []byte
+		justSetID uint64
+	)
+
+	for i, fragment := range proof.Fragments {
+		target, fj, err = verifyWarpSyncFragment(fragment, setID, voters)
+		if err != nil {
+			return nil, fmt.Errorf("invalid warp sync fragment %d: %w", i, err)
+		}
+
+		justified = fragment.Justification
+		justSetID = setID
+
+		// the last fragment of a finished proof proves the latest finalised block, not a change
+		if proof.IsFinished && i == len(proof.Fragments)-1 {
+			break
+		}
+
+		next, delay, err := findGrandpaChange(fragment.Header)
+		if err != nil {
+			return nil, fmt.Errorf("invalid warp sync fragment %d: %w", i, err)
+		}
+
+		voters = next
+		setID++
+		changes = append(changes, authoritySetChange{
+			voters: next,
+			at:     new(big.Int).Add(fragment.Header.Number, big.NewInt(int64(delay))),
+		})
+	}
+
+	if target == nil {
+		return s.highestFinalisedHeader()
+	}
+
+	for _, change := range changes {
+		if err = s.grandpaState.SetNextChange(change.voters, change.at); err != nil {
+			return nil, err
+		}
+
+		if err = s.grandpaState.IncrementSetID(); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = s.blockState.ImportWarpSyncHeader(target, justified, fj.Round, justSetID); err != nil {
+		return nil, err
+	}
+
+	logger.Infof("imported warp sync proof up to block %s with hash %s, set id %d",
+		target.Number, target.Hash(), setID)
+	return target, nil
+}
+
+// RevertWarpSync reverts the warp sync started at the given finalised header, finalised at the given round
+// and set ID. The header is set back as the latest finalised block and the set ID as the current set ID,
+// so that the blocks after it can be imported and their authority set changes applied again.
+func (s *Service) RevertWarpSync(header *types.Header, round, setID uint64) error {
+	if err := s.grandpaState.SetCurrentSetID(setID); err != nil {
+		return err
+	}
+
+	if err := s.blockState.RevertWarpSyncHeader(header, round, setID); err != nil {
+		return err
+	}
+
+	logger.Infof("reverted warp sync to block %s with hash %s, set id %d", header.Number, header.Hash(), setID)
+	return nil
+}
+
+// verifyWarpSyncFragment verifies that the fragment justification is signed by a supermajority of the
+// given voters for a block in the fragment. It returns the justified header and the decoded justification.
+func verifyWarpSyncFragment(fragment *network.WarpSyncFragment, setID uint64, voters []types.GrandpaVoter) (
+	*types.Header, *Justification, error) {
+	fj := Justification{}
+	err := scale.Unmarshal(fragment.Justification, &fj)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chain := append([]*types.Header{fragment.Header}, fragment.Ancestry...)
+	index := make(map[common.Hash]int, len(chain))
+	for i, header := range chain {
+		if i > 0 && header.ParentHash != chain[i-1].Hash() {
+			return nil, nil, errInvalidWarpSyncAncestry
+		}
+
+		index[header.Hash()] = i
+	}
+
+	targetIdx, has := index[fj.Commit.Hash]
+	if !has || chain[targetIdx].Number.Uint64() != uint64(fj.Commit.Number) {
+		return nil, nil, errWarpSyncTargetNotFound
+	}
+
+	// every precommit must be signed by a voter, voters that signed different votes are equivocatory
+	votes := make(map[ed25519.PublicKeyBytes][]Vote)
+	for _, just := range fj.Commit.Precommits {
+		pk, err := ed25519.NewPublicKey(just.AuthorityID[:])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !isInAuthSet(pk, voters) {
+			return nil, nil, ErrAuthorityNotInSet
+		}
+
+		msg, err := scale.Marshal(FullVote{
+			Stage: precommit,
+			Vote:  just.Vote,
+			Round: fj.Round,
+			SetID: setID,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		ok, err := pk.Verify(msg, just.Signature[:])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !ok {
+			return nil, nil, ErrInvalidSignature
+		}
+
+		if !containsVote(votes[just.AuthorityID], just.Vote) {
+			votes[just.AuthorityID] = append(votes[just.AuthorityID], just.Vote)
+		}
+	}
+
+	var counted, equivocatory int
+	for _, signed := range votes {
+		if len(signed) > 1 {
+			equivocatory++
+			continue
+		}
+
+		// the vote must be for the justified block or one of its descendants in the fragment
+		voteIdx, has := index[signed[0].Hash]
+		if !has {
+			// the vote may be for a block on another fork, which isn't part of the fragment
+			continue
+		}
+
+		if voteIdx < targetIdx {
+			return nil, nil, ErrPrecommitBlockMismatch
+		}
+
+		counted++
+	}
+
+	// a supermajority of the voters must have precommitted, equivocatory voters count towards it
+	if 3*(counted+equivocatory) <= 2*len(voters) {
+		return nil, nil, ErrMinVotesNotMet
+	}
+
+	return chain[targetIdx], &fj, nil
+}
+
+func containsVote(votes []Vote, vote Vote) bool {
+	for _, v := range votes {
+		if v == vote {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findGrandpaChange returns the authorities and delay of the GRANDPA authority set change signalled in the header
+func findGrandpaChange(header *types.Header) ([]types.GrandpaVoter, uint32, error) {
+	for _, d := range header.Digest.Types {
+		cd, ok := d.Value().(types.ConsensusDigest)
+		if !ok || cd.ConsensusEngineID != types.GrandpaEngineID {
+			continue
+		}
+
+		data := types.NewGrandpaConsensusDigest()
+		if err := scale.Unmarshal(cd.Data, &data); err != nil {
+			return nil, 0, err
+		}
+
+		var (
+			raw   []types.GrandpaAuthoritiesRaw
+			delay uint32
+		)
+
+		switch val := data.Value().(type) {
+		case types.GrandpaScheduledChange:
+			raw, delay = val.Auths, val.Delay
+		case types.GrandpaForcedChange:
+			raw, delay = val.Auths, val.Delay
+		default:
+			continue
+		}
+
+		auths, err := types.GrandpaAuthoritiesRawToAuthorities(raw)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		return types.NewGrandpaVotersFromAuthorities(auths), delay, nil
+	}
+
+	return nil, 0, errNoAuthoritySetChange
+}
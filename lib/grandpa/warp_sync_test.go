@@ -0,0 +1,187 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package grandpa
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/network"
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestChangeHeader(t *testing.T, parent *types.Header, delay uint32) *types.Header {
+	t.Helper()
+
+	sc := types.GrandpaScheduledChange{
+		Auths: []types.GrandpaAuthoritiesRaw{
+			{Key: kr.Alice().Public().(*ed25519.PublicKey).AsBytes(), ID: 0},
+		},
+		Delay: delay,
+	}
+
+	cd := types.NewGrandpaConsensusDigest()
+	require.NoError(t, cd.Set(sc))
+
+	data, err := scale.Marshal(cd)
+	require.NoError(t, err)
+
+	digest := types.NewDigest()
+	require.NoError(t, digest.Add(types.ConsensusDigest{
+		ConsensusEngineID: types.GrandpaEngineID,
+		Data:              data,
+	}))
+
+	return &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     big.NewInt(0).Add(parent.Number, big.NewInt(1)),
+		Digest:     digest,
+	}
+}
+
+func newTestWarpSyncJustification(t *testing.T, target *types.Header, qty int, round, setID uint64) []byte {
+	t.Helper()
+
+	vote := NewVoteFromHeader(target)
+	fj := Justification{
+		Round: round,
+		Commit: Commit{
+			Hash:   vote.Hash,
+			Number: vote.Number,
+		},
+	}
+
+	for _, key := range kr.Keys[:qty] {
+		msg, err := scale.Marshal(FullVote{
+			Stage: precommit,
+			Vote:  *vote,
+			Round: round,
+			SetID: setID,
+		})
+		require.NoError(t, err)
+
+		sig, err := key.Sign(msg)
+		require.NoError(t, err)
+
+		pc := SignedVote{
+			Vote:        *vote,
+			AuthorityID: key.Public().(*ed25519.PublicKey).AsBytes(),
+		}
+		copy(pc.Signature[:], sig)
+		fj.Commit.Precommits = append(fj.Commit.Precommits, pc)
+	}
+
+	enc, err := scale.Marshal(fj)
+	require.NoError(t, err)
+	return enc
+}
+
+func TestFindGrandpaChange(t *testing.T) {
+	header := newTestChangeHeader(t, testGenesisHeader, 3)
+
+	next, delay, err := findGrandpaChange(header)
+	require.NoError(t, err)
+	require.Equal(t, uint32(3), delay)
+	require.Len(t, next, 1)
+	require.Equal(t, kr.Alice().Public().(*ed25519.PublicKey).AsBytes(), next[0].Key.AsBytes())
+
+	_, _, err = findGrandpaChange(testHeader)
+	require.ErrorIs(t, err, errNoAuthoritySetChange)
+}
+
+func TestVerifyWarpSyncFragment(t *testing.T) {
+	signal := newTestChangeHeader(t, testGenesisHeader, 1)
+	enacted := &types.Header{
+		ParentHash: signal.Hash(),
+		Number:     big.NewInt(2),
+		Digest:     types.NewDigest(),
+	}
+
+	fragment := &network.WarpSyncFragment{
+		Header:        signal,
+		Justification: newTestWarpSyncJustification(t, enacted, len(voters), 1, 0),
+		Ancestry:      []*types.Header{enacted},
+	}
+
+	target, fj, err := verifyWarpSyncFragment(fragment, 0, voters)
+	require.NoError(t, err)
+	require.Equal(t, enacted.Hash(), target.Hash())
+	require.Equal(t, uint64(1), fj.Round)
+
+	// signed for another set ID
+	_, _, err = verifyWarpSyncFragment(fragment, 1, voters)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	// not enough votes
+	fragment.Justification = newTestWarpSyncJustification(t, enacted, len(voters)*2/3, 1, 0)
+	_, _, err = verifyWarpSyncFragment(fragment, 0, voters)
+	require.ErrorIs(t, err, ErrMinVotesNotMet)
+
+	// justified block missing from the fragment
+	fragment.Justification = newTestWarpSyncJustification(t, enacted, len(voters), 1, 0)
+	fragment.Ancestry = nil
+	_, _, err = verifyWarpSyncFragment(fragment, 0, voters)
+	require.ErrorIs(t, err, errWarpSyncTargetNotFound)
+
+	// ancestry not linked to the signal header
+	fragment.Ancestry = []*types.Header{testHeader}
+	_, _, err = verifyWarpSyncFragment(fragment, 0, voters)
+	require.ErrorIs(t, err, errInvalidWarpSyncAncestry)
+}
+
+func TestVerifyWarpSyncFragment_DuplicatedVoters(t *testing.T) {
+	signal := newTestChangeHeader(t, testGenesisHeader, 1)
+	enacted := &types.Header{
+		ParentHash: signal.Hash(),
+		Number:     big.NewInt(2),
+		Digest:     types.NewDigest(),
+	}
+
+	fragment := &network.WarpSyncFragment{
+		Header:   signal,
+		Ancestry: []*types.Header{enacted},
+	}
+
+	// every voter appears twice with unsigned votes for different blocks, which isn't an equivocation
+	vote := NewVoteFromHeader(enacted)
+	fj := Justification{
+		Round: 1,
+		Commit: Commit{
+			Hash:   vote.Hash,
+			Number: vote.Number,
+		},
+	}
+
+	for _, key := range kr.Keys[:len(voters)] {
+		id := key.Public().(*ed25519.PublicKey).AsBytes()
+		fj.Commit.Precommits = append(fj.Commit.Precommits,
+			SignedVote{Vote: *vote, AuthorityID: id},
+			SignedVote{Vote: *NewVoteFromHeader(signal), AuthorityID: id},
+		)
+	}
+
+	enc, err := scale.Marshal(fj)
+	require.NoError(t, err)
+	fragment.Justification = enc
+
+	_, _, err = verifyWarpSyncFragment(fragment, 0, voters)
+	require.ErrorIs(t, err, ErrInvalidSignature)
+
+	// the same signed precommit repeated is counted once
+	fj = Justification{}
+	err = scale.Unmarshal(newTestWarpSyncJustification(t, enacted, len(voters)*2/3, 1, 0), &fj)
+	require.NoError(t, err)
+	fj.Commit.Precommits = append(fj.Commit.Precommits, fj.Commit.Precommits...)
+
+	enc, err = scale.Marshal(fj)
+	require.NoError(t, err)
+	fragment.Justification = enc
+
+	_, _, err = verifyWarpSyncFragment(fragment, 0, voters)
+	require.ErrorIs(t, err, ErrMinVotesNotMet)
+}
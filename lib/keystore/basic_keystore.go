@@ -21,7 +21,7 @@ var (
 type BasicKeystore struct {
 	name Name
 	typ  crypto.KeyType
-	keys map[common.Address]crypto.Keypair // map of public key encodings to keypairs
+	keys map[string]crypto.Keypair // map of public key encodings to keypairs
 	lock sync.RWMutex
 }
 
@@ -30,7 +30,7 @@ func NewBasicKeystore(name Name, typ crypto.KeyType) *BasicKeystore {
 	return &BasicKeystore{
 		name: name,
 		typ:  typ,
-		keys: make(map[common.Address]crypto.Keypair),
+		keys: make(map[string]crypto.Keypair),
 	}
 }
 
@@ -58,9 +58,7 @@ func (ks *BasicKeystore) Insert(kp crypto.Keypair) error {
 		return fmt.Errorf("%v, passed key type: %s, acceptable key type: %s", ErrKeyTypeNotSupported, kp.Type(), ks.typ)
 	}
 
-	pub := kp.Public()
-	addr := crypto.PublicKeyToAddress(pub)
-	ks.keys[addr] = kp
+	ks.keys[string(kp.Public().Encode())] = kp
 	return nil
 }
 
@@ -74,11 +72,17 @@ func (ks *BasicKeystore) GetKeypair(pub crypto.PublicKey) crypto.Keypair {
 	return nil
 }
 
-// GetKeypairFromAddress returns a keypair corresponding to the given address, or nil if it doesn't exist
-func (ks *BasicKeystore) GetKeypairFromAddress(pub common.Address) crypto.Keypair {
+// GetKeypairFromAddress returns a keypair corresponding to the given ss58 address of any address format,
+// or nil if it doesn't exist
+func (ks *BasicKeystore) GetKeypairFromAddress(addr common.Address) crypto.Keypair {
+	pub, _, err := crypto.DecodeSS58(addr)
+	if err != nil {
+		return nil
+	}
+
 	ks.lock.RLock()
 	defer ks.lock.RUnlock()
-	return ks.keys[pub]
+	return ks.keys[string(pub)]
 }
 
 // PublicKeys returns all public keys in the keystore
@@ -20,20 +20,74 @@ import (
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 
 	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/scrypt"
 )
 
-// EncryptedKeystore holds Type PublicKey and Ciphertext
+// KeystoreVersion is the version of the keystore files written by gossamer. Version 0 files, which have no
+// Version field, derive their encryption key from a single blake2b hash of the password, whereas version 1 files
+// derive it with scrypt and the parameters stored in the file.
+const KeystoreVersion = 1
+
+var (
+	// DefaultScryptParams are the scrypt parameters of new keystore files, which are the ones of polkadot.js
+	DefaultScryptParams = ScryptParams{N: 1 << 15, R: 8, P: 1}
+
+	// ErrUnsupportedKeystoreVersion is returned when reading a keystore file written by a newer version of gossamer
+	ErrUnsupportedKeystoreVersion = errors.New("unsupported keystore version")
+)
+
+const scryptSaltLength = 32
+
+// EncryptedKeystore holds Type PublicKey and Ciphertext, along with the Version of the keystore file and
+// the scrypt parameters used to derive the encryption key from the password
 type EncryptedKeystore struct {
 	Type       string
 	PublicKey  string
 	Ciphertext []byte
+	Version    int           `json:",omitempty"`
+	Scrypt     *ScryptParams `json:",omitempty"`
+}
+
+// ScryptParams are the scrypt parameters used to derive an encryption key from a password
+type ScryptParams struct {
+	N    int
+	R    int
+	P    int
+	Salt []byte
+}
+
+// NewScryptParams returns the default scrypt parameters with a new random salt
+func NewScryptParams() (*ScryptParams, error) {
+	params := DefaultScryptParams
+	params.Salt = make([]byte, scryptSaltLength)
+	if _, err := io.ReadFull(rand.Reader, params.Salt); err != nil {
+		return nil, err
+	}
+
+	return &params, nil
 }
 
-// gcmFromPassphrase creates a symmetric AES key given a password
-func gcmFromPassphrase(password []byte) (cipher.AEAD, error) {
-	hash := blake2b.Sum256(password)
+// deriveKey derives a 32 byte symmetric key from the password with scrypt
+func (p *ScryptParams) deriveKey(password []byte) ([]byte, error) {
+	return scrypt.Key(password, p.Salt, p.N, p.R, p.P, 32)
+}
+
+// gcmFromPassphrase creates a symmetric AES key given a password. The key is derived with scrypt and the given
+// parameters, or for version 0 keystore files, which have no parameters, with a blake2b hash of the password.
+func gcmFromPassphrase(password []byte, params *ScryptParams) (cipher.AEAD, error) {
+	var key []byte
+	if params == nil {
+		hash := blake2b.Sum256(password)
+		key = hash[:]
+	} else {
+		var err error
+		key, err = params.deriveKey(password)
+		if err != nil {
+			return nil, fmt.Errorf("cannot derive key: %w", err)
+		}
+	}
 
-	block, err := aes.NewCipher(hash[:])
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
@@ -46,9 +100,14 @@ func gcmFromPassphrase(password []byte) (cipher.AEAD, error) {
 	return gcm, nil
 }
 
-// Encrypt uses AES to encrypt `msg` with the symmetric key deterministically created from `password`
-func Encrypt(msg, password []byte) ([]byte, error) {
-	gcm, err := gcmFromPassphrase(password)
+// Encrypt uses AES to encrypt `msg` with the symmetric key derived from `password` with scrypt and the given
+// parameters
+func Encrypt(msg, password []byte, params *ScryptParams) ([]byte, error) {
+	if params == nil {
+		return nil, errors.New("cannot encrypt without scrypt parameters")
+	}
+
+	gcm, err := gcmFromPassphrase(password, params)
 	if err != nil {
 		return nil, err
 	}
@@ -62,14 +121,19 @@ func Encrypt(msg, password []byte) ([]byte, error) {
 	return ciphertext, nil
 }
 
-// Decrypt uses AES to decrypt ciphertext with the symmetric key deterministically created from `password`
-func Decrypt(data, password []byte) ([]byte, error) {
-	gcm, err := gcmFromPassphrase(password)
+// Decrypt uses AES to decrypt ciphertext with the symmetric key derived from `password` with scrypt and the given
+// parameters, or if they are nil, with the blake2b hash of `password` used by version 0 keystore files
+func Decrypt(data, password []byte, params *ScryptParams) ([]byte, error) {
+	gcm, err := gcmFromPassphrase(password, params)
 	if err != nil {
 		return nil, err
 	}
 
 	nonceSize := gcm.NonceSize()
+	if len(data) < nonceSize {
+		return nil, errors.New("ciphertext too short")
+	}
+
 	nonce, ciphertext := data[:nonceSize], data[nonceSize:]
 	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
 	if err != nil {
@@ -79,34 +143,12 @@ func Decrypt(data, password []byte) ([]byte, error) {
 	return plaintext, nil
 }
 
-// EncryptPrivateKey uses AES to encrypt an encoded `crypto.PrivateKey` with a symmetric key deterministically
-// created from `password`
-func EncryptPrivateKey(pk crypto.PrivateKey, password []byte) ([]byte, error) {
-	return Encrypt(pk.Encode(), password)
-}
-
-// DecryptPrivateKey uses AES to decrypt the ciphertext into a
-// `crypto.PrivateKey` with a symmetric key deterministically
-// created from `password`
-func DecryptPrivateKey(data, password []byte, keytype string) (crypto.PrivateKey, error) {
-	pk, err := Decrypt(data, password)
-	if err != nil {
-		return nil, err
-	}
-
-	return DecodePrivateKey(pk, keytype)
-}
-
-// EncryptAndWriteToFile encrypts the `crypto.PrivateKey` using the password and saves it to the specified file
-func EncryptAndWriteToFile(file *os.File, pk crypto.PrivateKey, password []byte) error {
-	ciphertext, err := EncryptPrivateKey(pk, password)
-	if err != nil {
-		return err
-	}
-
+// EncryptPrivateKey uses AES to encrypt an encoded `crypto.PrivateKey` with a symmetric key derived from
+// `password` with scrypt, and returns the keystore data holding the ciphertext
+func EncryptPrivateKey(pk crypto.PrivateKey, password []byte) (*EncryptedKeystore, error) {
 	pub, err := pk.Public()
 	if err != nil {
-		return fmt.Errorf("cannot get public key: %s", err)
+		return nil, fmt.Errorf("cannot get public key: %s", err)
 	}
 
 	keytype := ""
@@ -123,13 +165,56 @@ func EncryptAndWriteToFile(file *os.File, pk crypto.PrivateKey, password []byte)
 	}
 
 	if keytype == "" {
-		return errors.New("cannot write key not of type sr25519, ed25519, secp256k1")
+		return nil, errors.New("cannot write key not of type sr25519, ed25519, secp256k1")
+	}
+
+	params, err := NewScryptParams()
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := Encrypt(pk.Encode(), password, params)
+	if err != nil {
+		return nil, err
 	}
 
-	keydata := &EncryptedKeystore{
+	return &EncryptedKeystore{
 		Type:       keytype,
 		PublicKey:  pub.Hex(),
 		Ciphertext: ciphertext,
+		Version:    KeystoreVersion,
+		Scrypt:     params,
+	}, nil
+}
+
+// DecryptPrivateKey uses AES to decrypt the ciphertext of the keystore data into a
+// `crypto.PrivateKey` with a symmetric key derived from `password`
+func DecryptPrivateKey(keydata *EncryptedKeystore, password []byte) (crypto.PrivateKey, error) {
+	var params *ScryptParams
+	switch keydata.Version {
+	case 0:
+	case 1:
+		if keydata.Scrypt == nil {
+			return nil, errors.New("missing scrypt parameters")
+		}
+		params = keydata.Scrypt
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedKeystoreVersion, keydata.Version)
+	}
+
+	pk, err := Decrypt(keydata.Ciphertext, password, params)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodePrivateKey(pk, keydata.Type)
+}
+
+// EncryptAndWriteToFile encrypts the `crypto.PrivateKey` using the password and saves it to the specified file
+func EncryptAndWriteToFile(file *os.File, pk crypto.PrivateKey, password []byte) error {
+	keydata, err := EncryptPrivateKey(pk, password)
+	if err != nil {
+		return err
 	}
 
 	data, err := json.MarshalIndent(keydata, "", "\t")
@@ -143,6 +228,53 @@ func EncryptAndWriteToFile(file *os.File, pk crypto.PrivateKey, password []byte)
 
 // ReadFromFileAndDecrypt reads ciphertext from a file and decrypts it using the password into a `crypto.PrivateKey`
 func ReadFromFileAndDecrypt(filename string, password []byte) (crypto.PrivateKey, error) {
+	keydata, err := readKeystoreFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecryptPrivateKey(keydata, password)
+}
+
+// MigrateKeystoreFile rewrites the given keystore file in the current keystore format if it was written in an
+// older one, and returns whether it was migrated. The file is decrypted and encrypted again with the password.
+func MigrateKeystoreFile(filename string, password []byte) (bool, error) {
+	keydata, err := readKeystoreFile(filename)
+	if err != nil {
+		return false, err
+	}
+
+	if keydata.Version >= KeystoreVersion {
+		return false, nil
+	}
+
+	priv, err := DecryptPrivateKey(keydata, password)
+	if err != nil {
+		return false, fmt.Errorf("cannot decrypt key: %w", err)
+	}
+
+	newdata, err := EncryptPrivateKey(priv, password)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := json.MarshalIndent(newdata, "", "\t")
+	if err != nil {
+		return false, err
+	}
+
+	// write to a temporary file first, so that the key is not lost if writing fails
+	tmp := filename + ".tmp"
+	err = os.WriteFile(tmp, append(data, byte('\n')), 0600)
+	if err != nil {
+		return false, err
+	}
+
+	return true, os.Rename(tmp, filename)
+}
+
+// readKeystoreFile reads and decodes the keystore data of a keystore file
+func readKeystoreFile(filename string) (*EncryptedKeystore, error) {
 	fp, err := filepath.Abs(filename)
 	if err != nil {
 		return nil, err
@@ -159,5 +291,5 @@ func ReadFromFileAndDecrypt(filename string, password []byte) (crypto.PrivateKey
 		return nil, err
 	}
 
-	return DecryptPrivateKey(keydata.Ciphertext, password, keydata.Type)
+	return keydata, nil
 }
@@ -6,26 +6,35 @@ package keystore
 import (
 	"bytes"
 	"crypto/rand"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
 
+	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
 	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestEncryptAndDecrypt(t *testing.T) {
 	password := []byte("noot")
 	msg := []byte("helloworld")
 
-	ciphertext, err := Encrypt(msg, password)
+	params, err := NewScryptParams()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ciphertext, err := Encrypt(msg, password, params)
 	if err != nil {
 		t.Fatal(err)
 	}
 
-	res, err := Decrypt(ciphertext, password)
+	res, err := Decrypt(ciphertext, password, params)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -33,6 +42,15 @@ func TestEncryptAndDecrypt(t *testing.T) {
 	if !bytes.Equal(msg, res) {
 		t.Fatalf("Fail to decrypt: got %x expected %x", res, msg)
 	}
+
+	_, err = Decrypt(ciphertext, []byte("wrong"), params)
+	require.Error(t, err)
+
+	// another salt derives another key
+	other, err := NewScryptParams()
+	require.NoError(t, err)
+	_, err = Decrypt(ciphertext, password, other)
+	require.Error(t, err)
 }
 
 func TestEncryptAndDecryptPrivateKey(t *testing.T) {
@@ -53,8 +71,10 @@ func TestEncryptAndDecryptPrivateKey(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
+	require.Equal(t, KeystoreVersion, data.Version)
+	require.Equal(t, crypto.Ed25519Type, data.Type)
 
-	res, err := DecryptPrivateKey(data, password, "ed25519")
+	res, err := DecryptPrivateKey(data, password)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -158,3 +178,68 @@ func TestEncryptAndDecryptFromFile_Secp256k1(t *testing.T) {
 		t.Fatalf("Fail: got %v expected %v", res, priv)
 	}
 }
+
+// writeLegacyKeystoreFile writes the private key to a version 0 keystore file, whose encryption key is the
+// blake2b hash of the password
+func writeLegacyKeystoreFile(t *testing.T, fp string, priv crypto.PrivateKey, password []byte) {
+	gcm, err := gcmFromPassphrase(password, nil)
+	require.NoError(t, err)
+
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = rand.Read(nonce)
+	require.NoError(t, err)
+
+	pub, err := priv.Public()
+	require.NoError(t, err)
+
+	data, err := json.Marshal(&EncryptedKeystore{
+		Type:       crypto.Sr25519Type,
+		PublicKey:  pub.Hex(),
+		Ciphertext: gcm.Seal(nonce, nonce, priv.Encode(), nil),
+	})
+	require.NoError(t, err)
+
+	err = os.WriteFile(fp, data, 0600)
+	require.NoError(t, err)
+}
+
+func TestMigrateKeystoreFile(t *testing.T) {
+	password := []byte("noot")
+	fp := filepath.Join(t.TempDir(), "legacy.key")
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	writeLegacyKeystoreFile(t, fp, kp.Private(), password)
+
+	// version 0 files can still be read
+	res, err := ReadFromFileAndDecrypt(fp, password)
+	require.NoError(t, err)
+	require.Equal(t, kp.Private().Encode(), res.Encode())
+
+	_, err = MigrateKeystoreFile(fp, []byte("wrong"))
+	require.Error(t, err)
+
+	migrated, err := MigrateKeystoreFile(fp, password)
+	require.NoError(t, err)
+	require.True(t, migrated)
+
+	keydata, err := readKeystoreFile(fp)
+	require.NoError(t, err)
+	require.Equal(t, KeystoreVersion, keydata.Version)
+	require.Equal(t, DefaultScryptParams.N, keydata.Scrypt.N)
+	require.Len(t, keydata.Scrypt.Salt, scryptSaltLength)
+	require.Equal(t, kp.Public().Hex(), keydata.PublicKey)
+
+	res, err = ReadFromFileAndDecrypt(fp, password)
+	require.NoError(t, err)
+	require.Equal(t, kp.Private().Encode(), res.Encode())
+
+	migrated, err = MigrateKeystoreFile(fp, password)
+	require.NoError(t, err)
+	require.False(t, migrated)
+}
+
+func TestDecryptPrivateKey_UnsupportedVersion(t *testing.T) {
+	_, err := DecryptPrivateKey(&EncryptedKeystore{Version: KeystoreVersion + 1}, []byte("noot"))
+	require.ErrorIs(t, err, ErrUnsupportedKeystoreVersion)
+}
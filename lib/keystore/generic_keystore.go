@@ -17,7 +17,7 @@ import (
 // GenericKeystore holds keys of any type
 type GenericKeystore struct {
 	name Name
-	keys map[common.Address]crypto.Keypair // map of public key encodings to keypairs
+	keys map[string]crypto.Keypair // map of public key encodings to keypairs
 	lock sync.RWMutex
 }
 
@@ -25,7 +25,7 @@ type GenericKeystore struct {
 func NewGenericKeystore(name Name) *GenericKeystore {
 	return &GenericKeystore{
 		name: name,
-		keys: make(map[common.Address]crypto.Keypair),
+		keys: make(map[string]crypto.Keypair),
 	}
 }
 
@@ -49,9 +49,7 @@ func (ks *GenericKeystore) Insert(kp crypto.Keypair) error {
 	ks.lock.Lock()
 	defer ks.lock.Unlock()
 
-	pub := kp.Public()
-	addr := crypto.PublicKeyToAddress(pub)
-	ks.keys[addr] = kp
+	ks.keys[string(kp.Public().Encode())] = kp
 	return nil
 }
 
@@ -65,11 +63,17 @@ func (ks *GenericKeystore) GetKeypair(pub crypto.PublicKey) crypto.Keypair {
 	return nil
 }
 
-// GetKeypairFromAddress returns a keypair corresponding to the given address, or nil if it doesn't exist
-func (ks *GenericKeystore) GetKeypairFromAddress(pub common.Address) crypto.Keypair {
+// GetKeypairFromAddress returns a keypair corresponding to the given ss58 address of any address format,
+// or nil if it doesn't exist
+func (ks *GenericKeystore) GetKeypairFromAddress(addr common.Address) crypto.Keypair {
+	pub, _, err := crypto.DecodeSS58(addr)
+	if err != nil {
+		return nil
+	}
+
 	ks.lock.RLock()
 	defer ks.lock.RUnlock()
-	return ks.keys[pub]
+	return ks.keys[string(pub)]
 }
 
 // PublicKeys returns all public keys in the keystore
@@ -64,6 +64,23 @@ func DecodeKeyPairFromHex(keystr []byte, keytype crypto.KeyType) (kp crypto.Keyp
 	return kp, err
 }
 
+// DecodeKeyPairFromSecretURI returns the keypair of the given type derived from the given secret URI, which is
+// either a mnemonic or a 0x prefixed hex encoded seed, followed by an optional derivation path and password
+func DecodeKeyPairFromSecretURI(suri string, keytype crypto.KeyType) (kp crypto.Keypair, err error) {
+	switch keytype {
+	case crypto.Sr25519Type:
+		kp, err = sr25519.NewKeypairFromSecretURI(suri)
+	case crypto.Ed25519Type:
+		kp, err = ed25519.NewKeypairFromSecretURI(suri)
+	case crypto.Secp256k1Type:
+		kp, err = secp256k1.NewKeypairFromSecretURI(suri)
+	default:
+		return nil, errors.New("cannot decode key: invalid key type")
+	}
+
+	return kp, err
+}
+
 // GenerateKeypair create a new keypair with the corresponding type and saves
 // it to basepath/keystore/[public key].key in json format encrypted using the
 // specified password and returns the resulting filepath of the new key
@@ -233,6 +250,102 @@ func ImportRawPrivateKey(key, keytype, basepath string, password []byte) (string
 	return GenerateKeypair(keytype, kp, basepath, password)
 }
 
+// ImportSecretURI imports the keypair derived from the given secret URI, such as //Alice, and saves it to
+// basepath/keystore/[public key].key in json format encrypted using the specified password
+func ImportSecretURI(suri, keytype, basepath string, password []byte) (string, error) {
+	if keytype == "" {
+		keytype = crypto.Sr25519Type
+	}
+
+	kp, err := DecodeKeyPairFromSecretURI(suri, keytype)
+	if err != nil {
+		return "", fmt.Errorf("failed to import %s keypair: %w", keytype, err)
+	}
+
+	return GenerateKeypair(keytype, kp, basepath, password)
+}
+
+// ImportPolkadotJSON imports a key exported by polkadot.js in its encrypted JSON format, which is decrypted with the
+// password, and saves it to the keystore directory encrypted with the same password
+func ImportPolkadotJSON(fp, basepath string, password []byte) (string, error) {
+	data, err := os.ReadFile(filepath.Clean(fp))
+	if err != nil {
+		return "", fmt.Errorf("failed to read polkadot.js json file: %s", err)
+	}
+
+	pj := new(PolkadotJSON)
+	err = json.Unmarshal(data, pj)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode polkadot.js json: %s", err)
+	}
+
+	kp, err := DecryptPolkadotJSON(pj, password)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt polkadot.js json: %w", err)
+	}
+
+	return GenerateKeypair(kp.Type(), kp, basepath, password)
+}
+
+// ExportPolkadotJSON decrypts the key with the given hex encoded public key from the keystore directory with the
+// password, and returns it in the encrypted JSON format of polkadot.js, encrypted with the same password
+func ExportPolkadotJSON(pub, basepath string, password []byte) ([]byte, error) {
+	keyDir, err := utils.KeystoreDir(basepath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get keystore directory: %s", err)
+	}
+
+	pubBytes, err := common.HexToBytes(pub)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	priv, err := ReadFromFileAndDecrypt(filepath.Join(keyDir, hex.EncodeToString(pubBytes)+".key"), password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt key file: %w", err)
+	}
+
+	kp, err := PrivateKeyToKeypair(priv)
+	if err != nil {
+		return nil, err
+	}
+
+	pj, err := EncryptPolkadotJSON(kp, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.MarshalIndent(pj, "", "\t")
+}
+
+// MigrateKeys rewrites the key files of the keystore directory written in an older keystore format in the current
+// format, and returns the names of the migrated files. The files are decrypted with the password.
+func MigrateKeys(basepath string, password []byte) ([]string, error) {
+	keyDir, err := utils.KeystoreDir(basepath)
+	if err != nil {
+		return nil, err
+	}
+
+	keyFiles, err := utils.KeystoreFiles(basepath)
+	if err != nil {
+		return nil, err
+	}
+
+	var migrated []string
+	for _, keyFile := range keyFiles {
+		ok, err := MigrateKeystoreFile(filepath.Join(keyDir, keyFile), password)
+		if err != nil {
+			return migrated, fmt.Errorf("failed to migrate key file %s: %w", keyFile, err)
+		}
+
+		if ok {
+			migrated = append(migrated, keyFile)
+		}
+	}
+
+	return migrated, nil
+}
+
 // UnlockKeys unlocks keys specified by the --unlock flag with the passwords given by --password
 // and places them into the keystore
 func UnlockKeys(ks Keystore, dir, unlock, password string) error {
@@ -284,6 +397,12 @@ func UnlockKeys(ks Keystore, dir, unlock, password string) error {
 			return fmt.Errorf("failed to decrypt key file %s: %s", keyFile, err)
 		}
 
+		// the password is known to be valid, so that keys in an older keystore format can be migrated
+		_, err = MigrateKeystoreFile(keyDir+"/"+keyFile, []byte(passwords[i]))
+		if err != nil {
+			return fmt.Errorf("failed to migrate key file %s: %s", keyFile, err)
+		}
+
 		kp, err := PrivateKeyToKeypair(priv)
 		if err != nil {
 			return fmt.Errorf("failed to create keypair from private key %d: %s", idx, err)
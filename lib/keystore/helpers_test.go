@@ -291,6 +291,121 @@ func TestUnlockKeys(t *testing.T) {
 	}
 }
 
+func TestUnlockKeys_MigratesLegacyKey(t *testing.T) {
+	testdir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+
+	keyDir, err := utils.KeystoreDir(testdir)
+	require.NoError(t, err)
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	keyfile := filepath.Join(keyDir, kp.Public().Hex()[2:]+".key")
+	writeLegacyKeystoreFile(t, keyfile, kp.Private(), testPassword)
+
+	ks := NewBasicKeystore("test", crypto.Sr25519Type)
+	err = UnlockKeys(ks, testdir, "0", string(testPassword))
+	require.NoError(t, err)
+	require.Equal(t, kp.Public().Hex(), ks.GetKeypair(kp.Public()).Public().Hex())
+
+	keydata, err := readKeystoreFile(keyfile)
+	require.NoError(t, err)
+	require.Equal(t, KeystoreVersion, keydata.Version)
+}
+
+func TestImportSecretURI(t *testing.T) {
+	testdir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+
+	testCases := []struct {
+		keytype   string
+		publicKey string
+	}{
+		{"", "0xd43593c715fdd31c61141abd04a99fd6822c8558854ccde39a5684e7a56da27d"},
+		{"ed25519", "0x88dc3417d5058ec4b4503e0c12ea1a0a89be200fe98922423d4334014fa6b0ee"},
+		{"secp256k1", "0x020a1091341fe5664bfa1782d5e04779689068c916b04cb365ec3153755684d9a1"},
+	}
+
+	for _, test := range testCases {
+		keyfile, err := ImportSecretURI("//Alice", test.keytype, testdir, testPassword)
+		require.NoError(t, err)
+
+		contents, err := os.ReadFile(keyfile)
+		require.NoError(t, err)
+
+		kscontents := new(EncryptedKeystore)
+		err = json.Unmarshal(contents, kscontents)
+		require.NoError(t, err)
+		require.Equal(t, test.publicKey, kscontents.PublicKey)
+	}
+
+	_, err := ImportSecretURI("//Alice/soft", "ed25519", testdir, testPassword)
+	require.Error(t, err)
+}
+
+func TestImportAndExportPolkadotJSON(t *testing.T) {
+	testdir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+
+	kp, err := sr25519.NewKeypairFromSecretURI("//Alice")
+	require.NoError(t, err)
+
+	pj, err := EncryptPolkadotJSON(kp, testPassword)
+	require.NoError(t, err)
+	data, err := json.Marshal(pj)
+	require.NoError(t, err)
+
+	fp := filepath.Join(testdir, "alice.json")
+	err = os.WriteFile(fp, data, 0600)
+	require.NoError(t, err)
+
+	keyfile, err := ImportPolkadotJSON(fp, testdir, testPassword)
+	require.NoError(t, err)
+
+	priv, err := ReadFromFileAndDecrypt(keyfile, testPassword)
+	require.NoError(t, err)
+	require.Equal(t, kp.Private().Encode(), priv.Encode())
+
+	_, err = ImportPolkadotJSON(fp, testdir, []byte("wrong"))
+	require.Error(t, err)
+
+	data, err = ExportPolkadotJSON(kp.Public().Hex(), testdir, testPassword)
+	require.NoError(t, err)
+
+	exported := new(PolkadotJSON)
+	err = json.Unmarshal(data, exported)
+	require.NoError(t, err)
+	require.Equal(t, "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY", exported.Address)
+
+	res, err := DecryptPolkadotJSON(exported, testPassword)
+	require.NoError(t, err)
+	require.Equal(t, kp.Private().Encode(), res.Private().Encode())
+}
+
+func TestMigrateKeys(t *testing.T) {
+	testdir := utils.NewTestDir(t)
+	defer utils.RemoveTestDir(t)
+
+	keyDir, err := utils.KeystoreDir(testdir)
+	require.NoError(t, err)
+
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	legacy := kp.Public().Hex()[2:] + ".key"
+	writeLegacyKeystoreFile(t, filepath.Join(keyDir, legacy), kp.Private(), testPassword)
+
+	_, err = GenerateKeypair("", nil, testdir, testPassword)
+	require.NoError(t, err)
+
+	migrated, err := MigrateKeys(testdir, testPassword)
+	require.NoError(t, err)
+	require.Equal(t, []string{legacy}, migrated)
+
+	migrated, err = MigrateKeys(testdir, testPassword)
+	require.NoError(t, err)
+	require.Empty(t, migrated)
+}
+
 func TestImportRawPrivateKey_NoType(t *testing.T) {
 	testdir := utils.NewTestDir(t)
 	defer utils.RemoveTestDir(t)
@@ -0,0 +1,248 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+
+	"golang.org/x/crypto/nacl/secretbox"
+)
+
+const (
+	polkadotJSONVersion     = "3"
+	polkadotJSONNonceLength = 24
+	// the encoded scrypt parameters are the salt followed by N, p and r as little endian uint32
+	polkadotJSONParamsLength = scryptSaltLength + 12
+
+	polkadotJSONEcdsaType = "ecdsa"
+)
+
+var (
+	// ErrUnsupportedPolkadotJSON is returned when a polkadot.js JSON key is not encrypted with scrypt and
+	// xsalsa20-poly1305, or holds a key of an unsupported type
+	ErrUnsupportedPolkadotJSON = errors.New("unsupported polkadot.js json")
+
+	// pkcs8Header and pkcs8Divider delimit the secret key and the public key in the plaintext of a polkadot.js
+	// JSON key
+	pkcs8Header  = []byte{48, 83, 2, 1, 1, 48, 5, 6, 3, 43, 101, 112, 4, 34, 4, 32}
+	pkcs8Divider = []byte{161, 35, 3, 33, 0}
+)
+
+// PolkadotJSON is a key in the encrypted JSON format of polkadot.js, as exported by its wallets. The encoded key
+// is the scrypt parameters, followed by the nonce and the xsalsa20-poly1305 ciphertext of the PKCS8 encoded key.
+type PolkadotJSON struct {
+	Encoded  string                 `json:"encoded"`
+	Encoding PolkadotJSONEncoding   `json:"encoding"`
+	Address  string                 `json:"address"`
+	Meta     map[string]interface{} `json:"meta"`
+}
+
+// PolkadotJSONEncoding describes the content and the encryption of a polkadot.js JSON key
+type PolkadotJSONEncoding struct {
+	Content []string `json:"content"`
+	Type    []string `json:"type"`
+	Version string   `json:"version"`
+}
+
+// EncryptPolkadotJSON encrypts the keypair with the password in the polkadot.js JSON format
+func EncryptPolkadotJSON(kp crypto.Keypair, password []byte) (*PolkadotJSON, error) {
+	var (
+		keytype string
+		secret  []byte
+	)
+
+	switch priv := kp.Private().(type) {
+	case *sr25519.PrivateKey:
+		keytype = crypto.Sr25519Type
+		secret = priv.EncodeEd25519()
+	case *ed25519.PrivateKey:
+		keytype = crypto.Ed25519Type
+		secret = priv.Encode()
+	case *secp256k1.PrivateKey:
+		keytype = polkadotJSONEcdsaType
+		secret = priv.Encode()
+	default:
+		return nil, fmt.Errorf("%w: key type %s", ErrUnsupportedPolkadotJSON, kp.Type())
+	}
+
+	params, err := NewScryptParams()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := params.deriveKey(password)
+	if err != nil {
+		return nil, fmt.Errorf("cannot derive key: %w", err)
+	}
+
+	var nonce [polkadotJSONNonceLength]byte
+	if _, err = io.ReadFull(rand.Reader, nonce[:]); err != nil {
+		return nil, err
+	}
+
+	plaintext := make([]byte, 0, len(pkcs8Header)+len(secret)+len(pkcs8Divider)+len(kp.Public().Encode()))
+	plaintext = append(plaintext, pkcs8Header...)
+	plaintext = append(plaintext, secret...)
+	plaintext = append(plaintext, pkcs8Divider...)
+	plaintext = append(plaintext, kp.Public().Encode()...)
+
+	encoded := make([]byte, polkadotJSONParamsLength, polkadotJSONParamsLength+polkadotJSONNonceLength)
+	copy(encoded, params.Salt)
+	binary.LittleEndian.PutUint32(encoded[scryptSaltLength:], uint32(params.N))
+	binary.LittleEndian.PutUint32(encoded[scryptSaltLength+4:], uint32(params.P))
+	binary.LittleEndian.PutUint32(encoded[scryptSaltLength+8:], uint32(params.R))
+	encoded = append(encoded, nonce[:]...)
+	encoded = secretbox.Seal(encoded, plaintext, &nonce, secretboxKey(key))
+
+	addr, err := polkadotJSONAddress(kp.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	return &PolkadotJSON{
+		Encoded: base64.StdEncoding.EncodeToString(encoded),
+		Encoding: PolkadotJSONEncoding{
+			Content: []string{"pkcs8", keytype},
+			Type:    []string{"scrypt", "xsalsa20-poly1305"},
+			Version: polkadotJSONVersion,
+		},
+		Address: string(addr),
+		Meta: map[string]interface{}{
+			"whenCreated": time.Now().UnixNano() / int64(time.Millisecond),
+		},
+	}, nil
+}
+
+// DecryptPolkadotJSON decrypts the keypair of the polkadot.js JSON key with the password
+func DecryptPolkadotJSON(data *PolkadotJSON, password []byte) (crypto.Keypair, error) {
+	enc := data.Encoding
+	if enc.Version != polkadotJSONVersion || len(enc.Type) != 2 || enc.Type[0] != "scrypt" ||
+		enc.Type[1] != "xsalsa20-poly1305" || len(enc.Content) != 2 || enc.Content[0] != "pkcs8" {
+		return nil, fmt.Errorf("%w: version %q, encoding %v and content %v",
+			ErrUnsupportedPolkadotJSON, enc.Version, enc.Type, enc.Content)
+	}
+
+	encoded, err := base64.StdEncoding.DecodeString(data.Encoded)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode base64 encoded key: %w", err)
+	}
+
+	if len(encoded) < polkadotJSONParamsLength+polkadotJSONNonceLength+secretbox.Overhead {
+		return nil, errors.New("encoded key too short")
+	}
+
+	params := &ScryptParams{
+		N:    int(binary.LittleEndian.Uint32(encoded[scryptSaltLength:])),
+		P:    int(binary.LittleEndian.Uint32(encoded[scryptSaltLength+4:])),
+		R:    int(binary.LittleEndian.Uint32(encoded[scryptSaltLength+8:])),
+		Salt: encoded[:scryptSaltLength],
+	}
+
+	key, err := params.deriveKey(password)
+	if err != nil {
+		return nil, fmt.Errorf("cannot derive key: %w", err)
+	}
+
+	var nonce [polkadotJSONNonceLength]byte
+	copy(nonce[:], encoded[polkadotJSONParamsLength:])
+
+	plaintext, ok := secretbox.Open(nil, encoded[polkadotJSONParamsLength+polkadotJSONNonceLength:], &nonce,
+		secretboxKey(key))
+	if !ok {
+		return nil, errors.New("cannot decrypt key: invalid password")
+	}
+
+	secret, pub, err := decodePKCS8(plaintext)
+	if err != nil {
+		return nil, err
+	}
+
+	var kp crypto.Keypair
+	switch enc.Content[1] {
+	case crypto.Sr25519Type:
+		var priv *sr25519.PrivateKey
+		priv, err = sr25519.NewPrivateKeyFromEd25519Bytes(secret)
+		if err != nil {
+			return nil, err
+		}
+		kp, err = sr25519.NewKeypairFromPrivate(priv)
+	case crypto.Ed25519Type:
+		var priv *ed25519.PrivateKey
+		priv, err = ed25519.NewPrivateKey(secret)
+		if err != nil {
+			return nil, err
+		}
+		kp, err = ed25519.NewKeypairFromPrivate(priv)
+	case polkadotJSONEcdsaType:
+		var priv *secp256k1.PrivateKey
+		priv, err = secp256k1.NewPrivateKey(secret)
+		if err != nil {
+			return nil, err
+		}
+		kp, err = secp256k1.NewKeypairFromPrivate(priv)
+	default:
+		return nil, fmt.Errorf("%w: key type %s", ErrUnsupportedPolkadotJSON, enc.Content[1])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(kp.Public().Encode(), pub) {
+		return nil, fmt.Errorf("public key 0x%x does not match the secret key", pub)
+	}
+
+	return kp, nil
+}
+
+// decodePKCS8 returns the secret key and the public key of the PKCS8 encoded key of a polkadot.js JSON key.
+// The secret key is 64 bytes long for sr25519 and ed25519 keys, and 32 bytes long for ecdsa keys.
+func decodePKCS8(in []byte) (secret, pub []byte, err error) {
+	if !bytes.HasPrefix(in, pkcs8Header) {
+		return nil, nil, errors.New("invalid pkcs8 header")
+	}
+
+	in = in[len(pkcs8Header):]
+	for _, length := range []int{64, 32} {
+		if len(in) > length && bytes.HasPrefix(in[length:], pkcs8Divider) {
+			return in[:length], in[length+len(pkcs8Divider):], nil
+		}
+	}
+
+	return nil, nil, errors.New("invalid pkcs8 divider")
+}
+
+// secretboxKey returns the first 32 bytes of the derived key as a secretbox key
+func secretboxKey(key []byte) *[32]byte {
+	var k [32]byte
+	copy(k[:], key)
+	return &k
+}
+
+// polkadotJSONAddress returns the address of the public key, which for ecdsa keys is the address of the blake2b
+// hash of the public key, in the SS58 format of the chain
+func polkadotJSONAddress(pub crypto.PublicKey) (common.Address, error) {
+	payload := pub.Encode()
+	if _, ok := pub.(*secp256k1.PublicKey); ok {
+		h, err := common.Blake2bHash(payload)
+		if err != nil {
+			return "", err
+		}
+		payload = h[:]
+	}
+
+	return crypto.EncodeSS58(payload, crypto.SS58Format())
+}
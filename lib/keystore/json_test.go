@@ -0,0 +1,110 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptAndDecryptPolkadotJSON(t *testing.T) {
+	password := []byte("noot")
+
+	srkp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	edkp, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+	secpkp, err := secp256k1.GenerateKeypair()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		kp      crypto.Keypair
+		keytype string
+	}{
+		{srkp, "sr25519"},
+		{edkp, "ed25519"},
+		{secpkp, "ecdsa"},
+	}
+
+	for _, test := range testCases {
+		pj, err := EncryptPolkadotJSON(test.kp, password)
+		require.NoError(t, err)
+		require.Equal(t, []string{"pkcs8", test.keytype}, pj.Encoding.Content)
+		require.Equal(t, []string{"scrypt", "xsalsa20-poly1305"}, pj.Encoding.Type)
+		require.Equal(t, "3", pj.Encoding.Version)
+
+		addr, err := polkadotJSONAddress(test.kp.Public())
+		require.NoError(t, err)
+		require.Equal(t, string(addr), pj.Address)
+
+		kp, err := DecryptPolkadotJSON(pj, password)
+		require.NoError(t, err)
+		require.Equal(t, test.kp.Public().Encode(), kp.Public().Encode())
+		require.Equal(t, test.kp.Private().Encode(), kp.Private().Encode())
+
+		_, err = DecryptPolkadotJSON(pj, []byte("wrong"))
+		require.Error(t, err)
+	}
+
+	// the secret of an sr25519 key holds its nonce, which signs along with the key
+	kp, err := DecryptPolkadotJSON(mustEncryptPolkadotJSON(t, srkp, password), password)
+	require.NoError(t, err)
+	msg := []byte("helloworld")
+	sig, err := kp.Sign(msg)
+	require.NoError(t, err)
+	ok, err := srkp.Public().Verify(msg, sig)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestDecryptPolkadotJSON_Invalid(t *testing.T) {
+	password := []byte("noot")
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	pj := mustEncryptPolkadotJSON(t, kp, password)
+	pj.Encoding.Type = []string{"xsalsa20-poly1305"}
+	_, err = DecryptPolkadotJSON(pj, password)
+	require.ErrorIs(t, err, ErrUnsupportedPolkadotJSON)
+
+	pj = mustEncryptPolkadotJSON(t, kp, password)
+	pj.Encoding.Content = []string{"pkcs8", "ethereum"}
+	_, err = DecryptPolkadotJSON(pj, password)
+	require.ErrorIs(t, err, ErrUnsupportedPolkadotJSON)
+
+	pj = mustEncryptPolkadotJSON(t, kp, password)
+	pj.Encoded = base64.StdEncoding.EncodeToString([]byte{1, 2, 3})
+	_, err = DecryptPolkadotJSON(pj, password)
+	require.Error(t, err)
+}
+
+func TestDecodePKCS8(t *testing.T) {
+	secret := make([]byte, 32)
+	pub := []byte{2, 3}
+	in := append(append(append(append([]byte{}, pkcs8Header...), secret...), pkcs8Divider...), pub...)
+
+	s, p, err := decodePKCS8(in)
+	require.NoError(t, err)
+	require.Equal(t, secret, s)
+	require.Equal(t, pub, p)
+
+	_, _, err = decodePKCS8(in[1:])
+	require.Error(t, err)
+
+	_, _, err = decodePKCS8(append(append([]byte{}, pkcs8Header...), secret...))
+	require.Error(t, err)
+}
+
+func mustEncryptPolkadotJSON(t *testing.T, kp crypto.Keypair, password []byte) *PolkadotJSON {
+	pj, err := EncryptPolkadotJSON(kp, password)
+	require.NoError(t, err)
+	return pj
+}
@@ -64,6 +64,11 @@ func NewGlobalKeystore() *GlobalKeystore {
 	}
 }
 
+// Keystores returns all the keystores of the GlobalKeystore
+func (k *GlobalKeystore) Keystores() []Keystore {
+	return []Keystore{k.Babe, k.Gran, k.Acco, k.Aura, k.Imon, k.Audi, k.Dumy}
+}
+
 // GetKeystore returns a keystore given its name
 func (k *GlobalKeystore) GetKeystore(name []byte) (Keystore, error) {
 	nameStr := Name(name)
@@ -0,0 +1,124 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/utils"
+)
+
+// sessionKeysDir is the directory of the keystore directory the session keys generated by the runtime are
+// persisted to. They are kept apart from the keys unlocked by index, so that generating keys does not change
+// the keys the indices refer to.
+const sessionKeysDir = "session"
+
+// ErrNoSessionKeysPassword is returned when session keys are persisted without a password to encrypt them with
+var ErrNoSessionKeysPassword = errors.New("no password to encrypt session keys with")
+
+// SaveSessionKey encrypts the session key of the named keystore with the password and writes it to the session
+// keys directory of the keystore directory of the base path. It returns the path of the written file.
+func SaveSessionKey(basepath string, name Name, kp crypto.Keypair, password []byte) (string, error) {
+	if len(password) == 0 {
+		return "", ErrNoSessionKeysPassword
+	}
+
+	keyPath, err := utils.KeystoreDir(basepath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get keystore directory: %s", err)
+	}
+
+	dir := filepath.Join(keyPath, sessionKeysDir, string(name))
+	err = os.MkdirAll(dir, 0700)
+	if err != nil {
+		return "", fmt.Errorf("failed to create session keys directory: %w", err)
+	}
+
+	fp := filepath.Join(dir, hex.EncodeToString(kp.Public().Encode())+".key")
+	file, err := os.OpenFile(filepath.Clean(fp), os.O_EXCL|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	err = EncryptAndWriteToFile(file, kp.Private(), password)
+	if err != nil {
+		_ = file.Close()
+		return "", fmt.Errorf("failed to write key to file: %s", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return "", fmt.Errorf("failed to close file: %s", err)
+	}
+
+	return fp, nil
+}
+
+// LoadSessionKeys decrypts the session keys persisted by SaveSessionKey to the base path with the password and
+// inserts them into the keystores of their names. It returns the number of keys loaded.
+func LoadSessionKeys(ks *GlobalKeystore, basepath string, password []byte) (int, error) {
+	keyPath, err := utils.KeystoreDir(basepath)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get keystore directory: %s", err)
+	}
+
+	dirs, err := os.ReadDir(filepath.Join(keyPath, sessionKeysDir))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read session keys directory: %w", err)
+	}
+
+	if len(dirs) > 0 && len(password) == 0 {
+		return 0, ErrNoSessionKeysPassword
+	}
+
+	var loaded int
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+
+		named, err := ks.GetKeystore([]byte(dir.Name()))
+		if err != nil {
+			return loaded, fmt.Errorf("failed to get keystore of session keys directory %s: %w", dir.Name(), err)
+		}
+
+		files, err := os.ReadDir(filepath.Join(keyPath, sessionKeysDir, dir.Name()))
+		if err != nil {
+			return loaded, fmt.Errorf("failed to read session keys directory: %w", err)
+		}
+
+		for _, f := range files {
+			if filepath.Ext(f.Name()) != ".key" {
+				continue
+			}
+
+			fp := filepath.Join(keyPath, sessionKeysDir, dir.Name(), f.Name())
+			priv, err := ReadFromFileAndDecrypt(fp, password)
+			if err != nil {
+				return loaded, fmt.Errorf("failed to decrypt session key file %s: %w", fp, err)
+			}
+
+			kp, err := PrivateKeyToKeypair(priv)
+			if err != nil {
+				return loaded, fmt.Errorf("failed to create keypair from session key file %s: %w", fp, err)
+			}
+
+			err = named.Insert(kp)
+			if err != nil {
+				return loaded, fmt.Errorf("failed to insert session key in keystore %s: %w", named.Name(), err)
+			}
+			loaded++
+		}
+	}
+
+	return loaded, nil
+}
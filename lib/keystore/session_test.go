@@ -0,0 +1,55 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package keystore
+
+import (
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/utils"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAndLoadSessionKeys(t *testing.T) {
+	basePath := t.TempDir()
+	password := []byte("password")
+
+	babeKp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	granKp, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	_, err = SaveSessionKey(basePath, BabeName, babeKp, nil)
+	require.ErrorIs(t, err, ErrNoSessionKeysPassword)
+
+	_, err = SaveSessionKey(basePath, BabeName, babeKp, password)
+	require.NoError(t, err)
+	_, err = SaveSessionKey(basePath, GranName, granKp, password)
+	require.NoError(t, err)
+
+	// session keys are not unlocked by index
+	files, err := utils.KeystoreFiles(basePath)
+	require.NoError(t, err)
+	require.Empty(t, files)
+
+	ks := NewGlobalKeystore()
+	_, err = LoadSessionKeys(ks, basePath, nil)
+	require.ErrorIs(t, err, ErrNoSessionKeysPassword)
+
+	_, err = LoadSessionKeys(ks, basePath, []byte("wrong"))
+	require.Error(t, err)
+
+	ks = NewGlobalKeystore()
+	loaded, err := LoadSessionKeys(ks, basePath, password)
+	require.NoError(t, err)
+	require.Equal(t, 2, loaded)
+	require.Equal(t, babeKp.Public(), ks.Babe.GetKeypair(babeKp.Public()).Public())
+	require.Equal(t, granKp.Public(), ks.Gran.GetKeypair(granKp.Public()).Public())
+
+	loaded, err = LoadSessionKeys(NewGlobalKeystore(), t.TempDir(), nil)
+	require.NoError(t, err)
+	require.Zero(t, loaded)
+}
@@ -0,0 +1,238 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package runtime
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// Identifiers of the runtime APIs, as listed in the API items of the runtime version
+var (
+	CoreAPIID                   = APIID("Core")
+	TaggedTransactionQueueAPIID = APIID("TaggedTransactionQueue")
+	BabeAPIID                   = APIID("BabeApi")
+	TransactionPaymentAPIID     = APIID("TransactionPaymentApi")
+)
+
+// coreVersionWithTransactionVersion is the version of the Core API from which the runtime
+// version holds the transaction version
+const coreVersionWithTransactionVersion = 3
+
+// APIID returns the identifier of the runtime API with the given name, which is the 64-bit blake2b hash of the name
+func APIID(name string) [8]byte {
+	var id [8]byte
+	h, err := common.Blake2b8([]byte(name))
+	if err != nil {
+		panic(err)
+	}
+
+	copy(id[:], h)
+	return id
+}
+
+// APIVersion returns the version of the runtime API with the given identifier implemented by the runtime of the
+// given version. It returns false if the runtime does not implement the API.
+func APIVersion(v Version, id [8]byte) (uint32, bool) {
+	for _, item := range v.APIItems() {
+		if item.Name == id {
+			return item.Ver, true
+		}
+	}
+
+	return 0, false
+}
+
+// DecodeVersion decodes the runtime version returned by Core_version. The transaction version is only decoded
+// if the version of the Core API implemented by the runtime has it, older runtimes returning a LegacyVersionData.
+func DecodeVersion(in []byte) (Version, error) {
+	var legacy legacyVersionData
+	decoder := scale.NewDecoder(bytes.NewReader(in))
+	err := decoder.Decode(&legacy)
+	if err != nil {
+		return nil, err
+	}
+
+	lversion := NewLegacyVersionData(legacy.SpecName, legacy.ImplName, legacy.AuthoringVersion,
+		legacy.SpecVersion, legacy.ImplVersion, legacy.APIItems)
+
+	coreVersion, ok := APIVersion(lversion, CoreAPIID)
+	if !ok || coreVersion < coreVersionWithTransactionVersion {
+		return lversion, nil
+	}
+
+	var transactionVersion uint32
+	err = decoder.Decode(&transactionVersion)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode transaction version: %w", err)
+	}
+
+	return NewVersionData(legacy.SpecName, legacy.ImplName, legacy.AuthoringVersion,
+		legacy.SpecVersion, legacy.ImplVersion, legacy.APIItems, transactionVersion), nil
+}
+
+// ValidateTransactionArgs returns the arguments of TaggedTransactionQueue_validate_transaction for the given
+// version of the TaggedTransactionQueue API. The given extrinsic is prefixed with its transaction source and
+// the block hash is the hash of the block the transaction is validated at.
+//   - version 1 takes the extrinsic only
+//   - version 2 takes the transaction source and the extrinsic
+//   - version 3 takes the transaction source, the extrinsic and the block hash
+func ValidateTransactionArgs(apiVersion uint32, e types.Extrinsic, blockHash common.Hash) ([]byte, error) {
+	if len(e) == 0 {
+		return nil, ErrCannotValidateTx
+	}
+
+	switch apiVersion {
+	case 1:
+		return e[1:], nil
+	case 2:
+		return e, nil
+	default:
+		args := make([]byte, 0, len(e)+common.HashLength)
+		args = append(args, e...)
+		return append(args, blockHash.ToBytes()...), nil
+	}
+}
+
+// babeConfigurationV1 is the BABE configuration returned by version 1 of the BabeApi, which only tells whether
+// secondary slots are allowed
+type babeConfigurationV1 struct {
+	SlotDuration       uint64
+	EpochLength        uint64
+	C1                 uint64
+	C2                 uint64
+	GenesisAuthorities []types.AuthorityRaw
+	Randomness         [types.RandomnessLength]byte
+	SecondarySlots     bool
+}
+
+// BabeEpoch is the epoch returned by BabeApi_current_epoch
+type BabeEpoch struct {
+	EpochIndex   uint64
+	StartSlot    uint64
+	Duration     uint64
+	Authorities  []types.AuthorityRaw
+	Randomness   [types.RandomnessLength]byte
+	C1           uint64
+	C2           uint64
+	AllowedSlots byte
+}
+
+// DecodeBabeConfiguration decodes the output of BabeApi_configuration for the given version of the BabeApi.
+//   - version 1 returns whether secondary slots are allowed, in which case primary and secondary plain
+//     slots are allowed
+//   - version 2 returns the allowed slots
+func DecodeBabeConfiguration(apiVersion uint32, in []byte) (*types.BabeConfiguration, error) {
+	if apiVersion > 1 {
+		bc := new(types.BabeConfiguration)
+		err := scale.Unmarshal(in, bc)
+		if err != nil {
+			return nil, err
+		}
+
+		return bc, nil
+	}
+
+	var v1 babeConfigurationV1
+	err := scale.Unmarshal(in, &v1)
+	if err != nil {
+		return nil, err
+	}
+
+	bc := &types.BabeConfiguration{
+		SlotDuration:       v1.SlotDuration,
+		EpochLength:        v1.EpochLength,
+		C1:                 v1.C1,
+		C2:                 v1.C2,
+		GenesisAuthorities: v1.GenesisAuthorities,
+		Randomness:         v1.Randomness,
+	}
+
+	if v1.SecondarySlots {
+		bc.SecondarySlots = 1
+	}
+
+	return bc, nil
+}
+
+// DecodeBabeEpoch decodes the output of BabeApi_current_epoch
+func DecodeBabeEpoch(in []byte) (*BabeEpoch, error) {
+	epoch := new(BabeEpoch)
+	err := scale.Unmarshal(in, epoch)
+	if err != nil {
+		return nil, err
+	}
+
+	return epoch, nil
+}
+
+// SetBabeCurrentEpoch sets the authorities, randomness and epoch configuration of the BABE configuration to the
+// ones of the epoch returned by BabeApi_current_epoch. Since version 2 of the BabeApi, the configuration returned
+// by BabeApi_configuration holds the genesis epoch configuration, which may since have been changed.
+func SetBabeCurrentEpoch(bc *types.BabeConfiguration, in []byte) error {
+	epoch, err := DecodeBabeEpoch(in)
+	if err != nil {
+		return err
+	}
+
+	bc.GenesisAuthorities = epoch.Authorities
+	bc.Randomness = epoch.Randomness
+	bc.C1 = epoch.C1
+	bc.C2 = epoch.C2
+	bc.SecondarySlots = epoch.AllowedSlots
+	return nil
+}
+
+// queryInfoV1 is the dispatch information returned by version 1 of the TransactionPaymentApi
+type queryInfoV1 struct {
+	Weight     uint64
+	Class      uint8
+	PartialFee *scale.Uint128
+}
+
+// queryInfo is the dispatch information returned by the TransactionPaymentApi since version 2, where the
+// weight holds the compact encoded reference time and proof size
+type queryInfo struct {
+	RefTime    uint
+	ProofSize  uint
+	Class      uint8
+	PartialFee *scale.Uint128
+}
+
+// DecodePaymentQueryInfo decodes the output of TransactionPaymentApi_query_info for the given version of the
+// TransactionPaymentApi.
+//   - version 1 returns the weight as a u64
+//   - version 2 and later return the weight as its reference time and proof size, the reference time
+//     being the weight of version 1
+func DecodePaymentQueryInfo(apiVersion uint32, in []byte) (*types.TransactionPaymentQueryInfo, error) {
+	if apiVersion > 1 {
+		var info queryInfo
+		err := scale.Unmarshal(in, &info)
+		if err != nil {
+			return nil, err
+		}
+
+		return &types.TransactionPaymentQueryInfo{
+			Weight:     uint64(info.RefTime),
+			Class:      int(info.Class),
+			PartialFee: info.PartialFee,
+		}, nil
+	}
+
+	var info queryInfoV1
+	err := scale.Unmarshal(in, &info)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TransactionPaymentQueryInfo{
+		Weight:     info.Weight,
+		Class:      int(info.Class),
+		PartialFee: info.PartialFee,
+	}, nil
+}
@@ -0,0 +1,197 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package runtime
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIID(t *testing.T) {
+	testCases := []struct {
+		name string
+		id   [8]byte
+	}{
+		{"Core", [8]byte{0xdf, 0x6a, 0xcb, 0x68, 0x99, 0x07, 0x60, 0x9b}},
+		{"TaggedTransactionQueue", [8]byte{0xd2, 0xbc, 0x98, 0x97, 0xee, 0xd0, 0x8f, 0x15}},
+		{"BabeApi", [8]byte{0xcb, 0xca, 0x25, 0xe3, 0x9f, 0x14, 0x23, 0x87}},
+		{"TransactionPaymentApi", [8]byte{0x37, 0xc8, 0xbb, 0x13, 0x50, 0xa9, 0xa2, 0xa8}},
+	}
+
+	for _, test := range testCases {
+		require.Equal(t, test.id, APIID(test.name), test.name)
+	}
+}
+
+func TestDecodeVersion(t *testing.T) {
+	legacy := NewLegacyVersionData(
+		[]byte("polkadot"),
+		[]byte("parity-polkadot"),
+		0,
+		25,
+		0,
+		[]APIItem{{Name: CoreAPIID, Ver: 2}},
+	)
+
+	enc, err := legacy.Encode()
+	require.NoError(t, err)
+
+	dec, err := DecodeVersion(enc)
+	require.NoError(t, err)
+	require.Equal(t, legacy, dec)
+
+	version := NewVersionData(
+		[]byte("polkadot"),
+		[]byte("parity-polkadot"),
+		0,
+		25,
+		0,
+		[]APIItem{{Name: CoreAPIID, Ver: 3}, {Name: TaggedTransactionQueueAPIID, Ver: 2}},
+		5,
+	)
+
+	enc, err = version.Encode()
+	require.NoError(t, err)
+
+	dec, err = DecodeVersion(enc)
+	require.NoError(t, err)
+	require.Equal(t, version, dec)
+
+	ver, ok := APIVersion(dec, TaggedTransactionQueueAPIID)
+	require.True(t, ok)
+	require.Equal(t, uint32(2), ver)
+
+	_, ok = APIVersion(dec, BabeAPIID)
+	require.False(t, ok)
+
+	// a Core v3 runtime version missing the transaction version
+	_, err = DecodeVersion(enc[:len(enc)-4])
+	require.Error(t, err)
+}
+
+func TestValidateTransactionArgs(t *testing.T) {
+	ext := types.Extrinsic{byte(types.TxnExternal), 1, 2, 3}
+	blockHash := common.Hash{0xa, 0xb}
+
+	args, err := ValidateTransactionArgs(1, ext, blockHash)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3}, args)
+
+	args, err = ValidateTransactionArgs(2, ext, blockHash)
+	require.NoError(t, err)
+	require.Equal(t, []byte(ext), args)
+
+	args, err = ValidateTransactionArgs(3, ext, blockHash)
+	require.NoError(t, err)
+	require.Equal(t, append([]byte{byte(types.TxnExternal), 1, 2, 3}, blockHash.ToBytes()...), args)
+
+	_, err = ValidateTransactionArgs(3, types.Extrinsic{}, blockHash)
+	require.ErrorIs(t, err, ErrCannotValidateTx)
+}
+
+func TestDecodeBabeConfiguration(t *testing.T) {
+	authorities := []types.AuthorityRaw{{Key: [32]byte{1}, Weight: 1}}
+
+	v1 := babeConfigurationV1{
+		SlotDuration:       6000,
+		EpochLength:        200,
+		C1:                 1,
+		C2:                 4,
+		GenesisAuthorities: authorities,
+		Randomness:         [types.RandomnessLength]byte{2},
+		SecondarySlots:     true,
+	}
+
+	enc, err := scale.Marshal(v1)
+	require.NoError(t, err)
+
+	expected := &types.BabeConfiguration{
+		SlotDuration:       6000,
+		EpochLength:        200,
+		C1:                 1,
+		C2:                 4,
+		GenesisAuthorities: authorities,
+		Randomness:         [types.RandomnessLength]byte{2},
+		SecondarySlots:     1,
+	}
+
+	bc, err := DecodeBabeConfiguration(1, enc)
+	require.NoError(t, err)
+	require.Equal(t, expected, bc)
+
+	// version 2 returns the allowed slots, such as primary and secondary VRF slots
+	expected.SecondarySlots = 2
+	enc, err = scale.Marshal(*expected)
+	require.NoError(t, err)
+
+	bc, err = DecodeBabeConfiguration(2, enc)
+	require.NoError(t, err)
+	require.Equal(t, expected, bc)
+
+	epoch := BabeEpoch{
+		EpochIndex:   3,
+		StartSlot:    600,
+		Duration:     200,
+		Authorities:  []types.AuthorityRaw{{Key: [32]byte{3}, Weight: 1}},
+		Randomness:   [types.RandomnessLength]byte{4},
+		C1:           1,
+		C2:           2,
+		AllowedSlots: 0,
+	}
+
+	enc, err = scale.Marshal(epoch)
+	require.NoError(t, err)
+
+	err = SetBabeCurrentEpoch(bc, enc)
+	require.NoError(t, err)
+	require.Equal(t, &types.BabeConfiguration{
+		SlotDuration:       6000,
+		EpochLength:        200,
+		C1:                 1,
+		C2:                 2,
+		GenesisAuthorities: epoch.Authorities,
+		Randomness:         epoch.Randomness,
+		SecondarySlots:     0,
+	}, bc)
+}
+
+func TestDecodePaymentQueryInfo(t *testing.T) {
+	fee := scale.MustNewUint128(big.NewInt(1000))
+
+	enc, err := scale.Marshal(queryInfoV1{
+		Weight:     1973000,
+		Class:      1,
+		PartialFee: fee,
+	})
+	require.NoError(t, err)
+
+	expected := &types.TransactionPaymentQueryInfo{
+		Weight:     1973000,
+		Class:      1,
+		PartialFee: fee,
+	}
+
+	info, err := DecodePaymentQueryInfo(1, enc)
+	require.NoError(t, err)
+	require.Equal(t, expected, info)
+
+	// version 2 returns the reference time and proof size of the weight
+	enc, err = scale.Marshal(queryInfo{
+		RefTime:    1973000,
+		ProofSize:  3593,
+		Class:      1,
+		PartialFee: fee,
+	})
+	require.NoError(t, err)
+
+	info, err = DecodePaymentQueryInfo(2, enc)
+	require.NoError(t, err)
+	require.Equal(t, expected, info)
+}
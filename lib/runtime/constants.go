@@ -57,6 +57,8 @@ var (
 	GrandpaAuthorities = "GrandpaApi_grandpa_authorities"
 	// BabeAPIConfiguration is the runtime API call BabeApi_configuration
 	BabeAPIConfiguration = "BabeApi_configuration"
+	// BabeAPICurrentEpoch is the runtime API call BabeApi_current_epoch
+	BabeAPICurrentEpoch = "BabeApi_current_epoch"
 	// BlockBuilderInherentExtrinsics is the runtime API call BlockBuilder_inherent_extrinsics
 	BlockBuilderInherentExtrinsics = "BlockBuilder_inherent_extrinsics"
 	// BlockBuilderApplyExtrinsic is the runtime API call BlockBuilder_apply_extrinsic
@@ -65,6 +67,8 @@ var (
 	BlockBuilderFinalizeBlock = "BlockBuilder_finalize_block"
 	// DecodeSessionKeys is the runtime API call SessionKeys_decode_session_keys
 	DecodeSessionKeys = "SessionKeys_decode_session_keys"
+	// GenerateSessionKeys is the runtime API call SessionKeys_generate_session_keys
+	GenerateSessionKeys = "SessionKeys_generate_session_keys"
 	// TransactionPaymentAPIQueryInfo returns information of a given extrinsic
 	TransactionPaymentAPIQueryInfo = "TransactionPaymentApi_query_info"
 )
@@ -88,6 +92,25 @@ func BABERandomnessKey() []byte {
 	return append(BABEPrefix, key...)
 }
 
+// BABENextAuthoritiesKey is the location of the BABE authorities of the next epoch in the storage trie
+func BABENextAuthoritiesKey() []byte {
+	key, _ := common.Twox128Hash([]byte("NextAuthorities"))
+	return append(BABEPrefix, key...)
+}
+
+// BABENextRandomnessKey is the location of the BABE randomness of the next epoch in the storage trie
+func BABENextRandomnessKey() []byte {
+	key, _ := common.Twox128Hash([]byte("NextRandomness"))
+	return append(BABEPrefix, key...)
+}
+
+// BABENextEpochConfigKey is the location of the BABE configuration of the next epoch in the storage trie.
+// It is only set if the configuration changes in the next epoch.
+func BABENextEpochConfigKey() []byte {
+	key, _ := common.Twox128Hash([]byte("NextEpochConfig"))
+	return append(BABEPrefix, key...)
+}
+
 // SystemAccountPrefix is the prefix for all System Account related storage values
 func SystemAccountPrefix() []byte {
 	// build prefix
@@ -20,5 +20,8 @@ var ErrInvalidTransaction = &json2.Error{Code: 1010, Message: "Invalid Transacti
 // TaggedTransactionQueueValidateTransaction fails with value of [1, 1, x]
 var ErrUnknownTransaction = &json2.Error{Code: 1011, Message: "Unknown Transaction Validity"}
 
+// ErrAPINotImplemented is returned when calling a runtime API which is not implemented by the runtime
+var ErrAPINotImplemented = errors.New("runtime API is not implemented by the runtime")
+
 // ErrNilStorage is returned when the runtime context storage isn't set
 var ErrNilStorage = errors.New("runtime context storage is nil")
@@ -7,6 +7,7 @@ import (
 	"github.com/ChainSafe/gossamer/dot/types"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/lib/signer"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/lib/trie"
 )
@@ -18,9 +19,11 @@ type Instance interface {
 	UpdateRuntimeCode([]byte) error
 	CheckRuntimeVersion([]byte) (Version, error)
 	Stop()
+	Clone() (Instance, error)
 	NodeStorage() NodeStorage
 	NetworkService() BasicNetwork
 	Keystore() *keystore.GlobalKeystore
+	Signer() signer.Signer
 	Validator() bool
 	Exec(function string, data []byte) ([]byte, error)
 	SetContextStorage(s Storage) // used to set the TrieState before a runtime call
@@ -30,13 +33,14 @@ type Instance interface {
 	Metadata() ([]byte, error)
 	BabeConfiguration() (*types.BabeConfiguration, error)
 	GrandpaAuthorities() ([]types.Authority, error)
-	ValidateTransaction(e types.Extrinsic) (*transaction.Validity, error)
+	ValidateTransaction(e types.Extrinsic, blockHash common.Hash) (*transaction.Validity, error)
 	InitializeBlock(header *types.Header) error
 	InherentExtrinsics(data []byte) ([]byte, error)
 	ApplyExtrinsic(data types.Extrinsic) ([]byte, error)
 	FinalizeBlock() (*types.Header, error)
 	ExecuteBlock(block *types.Block) ([]byte, error)
 	DecodeSessionKeys(enc []byte) ([]byte, error)
+	GenerateSessionKeys() ([]byte, error)
 	PaymentQueryInfo(ext []byte) (*types.TransactionPaymentQueryInfo, error)
 
 	CheckInherents() // TODO: use this in block verification process (#1873)
@@ -44,7 +48,6 @@ type Instance interface {
 	// parameters and return values for these are undefined in the spec
 	RandomSeed()
 	OffchainWorker()
-	GenerateSessionKeys()
 }
 
 // Storage interface
@@ -66,8 +69,8 @@ type Storage interface {
 	ClearPrefix(prefix []byte) error
 	ClearPrefixLimit(prefix []byte, limit uint32) (uint32, bool)
 	BeginStorageTransaction()
-	CommitStorageTransaction()
-	RollbackStorageTransaction()
+	CommitStorageTransaction() error
+	RollbackStorageTransaction() error
 	LoadCode() []byte
 }
 
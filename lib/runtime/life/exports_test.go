@@ -317,3 +317,28 @@ func TestInstance_ExecuteBlock_PolkadotRuntime_PolkadotBlock1(t *testing.T) {
 	_, err = instance.ExecuteBlock(block)
 	require.NoError(t, err)
 }
+
+func TestInstance_RuntimePool(t *testing.T) {
+	instance := NewTestInstance(t, runtime.NODE_RUNTIME)
+
+	// instances share the package level context, so a life runtime is pooled as a single shared instance
+	_, err := instance.Clone()
+	require.ErrorIs(t, err, runtime.ErrCloneNotSupported)
+
+	_, err = runtime.NewInstancePool(instance, runtime.DefaultPoolSize)
+	require.ErrorIs(t, err, runtime.ErrCloneNotSupported)
+
+	pool := runtime.NewSharedInstancePool(instance)
+	in, err := pool.Get()
+	require.NoError(t, err)
+	require.Same(t, instance, in)
+
+	_, err = in.Version()
+	require.NoError(t, err)
+	pool.Put(in)
+
+	// the shared instance is not stopped with the pool
+	pool.Close()
+	_, err = instance.Version()
+	require.NoError(t, err)
+}
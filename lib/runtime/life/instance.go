@@ -9,12 +9,15 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/sandbox"
+	"github.com/ChainSafe/gossamer/lib/signer"
 
 	"github.com/perlin-network/life/exec"
 	wasm_validation "github.com/perlin-network/life/wasm-validation"
@@ -25,8 +28,9 @@ const Name = "life"
 
 // Check that runtime interfaces are satisfied
 var (
-	_      runtime.Instance = (*Instance)(nil)
-	logger                  = log.NewFromGlobal(
+	_      runtime.Instance   = (*Instance)(nil)
+	_      sandbox.Supervisor = (*Instance)(nil)
+	logger                    = log.NewFromGlobal(
 		log.AddContext("pkg", "runtime"),
 		log.AddContext("component", "perlin/life"),
 	)
@@ -105,10 +109,16 @@ func NewInstance(code []byte, cfg *Config) (*Instance, error) {
 	// TODO: use __heap_base (#1874)
 	allocator := runtime.NewAllocator(memory, 0)
 
+	sgn := cfg.Signer
+	if sgn == nil {
+		sgn = signer.NewLocal(cfg.Keystore)
+	}
+
 	runtimeCtx := &runtime.Context{
 		Storage:     cfg.Storage,
 		Allocator:   allocator,
 		Keystore:    cfg.Keystore,
+		Signer:      sgn,
 		Validator:   cfg.Role == byte(4),
 		NodeStorage: cfg.NodeStorage,
 		Network:     cfg.Network,
@@ -122,6 +132,8 @@ func NewInstance(code []byte, cfg *Config) (*Instance, error) {
 		vm: instance,
 	}
 
+	runtimeCtx.Sandbox = sandbox.NewStore(inst)
+
 	ctx = runtimeCtx
 	inst.version, err = inst.Version()
 	if err != nil {
@@ -157,6 +169,12 @@ func (*Instance) UpdateRuntimeCode(_ []byte) error {
 	return errors.New("unimplemented")
 }
 
+// Clone returns runtime.ErrCloneNotSupported, since instances share the package level context holding the
+// storage and allocator, so separate instances cannot make runtime calls concurrently
+func (*Instance) Clone() (runtime.Instance, error) {
+	return nil, runtime.ErrCloneNotSupported
+}
+
 // CheckRuntimeVersion ...
 func (*Instance) CheckRuntimeVersion(_ []byte) (runtime.Version, error) {
 	return nil, errors.New("unimplemented")
@@ -172,6 +190,8 @@ func (in *Instance) Exec(function string, data []byte) ([]byte, error) {
 	in.mu.Lock()
 	defer in.mu.Unlock()
 
+	defer runtime.ObserveCallDuration(function, time.Now())
+
 	ptr, err := ctx.Allocator.Allocate(uint32(len(data)))
 	if err != nil {
 		return nil, err
@@ -195,6 +215,43 @@ func (in *Instance) Exec(function string, data []byte) ([]byte, error) {
 	return in.vm.Memory[offset : offset+length], nil
 }
 
+// Memory returns the instance's linear memory, it implements sandbox.Supervisor
+func (in *Instance) Memory() []byte {
+	return in.vm.Memory
+}
+
+// Allocate allocates memory using the instance's allocator, it implements sandbox.Supervisor
+func (*Instance) Allocate(size uint32) (uint32, error) {
+	return ctx.Allocator.Allocate(size)
+}
+
+// Deallocate frees memory using the instance's allocator, it implements sandbox.Supervisor
+func (*Instance) Deallocate(ptr uint32) error {
+	return ctx.Allocator.Deallocate(ptr)
+}
+
+// InvokeDispatchThunk calls the runtime's sandbox dispatch thunk, it implements sandbox.Supervisor.
+// life cannot re-enter a running vm, so the thunk runs on a copy of it with its own call stack.
+func (in *Instance) InvokeDispatchThunk(thunk, argsPtr, argsLen, state, funcIdx uint32) (int64, error) {
+	if int(thunk) >= len(in.vm.Table) {
+		return 0, fmt.Errorf("dispatch thunk %d out of table bounds", thunk)
+	}
+
+	nested := *in.vm
+	nested.CallStack = make([]exec.Frame, len(in.vm.CallStack))
+	nested.CurrentFrame = -1
+	nested.ExitError = nil
+	nested.InsideExecute = false
+
+	ret, err := nested.Run(int(in.vm.Table[thunk]), int64(argsPtr), int64(argsLen), int64(state), int64(funcIdx))
+	in.vm.Memory = nested.Memory
+	if err != nil {
+		return 0, err
+	}
+
+	return ret, nil
+}
+
 // Stop ...
 func (*Instance) Stop() {}
 
@@ -217,3 +274,8 @@ func (*Instance) Validator() bool {
 func (*Instance) Keystore() *keystore.GlobalKeystore {
 	return ctx.Keystore
 }
+
+// Signer returns the signer of the runtime
+func (*Instance) Signer() signer.Signer {
+	return ctx.Signer
+}
@@ -17,6 +17,8 @@ import (
 	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/sandbox"
+	"github.com/ChainSafe/gossamer/lib/signer"
 	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/perlin-network/life/exec"
@@ -120,6 +122,20 @@ func (*Resolver) ResolveFunc(module, field string) exec.FunctionImport { //nolin
 			return ext_hashing_twox_256_version_1
 		case "ext_trie_blake2_256_root_version_1":
 			return ext_trie_blake2_256_root_version_1
+		case "ext_sandbox_instantiate_version_1":
+			return ext_sandbox_instantiate_version_1
+		case "ext_sandbox_invoke_version_1":
+			return ext_sandbox_invoke_version_1
+		case "ext_sandbox_instance_teardown_version_1":
+			return ext_sandbox_instance_teardown_version_1
+		case "ext_sandbox_memory_new_version_1":
+			return ext_sandbox_memory_new_version_1
+		case "ext_sandbox_memory_get_version_1":
+			return ext_sandbox_memory_get_version_1
+		case "ext_sandbox_memory_set_version_1":
+			return ext_sandbox_memory_set_version_1
+		case "ext_sandbox_memory_teardown_version_1":
+			return ext_sandbox_memory_teardown_version_1
 		default:
 			panic(fmt.Errorf("unknown import resolved: %s", field))
 		}
@@ -890,8 +906,8 @@ func ext_crypto_ed25519_sign_version_1(vm *exec.VirtualMachine) int64 {
 	}
 
 	var ret int64
-	signingKey := ks.GetKeypair(pubKey)
-	if signingKey == nil {
+	sig, err := ctx.Signer.Sign(ks.Name(), pubKey, asMemorySlice(memory, msg), nil)
+	if errors.Is(err, signer.ErrKeyNotFound) {
 		logger.Error("could not find public key " + pubKey.Hex() + " in keystore")
 		ret, err = toWasmMemoryOptional(memory, nil)
 		if err != nil {
@@ -901,9 +917,8 @@ func ext_crypto_ed25519_sign_version_1(vm *exec.VirtualMachine) int64 {
 		return ret
 	}
 
-	sig, err := signingKey.Sign(asMemorySlice(memory, msg))
 	if err != nil {
-		logger.Error("could not sign message")
+		logger.Errorf("could not sign message: %s", err)
 	}
 
 	ret, err = toWasmMemoryFixedSizeOptional(memory, sig)
@@ -1079,14 +1094,12 @@ func ext_crypto_sr25519_sign_version_1(vm *exec.VirtualMachine) int64 {
 		return emptyRet
 	}
 
-	signingKey := ks.GetKeypair(pubKey)
-	if signingKey == nil {
+	msgData := asMemorySlice(memory, msg)
+	sig, err := ctx.Signer.Sign(ks.Name(), pubKey, msgData, nil)
+	if errors.Is(err, signer.ErrKeyNotFound) {
 		logger.Error("could not find public key " + pubKey.Hex() + " in keystore")
 		return emptyRet
 	}
-
-	msgData := asMemorySlice(memory, msg)
-	sig, err := signingKey.Sign(msgData)
 	if err != nil {
 		logger.Errorf("could not sign message: %s", err)
 		return emptyRet
@@ -1328,6 +1341,113 @@ func ext_trie_blake2_256_root_version_1(vm *exec.VirtualMachine) int64 {
 	return int64(ptr)
 }
 
+func ext_sandbox_instantiate_version_1(vm *exec.VirtualMachine) int64 {
+	logger.Trace("executing...")
+	dispatchThunk := uint32(vm.GetCurrentFrame().Locals[0])
+	wasmCodeSpan := vm.GetCurrentFrame().Locals[1]
+	envDefSpan := vm.GetCurrentFrame().Locals[2]
+	statePtr := uint32(vm.GetCurrentFrame().Locals[3])
+
+	code := asMemorySlice(vm.Memory, wasmCodeSpan)
+	envDef := asMemorySlice(vm.Memory, envDefSpan)
+
+	instanceIdx, err := ctx.Sandbox.Instantiate(dispatchThunk, code, envDef, statePtr)
+	if err != nil {
+		logger.Errorf("failed to instantiate sandbox module: %s", err)
+		return int64(sandbox.ReturnCode(err))
+	}
+
+	return int64(instanceIdx)
+}
+
+func ext_sandbox_invoke_version_1(vm *exec.VirtualMachine) int64 {
+	logger.Trace("executing...")
+	instanceIdx := uint32(vm.GetCurrentFrame().Locals[0])
+	exportNameSpan := vm.GetCurrentFrame().Locals[1]
+	argsSpan := vm.GetCurrentFrame().Locals[2]
+	returnValPtr := uint32(vm.GetCurrentFrame().Locals[3])
+	returnValLen := uint32(vm.GetCurrentFrame().Locals[4])
+	statePtr := uint32(vm.GetCurrentFrame().Locals[5])
+
+	exportName := string(asMemorySlice(vm.Memory, exportNameSpan))
+	args := append([]byte{}, asMemorySlice(vm.Memory, argsSpan)...)
+
+	err := ctx.Sandbox.Invoke(instanceIdx, exportName, args, returnValPtr, returnValLen, statePtr)
+	if err != nil {
+		logger.Errorf("failed to invoke sandbox function %s: %s", exportName, err)
+	}
+
+	return int64(sandbox.ReturnCode(err))
+}
+
+func ext_sandbox_instance_teardown_version_1(vm *exec.VirtualMachine) int64 {
+	logger.Trace("executing...")
+	instanceIdx := uint32(vm.GetCurrentFrame().Locals[0])
+
+	err := ctx.Sandbox.InstanceTeardown(instanceIdx)
+	if err != nil {
+		logger.Errorf("failed to teardown sandbox instance: %s", err)
+	}
+
+	return 0
+}
+
+func ext_sandbox_memory_new_version_1(vm *exec.VirtualMachine) int64 {
+	logger.Trace("executing...")
+	initial := uint32(vm.GetCurrentFrame().Locals[0])
+	maximum := uint32(vm.GetCurrentFrame().Locals[1])
+
+	memoryIdx, err := ctx.Sandbox.NewMemory(initial, maximum)
+	if err != nil {
+		logger.Errorf("failed to create sandbox memory: %s", err)
+		return int64(sandbox.ReturnCode(err))
+	}
+
+	return int64(memoryIdx)
+}
+
+func ext_sandbox_memory_get_version_1(vm *exec.VirtualMachine) int64 {
+	logger.Trace("executing...")
+	memoryIdx := uint32(vm.GetCurrentFrame().Locals[0])
+	offset := uint32(vm.GetCurrentFrame().Locals[1])
+	bufPtr := uint32(vm.GetCurrentFrame().Locals[2])
+	bufLen := uint32(vm.GetCurrentFrame().Locals[3])
+
+	err := ctx.Sandbox.MemoryGet(memoryIdx, offset, bufPtr, bufLen)
+	if err != nil {
+		logger.Errorf("failed to get sandbox memory: %s", err)
+	}
+
+	return int64(sandbox.ReturnCode(err))
+}
+
+func ext_sandbox_memory_set_version_1(vm *exec.VirtualMachine) int64 {
+	logger.Trace("executing...")
+	memoryIdx := uint32(vm.GetCurrentFrame().Locals[0])
+	offset := uint32(vm.GetCurrentFrame().Locals[1])
+	valPtr := uint32(vm.GetCurrentFrame().Locals[2])
+	valLen := uint32(vm.GetCurrentFrame().Locals[3])
+
+	err := ctx.Sandbox.MemorySet(memoryIdx, offset, valPtr, valLen)
+	if err != nil {
+		logger.Errorf("failed to set sandbox memory: %s", err)
+	}
+
+	return int64(sandbox.ReturnCode(err))
+}
+
+func ext_sandbox_memory_teardown_version_1(vm *exec.VirtualMachine) int64 {
+	logger.Trace("executing...")
+	memoryIdx := uint32(vm.GetCurrentFrame().Locals[0])
+
+	err := ctx.Sandbox.MemoryTeardown(memoryIdx)
+	if err != nil {
+		logger.Errorf("failed to teardown sandbox memory: %s", err)
+	}
+
+	return 0
+}
+
 // Convert 64bit wasm span descriptor to Go memory slice
 func asMemorySlice(memory []byte, span int64) []byte {
 	ptr, size := runtime.Int64ToPointerAndSize(span)
@@ -0,0 +1,22 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package runtime
+
+import (
+	"time"
+
+	"github.com/ChainSafe/gossamer/dot/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var callDuration = metrics.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "runtime_call_duration_seconds",
+	Help:    "Time taken by the calls to each runtime export",
+	Buckets: prometheus.ExponentialBuckets(0.0001, 2, 18),
+}, []string{"function"})
+
+// ObserveCallDuration records the time elapsed since start for a call to the given runtime export
+func ObserveCallDuration(function string, start time.Time) {
+	callDuration.WithLabelValues(function).Observe(time.Since(start).Seconds())
+}
@@ -10,6 +10,8 @@ import (
 
 	runtime "github.com/ChainSafe/gossamer/lib/runtime"
 
+	signer "github.com/ChainSafe/gossamer/lib/signer"
+
 	transaction "github.com/ChainSafe/gossamer/lib/transaction"
 
 	types "github.com/ChainSafe/gossamer/dot/types"
@@ -71,6 +73,29 @@ func (_m *Instance) CheckInherents() {
 	_m.Called()
 }
 
+// Clone provides a mock function with given fields:
+func (_m *Instance) Clone() (runtime.Instance, error) {
+	ret := _m.Called()
+
+	var r0 runtime.Instance
+	if rf, ok := ret.Get(0).(func() runtime.Instance); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(runtime.Instance)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CheckRuntimeVersion provides a mock function with given fields: _a0
 func (_m *Instance) CheckRuntimeVersion(_a0 []byte) (runtime.Version, error) {
 	ret := _m.Called(_a0)
@@ -187,8 +212,26 @@ func (_m *Instance) FinalizeBlock() (*types.Header, error) {
 }
 
 // GenerateSessionKeys provides a mock function with given fields:
-func (_m *Instance) GenerateSessionKeys() {
-	_m.Called()
+func (_m *Instance) GenerateSessionKeys() ([]byte, error) {
+	ret := _m.Called()
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func() []byte); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // GetCodeHash provides a mock function with given fields:
@@ -374,6 +417,22 @@ func (_m *Instance) SetContextStorage(s runtime.Storage) {
 	_m.Called(s)
 }
 
+// Signer provides a mock function with given fields:
+func (_m *Instance) Signer() signer.Signer {
+	ret := _m.Called()
+
+	var r0 signer.Signer
+	if rf, ok := ret.Get(0).(func() signer.Signer); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(signer.Signer)
+		}
+	}
+
+	return r0
+}
+
 // Stop provides a mock function with given fields:
 func (_m *Instance) Stop() {
 	_m.Called()
@@ -393,13 +452,13 @@ func (_m *Instance) UpdateRuntimeCode(_a0 []byte) error {
 	return r0
 }
 
-// ValidateTransaction provides a mock function with given fields: e
-func (_m *Instance) ValidateTransaction(e types.Extrinsic) (*transaction.Validity, error) {
-	ret := _m.Called(e)
+// ValidateTransaction provides a mock function with given fields: e, blockHash
+func (_m *Instance) ValidateTransaction(e types.Extrinsic, blockHash common.Hash) (*transaction.Validity, error) {
+	ret := _m.Called(e, blockHash)
 
 	var r0 *transaction.Validity
-	if rf, ok := ret.Get(0).(func(types.Extrinsic) *transaction.Validity); ok {
-		r0 = rf(e)
+	if rf, ok := ret.Get(0).(func(types.Extrinsic, common.Hash) *transaction.Validity); ok {
+		r0 = rf(e, blockHash)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*transaction.Validity)
@@ -407,8 +466,8 @@ func (_m *Instance) ValidateTransaction(e types.Extrinsic) (*transaction.Validit
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(types.Extrinsic) error); ok {
-		r1 = rf(e)
+	if rf, ok := ret.Get(1).(func(types.Extrinsic, common.Hash) error); ok {
+		r1 = rf(e, blockHash)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -4,19 +4,15 @@
 package offchain
 
 import (
-	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 	"sync"
-)
-
-type contextKey string
+	"time"
 
-const (
-	waitingKey contextKey = "waiting"
-	invalidKey contextKey = "invalid"
+	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
 const maxConcurrentRequests = 1000
@@ -26,9 +22,67 @@ var (
 	errIntBufferFull         = errors.New("int buffer is full")
 	errRequestIDNotAvailable = errors.New("request id not available")
 	errRequestInvalid        = errors.New("request is invalid")
+	errRequestAlreadyStarted = errors.New("request has already started")
 	errInvalidHeaderKey      = errors.New("invalid header key")
+	errDeadlineReached       = errors.New("deadline reached")
+	errIO                    = errors.New("io error")
 )
 
+// HTTPError is the error returned to the runtime by the offchain http host functions. Its values are the
+// discriminants of the substrate HttpError enum, which start at 1.
+type HTTPError uint8
+
+const (
+	// HTTPErrorDeadlineReached is returned when the deadline was reached before the operation completed
+	HTTPErrorDeadlineReached HTTPError = iota + 1
+	// HTTPErrorIO is returned when there was an IO error while processing the request
+	HTTPErrorIO
+	// HTTPErrorInvalid is returned when the request id is invalid or the request is in an invalid state
+	HTTPErrorInvalid
+)
+
+// ToHTTPError converts an error returned by the HTTPSet into the HTTPError expected by the runtime
+func ToHTTPError(err error) HTTPError {
+	switch {
+	case errors.Is(err, errDeadlineReached):
+		return HTTPErrorDeadlineReached
+	case errors.Is(err, errIO):
+		return HTTPErrorIO
+	default:
+		return HTTPErrorInvalid
+	}
+}
+
+// DeadlineReachedStatus is the status of a request that did not receive a response before the deadline
+type DeadlineReachedStatus struct{}
+
+// Index returns the VDT index
+func (DeadlineReachedStatus) Index() uint { return 0 }
+
+// IOErrorStatus is the status of a request that failed while being sent or received
+type IOErrorStatus struct{}
+
+// Index returns the VDT index
+func (IOErrorStatus) Index() uint { return 1 }
+
+// InvalidStatus is the status of a request whose id is unknown or whose response was already read
+type InvalidStatus struct{}
+
+// Index returns the VDT index
+func (InvalidStatus) Index() uint { return 2 }
+
+// FinishedStatus is the status of a request that received a response, it holds the response status code
+type FinishedStatus uint16
+
+// Index returns the VDT index
+func (FinishedStatus) Index() uint { return 3 }
+
+// NewHTTPRequestStatusSlice returns a VaryingDataTypeSlice able to hold http request statuses
+func NewHTTPRequestStatusSlice() scale.VaryingDataTypeSlice {
+	vdt := scale.MustNewVaryingDataType(DeadlineReachedStatus{}, IOErrorStatus{}, InvalidStatus{}, FinishedStatus(0))
+	return scale.NewVaryingDataTypeSlice(vdt)
+}
+
 // requestIDBuffer created to control the amount of available non-duplicated ids
 type requestIDBuffer chan int16
 
@@ -60,19 +114,42 @@ func (b requestIDBuffer) put(i int16) error {
 	}
 }
 
-// Request holds the request object and update the invalid and waiting status whenever
-// the request starts or is waiting to be read
+// readResult holds the outcome of a response body read that may outlive the caller's deadline
+type readResult struct {
+	data []byte
+	err  error
+}
+
+// Request holds the request object and tracks its progress from the moment it starts
+// to be sent until its response body is fully read
 type Request struct {
 	Request *http.Request
+
+	client  *http.Client
+	invalid bool
+
+	// bodyWriter is set while the request body is being streamed
+	bodyWriter *io.PipeWriter
+	// done is closed once the response or the sending error is available
+	done     chan struct{}
+	response *http.Response
+	err      error
+
+	pendingRead chan readResult
+	unread      []byte
 }
 
 // AddHeader adds a new HTTP header into request property, only if request is valid
+// and has not started yet
 func (r *Request) AddHeader(name, value string) error {
-	invalid, ok := r.Request.Context().Value(invalidKey).(bool)
-	if ok && invalid {
+	if r.invalid {
 		return errRequestInvalid
 	}
 
+	if r.started() {
+		return errRequestAlreadyStarted
+	}
+
 	name = strings.TrimSpace(name)
 	if len(name) == 0 {
 		return fmt.Errorf("%w: empty header key", errInvalidHeaderKey)
@@ -82,11 +159,214 @@ func (r *Request) AddHeader(name, value string) error {
 	return nil
 }
 
+func (r *Request) started() bool {
+	return r.done != nil
+}
+
+// send dispatches the request using body as the request body, the response
+// becomes available once the done channel is closed
+func (r *Request) send(body io.ReadCloser) {
+	r.Request.Body = body
+	r.done = make(chan struct{})
+
+	go func() {
+		defer close(r.done)
+		r.response, r.err = r.client.Do(r.Request) //nolint:bodyclose
+	}()
+}
+
+// WriteBody writes a chunk of the request body. The first call dispatches the request
+// and an empty chunk marks the end of the body.
+func (r *Request) WriteBody(chunk []byte, deadline *time.Time) error {
+	if r.invalid {
+		return errRequestInvalid
+	}
+
+	if !r.started() {
+		pr, pw := io.Pipe()
+		r.bodyWriter = pw
+		r.send(pr)
+	}
+
+	if r.bodyWriter == nil {
+		// the body was already finalised
+		return errRequestInvalid
+	}
+
+	if len(chunk) == 0 {
+		err := r.bodyWriter.Close()
+		r.bodyWriter = nil
+		if err != nil {
+			return fmt.Errorf("%w: %s", errIO, err)
+		}
+		return nil
+	}
+
+	written := make(chan error, 1)
+	go func(w io.Writer) {
+		_, err := w.Write(chunk)
+		written <- err
+	}(r.bodyWriter)
+
+	timeout, stop := deadlineTimer(deadline)
+	defer stop()
+
+	select {
+	case err := <-written:
+		if err != nil {
+			r.bodyWriter = nil
+			return fmt.Errorf("%w: %s", errIO, err)
+		}
+		return nil
+	case <-timeout:
+		return errDeadlineReached
+	}
+}
+
+// Wait waits until the response is available or the deadline is reached. If the request
+// was not dispatched yet it is sent with an empty body.
+func (r *Request) Wait(deadline *time.Time) scale.VaryingDataTypeValue {
+	if r.invalid {
+		return InvalidStatus{}
+	}
+
+	if !r.started() {
+		r.send(http.NoBody)
+	}
+
+	timeout, stop := deadlineTimer(deadline)
+	defer stop()
+
+	select {
+	case <-r.done:
+		if r.err != nil {
+			return IOErrorStatus{}
+		}
+		return FinishedStatus(r.response.StatusCode)
+	case <-timeout:
+		return DeadlineReachedStatus{}
+	}
+}
+
+// ResponseHeaders returns the list of response headers as name-value pairs, it returns
+// an empty list if the response is not available yet
+func (r *Request) ResponseHeaders() [][2][]byte {
+	headers := [][2][]byte{}
+	if r.invalid || !r.responseReady() {
+		return headers
+	}
+
+	for name, values := range r.response.Header {
+		for _, value := range values {
+			headers = append(headers, [2][]byte{[]byte(name), []byte(value)})
+		}
+	}
+
+	return headers
+}
+
+func (r *Request) responseReady() bool {
+	if !r.started() {
+		return false
+	}
+
+	select {
+	case <-r.done:
+		return r.err == nil
+	default:
+		return false
+	}
+}
+
+// ReadBody reads a chunk of the response body into buf, waiting for the response if needed.
+// It returns the number of bytes read, 0 means the body was fully read.
+func (r *Request) ReadBody(buf []byte, deadline *time.Time) (int, error) {
+	if r.invalid || !r.started() {
+		return 0, errRequestInvalid
+	}
+
+	if len(r.unread) > 0 {
+		n := copy(buf, r.unread)
+		r.unread = r.unread[n:]
+		return n, nil
+	}
+
+	timeout, stop := deadlineTimer(deadline)
+	defer stop()
+
+	select {
+	case <-r.done:
+		if r.err != nil {
+			return 0, fmt.Errorf("%w: %s", errIO, r.err)
+		}
+	case <-timeout:
+		return 0, errDeadlineReached
+	}
+
+	if r.pendingRead == nil {
+		r.pendingRead = make(chan readResult, 1)
+		go func(body io.Reader, size int, result chan<- readResult) {
+			data := make([]byte, size)
+			n, err := body.Read(data)
+			result <- readResult{data: data[:n], err: err}
+		}(r.response.Body, len(buf), r.pendingRead)
+	}
+
+	var res readResult
+	select {
+	case res = <-r.pendingRead:
+		r.pendingRead = nil
+	case <-timeout:
+		return 0, errDeadlineReached
+	}
+
+	n := copy(buf, res.data)
+	r.unread = res.data[n:]
+
+	switch {
+	case n > 0:
+		return n, nil
+	case errors.Is(res.err, io.EOF):
+		r.invalid = true
+		return 0, nil
+	case res.err != nil:
+		return 0, fmt.Errorf("%w: %s", errIO, res.err)
+	default:
+		return 0, nil
+	}
+}
+
+// close releases the resources held by the request
+func (r *Request) close() {
+	r.invalid = true
+
+	if r.bodyWriter != nil {
+		_ = r.bodyWriter.CloseWithError(errRequestInvalid)
+		r.bodyWriter = nil
+	}
+
+	if r.responseReady() {
+		_ = r.response.Body.Close()
+	}
+}
+
+// deadlineTimer returns a channel that fires once the deadline is reached, a nil
+// deadline returns a channel that never fires
+func deadlineTimer(deadline *time.Time) (<-chan time.Time, func()) {
+	if deadline == nil {
+		return nil, func() {}
+	}
+
+	timer := time.NewTimer(time.Until(*deadline))
+	return timer.C, func() { timer.Stop() }
+}
+
 // HTTPSet holds a pool of concurrent http request calls
 type HTTPSet struct {
 	*sync.Mutex
 	reqs   map[int16]*Request
 	idBuff requestIDBuffer
+	client *http.Client
 }
 
 // NewHTTPSet creates a offchain http set that can be used
@@ -96,6 +376,7 @@ func NewHTTPSet() *HTTPSet {
 		new(sync.Mutex),
 		make(map[int16]*Request),
 		newIntBuffer(maxConcurrentRequests),
+		new(http.Client),
 	}
 }
 
@@ -115,19 +396,15 @@ func (p *HTTPSet) StartRequest(method, uri string) (int16, error) {
 	}
 
 	req, err := http.NewRequest(method, uri, nil)
-	req.Header = make(http.Header)
-
-	ctx := context.WithValue(req.Context(), waitingKey, false)
-	ctx = context.WithValue(ctx, invalidKey, false)
-
-	req = req.WithContext(ctx)
-
 	if err != nil {
 		return 0, err
 	}
 
+	req.Header = make(http.Header)
+
 	p.reqs[id] = &Request{
 		Request: req,
+		client:  p.client,
 	}
 
 	return id, nil
@@ -138,6 +415,10 @@ func (p *HTTPSet) Remove(id int16) error {
 	p.Lock()
 	defer p.Unlock()
 
+	if req, ok := p.reqs[id]; ok && req != nil {
+		req.close()
+	}
+
 	delete(p.reqs, id)
 
 	return p.idBuff.put(id)
@@ -150,3 +431,65 @@ func (p *HTTPSet) Get(id int16) *Request {
 
 	return p.reqs[id]
 }
+
+// WriteBody writes a chunk of the body of the given request, an empty chunk finalises the body
+func (p *HTTPSet) WriteBody(id int16, chunk []byte, deadline *time.Time) error {
+	req := p.Get(id)
+	if req == nil {
+		return errRequestInvalid
+	}
+
+	return req.WriteBody(chunk, deadline)
+}
+
+// ResponseWait waits for the responses of the given requests until the deadline is reached,
+// it returns the status of each request in the same order as the ids
+func (p *HTTPSet) ResponseWait(ids []int16, deadline *time.Time) (scale.VaryingDataTypeSlice, error) {
+	statuses := NewHTTPRequestStatusSlice()
+	for _, id := range ids {
+		var status scale.VaryingDataTypeValue = InvalidStatus{}
+		if req := p.Get(id); req != nil {
+			status = req.Wait(deadline)
+		}
+
+		err := statuses.Add(status)
+		if err != nil {
+			return statuses, err
+		}
+	}
+
+	return statuses, nil
+}
+
+// ResponseHeaders returns the response headers of the given request
+func (p *HTTPSet) ResponseHeaders(id int16) [][2][]byte {
+	req := p.Get(id)
+	if req == nil {
+		return [][2][]byte{}
+	}
+
+	return req.ResponseHeaders()
+}
+
+// ReadBody reads a chunk of the response body of the given request into buf. Once the whole
+// body was read it returns 0 and the request is removed from the set.
+func (p *HTTPSet) ReadBody(id int16, buf []byte, deadline *time.Time) (int, error) {
+	req := p.Get(id)
+	if req == nil {
+		return 0, errRequestInvalid
+	}
+
+	n, err := req.ReadBody(buf, deadline)
+	if err != nil {
+		if !errors.Is(err, errDeadlineReached) {
+			_ = p.Remove(id)
+		}
+		return 0, err
+	}
+
+	if n == 0 {
+		return 0, p.Remove(id)
+	}
+
+	return n, nil
+}
@@ -4,11 +4,14 @@
 package offchain
 
 import (
-	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/ChainSafe/gossamer/pkg/scale"
 	"github.com/stretchr/testify/require"
 )
 
@@ -54,8 +57,7 @@ func TestHTTPSetGet(t *testing.T) {
 func TestOffchainRequest_AddHeader(t *testing.T) {
 	t.Parallel()
 
-	invalidCtx := context.WithValue(context.Background(), invalidKey, true)
-	invalidReq, err := http.NewRequestWithContext(invalidCtx, http.MethodGet, "http://test.com", nil)
+	invalidReq, err := http.NewRequest(http.MethodGet, "http://test.com", nil)
 	require.NoError(t, err)
 
 	cases := map[string]struct {
@@ -64,9 +66,13 @@ func TestOffchainRequest_AddHeader(t *testing.T) {
 		headerK, headerV string
 	}{
 		"should return invalid request": {
-			offReq: Request{invalidReq},
+			offReq: Request{Request: invalidReq, invalid: true},
 			err:    errRequestInvalid,
 		},
+		"should return already started": {
+			offReq: Request{Request: invalidReq, done: make(chan struct{})},
+			err:    errRequestAlreadyStarted,
+		},
 		"should add header": {
 			offReq:  Request{Request: &http.Request{Header: make(http.Header)}},
 			headerK: "key",
@@ -101,3 +107,161 @@ func TestOffchainRequest_AddHeader(t *testing.T) {
 		})
 	}
 }
+
+func TestHTTPSet_RequestLifecycle(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("X-Custom", r.Header.Get("X-Custom"))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(append([]byte("echo:"), body...))
+	}))
+	defer server.Close()
+
+	set := NewHTTPSet()
+	id, err := set.StartRequest(http.MethodPost, server.URL)
+	require.NoError(t, err)
+
+	err = set.Get(id).AddHeader("X-Custom", "value")
+	require.NoError(t, err)
+
+	deadline := time.Now().Add(5 * time.Second)
+	err = set.WriteBody(id, []byte("hello "), &deadline)
+	require.NoError(t, err)
+	err = set.WriteBody(id, []byte("world"), &deadline)
+	require.NoError(t, err)
+	err = set.WriteBody(id, nil, &deadline)
+	require.NoError(t, err)
+
+	err = set.Get(id).AddHeader("X-Other", "value")
+	require.ErrorIs(t, err, errRequestAlreadyStarted)
+
+	statuses, err := set.ResponseWait([]int16{id, id + 1}, &deadline)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{FinishedStatus(http.StatusCreated), InvalidStatus{}}, statusValues(statuses))
+
+	headers := set.ResponseHeaders(id)
+	require.Contains(t, headers, [2][]byte{[]byte("X-Custom"), []byte("value")})
+
+	var body []byte
+	buf := make([]byte, 4)
+	for {
+		n, err := set.ReadBody(id, buf, &deadline)
+		require.NoError(t, err)
+		if n == 0 {
+			break
+		}
+		body = append(body, buf[:n]...)
+	}
+	require.Equal(t, []byte("echo:hello world"), body)
+
+	// the request is removed once the body was fully read
+	require.Nil(t, set.Get(id))
+	_, err = set.ReadBody(id, buf, &deadline)
+	require.ErrorIs(t, err, errRequestInvalid)
+}
+
+func TestHTTPSet_ResponseWait_DeadlineReached(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	set := NewHTTPSet()
+	id, err := set.StartRequest(http.MethodGet, server.URL)
+	require.NoError(t, err)
+
+	deadline := time.Now().Add(50 * time.Millisecond)
+	statuses, err := set.ResponseWait([]int16{id}, &deadline)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{DeadlineReachedStatus{}}, statusValues(statuses))
+
+	_, err = set.ReadBody(id, make([]byte, 1), &deadline)
+	require.ErrorIs(t, err, errDeadlineReached)
+	require.Equal(t, HTTPErrorDeadlineReached, ToHTTPError(err))
+}
+
+func TestHTTPSet_ResponseWait_IOError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	set := NewHTTPSet()
+	id, err := set.StartRequest(http.MethodGet, url)
+	require.NoError(t, err)
+
+	statuses, err := set.ResponseWait([]int16{id}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{IOErrorStatus{}}, statusValues(statuses))
+
+	_, err = set.ReadBody(id, make([]byte, 1), nil)
+	require.Equal(t, HTTPErrorIO, ToHTTPError(err))
+}
+
+func statusValues(statuses scale.VaryingDataTypeSlice) []interface{} {
+	values := make([]interface{}, len(statuses.Types))
+	for i, vdt := range statuses.Types {
+		values[i] = vdt.Value()
+	}
+	return values
+}
+
+func TestHTTPError_Encoding(t *testing.T) {
+	t.Parallel()
+
+	// the byte vectors are the encodings of Result<(), HttpError> by substrate
+	tests := []struct {
+		httpErr  HTTPError
+		expected []byte
+	}{
+		{httpErr: HTTPErrorDeadlineReached, expected: []byte{1, 1}},
+		{httpErr: HTTPErrorIO, expected: []byte{1, 2}},
+		{httpErr: HTTPErrorInvalid, expected: []byte{1, 3}},
+	}
+
+	for _, test := range tests {
+		result := scale.NewResult(nil, HTTPError(0))
+		err := result.Set(scale.Err, test.httpErr)
+		require.NoError(t, err)
+
+		enc, err := scale.Marshal(result)
+		require.NoError(t, err)
+		require.Equal(t, test.expected, enc)
+	}
+}
+
+func TestHTTPRequestStatus_Encoding(t *testing.T) {
+	t.Parallel()
+
+	statuses := NewHTTPRequestStatusSlice()
+	for _, status := range []scale.VaryingDataTypeValue{
+		DeadlineReachedStatus{}, IOErrorStatus{}, InvalidStatus{}, FinishedStatus(http.StatusOK),
+	} {
+		err := statuses.Add(status)
+		require.NoError(t, err)
+	}
+
+	enc, err := scale.Marshal(statuses)
+	require.NoError(t, err)
+
+	// the encoding of Vec<HttpRequestStatus> by substrate
+	expected := []byte{16, 0, 1, 2, 3, 200, 0}
+	require.Equal(t, expected, enc)
+
+	decoded := NewHTTPRequestStatusSlice()
+	err = scale.Unmarshal(expected, &decoded)
+	require.NoError(t, err)
+	require.Equal(t, statusValues(statuses), statusValues(decoded))
+}
@@ -0,0 +1,135 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// DefaultPoolSize is the number of instances held by the instance pool of a runtime
+const DefaultPoolSize = 4
+
+var (
+	// ErrPoolClosed is returned when checking out an instance from a closed instance pool
+	ErrPoolClosed = errors.New("runtime instance pool is closed")
+	// ErrCloneNotSupported is returned by the Clone method of runtime instances that cannot be cloned
+	ErrCloneNotSupported = errors.New("runtime instance cannot be cloned")
+)
+
+// InstancePool is a pool of pre-instantiated instances of the same runtime code. Each instance has its own
+// memory and allocator, so that concurrent callers can make runtime calls without waiting on each other.
+type InstancePool struct {
+	sync.Mutex
+	instances chan Instance
+	closed    chan struct{}
+	isClosed  bool
+	// shared is true if the pool holds an instance it does not own, which is never stopped by the pool
+	shared bool
+}
+
+// NewInstancePool returns a new pool holding the given number of clones of the given instance
+func NewInstancePool(rt Instance, size int) (*InstancePool, error) {
+	if size < 1 {
+		return nil, fmt.Errorf("invalid instance pool size %d", size)
+	}
+
+	p := &InstancePool{
+		instances: make(chan Instance, size),
+		closed:    make(chan struct{}),
+	}
+
+	for i := 0; i < size; i++ {
+		in, err := rt.Clone()
+		if err != nil {
+			p.Close()
+			return nil, fmt.Errorf("cannot create runtime instance: %w", err)
+		}
+
+		p.instances <- in
+	}
+
+	return p, nil
+}
+
+// NewSharedInstancePool returns a new pool holding the given instance itself, for runtimes whose instances cannot
+// be cloned. Callers checking out the instance wait on each other, and the instance is not stopped by the pool
+// since it is still owned by its creator.
+func NewSharedInstancePool(rt Instance) *InstancePool {
+	p := &InstancePool{
+		instances: make(chan Instance, 1),
+		closed:    make(chan struct{}),
+		shared:    true,
+	}
+
+	p.instances <- rt
+	return p
+}
+
+// Get checks out an instance from the pool, waiting for one to be returned if they are all checked out.
+// The instance must be returned to the pool with Put once the caller is done with it. Since the instance
+// may have been used at another block before, its storage must be set with SetContextStorage before any call.
+func (p *InstancePool) Get() (Instance, error) {
+	select {
+	case <-p.closed:
+		return nil, ErrPoolClosed
+	default:
+	}
+
+	select {
+	case in := <-p.instances:
+		return in, nil
+	case <-p.closed:
+		return nil, ErrPoolClosed
+	}
+}
+
+// Put returns an instance checked out with Get to the pool. The instance is stopped if the pool is closed.
+func (p *InstancePool) Put(in Instance) {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.isClosed {
+		p.stop(in)
+		return
+	}
+
+	select {
+	case p.instances <- in:
+	default:
+		// the instance does not belong to the pool
+		p.stop(in)
+	}
+}
+
+// Close closes the pool and stops the instances it holds. Instances checked out are stopped when returned.
+func (p *InstancePool) Close() {
+	p.Lock()
+	defer p.Unlock()
+
+	if p.isClosed {
+		return
+	}
+
+	p.isClosed = true
+	close(p.closed)
+
+	for {
+		select {
+		case in := <-p.instances:
+			p.stop(in)
+		default:
+			return
+		}
+	}
+}
+
+func (p *InstancePool) stop(in Instance) {
+	if p.shared {
+		return
+	}
+
+	in.Stop()
+}
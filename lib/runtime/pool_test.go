@@ -0,0 +1,129 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package runtime
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// testInstance is an Instance which can only be cloned and stopped
+type testInstance struct {
+	Instance
+	cloneErr error
+	stopped  bool
+}
+
+func (in *testInstance) Clone() (Instance, error) {
+	if in.cloneErr != nil {
+		return nil, in.cloneErr
+	}
+
+	return &testInstance{}, nil
+}
+
+func (in *testInstance) Stop() {
+	in.stopped = true
+}
+
+func TestInstancePool(t *testing.T) {
+	pool, err := NewInstancePool(&testInstance{}, 2)
+	require.NoError(t, err)
+
+	in1, err := pool.Get()
+	require.NoError(t, err)
+	in2, err := pool.Get()
+	require.NoError(t, err)
+	require.NotSame(t, in1, in2)
+
+	// all the instances are checked out, so the next caller waits for one to be returned
+	got := make(chan Instance)
+	go func() {
+		in, err := pool.Get()
+		require.NoError(t, err)
+		got <- in
+	}()
+
+	select {
+	case <-got:
+		t.Fatal("got an instance while all the instances are checked out")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	pool.Put(in1)
+	select {
+	case in := <-got:
+		require.Same(t, in1, in)
+	case <-time.After(time.Second):
+		t.Fatal("did not get the returned instance")
+	}
+
+	// the pool is full, so the instance is not kept
+	extra := &testInstance{}
+	pool.Put(in1)
+	pool.Put(in2)
+	pool.Put(extra)
+	require.True(t, extra.stopped)
+	require.False(t, in1.(*testInstance).stopped)
+
+	pool.Close()
+	require.True(t, in1.(*testInstance).stopped)
+	require.True(t, in2.(*testInstance).stopped)
+
+	_, err = pool.Get()
+	require.ErrorIs(t, err, ErrPoolClosed)
+
+	// an instance returned to a closed pool is stopped
+	checkedOut := &testInstance{}
+	pool.Put(checkedOut)
+	require.True(t, checkedOut.stopped)
+}
+
+func TestNewInstancePool_Errors(t *testing.T) {
+	_, err := NewInstancePool(&testInstance{}, 0)
+	require.Error(t, err)
+
+	cloneErr := errors.New("cannot clone")
+	_, err = NewInstancePool(&testInstance{cloneErr: cloneErr}, 2)
+	require.ErrorIs(t, err, cloneErr)
+}
+
+func TestSharedInstancePool(t *testing.T) {
+	rt := &testInstance{}
+	pool := NewSharedInstancePool(rt)
+
+	in, err := pool.Get()
+	require.NoError(t, err)
+	require.Same(t, rt, in)
+
+	// the instance is checked out, so the next caller waits for it to be returned
+	got := make(chan Instance)
+	go func() {
+		in, err := pool.Get()
+		require.NoError(t, err)
+		got <- in
+	}()
+
+	select {
+	case <-got:
+		t.Fatal("got the instance while it is checked out")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	pool.Put(in)
+	select {
+	case in = <-got:
+		require.Same(t, rt, in)
+	case <-time.After(time.Second):
+		t.Fatal("did not get the returned instance")
+	}
+
+	// the shared instance is never stopped by the pool
+	pool.Close()
+	pool.Put(in)
+	require.False(t, rt.stopped)
+}
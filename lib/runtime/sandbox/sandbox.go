@@ -0,0 +1,630 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sandbox
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ChainSafe/gossamer/internal/log"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+
+	"github.com/go-interpreter/wagon/wasm"
+	"github.com/go-interpreter/wagon/wasm/leb128"
+	"github.com/perlin-network/life/exec"
+)
+
+// Return codes of the sandbox host functions
+const (
+	// ErrOK is returned when the call succeeded
+	ErrOK uint32 = 0
+	// ErrExecution is returned when the sandboxed code trapped
+	ErrExecution uint32 = math.MaxUint32
+	// ErrModule is returned when the module could not be instantiated
+	ErrModule uint32 = math.MaxUint32 - 1
+	// ErrOutOfBounds is returned when a memory access is out of bounds
+	ErrOutOfBounds uint32 = math.MaxUint32 - 2
+)
+
+const (
+	pageSize = 65536
+	// maxPages is the maximum number of pages a 32 bit linear memory can hold
+	maxPages = 65536
+	// noMaximum is the value used by the supervisor to create a memory without maximum size
+	noMaximum = math.MaxUint32
+)
+
+var (
+	errModule          = errors.New("cannot instantiate module")
+	errExecution       = errors.New("execution trapped")
+	errOutOfBounds     = errors.New("out of bounds")
+	errInstanceUnknown = errors.New("unknown sandbox instance")
+	errMemoryUnknown   = errors.New("unknown sandbox memory")
+	errHostError       = errors.New("supervisor function returned an error")
+	errReentrantCall   = errors.New("sandbox instance is already running")
+
+	logger = log.NewFromGlobal(
+		log.AddContext("pkg", "runtime"),
+		log.AddContext("module", "sandbox"),
+	)
+)
+
+// ReturnCode converts an error returned by the Store into the code expected by the runtime
+func ReturnCode(err error) uint32 {
+	switch {
+	case err == nil:
+		return ErrOK
+	case errors.Is(err, errOutOfBounds):
+		return ErrOutOfBounds
+	case errors.Is(err, errModule):
+		return ErrModule
+	default:
+		return ErrExecution
+	}
+}
+
+// Supervisor is the runtime instance that creates and drives sandboxed instances
+type Supervisor interface {
+	// Memory returns the supervisor's linear memory
+	Memory() []byte
+	// Allocate allocates size bytes in the supervisor's linear memory
+	Allocate(size uint32) (uint32, error)
+	// Deallocate frees memory previously allocated with Allocate
+	Deallocate(ptr uint32) error
+	// InvokeDispatchThunk calls the function at the given table index of the supervisor
+	// with the serialised arguments pointer and length, the state and the function index
+	InvokeDispatchThunk(thunk, argsPtr, argsLen, state, funcIdx uint32) (int64, error)
+}
+
+// Memory is a linear memory created by the supervisor for sandboxed instances
+type Memory struct {
+	data    []byte
+	maximum uint32
+}
+
+// Instance is an instantiated sandboxed module
+type Instance struct {
+	vm            *exec.VirtualMachine
+	memory        *Memory
+	dispatchThunk uint32
+	functions     map[string]uint32
+	state         uint32
+	running       bool
+}
+
+// Store holds the sandboxed instances and memories created by a supervisor
+type Store struct {
+	supervisor Supervisor
+	instances  []*Instance
+	memories   []*Memory
+}
+
+// NewStore returns a new Store for the given supervisor
+func NewStore(supervisor Supervisor) *Store {
+	return &Store{
+		supervisor: supervisor,
+	}
+}
+
+// NewMemory creates a new memory with the given initial and maximum number of pages,
+// it returns the index of the memory
+func (s *Store) NewMemory(initial, maximum uint32) (uint32, error) {
+	if maximum != noMaximum && (maximum > maxPages || initial > maximum) {
+		return 0, fmt.Errorf("%w: invalid memory limits %d, %d", errModule, initial, maximum)
+	}
+
+	if initial > maxPages {
+		return 0, fmt.Errorf("%w: invalid initial size %d", errModule, initial)
+	}
+
+	s.memories = append(s.memories, &Memory{
+		data:    make([]byte, int(initial)*pageSize),
+		maximum: maximum,
+	})
+
+	return uint32(len(s.memories) - 1), nil
+}
+
+func (s *Store) memory(idx uint32) (*Memory, error) {
+	if int(idx) >= len(s.memories) || s.memories[idx] == nil {
+		return nil, fmt.Errorf("%w: %d", errMemoryUnknown, idx)
+	}
+
+	return s.memories[idx], nil
+}
+
+// MemoryGet copies length bytes from the sandbox memory at offset into the supervisor memory at ptr
+func (s *Store) MemoryGet(memoryIdx, offset, ptr, length uint32) error {
+	mem, err := s.memory(memoryIdx)
+	if err != nil {
+		return err
+	}
+
+	src, err := memorySlice(mem.data, offset, length)
+	if err != nil {
+		return err
+	}
+
+	dst, err := memorySlice(s.supervisor.Memory(), ptr, length)
+	if err != nil {
+		return err
+	}
+
+	copy(dst, src)
+	return nil
+}
+
+// MemorySet copies length bytes from the supervisor memory at ptr into the sandbox memory at offset
+func (s *Store) MemorySet(memoryIdx, offset, ptr, length uint32) error {
+	mem, err := s.memory(memoryIdx)
+	if err != nil {
+		return err
+	}
+
+	src, err := memorySlice(s.supervisor.Memory(), ptr, length)
+	if err != nil {
+		return err
+	}
+
+	dst, err := memorySlice(mem.data, offset, length)
+	if err != nil {
+		return err
+	}
+
+	copy(dst, src)
+	return nil
+}
+
+// MemoryTeardown releases the memory with the given index
+func (s *Store) MemoryTeardown(memoryIdx uint32) error {
+	if _, err := s.memory(memoryIdx); err != nil {
+		return err
+	}
+
+	s.memories[memoryIdx] = nil
+	return nil
+}
+
+func memorySlice(data []byte, offset, length uint32) ([]byte, error) {
+	end := uint64(offset) + uint64(length)
+	if end > uint64(len(data)) {
+		return nil, fmt.Errorf("%w: %d > %d", errOutOfBounds, end, len(data))
+	}
+
+	return data[offset:end], nil
+}
+
+// Instantiate instantiates the given wasm code with the imports defined by the SCALE encoded
+// environment definition and runs its start function. It returns the index of the instance.
+func (s *Store) Instantiate(dispatchThunk uint32, code, envDef []byte, state uint32) (uint32, error) {
+	entries, err := DecodeEnvironmentDefinition(envDef)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", errModule, err)
+	}
+
+	inst := &Instance{
+		dispatchThunk: dispatchThunk,
+		functions:     make(map[string]uint32),
+		state:         state,
+	}
+
+	memories := make(map[string]*Memory)
+	for _, entry := range entries {
+		name := importName(string(entry.Module), string(entry.Field))
+		switch entity := entry.Entity.(type) {
+		case FunctionEntity:
+			inst.functions[name] = uint32(entity)
+		case MemoryEntity:
+			mem, err := s.memory(uint32(entity))
+			if err != nil {
+				return 0, fmt.Errorf("%w: %s", errModule, err)
+			}
+			memories[name] = mem
+		}
+	}
+
+	cfg := exec.VMConfig{}
+	module, err := readImports(code)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", errModule, err)
+	}
+
+	if module.Import != nil {
+		for _, imp := range module.Import.Entries {
+			name := importName(imp.ModuleName, imp.FieldName)
+			switch imp.Type.Kind() {
+			case wasm.ExternalFunction:
+				if _, ok := inst.functions[name]; !ok {
+					return 0, fmt.Errorf("%w: function %s is not provided", errModule, name)
+				}
+			case wasm.ExternalMemory:
+				mem, ok := memories[name]
+				if !ok {
+					return 0, fmt.Errorf("%w: memory %s is not provided", errModule, name)
+				}
+				inst.memory = mem
+			default:
+				return 0, fmt.Errorf("%w: cannot import %s of kind %s", errModule, name, imp.Type.Kind())
+			}
+		}
+	}
+
+	if inst.memory != nil {
+		cfg.DefaultMemoryPages = len(inst.memory.data) / pageSize
+		cfg.MaxMemoryPages = maxPages
+		if inst.memory.maximum != noMaximum {
+			cfg.MaxMemoryPages = int(inst.memory.maximum)
+		}
+	}
+
+	inst.vm, err = exec.NewVirtualMachine(code, cfg, &resolver{store: s, instance: inst}, nil)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", errModule, err)
+	}
+
+	if inst.memory != nil {
+		// data segments were written into the interpreter's own memory, apply them to the imported one
+		err = initMemory(inst.vm, inst.memory)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %s", errModule, err)
+		}
+		inst.vm.Memory = inst.memory.data
+	}
+
+	if start := inst.vm.Module.Base.Start; start != nil {
+		_, err = inst.run(int(start.Index))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	s.instances = append(s.instances, inst)
+	return uint32(len(s.instances) - 1), nil
+}
+
+// Invoke calls the exported function of the given instance with the SCALE encoded arguments.
+// The SCALE encoded return value is written into the supervisor memory at retPtr.
+func (s *Store) Invoke(instanceIdx uint32, export string, args []byte, retPtr, retLen, state uint32) error {
+	if int(instanceIdx) >= len(s.instances) || s.instances[instanceIdx] == nil {
+		return fmt.Errorf("%w: %d", errInstanceUnknown, instanceIdx)
+	}
+
+	inst := s.instances[instanceIdx]
+
+	values := NewValueSlice()
+	err := scale.Unmarshal(args, &values)
+	if err != nil {
+		return fmt.Errorf("cannot decode arguments: %w", err)
+	}
+
+	fnID, ok := inst.vm.GetFunctionExport(export)
+	if !ok {
+		return fmt.Errorf("%w: function %s is not exported", errExecution, export)
+	}
+
+	sig, err := functionSignature(inst.vm.Module.Base, uint32(fnID))
+	if err != nil {
+		return err
+	}
+
+	if len(sig.ParamTypes) != len(values.Types) {
+		return fmt.Errorf("%w: expected %d arguments, got %d", errExecution, len(sig.ParamTypes), len(values.Types))
+	}
+
+	params := make([]int64, len(values.Types))
+	for i, value := range values.Types {
+		raw, t, err := valueToRaw(value.Value())
+		if err != nil {
+			return err
+		}
+
+		if t != sig.ParamTypes[i] {
+			return fmt.Errorf("%w: argument %d has type %s, expected %s", errExecution, i, t, sig.ParamTypes[i])
+		}
+
+		params[i] = raw
+	}
+
+	inst.state = state
+	ret, err := inst.run(fnID, params...)
+	if err != nil {
+		return err
+	}
+
+	var retValue scale.VaryingDataTypeValue
+	if len(sig.ReturnTypes) > 0 {
+		retValue, err = valueFromRaw(sig.ReturnTypes[0], ret)
+		if err != nil {
+			return err
+		}
+	}
+
+	enc, err := encodeReturnValue(retValue)
+	if err != nil {
+		return err
+	}
+
+	if uint32(len(enc)) > retLen {
+		return fmt.Errorf("%w: return value buffer is too small", errOutOfBounds)
+	}
+
+	dst, err := memorySlice(s.supervisor.Memory(), retPtr, uint32(len(enc)))
+	if err != nil {
+		return err
+	}
+
+	copy(dst, enc)
+	return nil
+}
+
+// InstanceTeardown releases the instance with the given index
+func (s *Store) InstanceTeardown(instanceIdx uint32) error {
+	if int(instanceIdx) >= len(s.instances) || s.instances[instanceIdx] == nil {
+		return fmt.Errorf("%w: %d", errInstanceUnknown, instanceIdx)
+	}
+
+	s.instances[instanceIdx] = nil
+	return nil
+}
+
+// run executes the function with the given id, keeping the imported memory in sync with the interpreter
+func (inst *Instance) run(fnID int, params ...int64) (int64, error) {
+	if inst.running {
+		return 0, errReentrantCall
+	}
+
+	inst.running = true
+	defer func() {
+		inst.running = false
+	}()
+
+	vm := inst.vm
+	vm.ExitError = nil
+	vm.CurrentFrame = -1
+	vm.Delegate = nil
+
+	if inst.memory != nil {
+		vm.Memory = inst.memory.data
+		defer func() {
+			// the memory might have been grown by the instance
+			inst.memory.data = vm.Memory
+		}()
+	}
+
+	ret, err := runVM(vm, fnID, params...)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", errExecution, err)
+	}
+
+	return ret, nil
+}
+
+func runVM(vm *exec.VirtualMachine, fnID int, params ...int64) (ret int64, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	return vm.Run(fnID, params...)
+}
+
+// dispatch calls the supervisor function the sandboxed instance imported through the dispatch thunk. Errors trap
+// the sandboxed instance: they are raised as panics, which runVM returns as the error of the call.
+func (s *Store) dispatch(inst *Instance, funcIdx uint32, sig *wasm.FunctionSig, vm *exec.VirtualMachine) int64 {
+	frame := vm.GetCurrentFrame()
+
+	args := NewValueSlice()
+	for i, t := range sig.ParamTypes {
+		value, err := valueFromRaw(t, frame.Locals[i])
+		if err != nil {
+			panic(err)
+		}
+
+		err = args.Add(value)
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	enc, err := scale.Marshal(args)
+	if err != nil {
+		panic(err)
+	}
+
+	if inst.memory != nil {
+		// make the memory as seen by the instance visible to the supervisor
+		inst.memory.data = vm.Memory
+		defer func() {
+			vm.Memory = inst.memory.data
+		}()
+	}
+
+	argsPtr, err := s.supervisor.Allocate(uint32(len(enc)))
+	if err != nil {
+		panic(err)
+	}
+	argsMem, err := memorySlice(s.supervisor.Memory(), argsPtr, uint32(len(enc)))
+	if err != nil {
+		panic(err)
+	}
+	copy(argsMem, enc)
+
+	res, err := s.supervisor.InvokeDispatchThunk(inst.dispatchThunk, argsPtr, uint32(len(enc)), inst.state, funcIdx)
+	if err != nil {
+		panic(err)
+	}
+
+	err = s.supervisor.Deallocate(argsPtr)
+	if err != nil {
+		logger.Warnf("failed to deallocate dispatch thunk arguments: %s", err)
+	}
+
+	// the dispatch thunk returns the pointer in the upper 32 bits and the length in the lower 32 bits
+	resPtr, resLen := uint32(uint64(res)>>32), uint32(res)
+	data, err := memorySlice(s.supervisor.Memory(), resPtr, resLen)
+	if err != nil {
+		panic(err)
+	}
+
+	result := make([]byte, len(data))
+	copy(result, data)
+
+	err = s.supervisor.Deallocate(resPtr)
+	if err != nil {
+		logger.Warnf("failed to deallocate dispatch thunk result: %s", err)
+	}
+
+	// Result<ReturnValue, HostError>
+	if len(result) == 0 || result[0] != 0 {
+		panic(errHostError)
+	}
+
+	value, err := decodeReturnValue(result[1:])
+	if err != nil {
+		panic(err)
+	}
+
+	if value == nil {
+		return 0
+	}
+
+	raw, _, err := valueToRaw(value)
+	if err != nil {
+		panic(err)
+	}
+
+	return raw
+}
+
+// resolver resolves the imports of a sandboxed instance to supervisor functions
+type resolver struct {
+	store    *Store
+	instance *Instance
+}
+
+// ResolveFunc returns a function calling the supervisor through the dispatch thunk
+func (r *resolver) ResolveFunc(module, field string) exec.FunctionImport {
+	funcIdx, ok := r.instance.functions[importName(module, field)]
+	if !ok {
+		panic(fmt.Errorf("function %s.%s is not provided", module, field))
+	}
+
+	return func(vm *exec.VirtualMachine) int64 {
+		sig, err := importSignature(vm.Module.Base, module, field)
+		if err != nil {
+			panic(err)
+		}
+
+		return r.store.dispatch(r.instance, funcIdx, sig, vm)
+	}
+}
+
+// ResolveGlobal panics since importing globals is not supported
+func (*resolver) ResolveGlobal(module, field string) int64 {
+	panic(fmt.Errorf("cannot import global %s.%s", module, field))
+}
+
+func importName(module, field string) string {
+	return module + "." + field
+}
+
+// readImports decodes the module sections needed before instantiation
+func readImports(code []byte) (*wasm.Module, error) {
+	return wasm.DecodeModule(bytes.NewReader(code))
+}
+
+func importSignature(m *wasm.Module, module, field string) (*wasm.FunctionSig, error) {
+	if m.Import == nil {
+		return nil, fmt.Errorf("import %s.%s not found", module, field)
+	}
+
+	for _, imp := range m.Import.Entries {
+		if imp.ModuleName != module || imp.FieldName != field {
+			continue
+		}
+
+		fn, ok := imp.Type.(wasm.FuncImport)
+		if !ok {
+			break
+		}
+
+		if m.Types == nil || int(fn.Type) >= len(m.Types.Entries) {
+			return nil, fmt.Errorf("invalid type index %d", fn.Type)
+		}
+
+		return &m.Types.Entries[fn.Type], nil
+	}
+
+	return nil, fmt.Errorf("import %s.%s not found", module, field)
+}
+
+// functionSignature returns the signature of the function at the given index of the function index space
+func functionSignature(m *wasm.Module, idx uint32) (*wasm.FunctionSig, error) {
+	var importedFuncs []uint32
+	if m.Import != nil {
+		for _, imp := range m.Import.Entries {
+			if fn, ok := imp.Type.(wasm.FuncImport); ok {
+				importedFuncs = append(importedFuncs, fn.Type)
+			}
+		}
+	}
+
+	var typeIdx uint32
+	switch {
+	case int(idx) < len(importedFuncs):
+		typeIdx = importedFuncs[idx]
+	case m.Function != nil && int(idx)-len(importedFuncs) < len(m.Function.Types):
+		typeIdx = m.Function.Types[int(idx)-len(importedFuncs)]
+	default:
+		return nil, fmt.Errorf("%w: function %d not found", errExecution, idx)
+	}
+
+	if m.Types == nil || int(typeIdx) >= len(m.Types.Entries) {
+		return nil, fmt.Errorf("%w: invalid type index %d", errExecution, typeIdx)
+	}
+
+	return &m.Types.Entries[typeIdx], nil
+}
+
+// initMemory copies the data segments of the module into the imported memory
+func initMemory(vm *exec.VirtualMachine, mem *Memory) error {
+	data := vm.Module.Base.Data
+	if data == nil {
+		return nil
+	}
+
+	for _, segment := range data.Entries {
+		offset, err := constOffset(segment.Offset)
+		if err != nil {
+			return err
+		}
+
+		dst, err := memorySlice(mem.data, offset, uint32(len(segment.Data)))
+		if err != nil {
+			return err
+		}
+
+		copy(dst, segment.Data)
+	}
+
+	return nil
+}
+
+// constOffset evaluates an i32.const initialiser expression
+func constOffset(expr []byte) (uint32, error) {
+	const i32Const = 0x41
+	if len(expr) == 0 || expr[0] != i32Const {
+		return 0, errors.New("unsupported data segment offset expression")
+	}
+
+	offset, err := leb128.ReadVarint32(bytes.NewReader(expr[1:]))
+	if err != nil {
+		return 0, err
+	}
+
+	return uint32(offset), nil
+}
@@ -0,0 +1,294 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sandbox
+
+import (
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/go-interpreter/wagon/wasm/leb128"
+	"github.com/perlin-network/life/exec"
+	"github.com/stretchr/testify/require"
+)
+
+func wasmSection(id byte, content ...byte) []byte {
+	return append(append([]byte{id}, leb128.AppendUleb128(nil, uint64(len(content)))...), content...)
+}
+
+func wasmModule(sections ...[]byte) []byte {
+	code := append(append([]byte{}, wasmMagic...), wasmVersion...)
+	for _, s := range sections {
+		code = append(code, s...)
+	}
+	return code
+}
+
+// guestModule imports env.double (i32) -> i32 and env.memory, exports call (i32) -> i32
+// which returns double(arg), and write () -> () which stores 42 at address 0
+var guestModule = wasmModule(
+	wasmSection(1, 0x02, 0x60, 0x01, 0x7f, 0x01, 0x7f, 0x60, 0x00, 0x00),
+	wasmSection(2, 0x02,
+		0x03, 'e', 'n', 'v', 0x06, 'd', 'o', 'u', 'b', 'l', 'e', 0x00, 0x00,
+		0x03, 'e', 'n', 'v', 0x06, 'm', 'e', 'm', 'o', 'r', 'y', 0x02, 0x00, 0x01),
+	wasmSection(3, 0x02, 0x00, 0x01),
+	wasmSection(7, 0x02,
+		0x04, 'c', 'a', 'l', 'l', 0x00, 0x01,
+		0x05, 'w', 'r', 'i', 't', 'e', 0x00, 0x02),
+	wasmSection(10, 0x02,
+		0x06, 0x00, 0x20, 0x00, 0x10, 0x00, 0x0b,
+		0x09, 0x00, 0x41, 0x00, 0x41, 0x2a, 0x36, 0x02, 0x00, 0x0b),
+	wasmSection(11, 0x01, 0x00, 0x41, 0x08, 0x0b, 0x02, 'h', 'i'),
+)
+
+const doubleFuncIdx = 7
+
+// mockSupervisor implements Supervisor with a bump allocator, its dispatch thunk doubles i32 values
+type mockSupervisor struct {
+	memory []byte
+	next   uint32
+	states []uint32
+}
+
+func newMockSupervisor() *mockSupervisor {
+	return &mockSupervisor{
+		memory: make([]byte, pageSize),
+		next:   1024,
+	}
+}
+
+func (m *mockSupervisor) Memory() []byte {
+	return m.memory
+}
+
+func (m *mockSupervisor) Allocate(size uint32) (uint32, error) {
+	ptr := m.next
+	m.next += size
+	return ptr, nil
+}
+
+func (*mockSupervisor) Deallocate(uint32) error {
+	return nil
+}
+
+func (m *mockSupervisor) InvokeDispatchThunk(_, argsPtr, argsLen, state, funcIdx uint32) (int64, error) {
+	m.states = append(m.states, state)
+	if funcIdx != doubleFuncIdx {
+		return 0, errors.New("unknown function")
+	}
+
+	args := NewValueSlice()
+	err := scale.Unmarshal(m.memory[argsPtr:argsPtr+argsLen], &args)
+	if err != nil {
+		return 0, err
+	}
+
+	ret, err := encodeReturnValue(args.Types[0].Value().(I32) * 2)
+	if err != nil {
+		return 0, err
+	}
+
+	res := append([]byte{0}, ret...)
+	ptr, _ := m.Allocate(uint32(len(res)))
+	copy(m.memory[ptr:], res)
+	return int64(uint64(ptr)<<32 | uint64(len(res))), nil
+}
+
+func encodeEnvironment(t *testing.T, memoryIdx uint32) []byte {
+	t.Helper()
+
+	enc := []byte{0x08} // two entries
+	for _, entry := range []struct {
+		field  string
+		entity scale.VaryingDataTypeValue
+	}{
+		{"double", FunctionEntity(doubleFuncIdx)},
+		{"memory", MemoryEntity(memoryIdx)},
+	} {
+		module, err := scale.Marshal([]byte("env"))
+		require.NoError(t, err)
+		field, err := scale.Marshal([]byte(entry.field))
+		require.NoError(t, err)
+
+		entity := scale.MustNewVaryingDataType(FunctionEntity(0), MemoryEntity(0))
+		require.NoError(t, entity.Set(entry.entity))
+		encEntity, err := scale.Marshal(entity)
+		require.NoError(t, err)
+
+		enc = append(enc, module...)
+		enc = append(enc, field...)
+		enc = append(enc, encEntity...)
+	}
+
+	return enc
+}
+
+func TestStore_InstantiateAndInvoke(t *testing.T) {
+	supervisor := newMockSupervisor()
+	store := NewStore(supervisor)
+
+	memIdx, err := store.NewMemory(1, noMaximum)
+	require.NoError(t, err)
+
+	instIdx, err := store.Instantiate(0, guestModule, encodeEnvironment(t, memIdx), 1)
+	require.NoError(t, err)
+
+	// the data segment was written into the imported memory
+	const bufPtr = 100
+	err = store.MemoryGet(memIdx, 8, bufPtr, 2)
+	require.NoError(t, err)
+	require.Equal(t, []byte("hi"), supervisor.memory[bufPtr:bufPtr+2])
+
+	args := NewValueSlice()
+	require.NoError(t, args.Add(I32(21)))
+	encArgs, err := scale.Marshal(args)
+	require.NoError(t, err)
+
+	const retPtr = 200
+	err = store.Invoke(instIdx, "call", encArgs, retPtr, 16, 5)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{5}, supervisor.states)
+
+	ret, err := decodeReturnValue(supervisor.memory[retPtr : retPtr+16])
+	require.NoError(t, err)
+	require.Equal(t, I32(42), ret)
+
+	// writes of the instance are visible through the memory
+	noArgs, err := scale.Marshal(NewValueSlice())
+	require.NoError(t, err)
+	err = store.Invoke(instIdx, "write", noArgs, retPtr, 16, 5)
+	require.NoError(t, err)
+	require.Equal(t, byte(0), supervisor.memory[retPtr])
+
+	err = store.MemoryGet(memIdx, 0, bufPtr, 4)
+	require.NoError(t, err)
+	require.Equal(t, uint32(42), binary.LittleEndian.Uint32(supervisor.memory[bufPtr:]))
+
+	// and writes of the supervisor are visible to the instance
+	copy(supervisor.memory[bufPtr:], []byte{1, 2, 3, 4})
+	err = store.MemorySet(memIdx, 16, bufPtr, 4)
+	require.NoError(t, err)
+	require.Equal(t, []byte{1, 2, 3, 4}, store.instances[instIdx].vm.Memory[16:20])
+
+	err = store.Invoke(instIdx, "call", noArgs, retPtr, 16, 5)
+	require.ErrorIs(t, err, errExecution)
+	require.Equal(t, ErrExecution, ReturnCode(err))
+
+	err = store.Invoke(instIdx, "unknown", noArgs, retPtr, 16, 5)
+	require.Equal(t, ErrExecution, ReturnCode(err))
+
+	err = store.Invoke(instIdx, "call", encArgs, retPtr, 1, 5)
+	require.Equal(t, ErrOutOfBounds, ReturnCode(err))
+
+	require.NoError(t, store.InstanceTeardown(instIdx))
+	err = store.Invoke(instIdx, "call", encArgs, retPtr, 16, 5)
+	require.ErrorIs(t, err, errInstanceUnknown)
+
+	require.NoError(t, store.MemoryTeardown(memIdx))
+	err = store.MemoryGet(memIdx, 0, bufPtr, 4)
+	require.ErrorIs(t, err, errMemoryUnknown)
+}
+
+func TestStore_Invoke_ArgsOutOfBounds(t *testing.T) {
+	supervisor := newMockSupervisor()
+	store := NewStore(supervisor)
+
+	memIdx, err := store.NewMemory(1, noMaximum)
+	require.NoError(t, err)
+
+	instIdx, err := store.Instantiate(0, guestModule, encodeEnvironment(t, memIdx), 1)
+	require.NoError(t, err)
+
+	args := NewValueSlice()
+	require.NoError(t, args.Add(I32(21)))
+	encArgs, err := scale.Marshal(args)
+	require.NoError(t, err)
+
+	// the dispatch thunk arguments are allocated past the end of the supervisor memory
+	supervisor.next = uint32(len(supervisor.memory)) - 1
+
+	const retPtr = 200
+	err = store.Invoke(instIdx, "call", encArgs, retPtr, 16, 5)
+	require.Equal(t, ErrExecution, ReturnCode(err))
+	require.Empty(t, supervisor.states)
+}
+
+func TestStore_Instantiate_MissingImport(t *testing.T) {
+	store := NewStore(newMockSupervisor())
+
+	_, err := store.Instantiate(0, guestModule, []byte{0}, 0)
+	require.Equal(t, ErrModule, ReturnCode(err))
+
+	_, err = store.Instantiate(0, []byte{1, 2, 3}, []byte{0}, 0)
+	require.Equal(t, ErrModule, ReturnCode(err))
+}
+
+func TestStore_Memory_OutOfBounds(t *testing.T) {
+	store := NewStore(newMockSupervisor())
+
+	memIdx, err := store.NewMemory(1, 2)
+	require.NoError(t, err)
+
+	err = store.MemoryGet(memIdx, pageSize-1, 0, 2)
+	require.Equal(t, ErrOutOfBounds, ReturnCode(err))
+
+	err = store.MemorySet(memIdx, 0, pageSize-1, 2)
+	require.Equal(t, ErrOutOfBounds, ReturnCode(err))
+
+	_, err = store.NewMemory(3, 2)
+	require.Equal(t, ErrModule, ReturnCode(err))
+}
+
+func TestImportsSandbox(t *testing.T) {
+	usesSandbox, err := ImportsSandbox(guestModule)
+	require.NoError(t, err)
+	require.False(t, usesSandbox)
+
+	supervisor := wasmModule(
+		wasmSection(1, 0x01, 0x60, 0x00, 0x00),
+		wasmSection(2, 0x01,
+			0x03, 'e', 'n', 'v', 0x1c, 'e', 'x', 't', '_', 's', 'a', 'n', 'd', 'b', 'o', 'x', '_',
+			'i', 'n', 'v', 'o', 'k', 'e', '_', 'v', 'e', 'r', 's', 'i', 'o', 'n', '_', '1', 0x00, 0x00),
+	)
+	usesSandbox, err = ImportsSandbox(supervisor)
+	require.NoError(t, err)
+	require.True(t, usesSandbox)
+
+	_, err = ImportsSandbox([]byte{1, 2, 3})
+	require.ErrorIs(t, err, errInvalidWasm)
+}
+
+func TestInjectDispatchThunkExport(t *testing.T) {
+	// the supervisor's dispatch thunk at table index 0 returns its func_idx argument
+	supervisor := wasmModule(
+		wasmSection(1, 0x01, 0x60, 0x04, 0x7f, 0x7f, 0x7f, 0x7f, 0x01, 0x7e),
+		wasmSection(3, 0x01, 0x00),
+		wasmSection(4, 0x01, 0x70, 0x00, 0x01),
+		wasmSection(7, 0x01, 0x05, 't', 'h', 'u', 'n', 'k', 0x00, 0x00),
+		wasmSection(9, 0x01, 0x00, 0x41, 0x00, 0x0b, 0x01, 0x00),
+		wasmSection(10, 0x01, 0x05, 0x00, 0x20, 0x03, 0xad, 0x0b),
+	)
+
+	code, err := InjectDispatchThunkExport(supervisor)
+	require.NoError(t, err)
+
+	vm, err := exec.NewVirtualMachine(code, exec.VMConfig{}, nil, nil)
+	require.NoError(t, err)
+
+	fnID, ok := vm.GetFunctionExport(DispatchThunkExport)
+	require.True(t, ok)
+
+	ret, err := vm.Run(fnID, 1, 2, 3, 42, 0)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), ret)
+
+	// modules without a table are returned unchanged
+	code, err = InjectDispatchThunkExport(guestModule)
+	require.NoError(t, err)
+	require.Equal(t, guestModule, code)
+
+	_, err = InjectDispatchThunkExport([]byte{1, 2, 3})
+	require.ErrorIs(t, err, errInvalidWasm)
+}
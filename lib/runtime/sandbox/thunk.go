@@ -0,0 +1,308 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sandbox
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-interpreter/wagon/wasm/leb128"
+)
+
+// DispatchThunkExport is the name of the function exported by InjectDispatchThunkExport.
+// It takes the dispatch thunk arguments followed by the dispatch thunk table index and
+// calls the dispatch thunk indirectly.
+const DispatchThunkExport = "gossamer_sandbox_dispatch_thunk"
+
+// hostFunctionPrefix is the prefix of the names of the sandbox host functions
+const hostFunctionPrefix = "ext_sandbox_"
+
+const (
+	sectionType     = 1
+	sectionImport   = 2
+	sectionFunction = 3
+	sectionTable    = 4
+	sectionExport   = 7
+	sectionCode     = 10
+
+	externalFunction = 0x00
+	externalTable    = 0x01
+	externalMemory   = 0x02
+	externalGlobal   = 0x03
+
+	valueTypeI32 = 0x7f
+	valueTypeI64 = 0x7e
+	funcTypeForm = 0x60
+)
+
+var (
+	wasmMagic   = []byte{0x00, 0x61, 0x73, 0x6d}
+	wasmVersion = []byte{0x01, 0x00, 0x00, 0x00}
+
+	errInvalidWasm    = errors.New("invalid wasm module")
+	errMissingSection = errors.New("missing section")
+)
+
+type section struct {
+	id      byte
+	content []byte
+}
+
+// moduleImports summarises the import section of a module
+type moduleImports struct {
+	funcs    uint32
+	hasTable bool
+	sandbox  bool
+}
+
+// ImportsSandbox returns whether the given wasm module imports any of the sandbox host functions, in which case
+// it needs the export added by InjectDispatchThunkExport.
+func ImportsSandbox(code []byte) (bool, error) {
+	sections, indices, err := parseModule(code)
+	if err != nil {
+		return false, err
+	}
+
+	imports, err := readModuleImports(sections, indices)
+	if err != nil {
+		return false, err
+	}
+
+	return imports.sandbox, nil
+}
+
+func parseModule(code []byte) ([]section, map[byte]int, error) {
+	if len(code) < 8 || !bytes.Equal(code[:4], wasmMagic) || !bytes.Equal(code[4:8], wasmVersion) {
+		return nil, nil, errInvalidWasm
+	}
+
+	sections, err := readSections(code[8:])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	indices := make(map[byte]int)
+	for i, s := range sections {
+		if s.id != 0 {
+			indices[s.id] = i
+		}
+	}
+
+	return sections, indices, nil
+}
+
+// InjectDispatchThunkExport returns a copy of the given wasm module with an additional exported
+// function named DispatchThunkExport. Backends that cannot call functions of the supervisor's table
+// directly use it to call the dispatch thunk. Modules without a table cannot have a dispatch thunk,
+// in which case the code is returned unchanged.
+func InjectDispatchThunkExport(code []byte) ([]byte, error) {
+	sections, indices, err := parseModule(code)
+	if err != nil {
+		return nil, err
+	}
+
+	imports, err := readModuleImports(sections, indices)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := indices[sectionTable]; !ok && !imports.hasTable {
+		return code, nil
+	}
+
+	for _, id := range []byte{sectionType, sectionFunction, sectionExport, sectionCode} {
+		if _, ok := indices[id]; !ok {
+			return nil, fmt.Errorf("%w: %d", errMissingSection, id)
+		}
+	}
+
+	// the dispatch thunk signature and the signature of the injected function
+	thunkType := []byte{funcTypeForm, 4, valueTypeI32, valueTypeI32, valueTypeI32, valueTypeI32, 1, valueTypeI64}
+	injectedType := []byte{funcTypeForm, 5, valueTypeI32, valueTypeI32, valueTypeI32, valueTypeI32, valueTypeI32,
+		1, valueTypeI64}
+
+	typeSection := &sections[indices[sectionType]]
+	numTypes, err := appendEntries(typeSection, 2, append(thunkType, injectedType...))
+	if err != nil {
+		return nil, err
+	}
+
+	functionSection := &sections[indices[sectionFunction]]
+	numFuncs, err := appendEntries(functionSection, 1, leb128.AppendUleb128(nil, uint64(numTypes+1)))
+	if err != nil {
+		return nil, err
+	}
+
+	// local.get 0..4, call_indirect thunkType 0, end
+	body := []byte{0x00, 0x20, 0x00, 0x20, 0x01, 0x20, 0x02, 0x20, 0x03, 0x20, 0x04, 0x11}
+	body = leb128.AppendUleb128(body, uint64(numTypes))
+	body = append(body, 0x00, 0x0b)
+
+	codeSection := &sections[indices[sectionCode]]
+	_, err = appendEntries(codeSection, 1, append(leb128.AppendUleb128(nil, uint64(len(body))), body...))
+	if err != nil {
+		return nil, err
+	}
+
+	export := leb128.AppendUleb128(nil, uint64(len(DispatchThunkExport)))
+	export = append(export, DispatchThunkExport...)
+	export = append(export, externalFunction)
+	export = leb128.AppendUleb128(export, uint64(imports.funcs+numFuncs))
+
+	exportSection := &sections[indices[sectionExport]]
+	_, err = appendEntries(exportSection, 1, export)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 0, len(code)+128)
+	out = append(out, wasmMagic...)
+	out = append(out, wasmVersion...)
+	for _, s := range sections {
+		out = append(out, s.id)
+		out = leb128.AppendUleb128(out, uint64(len(s.content)))
+		out = append(out, s.content...)
+	}
+
+	return out, nil
+}
+
+func readSections(data []byte) ([]section, error) {
+	r := bytes.NewReader(data)
+
+	var sections []section
+	for r.Len() > 0 {
+		id, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		size, err := leb128.ReadVarUint32(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if int(size) > r.Len() {
+			return nil, fmt.Errorf("%w: section %d out of bounds", errInvalidWasm, id)
+		}
+
+		content := make([]byte, size)
+		_, err = io.ReadFull(r, content)
+		if err != nil {
+			return nil, err
+		}
+
+		sections = append(sections, section{id: id, content: content})
+	}
+
+	return sections, nil
+}
+
+// readModuleImports reads the import section of a module
+func readModuleImports(sections []section, indices map[byte]int) (*moduleImports, error) {
+	imports := new(moduleImports)
+	i, ok := indices[sectionImport]
+	if !ok {
+		return imports, nil
+	}
+
+	r := bytes.NewReader(sections[i].content)
+	count, err := leb128.ReadVarUint32(r)
+	if err != nil {
+		return nil, err
+	}
+
+	for j := uint32(0); j < count; j++ {
+		// module and field names
+		var field string
+		for k := 0; k < 2; k++ {
+			l, err := leb128.ReadVarUint32(r)
+			if err != nil {
+				return nil, err
+			}
+
+			if int(l) > r.Len() {
+				return nil, fmt.Errorf("%w: import name out of bounds", errInvalidWasm)
+			}
+
+			name := make([]byte, l)
+			_, err = io.ReadFull(r, name)
+			if err != nil {
+				return nil, err
+			}
+			field = string(name)
+		}
+
+		kind, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch kind {
+		case externalFunction:
+			imports.funcs++
+			if strings.HasPrefix(field, hostFunctionPrefix) {
+				imports.sandbox = true
+			}
+			_, err = leb128.ReadVarUint32(r)
+		case externalTable:
+			imports.hasTable = true
+			if _, err = r.ReadByte(); err == nil {
+				err = skipLimits(r)
+			}
+		case externalMemory:
+			err = skipLimits(r)
+		case externalGlobal:
+			_, err = r.Seek(2, io.SeekCurrent)
+		default:
+			err = fmt.Errorf("%w: unknown import kind %d", errInvalidWasm, kind)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return imports, nil
+}
+
+func skipLimits(r *bytes.Reader) error {
+	flags, err := leb128.ReadVarUint32(r)
+	if err != nil {
+		return err
+	}
+
+	_, err = leb128.ReadVarUint32(r)
+	if err != nil {
+		return err
+	}
+
+	if flags&1 == 1 {
+		_, err = leb128.ReadVarUint32(r)
+	}
+
+	return err
+}
+
+// appendEntries appends n encoded entries to a vector section and returns the
+// number of entries the section held before
+func appendEntries(s *section, n uint32, entries []byte) (uint32, error) {
+	r := bytes.NewReader(s.content)
+	count, err := leb128.ReadVarUint32(r)
+	if err != nil {
+		return 0, err
+	}
+
+	rest := s.content[len(s.content)-r.Len():]
+
+	content := leb128.AppendUleb128(nil, uint64(count+n))
+	content = append(content, rest...)
+	content = append(content, entries...)
+	s.content = content
+
+	return count, nil
+}
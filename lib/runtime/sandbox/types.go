@@ -0,0 +1,177 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package sandbox
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ChainSafe/gossamer/pkg/scale"
+	"github.com/go-interpreter/wagon/wasm"
+)
+
+var errUnknownReturnValue = errors.New("unknown return value variant")
+
+// I32 is a 32 bit integer value passed between the supervisor and a sandboxed instance
+type I32 int32
+
+// Index returns the VDT index
+func (I32) Index() uint { return 0 }
+
+// I64 is a 64 bit integer value passed between the supervisor and a sandboxed instance
+type I64 int64
+
+// Index returns the VDT index
+func (I64) Index() uint { return 1 }
+
+// F32 holds the bits of a 32 bit float value passed between the supervisor and a sandboxed instance
+type F32 uint32
+
+// Index returns the VDT index
+func (F32) Index() uint { return 2 }
+
+// F64 holds the bits of a 64 bit float value passed between the supervisor and a sandboxed instance
+type F64 uint64
+
+// Index returns the VDT index
+func (F64) Index() uint { return 3 }
+
+// NewValue returns a VaryingDataType able to hold a sandbox value
+func NewValue() scale.VaryingDataType {
+	return scale.MustNewVaryingDataType(I32(0), I64(0), F32(0), F64(0))
+}
+
+// NewValueSlice returns a VaryingDataTypeSlice able to hold sandbox values
+func NewValueSlice() scale.VaryingDataTypeSlice {
+	return scale.NewVaryingDataTypeSlice(NewValue())
+}
+
+// valueFromRaw converts a raw interpreter register into a sandbox value of the given type
+func valueFromRaw(t wasm.ValueType, raw int64) (scale.VaryingDataTypeValue, error) {
+	switch t {
+	case wasm.ValueTypeI32:
+		return I32(int32(raw)), nil
+	case wasm.ValueTypeI64:
+		return I64(raw), nil
+	case wasm.ValueTypeF32:
+		return F32(uint32(raw)), nil
+	case wasm.ValueTypeF64:
+		return F64(uint64(raw)), nil
+	default:
+		return nil, fmt.Errorf("unsupported value type %s", t)
+	}
+}
+
+// valueToRaw converts a sandbox value into a raw interpreter register
+func valueToRaw(v scale.VaryingDataTypeValue) (int64, wasm.ValueType, error) {
+	switch v := v.(type) {
+	case I32:
+		return int64(v), wasm.ValueTypeI32, nil
+	case I64:
+		return int64(v), wasm.ValueTypeI64, nil
+	case F32:
+		return int64(v), wasm.ValueTypeF32, nil
+	case F64:
+		return int64(v), wasm.ValueTypeF64, nil
+	default:
+		return 0, 0, fmt.Errorf("unsupported value %T", v)
+	}
+}
+
+// encodeReturnValue SCALE encodes the ReturnValue enum, a nil value is encoded as Unit
+func encodeReturnValue(v scale.VaryingDataTypeValue) ([]byte, error) {
+	if v == nil {
+		return []byte{0}, nil
+	}
+
+	value := NewValue()
+	err := value.Set(v)
+	if err != nil {
+		return nil, err
+	}
+
+	enc, err := scale.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{1}, enc...), nil
+}
+
+// decodeReturnValue decodes a SCALE encoded ReturnValue enum, Unit is decoded as a nil value
+func decodeReturnValue(data []byte) (scale.VaryingDataTypeValue, error) {
+	if len(data) == 0 {
+		return nil, errUnknownReturnValue
+	}
+
+	switch data[0] {
+	case 0:
+		return nil, nil //nolint:nilnil
+	case 1:
+		value := NewValue()
+		err := scale.Unmarshal(data[1:], &value)
+		if err != nil {
+			return nil, err
+		}
+		return value.Value(), nil
+	default:
+		return nil, errUnknownReturnValue
+	}
+}
+
+// FunctionEntity is an environment entry resolving to a supervisor function
+type FunctionEntity uint32
+
+// Index returns the VDT index
+func (FunctionEntity) Index() uint { return 1 }
+
+// MemoryEntity is an environment entry resolving to a sandbox memory
+type MemoryEntity uint32
+
+// Index returns the VDT index
+func (MemoryEntity) Index() uint { return 2 }
+
+// EnvironmentEntry is an entry of the environment definition provided by the supervisor
+type EnvironmentEntry struct {
+	Module []byte
+	Field  []byte
+	Entity scale.VaryingDataTypeValue
+}
+
+// DecodeEnvironmentDefinition decodes the SCALE encoded list of imports the supervisor
+// provides to a sandboxed instance
+func DecodeEnvironmentDefinition(data []byte) ([]EnvironmentEntry, error) {
+	decoder := scale.NewDecoder(bytes.NewReader(data))
+
+	var length *big.Int
+	err := decoder.Decode(&length)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode number of entries: %w", err)
+	}
+
+	entries := make([]EnvironmentEntry, length.Uint64())
+	for i := range entries {
+		err = decoder.Decode(&entries[i].Module)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode module name: %w", err)
+		}
+
+		err = decoder.Decode(&entries[i].Field)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode field name: %w", err)
+		}
+
+		entity := scale.MustNewVaryingDataType(FunctionEntity(0), MemoryEntity(0))
+		err = decoder.Decode(&entity)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode entity: %w", err)
+		}
+
+		entries[i].Entity = entity.Value()
+	}
+
+	return entries, nil
+}
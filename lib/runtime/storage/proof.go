@@ -0,0 +1,151 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/trie"
+)
+
+var (
+	// ErrIncompleteProof is returned when the storage accessed from a TrieState built with
+	// NewTrieStateFromProof is not covered by the proof
+	ErrIncompleteProof = errors.New("storage is not covered by the proof")
+
+	errProofRootNotFound = errors.New("proof does not hold the state root")
+)
+
+// NewTrieStateFromProof returns a new TrieState holding the partial trie built from the given proof nodes
+// with trie.LoadFromProof. Accessing storage not covered by the proof is recorded and reported by ProofError.
+func NewTrieStateFromProof(root common.Hash, proof [][]byte) (*TrieState, error) {
+	t := trie.NewEmptyTrie()
+	err := t.LoadFromProof(proof, root.ToBytes())
+	if err != nil {
+		return nil, err
+	}
+
+	if t.RootNode() == nil {
+		return nil, fmt.Errorf("%w: %s", errProofRootNotFound, root)
+	}
+
+	return &TrieState{
+		// the partial trie is kept unmodified to check the accessed storage against
+		t:       t.Snapshot(),
+		checker: &proofChecker{base: t},
+	}, nil
+}
+
+// ProofError returns an error wrapping ErrIncompleteProof for the first storage access not covered by the proof
+// of a TrieState built with NewTrieStateFromProof, or nil if the proof covered all the storage accessed.
+func (s *TrieState) ProofError() error {
+	if s.checker == nil {
+		return nil
+	}
+
+	s.checker.Lock()
+	defer s.checker.Unlock()
+	return s.checker.err
+}
+
+// proofChecker checks the storage accessed is covered by the proof of the partial trie it holds
+type proofChecker struct {
+	sync.Mutex
+	base *trie.Trie
+	err  error
+}
+
+func (c *proofChecker) checkKeys(keys ...[]byte) {
+	c.Lock()
+	defer c.Unlock()
+
+	for _, k := range keys {
+		if k != nil && !c.base.IsProven(k) {
+			c.fail(fmt.Errorf("%w: key 0x%x", ErrIncompleteProof, k))
+		}
+	}
+}
+
+func (c *proofChecker) checkPrefix(prefix []byte) {
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.base.IsPrefixProven(prefix) {
+		c.fail(fmt.Errorf("%w: prefix 0x%x", ErrIncompleteProof, prefix))
+	}
+}
+
+// checkMerged checks the node a branch is merged with when deleting the given key, or the keys starting
+// with it, is in the proof. The node is not on the path of the deleted keys, and merging a branch with
+// a node missing from the proof gives a different state root.
+func (c *proofChecker) checkMerged(key []byte, merged trie.Node) {
+	c.Lock()
+	defer c.Unlock()
+
+	if !trie.IsNodeProven(merged) {
+		c.fail(fmt.Errorf("%w: node merged when deleting 0x%x", ErrIncompleteProof, key))
+	}
+}
+
+func (c *proofChecker) checkChildKeys(keyToChild []byte, keys ...[]byte) {
+	c.Lock()
+	defer c.Unlock()
+
+	child, ok := c.child(keyToChild)
+	if !ok || child == nil {
+		return
+	}
+
+	for _, k := range keys {
+		if k != nil && !child.IsProven(k) {
+			c.fail(fmt.Errorf("%w: key 0x%x of child trie 0x%x", ErrIncompleteProof, k, keyToChild))
+		}
+	}
+}
+
+func (c *proofChecker) checkChildPrefix(keyToChild, prefix []byte) {
+	c.Lock()
+	defer c.Unlock()
+
+	child, ok := c.child(keyToChild)
+	if !ok || child == nil {
+		return
+	}
+
+	if !child.IsPrefixProven(prefix) {
+		c.fail(fmt.Errorf("%w: prefix 0x%x of child trie 0x%x", ErrIncompleteProof, prefix, keyToChild))
+	}
+}
+
+// child returns the child trie at the given key, which is nil if the child trie did not exist in the
+// proven state. It returns false if the proof does not cover the child trie.
+func (c *proofChecker) child(keyToChild []byte) (*trie.Trie, bool) {
+	key := childStorageKey(keyToChild)
+	if !c.base.IsProven(key) {
+		c.fail(fmt.Errorf("%w: key 0x%x", ErrIncompleteProof, key))
+		return nil, false
+	}
+
+	if c.base.Get(key) == nil {
+		return nil, true
+	}
+
+	child, err := c.base.GetChild(keyToChild)
+	if err != nil || child == nil {
+		c.fail(fmt.Errorf("%w: child trie 0x%x", ErrIncompleteProof, keyToChild))
+		return nil, false
+	}
+
+	return child, true
+}
+
+// fail records the given error if no error was recorded before
+func (c *proofChecker) fail(err error) {
+	if c.err == nil {
+		c.err = err
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package storage
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/lib/trie"
+)
+
+// recorder records the storage accessed by a runtime call. The proof of the accessed storage
+// is generated from the trie as it was when the recording started, so that it holds the trie
+// nodes the call read from the state it was executed against.
+type recorder struct {
+	sync.Mutex
+	base *trie.Trie
+	top  *accessed
+	// children holds the storage accessed in the child tries, by key to the child trie
+	children map[string]*accessed
+	// merged holds the nodes branches were merged with by deletions, which are read
+	// although they are not on the path of the deleted keys
+	merged []trie.Node
+}
+
+// accessed holds the keys and the key prefixes accessed in a trie
+type accessed struct {
+	keys     map[string]struct{}
+	prefixes map[string]struct{}
+}
+
+func newAccessed() *accessed {
+	return &accessed{
+		keys:     make(map[string]struct{}),
+		prefixes: make(map[string]struct{}),
+	}
+}
+
+func newRecorder(base *trie.Trie) *recorder {
+	return &recorder{
+		base:     base,
+		top:      newAccessed(),
+		children: make(map[string]*accessed),
+	}
+}
+
+// recordKeys records the given keys of the main trie
+func (r *recorder) recordKeys(keys ...[]byte) {
+	r.Lock()
+	defer r.Unlock()
+	r.top.addKeys(keys...)
+}
+
+// recordPrefix records the keys of the main trie starting with the given prefix
+func (r *recorder) recordPrefix(prefix []byte) {
+	r.Lock()
+	defer r.Unlock()
+	r.top.prefixes[string(prefix)] = struct{}{}
+}
+
+// recordChildKeys records the given keys of the child trie at the given key, along with the
+// key of the main trie holding the root of the child trie
+func (r *recorder) recordChildKeys(keyToChild []byte, keys ...[]byte) {
+	r.Lock()
+	defer r.Unlock()
+	r.child(keyToChild).addKeys(keys...)
+}
+
+// recordChildPrefix records the keys starting with the given prefix of the child trie at the given key,
+// along with the key of the main trie holding the root of the child trie
+func (r *recorder) recordChildPrefix(keyToChild, prefix []byte) {
+	r.Lock()
+	defer r.Unlock()
+	r.child(keyToChild).prefixes[string(prefix)] = struct{}{}
+}
+
+// recordMerged records the node a branch of the main trie or of a child trie is merged with by a deletion.
+// The node may have been written by the call, in which case its encoding is not needed by the proof
+// but does not invalidate it.
+func (r *recorder) recordMerged(n trie.Node) {
+	r.Lock()
+	defer r.Unlock()
+	r.merged = append(r.merged, n)
+}
+
+func (r *recorder) child(keyToChild []byte) *accessed {
+	r.top.addKeys(childStorageKey(keyToChild))
+
+	child, has := r.children[string(keyToChild)]
+	if !has {
+		child = newAccessed()
+		r.children[string(keyToChild)] = child
+	}
+	return child
+}
+
+// proof returns the nodes of the main trie and of the child tries read to access the recorded storage,
+// without duplicates and sorted in lexicographical order.
+func (r *recorder) proof() ([][]byte, error) {
+	r.Lock()
+	defer r.Unlock()
+
+	nodes := make(map[string]struct{})
+	err := addProof(nodes, r.base, r.top)
+	if err != nil {
+		return nil, err
+	}
+
+	for keyToChild, acc := range r.children {
+		child, err := r.base.GetChild([]byte(keyToChild))
+		if err != nil || child == nil {
+			// the child trie was created by the call, so there is nothing to prove
+			continue
+		}
+
+		err = addProof(nodes, child, acc)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, n := range r.merged {
+		enc, _, err := n.EncodeAndHash()
+		if err != nil {
+			return nil, err
+		}
+		nodes[string(enc)] = struct{}{}
+	}
+
+	proof := make([][]byte, 0, len(nodes))
+	for n := range nodes {
+		proof = append(proof, []byte(n))
+	}
+
+	sort.Slice(proof, func(i, j int) bool {
+		return bytes.Compare(proof[i], proof[j]) < 0
+	})
+	return proof, nil
+}
+
+func (a *accessed) addKeys(keys ...[]byte) {
+	for _, k := range keys {
+		if k != nil {
+			a.keys[string(k)] = struct{}{}
+		}
+	}
+}
+
+// addProof adds to the given set the nodes of the trie on the path of the accessed keys
+func addProof(nodes map[string]struct{}, t *trie.Trie, acc *accessed) error {
+	if t.RootNode() == nil {
+		return nil
+	}
+
+	keys := make([][]byte, 0, len(acc.keys)+len(acc.prefixes))
+	for k := range acc.keys {
+		keys = append(keys, []byte(k))
+	}
+
+	for prefix := range acc.prefixes {
+		// the path to the prefix proves there are no other keys with the prefix
+		keys = append(keys, []byte(prefix))
+		keys = append(keys, t.GetKeysWithPrefix([]byte(prefix))...)
+	}
+
+	proof, err := trie.GenerateProofFromTrie(t, keys)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range proof {
+		nodes[string(n)] = struct{}{}
+	}
+	return nil
+}
+
+func childStorageKey(keyToChild []byte) []byte {
+	key := make([]byte, 0, len(trie.ChildStorageKeyPrefix)+len(keyToChild))
+	key = append(key, trie.ChildStorageKeyPrefix...)
+	return append(key, keyToChild...)
+}
@@ -5,6 +5,7 @@ package storage
 
 import (
 	"encoding/binary"
+	"errors"
 	"sort"
 	"sync"
 
@@ -12,12 +13,23 @@ import (
 	"github.com/ChainSafe/gossamer/lib/trie"
 )
 
+// ErrNoTransaction is returned when a storage transaction is committed or rolled back
+// without a matching call to BeginStorageTransaction
+var ErrNoTransaction = errors.New("no active storage transaction")
+
 // TrieState is a wrapper around a transient trie that is used during the course of executing some runtime call.
 // If the execution of the call is successful, the trie will be saved in the StorageState.
 type TrieState struct {
-	t       *trie.Trie
-	oldTrie *trie.Trie // this is the trie before BeginStorageTransaction is called. set to nil if it isn't called
-	lock    sync.RWMutex
+	t *trie.Trie
+	// transactions holds, for each active storage transaction, the trie as it was when
+	// BeginStorageTransaction was called. The innermost transaction is last.
+	transactions []*trie.Trie
+	lock         sync.RWMutex
+
+	// recorder records the storage accessed since EnableRecording was called
+	recorder *recorder
+	// checker checks the storage accessed is covered by the proof the TrieState was built from
+	checker *proofChecker
 }
 
 // NewTrieState returns a new TrieState with the given trie
@@ -45,32 +57,148 @@ func (s *TrieState) Snapshot() *trie.Trie {
 	return s.t.Snapshot()
 }
 
+// EnableRecording makes the TrieState record the storage accessed from now on, including the storage
+// of child tries, so that the proof of the state used by a runtime call can be retrieved with RecordedProof.
+func (s *TrieState) EnableRecording() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	// the current version of the trie is kept unmodified to generate the proof from
+	base := s.t
+	s.t = s.t.Snapshot()
+	s.recorder = newRecorder(base)
+}
+
+// RecordedProof returns the trie nodes read to access the storage recorded since EnableRecording was
+// called. The nodes of child tries are included and the proof can be replayed against the partial trie
+// built with trie.LoadFromProof. It returns nil if recording is not enabled.
+func (s *TrieState) RecordedProof() ([][]byte, error) {
+	if s.recorder == nil {
+		return nil, nil
+	}
+
+	return s.recorder.proof()
+}
+
+// record records the access to the given keys, for the proof recording and the proof checking
+func (s *TrieState) record(keys ...[]byte) {
+	if s.recorder != nil {
+		s.recorder.recordKeys(keys...)
+	}
+
+	if s.checker != nil {
+		s.checker.checkKeys(keys...)
+	}
+}
+
+func (s *TrieState) recordPrefix(prefix []byte) {
+	if s.recorder != nil {
+		s.recorder.recordPrefix(prefix)
+	}
+
+	if s.checker != nil {
+		s.checker.checkPrefix(prefix)
+	}
+}
+
+// recordMerged records the node the given trie merges a branch with when deleting the key, or the keys
+// starting with the prefix if prefix is true, for the proof recording and the proof checking.
+// It must be called before the deletion.
+func (s *TrieState) recordMerged(t *trie.Trie, key []byte, prefix bool) {
+	if s.recorder == nil && s.checker == nil {
+		return
+	}
+
+	var merged trie.Node
+	if prefix {
+		merged = t.MergedOnClearPrefix(key)
+	} else {
+		merged = t.MergedOnDelete(key)
+	}
+
+	if merged == nil {
+		return
+	}
+
+	if s.recorder != nil {
+		s.recorder.recordMerged(merged)
+	}
+
+	if s.checker != nil {
+		s.checker.checkMerged(key, merged)
+	}
+}
+
+func (s *TrieState) recordChild(keyToChild []byte, keys ...[]byte) {
+	if s.recorder != nil {
+		s.recorder.recordChildKeys(keyToChild, keys...)
+	}
+
+	if s.checker != nil {
+		s.checker.checkChildKeys(keyToChild, keys...)
+	}
+}
+
+func (s *TrieState) recordChildPrefix(keyToChild, prefix []byte) {
+	if s.recorder != nil {
+		s.recorder.recordChildPrefix(keyToChild, prefix)
+	}
+
+	if s.checker != nil {
+		s.checker.checkChildPrefix(keyToChild, prefix)
+	}
+}
+
 // BeginStorageTransaction begins a new nested storage transaction
 // which will either be committed or rolled back at a later time.
 func (s *TrieState) BeginStorageTransaction() {
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	s.oldTrie = s.t
+	s.transactions = append(s.transactions, s.t)
 	s.t = s.t.Snapshot()
 }
 
-// CommitStorageTransaction commits all storage changes made since BeginStorageTransaction was called.
-func (s *TrieState) CommitStorageTransaction() {
+// CommitStorageTransaction commits all storage changes made since the innermost
+// BeginStorageTransaction was called into the enclosing transaction, if any.
+func (s *TrieState) CommitStorageTransaction() error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	s.oldTrie = nil
+
+	if len(s.transactions) == 0 {
+		return ErrNoTransaction
+	}
+
+	s.transactions = s.transactions[:len(s.transactions)-1]
+	return nil
 }
 
-// RollbackStorageTransaction rolls back all storage changes made since BeginStorageTransaction was called.
-func (s *TrieState) RollbackStorageTransaction() {
+// RollbackStorageTransaction rolls back all storage changes made since the innermost
+// BeginStorageTransaction was called, including changes to child tries.
+func (s *TrieState) RollbackStorageTransaction() error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
-	s.t = s.oldTrie
-	s.oldTrie = nil
+
+	if len(s.transactions) == 0 {
+		return ErrNoTransaction
+	}
+
+	last := len(s.transactions) - 1
+	s.t = s.transactions[last]
+	s.transactions = s.transactions[:last]
+	return nil
+}
+
+// TransactionDepth returns the number of active nested storage transactions
+func (s *TrieState) TransactionDepth() int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.transactions)
 }
 
 // Set sets a key-value pair in the trie
 func (s *TrieState) Set(key, value []byte) {
+	s.record(key)
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
 	s.t.Put(key, value)
@@ -78,6 +206,8 @@ func (s *TrieState) Set(key, value []byte) {
 
 // Get gets a value from the trie
 func (s *TrieState) Get(key []byte) []byte {
+	s.record(key)
+
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 	return s.t.Get(key)
@@ -100,6 +230,8 @@ func (s *TrieState) Has(key []byte) bool {
 
 // Delete deletes a key from the trie
 func (s *TrieState) Delete(key []byte) {
+	s.record(key)
+
 	val := s.t.Get(key)
 	if val == nil {
 		return
@@ -107,6 +239,7 @@ func (s *TrieState) Delete(key []byte) {
 
 	s.lock.Lock()
 	defer s.lock.Unlock()
+	s.recordMerged(s.t, key, false)
 	s.t.Delete(key)
 }
 
@@ -114,28 +247,40 @@ func (s *TrieState) Delete(key []byte) {
 func (s *TrieState) NextKey(key []byte) []byte {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
-	return s.t.NextKey(key)
+
+	next := s.t.NextKey(key)
+	s.record(key, next)
+	return next
 }
 
 // ClearPrefix deletes all key-value pairs from the trie where the key starts with the given prefix
 func (s *TrieState) ClearPrefix(prefix []byte) error {
+	s.recordPrefix(prefix)
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
+	s.recordMerged(s.t, prefix, true)
 	s.t.ClearPrefix(prefix)
 	return nil
 }
 
 // ClearPrefixLimit deletes key-value pairs from the trie where the key starts with the given prefix till limit reached
 func (s *TrieState) ClearPrefixLimit(prefix []byte, limit uint32) (uint32, bool) {
+	s.recordPrefix(prefix)
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
+	// the limit may leave keys with the prefix, in which case the recorded node is not needed
+	s.recordMerged(s.t, prefix, true)
 	num, del := s.t.ClearPrefixLimit(prefix, limit)
 	return num, del
 }
 
 // TrieEntries returns every key-value pair in the trie
 func (s *TrieState) TrieEntries() map[string][]byte {
+	s.recordPrefix(nil)
+
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 	return s.t.Entries()
@@ -143,6 +288,8 @@ func (s *TrieState) TrieEntries() map[string][]byte {
 
 // SetChild sets the child trie at the given key
 func (s *TrieState) SetChild(keyToChild []byte, child *trie.Trie) error {
+	s.record(childStorageKey(keyToChild))
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
 	return s.t.PutChild(keyToChild, child)
@@ -150,6 +297,8 @@ func (s *TrieState) SetChild(keyToChild []byte, child *trie.Trie) error {
 
 // SetChildStorage sets a key-value pair in a child trie
 func (s *TrieState) SetChildStorage(keyToChild, key, value []byte) error {
+	s.recordChild(keyToChild, key)
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
 	return s.t.PutIntoChild(keyToChild, key, value)
@@ -157,6 +306,8 @@ func (s *TrieState) SetChildStorage(keyToChild, key, value []byte) error {
 
 // GetChild returns the child trie at the given key
 func (s *TrieState) GetChild(keyToChild []byte) (*trie.Trie, error) {
+	s.record(childStorageKey(keyToChild))
+
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 	return s.t.GetChild(keyToChild)
@@ -164,6 +315,8 @@ func (s *TrieState) GetChild(keyToChild []byte) (*trie.Trie, error) {
 
 // GetChildStorage returns a value from a child trie
 func (s *TrieState) GetChildStorage(keyToChild, key []byte) ([]byte, error) {
+	s.recordChild(keyToChild, key)
+
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 	return s.t.GetFromChild(keyToChild, key)
@@ -171,14 +324,19 @@ func (s *TrieState) GetChildStorage(keyToChild, key []byte) ([]byte, error) {
 
 // DeleteChild deletes a child trie from the main trie
 func (s *TrieState) DeleteChild(key []byte) {
+	s.record(childStorageKey(key))
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
+	s.recordMerged(s.t, childStorageKey(key), false)
 	s.t.DeleteChild(key)
 }
 
 // DeleteChildLimit deletes up to limit of database entries by lexicographic order, return number
 //  deleted, true if all delete otherwise false
 func (s *TrieState) DeleteChildLimit(key []byte, limit *[]byte) (uint32, bool, error) {
+	s.recordChildPrefix(key, nil)
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
 	tr, err := s.t.GetChild(key)
@@ -187,6 +345,7 @@ func (s *TrieState) DeleteChildLimit(key []byte, limit *[]byte) (uint32, bool, e
 	}
 	qtyEntries := uint32(len(tr.Entries()))
 	if limit == nil {
+		s.recordMerged(s.t, childStorageKey(key), false)
 		s.t.DeleteChild(key)
 		return qtyEntries, true, nil
 	}
@@ -215,13 +374,22 @@ func (s *TrieState) DeleteChildLimit(key []byte, limit *[]byte) (uint32, bool, e
 
 // ClearChildStorage removes the child storage entry from the trie
 func (s *TrieState) ClearChildStorage(keyToChild, key []byte) error {
+	s.recordChild(keyToChild, key)
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
+
+	child, err := s.t.GetChild(keyToChild)
+	if err == nil && child != nil {
+		s.recordMerged(child, key, false)
+	}
 	return s.t.ClearFromChild(keyToChild, key)
 }
 
 // ClearPrefixInChild clears all the keys from the child trie that have the given prefix
 func (s *TrieState) ClearPrefixInChild(keyToChild, prefix []byte) error {
+	s.recordChildPrefix(keyToChild, prefix)
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
@@ -233,6 +401,7 @@ func (s *TrieState) ClearPrefixInChild(keyToChild, prefix []byte) error {
 		return nil
 	}
 
+	s.recordMerged(child, prefix, true)
 	child.ClearPrefix(prefix)
 	return nil
 }
@@ -248,7 +417,10 @@ func (s *TrieState) GetChildNextKey(keyToChild, key []byte) ([]byte, error) {
 	if child == nil {
 		return nil, nil
 	}
-	return child.NextKey(key), nil
+
+	next := child.NextKey(key)
+	s.recordChild(keyToChild, key, next)
+	return next, nil
 }
 
 // GetKeysWithPrefixFromChild ...
@@ -260,6 +432,8 @@ func (s *TrieState) GetKeysWithPrefixFromChild(keyToChild, prefix []byte) ([][]b
 	if child == nil {
 		return nil, nil
 	}
+
+	s.recordChildPrefix(keyToChild, prefix)
 	return child.GetKeysWithPrefix(prefix), nil
 }
 
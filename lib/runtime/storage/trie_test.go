@@ -164,7 +164,8 @@ func TestTrieState_CommitStorageTransaction(t *testing.T) {
 	ts.BeginStorageTransaction()
 	testValue := []byte("noot")
 	ts.Set([]byte(testCases[0]), testValue)
-	ts.CommitStorageTransaction()
+	err := ts.CommitStorageTransaction()
+	require.NoError(t, err)
 
 	val := ts.Get([]byte(testCases[0]))
 	require.Equal(t, testValue, val)
@@ -180,12 +181,105 @@ func TestTrieState_RollbackStorageTransaction(t *testing.T) {
 	ts.BeginStorageTransaction()
 	testValue := []byte("noot")
 	ts.Set([]byte(testCases[0]), testValue)
-	ts.RollbackStorageTransaction()
+	err := ts.RollbackStorageTransaction()
+	require.NoError(t, err)
 
 	val := ts.Get([]byte(testCases[0]))
 	require.Equal(t, []byte(testCases[0]), val)
 }
 
+func TestTrieState_NestedStorageTransactions(t *testing.T) {
+	ts := newTestTrieState(t)
+	key := []byte(testCases[0])
+	ts.Set(key, []byte("a"))
+
+	ts.BeginStorageTransaction()
+	ts.Set(key, []byte("b"))
+
+	ts.BeginStorageTransaction()
+	ts.Set(key, []byte("c"))
+	require.Equal(t, 2, ts.TransactionDepth())
+
+	err := ts.RollbackStorageTransaction()
+	require.NoError(t, err)
+	require.Equal(t, []byte("b"), ts.Get(key))
+
+	ts.BeginStorageTransaction()
+	ts.Set(key, []byte("d"))
+	err = ts.CommitStorageTransaction()
+	require.NoError(t, err)
+	require.Equal(t, []byte("d"), ts.Get(key))
+	require.Equal(t, 1, ts.TransactionDepth())
+
+	// rolling back the outer transaction discards the committed inner one
+	err = ts.RollbackStorageTransaction()
+	require.NoError(t, err)
+	require.Equal(t, []byte("a"), ts.Get(key))
+	require.Equal(t, 0, ts.TransactionDepth())
+}
+
+func TestTrieState_UnbalancedStorageTransaction(t *testing.T) {
+	ts := newTestTrieState(t)
+
+	err := ts.CommitStorageTransaction()
+	require.ErrorIs(t, err, ErrNoTransaction)
+
+	err = ts.RollbackStorageTransaction()
+	require.ErrorIs(t, err, ErrNoTransaction)
+
+	ts.BeginStorageTransaction()
+	err = ts.CommitStorageTransaction()
+	require.NoError(t, err)
+
+	err = ts.CommitStorageTransaction()
+	require.ErrorIs(t, err, ErrNoTransaction)
+}
+
+func TestTrieState_RollbackStorageTransaction_ChildTrie(t *testing.T) {
+	ts := newTestTrieState(t)
+	keyToChild := []byte("child")
+
+	child := trie.NewEmptyTrie()
+	child.Put([]byte("key1"), []byte("value1"))
+	child.Put([]byte("key2"), []byte("value2"))
+	err := ts.SetChild(keyToChild, child)
+	require.NoError(t, err)
+
+	root, err := ts.Root()
+	require.NoError(t, err)
+
+	ts.BeginStorageTransaction()
+	err = ts.SetChildStorage(keyToChild, []byte("key1"), []byte("changed"))
+	require.NoError(t, err)
+
+	ts.BeginStorageTransaction()
+	err = ts.ClearChildStorage(keyToChild, []byte("key2"))
+	require.NoError(t, err)
+	err = ts.ClearPrefixInChild(keyToChild, []byte("key"))
+	require.NoError(t, err)
+
+	err = ts.RollbackStorageTransaction()
+	require.NoError(t, err)
+
+	val, err := ts.GetChildStorage(keyToChild, []byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("changed"), val)
+	val, err = ts.GetChildStorage(keyToChild, []byte("key2"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), val)
+
+	err = ts.RollbackStorageTransaction()
+	require.NoError(t, err)
+
+	val, err = ts.GetChildStorage(keyToChild, []byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), val)
+
+	newRoot, err := ts.Root()
+	require.NoError(t, err)
+	require.Equal(t, root, newRoot)
+}
+
 func TestTrieState_DeleteChildLimit(t *testing.T) {
 	ts := newTestTrieState(t)
 	child := trie.NewEmptyTrie()
@@ -238,3 +332,179 @@ func TestTrieState_DeleteChildLimit(t *testing.T) {
 		require.Equal(t, test.expectedDelAll, all)
 	}
 }
+
+func TestTrieState_RecordedProof(t *testing.T) {
+	tr := trie.NewEmptyTrie()
+	for _, tc := range testCases {
+		tr.Put([]byte(tc), []byte(tc))
+	}
+
+	child := trie.NewEmptyTrie()
+	child.Put([]byte("key"), []byte("value"))
+	child.Put([]byte("other"), []byte("othervalue"))
+	err := tr.PutChild([]byte("child"), child)
+	require.NoError(t, err)
+
+	root := tr.MustHash()
+
+	ts, err := NewTrieState(tr)
+	require.NoError(t, err)
+
+	proof, err := ts.RecordedProof()
+	require.NoError(t, err)
+	require.Nil(t, proof)
+
+	ts.EnableRecording()
+
+	// replay runs the same storage accesses on the given TrieState and returns their results
+	replay := func(ts *TrieState) [][]byte {
+		childValue, err := ts.GetChildStorage([]byte("child"), []byte("key"))
+		require.NoError(t, err)
+
+		var found []byte
+		if ts.Has([]byte("notfound")) {
+			found = []byte{1}
+		}
+
+		ts.Set([]byte("written"), []byte("value"))
+
+		return [][]byte{
+			ts.Get([]byte("qwerty")),
+			ts.NextKey([]byte("qwerty")),
+			childValue,
+			found,
+		}
+	}
+
+	expected := replay(ts)
+	require.Equal(t, [][]byte{[]byte("qwerty"), []byte("uiopl"), []byte("value"), nil}, expected)
+
+	proof, err = ts.RecordedProof()
+	require.NoError(t, err)
+
+	// the proof is generated from the trie as it was when recording started
+	ts.Delete([]byte("qwerty"))
+	proofAfterDelete, err := ts.RecordedProof()
+	require.NoError(t, err)
+	require.Equal(t, proof, proofAfterDelete)
+
+	proofTrie := trie.NewEmptyTrie()
+	err = proofTrie.LoadFromProof(proof, root.ToBytes())
+	require.NoError(t, err)
+
+	// the storage not accessed is not in the proof
+	require.Nil(t, proofTrie.Get([]byte("asdf")))
+
+	proofTrieState, err := NewTrieState(proofTrie)
+	require.NoError(t, err)
+	require.Equal(t, expected, replay(proofTrieState))
+}
+
+func TestNewTrieStateFromProof(t *testing.T) {
+	tr := trie.NewEmptyTrie()
+	for _, tc := range testCases {
+		tr.Put([]byte(tc), []byte(tc))
+	}
+
+	child := trie.NewEmptyTrie()
+	child.Put([]byte("key"), []byte("value"))
+	child.Put([]byte("other"), []byte("othervalue"))
+	err := tr.PutChild([]byte("child"), child)
+	require.NoError(t, err)
+
+	root := tr.MustHash()
+
+	ts, err := NewTrieState(tr)
+	require.NoError(t, err)
+	ts.EnableRecording()
+
+	run := func(ts *TrieState) {
+		_, err := ts.GetChildStorage([]byte("child"), []byte("key"))
+		require.NoError(t, err)
+		ts.Get([]byte("qwerty"))
+		ts.Set([]byte("written"), []byte("value"))
+		ts.Set([]byte("asdf"), []byte("newvalue"))
+	}
+	run(ts)
+
+	proof, err := ts.RecordedProof()
+	require.NoError(t, err)
+
+	_, err = NewTrieStateFromProof(common.Hash{1}, proof)
+	require.ErrorIs(t, err, errProofRootNotFound)
+
+	proofState, err := NewTrieStateFromProof(root, proof)
+	require.NoError(t, err)
+
+	run(proofState)
+	require.NoError(t, proofState.ProofError())
+	require.Equal(t, ts.MustRoot(), proofState.MustRoot())
+
+	proofState.Get([]byte("zxcv"))
+	require.ErrorIs(t, proofState.ProofError(), ErrIncompleteProof)
+
+	proofState, err = NewTrieStateFromProof(root, proof)
+	require.NoError(t, err)
+
+	_, err = proofState.GetChildStorage([]byte("child"), []byte("other"))
+	require.NoError(t, err)
+	require.ErrorIs(t, proofState.ProofError(), ErrIncompleteProof)
+}
+
+func TestNewTrieStateFromProof_Deletion(t *testing.T) {
+	// the values are long enough for the leaves to be hashed rather than inlined in their parent,
+	// and differ so that the proof does not hold the sibling leaf as a duplicate of the deleted one
+	value := func(b byte) []byte {
+		return bytes.Repeat([]byte{b}, 40)
+	}
+
+	testCases := map[string]func(ts *TrieState){
+		"delete": func(ts *TrieState) {
+			ts.Delete([]byte("abcdefgh1"))
+		},
+		"clear prefix": func(ts *TrieState) {
+			err := ts.ClearPrefix([]byte("abcdefgh1"))
+			require.NoError(t, err)
+		},
+		"clear prefix limit": func(ts *TrieState) {
+			ts.ClearPrefixLimit([]byte("abcdefgh1"), 1)
+		},
+	}
+
+	for name, run := range testCases {
+		run := run
+		t.Run(name, func(t *testing.T) {
+			tr := trie.NewEmptyTrie()
+			tr.Put([]byte("abcdefgh1"), value(1))
+			tr.Put([]byte("abcdefgh2"), value(2))
+			tr.Put([]byte("zzzz"), value(3))
+			root := tr.MustHash()
+
+			ts, err := NewTrieState(tr)
+			require.NoError(t, err)
+			ts.EnableRecording()
+			run(ts)
+
+			proof, err := ts.RecordedProof()
+			require.NoError(t, err)
+
+			// the branch holding the deleted key is merged with its sibling, which must be in the proof
+			proofState, err := NewTrieStateFromProof(root, proof)
+			require.NoError(t, err)
+			run(proofState)
+
+			require.NoError(t, proofState.ProofError())
+			require.Equal(t, ts.MustRoot(), proofState.MustRoot())
+
+			// the proof of the path of the deleted key only does not hold the sibling
+			pathProof, err := trie.GenerateProofFromTrie(tr, [][]byte{[]byte("abcdefgh1")})
+			require.NoError(t, err)
+
+			proofState, err = NewTrieStateFromProof(root, pathProof)
+			require.NoError(t, err)
+			run(proofState)
+
+			require.ErrorIs(t, proofState.ProofError(), ErrIncompleteProof)
+		})
+	}
+}
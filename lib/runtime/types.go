@@ -9,6 +9,8 @@ import (
 	"github.com/ChainSafe/gossamer/lib/crypto"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
+	"github.com/ChainSafe/gossamer/lib/runtime/sandbox"
+	"github.com/ChainSafe/gossamer/lib/signer"
 )
 
 // NodeStorageType type to identify offchain storage type
@@ -51,6 +53,7 @@ func (n *NodeStorage) GetPersistent(k []byte) ([]byte, error) {
 type InstanceConfig struct {
 	Storage     Storage
 	Keystore    *keystore.GlobalKeystore
+	Signer      signer.Signer // signs with the keys of the keystore if nil
 	LogLvl      log.Level
 	Role        byte
 	NodeStorage NodeStorage
@@ -64,12 +67,31 @@ type Context struct {
 	Storage         Storage
 	Allocator       *FreeingBumpHeapAllocator
 	Keystore        *keystore.GlobalKeystore
+	Signer          signer.Signer
 	Validator       bool
 	NodeStorage     NodeStorage
 	Network         BasicNetwork
 	Transaction     TransactionState
 	SigVerifier     *crypto.SignatureVerifier
 	OffchainHTTPSet *offchain.HTTPSet
+	Sandbox         *sandbox.Store
+
+	trap error
+}
+
+// Trap fails the running call with the given error, as a trap of the runtime would. It is used by host functions
+// which cannot unwind the wasm stack: the call returns the first error trapped once the runtime function returns.
+func (c *Context) Trap(err error) {
+	if c.trap == nil {
+		c.trap = err
+	}
+}
+
+// TakeTrap returns the error trapped during the running call, if any, and resets it
+func (c *Context) TakeTrap() error {
+	err := c.trap
+	c.trap = nil
+	return err
 }
 
 // NewValidateTransactionError returns an error based on a return value from TaggedTransactionQueueValidateTransaction
@@ -4,6 +4,7 @@
 package runtime
 
 import (
+	"errors"
 	"io"
 	"testing"
 	"time"
@@ -135,3 +136,16 @@ func TestAllCryptoTypeSignature(t *testing.T) {
 
 	require.True(t, signVerify.Finish())
 }
+
+func TestContext_Trap(t *testing.T) {
+	ctx := &Context{}
+	require.NoError(t, ctx.TakeTrap())
+
+	first := errors.New("first")
+	ctx.Trap(first)
+	ctx.Trap(errors.New("second"))
+
+	// the first error trapped fails the call
+	require.Equal(t, first, ctx.TakeTrap())
+	require.NoError(t, ctx.TakeTrap())
+}
@@ -5,18 +5,29 @@ package wasmer
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/pkg/scale"
 )
 
-// ValidateTransaction runs the extrinsic through the runtime function
-// TaggedTransactionQueue_validate_transaction and returns *Validity
-func (in *Instance) ValidateTransaction(e types.Extrinsic) (*transaction.Validity, error) {
-	ret, err := in.exec(runtime.TaggedTransactionQueueValidateTransaction, e)
+// ValidateTransaction runs the extrinsic, prefixed with its transaction source, through the runtime function
+// TaggedTransactionQueue_validate_transaction at the given block and returns *Validity. The arguments
+// passed to the runtime depend on the version of the TaggedTransactionQueue API it implements.
+func (in *Instance) ValidateTransaction(e types.Extrinsic, blockHash common.Hash) (*transaction.Validity, error) {
+	apiVersion, err := in.apiVersion(runtime.TaggedTransactionQueueAPIID, "TaggedTransactionQueue")
+	if err != nil {
+		return nil, err
+	}
+
+	args, err := runtime.ValidateTransactionArgs(apiVersion, e, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := in.exec(runtime.TaggedTransactionQueueValidateTransaction, args)
 	if err != nil {
 		return nil, err
 	}
@@ -27,13 +38,11 @@ func (in *Instance) ValidateTransaction(e types.Extrinsic) (*transaction.Validit
 
 	v := transaction.NewValidity(0, [][]byte{{}}, [][]byte{{}}, 0, false)
 	err = scale.Unmarshal(ret[1:], v)
-
 	return v, err
 }
 
 // Version calls runtime function Core_Version
 func (in *Instance) Version() (runtime.Version, error) {
-	// kusama seems to use the legacy version format
 	if in.version != nil {
 		return in.version, nil
 	}
@@ -43,20 +52,22 @@ func (in *Instance) Version() (runtime.Version, error) {
 		return nil, err
 	}
 
-	version := &runtime.VersionData{}
-	err = version.Decode(res)
-	// error comes from scale now, so do a string check
+	return runtime.DecodeVersion(res)
+}
+
+// apiVersion returns the version of the given runtime API implemented by the runtime
+func (in *Instance) apiVersion(id [8]byte, name string) (uint32, error) {
+	version, err := in.Version()
 	if err != nil {
-		if strings.Contains(err.Error(), "EOF") {
-			// TODO: kusama seems to use the legacy version format
-			lversion := &runtime.LegacyVersionData{}
-			err = lversion.Decode(res)
-			return lversion, err
-		}
-		return nil, err
+		return 0, err
+	}
+
+	ver, ok := runtime.APIVersion(version, id)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", runtime.ErrAPINotImplemented, name)
 	}
 
-	return version, nil
+	return ver, nil
 }
 
 // Metadata calls runtime function Metadata_metadata
@@ -64,15 +75,36 @@ func (in *Instance) Metadata() ([]byte, error) {
 	return in.exec(runtime.Metadata, []byte{})
 }
 
-// BabeConfiguration gets the configuration data for BABE from the runtime
+// BabeConfiguration gets the configuration data for BABE from the runtime, decoded according to the version of
+// the BabeApi it implements. Since version 2, the authorities, randomness and epoch configuration are the ones of
+// the current epoch if the runtime has BabeApi_current_epoch, which was added without a version change.
 func (in *Instance) BabeConfiguration() (*types.BabeConfiguration, error) {
+	apiVersion, err := in.apiVersion(runtime.BabeAPIID, "BabeApi")
+	if err != nil {
+		return nil, err
+	}
+
 	data, err := in.exec(runtime.BabeAPIConfiguration, []byte{})
 	if err != nil {
 		return nil, err
 	}
 
-	bc := new(types.BabeConfiguration)
-	err = scale.Unmarshal(data, bc)
+	bc, err := runtime.DecodeBabeConfiguration(apiVersion, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiVersion < 2 {
+		return bc, nil
+	}
+
+	data, err = in.exec(runtime.BabeAPICurrentEpoch, []byte{})
+	if err != nil {
+		logger.Debugf("using BABE configuration without current epoch: %s", err)
+		return bc, nil
+	}
+
+	err = runtime.SetBabeCurrentEpoch(bc, data)
 	if err != nil {
 		return nil, err
 	}
@@ -176,27 +208,45 @@ func (in *Instance) DecodeSessionKeys(enc []byte) ([]byte, error) {
 	return in.exec(runtime.DecodeSessionKeys, enc)
 }
 
-// PaymentQueryInfo returns information of a given extrinsic
+// GenerateSessionKeys generates new session keys into the instance's keystore using a random seed.
+// It returns the concatenated public keys, which can be decoded using DecodeSessionKeys.
+func (in *Instance) GenerateSessionKeys() ([]byte, error) {
+	// the seed is an Option<Vec<u8>>, None generates random keys
+	ret, err := in.exec(runtime.GenerateSessionKeys, []byte{0})
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []byte
+	err = scale.Unmarshal(ret, &keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// PaymentQueryInfo returns information of a given extrinsic, decoded according to the version of the
+// TransactionPaymentApi implemented by the runtime
 func (in *Instance) PaymentQueryInfo(ext []byte) (*types.TransactionPaymentQueryInfo, error) {
-	encLen, err := scale.Marshal(uint32(len(ext)))
+	apiVersion, err := in.apiVersion(runtime.TransactionPaymentAPIID, "TransactionPaymentApi")
 	if err != nil {
 		return nil, err
 	}
 
-	resBytes, err := in.exec(runtime.TransactionPaymentAPIQueryInfo, append(ext, encLen...))
+	encLen, err := scale.Marshal(uint32(len(ext)))
 	if err != nil {
 		return nil, err
 	}
 
-	i := new(types.TransactionPaymentQueryInfo)
-	if err = scale.Unmarshal(resBytes, i); err != nil {
+	resBytes, err := in.exec(runtime.TransactionPaymentAPIQueryInfo, append(ext, encLen...))
+	if err != nil {
 		return nil, err
 	}
 
-	return i, nil
+	return runtime.DecodePaymentQueryInfo(apiVersion, resBytes)
 }
 
-func (in *Instance) CheckInherents()      {} //nolint:revive
-func (in *Instance) RandomSeed()          {} //nolint:revive
-func (in *Instance) OffchainWorker()      {} //nolint:revive
-func (in *Instance) GenerateSessionKeys() {} //nolint:revive
+func (in *Instance) CheckInherents() {} //nolint:revive
+func (in *Instance) RandomSeed()     {} //nolint:revive
+func (in *Instance) OffchainWorker() {} //nolint:revive
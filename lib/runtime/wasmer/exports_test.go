@@ -342,7 +342,7 @@ func TestNodeRuntime_ValidateTransaction(t *testing.T) {
 	ext = append([]byte{byte(types.TxnExternal)}, ext...)
 
 	_ = buildBlockVdt(t, rt, genesisHeader.Hash())
-	_, err = rt.ValidateTransaction(ext)
+	_, err = rt.ValidateTransaction(ext, genesisHeader.Hash())
 	require.NoError(t, err)
 }
 
@@ -1075,6 +1075,35 @@ func TestInstance_DecodeSessionKeys(t *testing.T) {
 	require.Len(t, *decodedKeys, 4)
 }
 
+func TestInstance_GenerateSessionKeys(t *testing.T) {
+	instance := NewTestInstance(t, runtime.NODE_RUNTIME_v098)
+
+	keys, err := instance.GenerateSessionKeys()
+	require.NoError(t, err)
+
+	encKeys, err := scale.Marshal(keys)
+	require.NoError(t, err)
+
+	decoded, err := instance.DecodeSessionKeys(encKeys)
+	require.NoError(t, err)
+
+	var decodedKeys *[]struct {
+		Data []uint8
+		Type [4]uint8
+	}
+
+	err = scale.Unmarshal(decoded, &decodedKeys)
+	require.NoError(t, err)
+	require.Len(t, *decodedKeys, 4)
+
+	for _, key := range *decodedKeys {
+		ks, err := instance.ctx.Keystore.GetKeystore(key.Type[:])
+		require.NoError(t, err)
+		require.Equal(t, 1, ks.Size())
+		require.Equal(t, key.Data, ks.PublicKeys()[0].Encode())
+	}
+}
+
 func TestInstance_PaymentQueryInfo(t *testing.T) {
 	tests := []struct {
 		extB   []byte
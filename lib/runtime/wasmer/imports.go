@@ -78,6 +78,10 @@ package wasmer
 // extern void ext_offchain_sleep_until_version_1(void *context, int64_t a);
 // extern int64_t ext_offchain_http_request_start_version_1(void *context, int64_t a, int64_t b, int64_t c);
 // extern int64_t ext_offchain_http_request_add_header_version_1(void *context, int32_t a, int64_t k, int64_t v);
+// extern int64_t ext_offchain_http_request_write_body_version_1(void *context, int32_t a, int64_t b, int64_t c);
+// extern int64_t ext_offchain_http_response_wait_version_1(void *context, int64_t a, int64_t b);
+// extern int64_t ext_offchain_http_response_headers_version_1(void *context, int32_t a);
+// extern int64_t ext_offchain_http_response_read_body_version_1(void *context, int32_t a, int64_t b, int64_t c);
 //
 // extern void ext_storage_append_version_1(void *context, int64_t a, int64_t b);
 // extern int64_t ext_storage_changes_root_version_1(void *context, int64_t a);
@@ -116,7 +120,10 @@ import (
 	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
+	"github.com/ChainSafe/gossamer/lib/runtime/sandbox"
 	rtstorage "github.com/ChainSafe/gossamer/lib/runtime/storage"
+	"github.com/ChainSafe/gossamer/lib/signer"
 	"github.com/ChainSafe/gossamer/lib/transaction"
 	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/ChainSafe/gossamer/pkg/scale"
@@ -167,50 +174,106 @@ func ext_transaction_index_renew_version_1(context unsafe.Pointer, a, b C.int32_
 }
 
 //export ext_sandbox_instance_teardown_version_1
-func ext_sandbox_instance_teardown_version_1(context unsafe.Pointer, a C.int32_t) {
+func ext_sandbox_instance_teardown_version_1(context unsafe.Pointer, instanceIdx C.int32_t) {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	err := runtimeCtx.Sandbox.InstanceTeardown(uint32(instanceIdx))
+	if err != nil {
+		logger.Errorf("failed to teardown sandbox instance: %s", err)
+	}
 }
 
 //export ext_sandbox_instantiate_version_1
-func ext_sandbox_instantiate_version_1(context unsafe.Pointer, a C.int32_t, x, y C.int64_t, z C.int32_t) C.int32_t {
+func ext_sandbox_instantiate_version_1(context unsafe.Pointer, dispatchThunk C.int32_t, wasmCodeSpan, envDefSpan C.int64_t, statePtr C.int32_t) C.int32_t {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
-	return 0
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	code := asMemorySlice(instanceContext, wasmCodeSpan)
+	envDef := asMemorySlice(instanceContext, envDefSpan)
+
+	instanceIdx, err := runtimeCtx.Sandbox.Instantiate(uint32(dispatchThunk), code, envDef, uint32(statePtr))
+	if err != nil {
+		logger.Errorf("failed to instantiate sandbox module: %s", err)
+		return C.int32_t(sandbox.ReturnCode(err))
+	}
+
+	return C.int32_t(instanceIdx)
 }
 
 //export ext_sandbox_invoke_version_1
-func ext_sandbox_invoke_version_1(context unsafe.Pointer, a C.int32_t, x, y C.int64_t, z, d, e C.int32_t) C.int32_t {
+func ext_sandbox_invoke_version_1(context unsafe.Pointer, instanceIdx C.int32_t, exportNameSpan, argsSpan C.int64_t, returnValPtr, returnValLen, statePtr C.int32_t) C.int32_t {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
-	return 0
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	exportName := string(asMemorySlice(instanceContext, exportNameSpan))
+	args := append([]byte{}, asMemorySlice(instanceContext, argsSpan)...)
+
+	err := runtimeCtx.Sandbox.Invoke(uint32(instanceIdx), exportName, args,
+		uint32(returnValPtr), uint32(returnValLen), uint32(statePtr))
+	if err != nil {
+		logger.Errorf("failed to invoke sandbox function %s: %s", exportName, err)
+	}
+
+	return C.int32_t(sandbox.ReturnCode(err))
 }
 
 //export ext_sandbox_memory_get_version_1
-func ext_sandbox_memory_get_version_1(context unsafe.Pointer, a, z, d, e C.int32_t) C.int32_t {
+func ext_sandbox_memory_get_version_1(context unsafe.Pointer, memoryIdx, offset, bufPtr, bufLen C.int32_t) C.int32_t {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
-	return 0
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	err := runtimeCtx.Sandbox.MemoryGet(uint32(memoryIdx), uint32(offset), uint32(bufPtr), uint32(bufLen))
+	if err != nil {
+		logger.Errorf("failed to get sandbox memory: %s", err)
+	}
+
+	return C.int32_t(sandbox.ReturnCode(err))
 }
 
 //export ext_sandbox_memory_new_version_1
-func ext_sandbox_memory_new_version_1(context unsafe.Pointer, a, z C.int32_t) C.int32_t {
+func ext_sandbox_memory_new_version_1(context unsafe.Pointer, initial, maximum C.int32_t) C.int32_t {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
-	return 0
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	memoryIdx, err := runtimeCtx.Sandbox.NewMemory(uint32(initial), uint32(maximum))
+	if err != nil {
+		logger.Errorf("failed to create sandbox memory: %s", err)
+		return C.int32_t(sandbox.ReturnCode(err))
+	}
+
+	return C.int32_t(memoryIdx)
 }
 
 //export ext_sandbox_memory_set_version_1
-func ext_sandbox_memory_set_version_1(context unsafe.Pointer, a, z, d, e C.int32_t) C.int32_t {
+func ext_sandbox_memory_set_version_1(context unsafe.Pointer, memoryIdx, offset, valPtr, valLen C.int32_t) C.int32_t {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
-	return 0
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	err := runtimeCtx.Sandbox.MemorySet(uint32(memoryIdx), uint32(offset), uint32(valPtr), uint32(valLen))
+	if err != nil {
+		logger.Errorf("failed to set sandbox memory: %s", err)
+	}
+
+	return C.int32_t(sandbox.ReturnCode(err))
 }
 
 //export ext_sandbox_memory_teardown_version_1
-func ext_sandbox_memory_teardown_version_1(context unsafe.Pointer, a C.int32_t) {
+func ext_sandbox_memory_teardown_version_1(context unsafe.Pointer, memoryIdx C.int32_t) {
 	logger.Trace("executing...")
-	logger.Warn("unimplemented")
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	err := runtimeCtx.Sandbox.MemoryTeardown(uint32(memoryIdx))
+	if err != nil {
+		logger.Errorf("failed to teardown sandbox memory: %s", err)
+	}
 }
 
 //export ext_crypto_ed25519_generate_version_1
@@ -340,8 +403,8 @@ func ext_crypto_ed25519_sign_version_1(context unsafe.Pointer, keyTypeID, key C.
 	}
 
 	var ret int64
-	signingKey := ks.GetKeypair(pubKey)
-	if signingKey == nil {
+	sig, err := runtimeCtx.Signer.Sign(ks.Name(), pubKey, asMemorySlice(instanceContext, msg), nil)
+	if errors.Is(err, signer.ErrKeyNotFound) {
 		logger.Error("could not find public key " + pubKey.Hex() + " in keystore")
 		ret, err = toWasmMemoryOptional(instanceContext, nil)
 		if err != nil {
@@ -351,9 +414,8 @@ func ext_crypto_ed25519_sign_version_1(context unsafe.Pointer, keyTypeID, key C.
 		return C.int64_t(ret)
 	}
 
-	sig, err := signingKey.Sign(asMemorySlice(instanceContext, msg))
 	if err != nil {
-		logger.Error("could not sign message")
+		logger.Errorf("could not sign message: %s", err)
 	}
 
 	ret, err = toWasmMemoryFixedSizeOptional(instanceContext, sig)
@@ -657,14 +719,12 @@ func ext_crypto_sr25519_sign_version_1(context unsafe.Pointer, keyTypeID, key C.
 		return C.int64_t(emptyRet)
 	}
 
-	signingKey := ks.GetKeypair(pubKey)
-	if signingKey == nil {
+	msgData := asMemorySlice(instanceContext, msg)
+	sig, err := runtimeCtx.Signer.Sign(ks.Name(), pubKey, msgData, nil)
+	if errors.Is(err, signer.ErrKeyNotFound) {
 		logger.Error("could not find public key " + pubKey.Hex() + " in keystore")
 		return C.int64_t(emptyRet)
 	}
-
-	msgData := asMemorySlice(instanceContext, msg)
-	sig, err := signingKey.Sign(msgData)
 	if err != nil {
 		logger.Errorf("could not sign message: %s", err)
 		return C.int64_t(emptyRet)
@@ -1746,13 +1806,15 @@ func ext_offchain_http_request_add_header_version_1(context unsafe.Pointer, reqI
 	result := scale.NewResult(nil, nil)
 	resultMode := scale.OK
 
-	err := offchainReq.AddHeader(string(name), string(value))
-	if err != nil {
+	if offchainReq == nil {
+		logger.Errorf("failed to add request header: request %d not found", reqID)
+		resultMode = scale.Err
+	} else if err := offchainReq.AddHeader(string(name), string(value)); err != nil {
 		logger.Errorf("failed to add request header: %s", err)
 		resultMode = scale.Err
 	}
 
-	err = result.Set(resultMode, nil)
+	err := result.Set(resultMode, nil)
 	if err != nil {
 		logger.Errorf("failed to set the result data: %s", err)
 		return C.int64_t(0)
@@ -1773,6 +1835,175 @@ func ext_offchain_http_request_add_header_version_1(context unsafe.Pointer, reqI
 	return C.int64_t(ptr)
 }
 
+//export ext_offchain_http_request_write_body_version_1
+func ext_offchain_http_request_write_body_version_1(context unsafe.Pointer, reqID C.int32_t, chunkSpan, deadlineSpan C.int64_t) C.int64_t {
+	logger.Debug("executing...")
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	chunk := asMemorySlice(instanceContext, chunkSpan)
+
+	result := scale.NewResult(nil, offchain.HTTPError(0))
+
+	deadline, err := decodeDeadline(instanceContext, deadlineSpan)
+	if err != nil {
+		logger.Errorf("failed to decode deadline: %s", err)
+		err = result.Set(scale.Err, offchain.HTTPErrorInvalid)
+	} else if err = runtimeCtx.OffchainHTTPSet.WriteBody(int16(reqID), chunk, deadline); err != nil {
+		logger.Errorf("failed to write request body: %s", err)
+		err = result.Set(scale.Err, offchain.ToHTTPError(err))
+	} else {
+		err = result.Set(scale.OK, nil)
+	}
+
+	if err != nil {
+		logger.Errorf("failed to set the result data: %s", err)
+		return C.int64_t(0)
+	}
+
+	enc, err := scale.Marshal(result)
+	if err != nil {
+		logger.Errorf("failed to scale marshal the result: %s", err)
+		return C.int64_t(0)
+	}
+
+	ptr, err := toWasmMemory(instanceContext, enc)
+	if err != nil {
+		logger.Errorf("failed to allocate result on memory: %s", err)
+		return C.int64_t(0)
+	}
+
+	return C.int64_t(ptr)
+}
+
+//export ext_offchain_http_response_wait_version_1
+func ext_offchain_http_response_wait_version_1(context unsafe.Pointer, idsSpan, deadlineSpan C.int64_t) C.int64_t {
+	logger.Debug("executing...")
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	var ids []int16
+	err := scale.Unmarshal(asMemorySlice(instanceContext, idsSpan), &ids)
+	if err != nil {
+		logger.Errorf("failed to decode request ids: %s", err)
+		return C.int64_t(0)
+	}
+
+	deadline, err := decodeDeadline(instanceContext, deadlineSpan)
+	if err != nil {
+		logger.Errorf("failed to decode deadline: %s", err)
+		return C.int64_t(0)
+	}
+
+	statuses, err := runtimeCtx.OffchainHTTPSet.ResponseWait(ids, deadline)
+	if err != nil {
+		logger.Errorf("failed to wait for responses: %s", err)
+		return C.int64_t(0)
+	}
+
+	enc, err := scale.Marshal(statuses)
+	if err != nil {
+		logger.Errorf("failed to scale marshal the statuses: %s", err)
+		return C.int64_t(0)
+	}
+
+	ptr, err := toWasmMemory(instanceContext, enc)
+	if err != nil {
+		logger.Errorf("failed to allocate result on memory: %s", err)
+		return C.int64_t(0)
+	}
+
+	return C.int64_t(ptr)
+}
+
+//export ext_offchain_http_response_headers_version_1
+func ext_offchain_http_response_headers_version_1(context unsafe.Pointer, reqID C.int32_t) C.int64_t {
+	logger.Debug("executing...")
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	headers := runtimeCtx.OffchainHTTPSet.ResponseHeaders(int16(reqID))
+
+	enc, err := scale.Marshal(headers)
+	if err != nil {
+		logger.Errorf("failed to scale marshal the headers: %s", err)
+		return C.int64_t(0)
+	}
+
+	ptr, err := toWasmMemory(instanceContext, enc)
+	if err != nil {
+		logger.Errorf("failed to allocate result on memory: %s", err)
+		return C.int64_t(0)
+	}
+
+	return C.int64_t(ptr)
+}
+
+//export ext_offchain_http_response_read_body_version_1
+func ext_offchain_http_response_read_body_version_1(context unsafe.Pointer, reqID C.int32_t, bufferSpan, deadlineSpan C.int64_t) C.int64_t {
+	logger.Debug("executing...")
+	instanceContext := wasm.IntoInstanceContext(context)
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+
+	result := scale.NewResult(uint32(0), offchain.HTTPError(0))
+
+	deadline, err := decodeDeadline(instanceContext, deadlineSpan)
+	if err != nil {
+		logger.Errorf("failed to decode deadline: %s", err)
+		err = result.Set(scale.Err, offchain.HTTPErrorInvalid)
+	} else {
+		// read into an intermediate buffer since the wasm memory may grow while waiting
+		_, size := runtime.Int64ToPointerAndSize(int64(bufferSpan))
+		buf := make([]byte, size)
+
+		var n int
+		n, err = runtimeCtx.OffchainHTTPSet.ReadBody(int16(reqID), buf, deadline)
+		if err != nil {
+			logger.Errorf("failed to read response body: %s", err)
+			err = result.Set(scale.Err, offchain.ToHTTPError(err))
+		} else {
+			copy(asMemorySlice(instanceContext, bufferSpan), buf[:n])
+			err = result.Set(scale.OK, uint32(n))
+		}
+	}
+
+	if err != nil {
+		logger.Errorf("failed to set the result data: %s", err)
+		return C.int64_t(0)
+	}
+
+	enc, err := scale.Marshal(result)
+	if err != nil {
+		logger.Errorf("failed to scale marshal the result: %s", err)
+		return C.int64_t(0)
+	}
+
+	ptr, err := toWasmMemory(instanceContext, enc)
+	if err != nil {
+		logger.Errorf("failed to allocate result on memory: %s", err)
+		return C.int64_t(0)
+	}
+
+	return C.int64_t(ptr)
+}
+
+// decodeDeadline decodes the SCALE encoded optional deadline, expressed in milliseconds
+// since the UNIX epoch, a nil result means there is no deadline
+func decodeDeadline(context wasm.InstanceContext, span C.int64_t) (*time.Time, error) {
+	var deadline *uint64
+	err := scale.Unmarshal(asMemorySlice(context, span), &deadline)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	t := time.UnixMilli(int64(*deadline))
+	return &t, nil
+}
+
 func storageAppend(storage runtime.Storage, key, valueToAppend []byte) error {
 	nextLength := big.NewInt(1)
 	var valueRes []byte
@@ -2086,14 +2317,24 @@ func ext_storage_start_transaction_version_1(context unsafe.Pointer) {
 func ext_storage_rollback_transaction_version_1(context unsafe.Pointer) {
 	logger.Debug("executing...")
 	instanceContext := wasm.IntoInstanceContext(context)
-	instanceContext.Data().(*runtime.Context).Storage.RollbackStorageTransaction()
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+	err := runtimeCtx.Storage.RollbackStorageTransaction()
+	if err != nil {
+		logger.Errorf("failed to rollback storage transaction: %s", err)
+		runtimeCtx.Trap(fmt.Errorf("cannot rollback storage transaction: %w", err))
+	}
 }
 
 //export ext_storage_commit_transaction_version_1
 func ext_storage_commit_transaction_version_1(context unsafe.Pointer) {
 	logger.Debug("[ext_storage_commit_transaction_version_1] executing...")
 	instanceContext := wasm.IntoInstanceContext(context)
-	instanceContext.Data().(*runtime.Context).Storage.CommitStorageTransaction()
+	runtimeCtx := instanceContext.Data().(*runtime.Context)
+	err := runtimeCtx.Storage.CommitStorageTransaction()
+	if err != nil {
+		logger.Errorf("failed to commit storage transaction: %s", err)
+		runtimeCtx.Trap(fmt.Errorf("cannot commit storage transaction: %w", err))
+	}
 }
 
 // Convert 64bit wasm span descriptor to Go memory slice
@@ -2453,6 +2694,22 @@ func ImportsNodeRuntime() (*wasm.Imports, error) { //nolint:gocyclo
 	if err != nil {
 		return nil, err
 	}
+	_, err = imports.Append("ext_offchain_http_request_write_body_version_1", ext_offchain_http_request_write_body_version_1, C.ext_offchain_http_request_write_body_version_1)
+	if err != nil {
+		return nil, err
+	}
+	_, err = imports.Append("ext_offchain_http_response_wait_version_1", ext_offchain_http_response_wait_version_1, C.ext_offchain_http_response_wait_version_1)
+	if err != nil {
+		return nil, err
+	}
+	_, err = imports.Append("ext_offchain_http_response_headers_version_1", ext_offchain_http_response_headers_version_1, C.ext_offchain_http_response_headers_version_1)
+	if err != nil {
+		return nil, err
+	}
+	_, err = imports.Append("ext_offchain_http_response_read_body_version_1", ext_offchain_http_response_read_body_version_1, C.ext_offchain_http_response_read_body_version_1)
+	if err != nil {
+		return nil, err
+	}
 	_, err = imports.Append("ext_sandbox_instance_teardown_version_1", ext_sandbox_instance_teardown_version_1, C.ext_sandbox_instance_teardown_version_1)
 	if err != nil {
 		return nil, err
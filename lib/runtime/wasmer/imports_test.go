@@ -6,7 +6,9 @@ package wasmer
 import (
 	"bytes"
 	"encoding/binary"
+	"io"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"sort"
 	"testing"
@@ -22,6 +24,7 @@ import (
 	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
+	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
 	"github.com/ChainSafe/gossamer/lib/runtime/storage"
 	"github.com/ChainSafe/gossamer/lib/trie"
 	"github.com/ChainSafe/gossamer/pkg/scale"
@@ -378,6 +381,116 @@ func Test_ext_offchain_http_request_add_header(t *testing.T) {
 	}
 }
 
+func Test_ext_offchain_http_request_write_body_response(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		w.Header().Set("X-Echo", "true")
+		_, err = w.Write(append([]byte("echo:"), body...))
+		require.NoError(t, err)
+	}))
+	defer server.Close()
+
+	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME)
+
+	reqID, err := inst.ctx.OffchainHTTPSet.StartRequest(http.MethodPost, server.URL)
+	require.NoError(t, err)
+
+	encID, err := scale.Marshal(uint32(reqID))
+	require.NoError(t, err)
+
+	var deadline *uint64
+	encDeadline, err := scale.Marshal(deadline)
+	require.NoError(t, err)
+
+	// an empty chunk ends the request body
+	for _, chunk := range [][]byte{[]byte("hello"), {}} {
+		encChunk, err := scale.Marshal(chunk)
+		require.NoError(t, err)
+
+		params := append([]byte{}, encID...)
+		params = append(params, encChunk...)
+		params = append(params, encDeadline...)
+
+		ret, err := inst.Exec("rtm_ext_offchain_http_request_write_body_version_1", params)
+		require.NoError(t, err)
+
+		result := scale.NewResult(nil, offchain.HTTPError(0))
+		err = scale.Unmarshal(ret, &result)
+		require.NoError(t, err)
+
+		_, err = result.Unwrap()
+		require.NoError(t, err)
+	}
+
+	encIDs, err := scale.Marshal([]int16{reqID})
+	require.NoError(t, err)
+
+	ret, err := inst.Exec("rtm_ext_offchain_http_response_wait_version_1", append(encIDs, encDeadline...))
+	require.NoError(t, err)
+
+	statuses := offchain.NewHTTPRequestStatusSlice()
+	err = scale.Unmarshal(ret, &statuses)
+	require.NoError(t, err)
+	require.Len(t, statuses.Types, 1)
+	require.Equal(t, offchain.FinishedStatus(http.StatusOK), statuses.Types[0].Value())
+
+	ret, err = inst.Exec("rtm_ext_offchain_http_response_headers_version_1", encID)
+	require.NoError(t, err)
+
+	var headers [][2][]byte
+	err = scale.Unmarshal(ret, &headers)
+	require.NoError(t, err)
+	require.Contains(t, headers, [2][]byte{[]byte("X-Echo"), []byte("true")})
+
+	encBuffer, err := scale.Marshal(make([]byte, 64))
+	require.NoError(t, err)
+
+	params := append([]byte{}, encID...)
+	params = append(params, encBuffer...)
+	params = append(params, encDeadline...)
+
+	ret, err = inst.Exec("rtm_ext_offchain_http_response_read_body_version_1", params)
+	require.NoError(t, err)
+
+	result := scale.NewResult(uint32(0), offchain.HTTPError(0))
+	err = scale.Unmarshal(ret, &result)
+	require.NoError(t, err)
+
+	n, err := result.Unwrap()
+	require.NoError(t, err)
+	require.Equal(t, uint32(len("echo:hello")), n)
+}
+
+func Test_ext_offchain_http_request_write_body_invalid_id(t *testing.T) {
+	t.Parallel()
+
+	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME)
+
+	encID, err := scale.Marshal(uint32(999))
+	require.NoError(t, err)
+
+	encChunk, err := scale.Marshal([]byte("hello"))
+	require.NoError(t, err)
+
+	var deadline *uint64
+	encDeadline, err := scale.Marshal(deadline)
+	require.NoError(t, err)
+
+	params := append([]byte{}, encID...)
+	params = append(params, encChunk...)
+	params = append(params, encDeadline...)
+
+	ret, err := inst.Exec("rtm_ext_offchain_http_request_write_body_version_1", params)
+	require.NoError(t, err)
+
+	// Err(HttpError::Invalid)
+	require.Equal(t, []byte{1, 3}, ret)
+}
+
 func Test_ext_storage_clear_prefix_version_1_hostAPI(t *testing.T) {
 	t.Parallel()
 	inst := NewTestInstance(t, runtime.HOST_API_TEST_RUNTIME)
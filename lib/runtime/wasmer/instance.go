@@ -7,12 +7,15 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/ChainSafe/gossamer/internal/log"
 	"github.com/ChainSafe/gossamer/lib/common"
 	"github.com/ChainSafe/gossamer/lib/keystore"
 	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/lib/runtime/offchain"
+	"github.com/ChainSafe/gossamer/lib/runtime/sandbox"
+	"github.com/ChainSafe/gossamer/lib/signer"
 	"github.com/ChainSafe/gossamer/lib/trie"
 
 	"github.com/ChainSafe/gossamer/lib/crypto"
@@ -25,8 +28,9 @@ const Name = "wasmer"
 
 // Check that runtime interfaces are satisfied
 var (
-	_ runtime.Instance = (*Instance)(nil)
-	_ runtime.Memory   = (*wasm.Memory)(nil)
+	_ runtime.Instance   = (*Instance)(nil)
+	_ runtime.Memory     = (*wasm.Memory)(nil)
+	_ sandbox.Supervisor = (*Instance)(nil)
 
 	logger = log.NewFromGlobal(
 		log.AddContext("pkg", "runtime"),
@@ -46,6 +50,8 @@ type Instance struct {
 	ctx      *runtime.Context
 	version  runtime.Version
 	imports  func() (*wasm.Imports, error)
+	code     []byte
+	logLvl   log.Level
 	isClosed bool
 	codeHash common.Hash
 	sync.Mutex
@@ -96,6 +102,7 @@ func NewInstance(code []byte, cfg *Config) (*Instance, error) {
 
 	logger.Patch(log.SetLevel(cfg.LogLvl), log.SetCallerFunc(true))
 
+	originalCode := code
 	imports, err := cfg.Imports()
 	if err != nil {
 		return nil, err
@@ -114,6 +121,11 @@ func NewInstance(code []byte, cfg *Config) (*Instance, error) {
 		return nil, err
 	}
 
+	code, err = injectDispatchThunk(code)
+	if err != nil {
+		return nil, err
+	}
+
 	// Instantiates the WebAssembly module.
 	instance, err := wasm.NewInstanceWithImports(code, imports)
 	if err != nil {
@@ -131,10 +143,16 @@ func NewInstance(code []byte, cfg *Config) (*Instance, error) {
 
 	allocator := runtime.NewAllocator(instance.Memory, heapBase)
 
+	sgn := cfg.Signer
+	if sgn == nil {
+		sgn = signer.NewLocal(cfg.Keystore)
+	}
+
 	runtimeCtx := &runtime.Context{
 		Storage:         cfg.Storage,
 		Allocator:       allocator,
 		Keystore:        cfg.Keystore,
+		Signer:          sgn,
 		Validator:       cfg.Role == byte(4),
 		NodeStorage:     cfg.NodeStorage,
 		Network:         cfg.Network,
@@ -150,8 +168,11 @@ func NewInstance(code []byte, cfg *Config) (*Instance, error) {
 		vm:       instance,
 		ctx:      runtimeCtx,
 		imports:  cfg.Imports,
+		code:     originalCode,
+		logLvl:   cfg.LogLvl,
 		codeHash: cfg.CodeHash,
 	}
+	runtimeCtx.Sandbox = sandbox.NewStore(inst)
 
 	inst.version, _ = inst.Version()
 	return inst, nil
@@ -171,6 +192,7 @@ func (in *Instance) UpdateRuntimeCode(code []byte) error {
 		return err
 	}
 
+	in.code = code
 	in.version = nil
 	in.version, err = in.Version()
 	if err != nil {
@@ -180,6 +202,30 @@ func (in *Instance) UpdateRuntimeCode(code []byte) error {
 	return nil
 }
 
+// Clone returns a new instance of the runtime code of the instance, with the same configuration and storage
+// but its own memory and allocator, so that it can make runtime calls concurrently with the instance.
+func (in *Instance) Clone() (runtime.Instance, error) {
+	in.Lock()
+	cfg := &Config{
+		Imports: in.imports,
+	}
+	cfg.Storage = in.ctx.Storage
+	cfg.Keystore = in.ctx.Keystore
+	cfg.Signer = in.ctx.Signer
+	cfg.LogLvl = in.logLvl
+	cfg.NodeStorage = in.ctx.NodeStorage
+	cfg.Network = in.ctx.Network
+	cfg.Transaction = in.ctx.Transaction
+	cfg.CodeHash = in.codeHash
+	if in.ctx.Validator {
+		cfg.Role = 4
+	}
+	code := in.code
+	in.Unlock()
+
+	return NewInstance(code, cfg)
+}
+
 // CheckRuntimeVersion calculates runtime Version for runtime blob passed in
 func (in *Instance) CheckRuntimeVersion(code []byte) (runtime.Version, error) {
 	tmp := &Instance{
@@ -216,6 +262,11 @@ func (in *Instance) setupInstanceVM(code []byte) error {
 		return err
 	}
 
+	code, err = injectDispatchThunk(code)
+	if err != nil {
+		return err
+	}
+
 	// Instantiates the WebAssembly module.
 	in.vm, err = wasm.NewInstanceWithImports(code, imports)
 	if err != nil {
@@ -279,6 +330,8 @@ func (in *Instance) exec(function string, data []byte) ([]byte, error) {
 	in.Lock()
 	defer in.Unlock()
 
+	defer runtime.ObserveCallDuration(function, time.Now())
+
 	if in.isClosed {
 		return nil, errors.New("instance is stopped")
 	}
@@ -299,11 +352,20 @@ func (in *Instance) exec(function string, data []byte) ([]byte, error) {
 		return nil, fmt.Errorf("could not find exported function %s", function)
 	}
 
+	// discard an error trapped after a failed call
+	_ = in.ctx.TakeTrap()
+
 	res, err := runtimeFunc(int32(ptr), datalen)
 	if err != nil {
 		return nil, err
 	}
 
+	// host functions which must trap record their error, the call fails as the execution trapped
+	err = in.ctx.TakeTrap()
+	if err != nil {
+		return nil, fmt.Errorf("runtime trapped: %w", err)
+	}
+
 	offset, length := runtime.Int64ToPointerAndSize(res.ToI64())
 	return in.load(offset, length), nil
 }
@@ -316,6 +378,52 @@ func (in *Instance) clear() {
 	in.ctx.Allocator.Clear()
 }
 
+// Memory returns the instance's linear memory, it implements sandbox.Supervisor
+func (in *Instance) Memory() []byte {
+	return in.vm.Memory.Data()
+}
+
+// Allocate allocates memory using the instance's allocator, it implements sandbox.Supervisor
+func (in *Instance) Allocate(size uint32) (uint32, error) {
+	return in.ctx.Allocator.Allocate(size)
+}
+
+// Deallocate frees memory using the instance's allocator, it implements sandbox.Supervisor
+func (in *Instance) Deallocate(ptr uint32) error {
+	return in.ctx.Allocator.Deallocate(ptr)
+}
+
+// injectDispatchThunk adds the export InvokeDispatchThunk calls to runtimes importing the sandbox host functions,
+// other runtimes are returned unchanged
+func injectDispatchThunk(code []byte) ([]byte, error) {
+	usesSandbox, err := sandbox.ImportsSandbox(code)
+	if err != nil {
+		return nil, err
+	}
+
+	if !usesSandbox {
+		return code, nil
+	}
+
+	return sandbox.InjectDispatchThunkExport(code)
+}
+
+// InvokeDispatchThunk calls the runtime's sandbox dispatch thunk, it implements sandbox.Supervisor.
+// It must only be called while the instance executes a runtime call, since the instance lock is held.
+func (in *Instance) InvokeDispatchThunk(thunk, argsPtr, argsLen, state, funcIdx uint32) (int64, error) {
+	dispatch, ok := in.vm.Exports[sandbox.DispatchThunkExport]
+	if !ok {
+		return 0, fmt.Errorf("could not find exported function %s", sandbox.DispatchThunkExport)
+	}
+
+	res, err := dispatch(int32(argsPtr), int32(argsLen), int32(state), int32(funcIdx), int32(thunk))
+	if err != nil {
+		return 0, err
+	}
+
+	return res.ToI64(), nil
+}
+
 // NodeStorage to get reference to runtime node service
 func (in *Instance) NodeStorage() runtime.NodeStorage {
 	return in.ctx.NodeStorage
@@ -331,6 +439,11 @@ func (in *Instance) Keystore() *keystore.GlobalKeystore {
 	return in.ctx.Keystore
 }
 
+// Signer returns the signer of the runtime
+func (in *Instance) Signer() signer.Signer {
+	return in.ctx.Signer
+}
+
 // Validator returns the context's Validator
 func (in *Instance) Validator() bool {
 	return in.ctx.Validator
@@ -63,3 +63,22 @@ func TestInstance_CheckRuntimeVersion(t *testing.T) {
 	require.Equal(t, expected.ImplVersion(), version.ImplVersion())
 	require.Equal(t, expected.TransactionVersion(), version.TransactionVersion())
 }
+
+func TestInstance_Clone(t *testing.T) {
+	instance := NewTestInstance(t, runtime.NODE_RUNTIME)
+
+	clone, err := instance.Clone()
+	require.NoError(t, err)
+	defer clone.Stop()
+
+	cloned := clone.(*Instance)
+	require.NotSame(t, instance.ctx, cloned.ctx)
+	require.NotSame(t, instance.ctx.Allocator, cloned.ctx.Allocator)
+	require.Equal(t, instance.ctx.Storage, cloned.ctx.Storage)
+
+	expected, err := instance.Version()
+	require.NoError(t, err)
+	version, err := clone.Version()
+	require.NoError(t, err)
+	require.Equal(t, expected, version)
+}
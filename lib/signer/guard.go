@@ -0,0 +1,131 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+)
+
+// guardRecord is the last message signed by a key for an equivocation kind
+type guardRecord struct {
+	Position    []byte      `json:"position"`
+	MessageHash common.Hash `json:"messageHash"`
+}
+
+// EquivocationGuard is a double-sign protection, which records for each key and equivocation kind the position
+// and the message hash of the last message signed. It refuses to sign another message at the same position, or a
+// message at a position before the last one. The records are persisted to a file, if any, before signing, so that
+// the protection holds across restarts.
+type EquivocationGuard struct {
+	sync.Mutex
+	path    string
+	records map[string]*guardRecord
+}
+
+// NewEquivocationGuard returns a new double-sign protection persisted to the given file, which is loaded if it
+// exists. The records are only kept in memory if the path is empty.
+func NewEquivocationGuard(path string) (*EquivocationGuard, error) {
+	g := &EquivocationGuard{
+		path:    path,
+		records: make(map[string]*guardRecord),
+	}
+
+	if path == "" {
+		return g, nil
+	}
+
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, os.ErrNotExist) {
+		return g, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot read double-sign protection file: %w", err)
+	}
+
+	err = json.Unmarshal(data, &g.records)
+	if err != nil {
+		return nil, fmt.Errorf("cannot decode double-sign protection file: %w", err)
+	}
+
+	return g, nil
+}
+
+// Check returns ErrEquivocation if signing the message with the key would equivocate, otherwise it records the
+// message as signed. Messages without equivocation are always allowed.
+func (g *EquivocationGuard) Check(pub crypto.PublicKey, msg []byte, eq *Equivocation) error {
+	if eq == nil {
+		return nil
+	}
+
+	h, err := common.Blake2bHash(msg)
+	if err != nil {
+		return err
+	}
+
+	g.Lock()
+	defer g.Unlock()
+
+	key := pub.Hex() + "/" + string(eq.Kind)
+	last, has := g.records[key]
+	if has {
+		switch cmp := bytes.Compare(eq.Position, last.Position); {
+		case cmp < 0:
+			return fmt.Errorf("%w: %s at position 0x%x before last signed position 0x%x",
+				ErrEquivocation, eq.Kind, eq.Position, last.Position)
+		case cmp == 0 && h != last.MessageHash:
+			return fmt.Errorf("%w: %s at position 0x%x already signed with another message",
+				ErrEquivocation, eq.Kind, eq.Position)
+		case cmp == 0:
+			// signing the same message again cannot equivocate
+			return nil
+		}
+	}
+
+	g.records[key] = &guardRecord{
+		Position:    eq.Position,
+		MessageHash: h,
+	}
+
+	err = g.persist()
+	if err != nil {
+		// the record cannot be kept, so the message must not be signed
+		if has {
+			g.records[key] = last
+		} else {
+			delete(g.records, key)
+		}
+		return fmt.Errorf("cannot persist double-sign protection: %w", err)
+	}
+
+	return nil
+}
+
+// persist writes the records to the file of the guard, if any
+func (g *EquivocationGuard) persist() error {
+	if g.path == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(g.records)
+	if err != nil {
+		return err
+	}
+
+	tmp := g.path + ".tmp"
+	err = os.WriteFile(tmp, data, 0600)
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, g.path)
+}
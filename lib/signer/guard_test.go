@@ -0,0 +1,75 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signer
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEquivocationGuard_Check(t *testing.T) {
+	kp, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+	pub := kp.Public()
+
+	g, err := NewEquivocationGuard("")
+	require.NoError(t, err)
+
+	// messages without equivocation are always allowed
+	require.NoError(t, g.Check(pub, []byte("a"), nil))
+	require.NoError(t, g.Check(pub, []byte("b"), nil))
+
+	prevote := NewGrandpaEquivocation(GrandpaPrevoteKind, 0, 2)
+	require.NoError(t, g.Check(pub, []byte("a"), prevote))
+	// signing the same message again is allowed
+	require.NoError(t, g.Check(pub, []byte("a"), prevote))
+	// another message for the same round equivocates
+	err = g.Check(pub, []byte("b"), prevote)
+	require.True(t, errors.Is(err, ErrEquivocation))
+	// a message for a previous round is refused
+	err = g.Check(pub, []byte("c"), NewGrandpaEquivocation(GrandpaPrevoteKind, 0, 1))
+	require.True(t, errors.Is(err, ErrEquivocation))
+
+	// the kinds of messages are independent
+	require.NoError(t, g.Check(pub, []byte("b"), NewGrandpaEquivocation(GrandpaPrecommitKind, 0, 2)))
+	// a round of a later set is after the rounds of the previous sets
+	require.NoError(t, g.Check(pub, []byte("d"), NewGrandpaEquivocation(GrandpaPrevoteKind, 1, 0)))
+
+	// the keys are independent
+	other, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+	require.NoError(t, g.Check(other.Public(), []byte("b"), prevote))
+}
+
+func TestEquivocationGuard_Persist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "protection.json")
+
+	kp, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+	pub := kp.Public()
+
+	g, err := NewEquivocationGuard(path)
+	require.NoError(t, err)
+
+	seal := &Equivocation{Kind: BabeSealKind, Position: babeSealPosition(10)}
+	require.NoError(t, g.Check(pub, []byte("a"), seal))
+
+	// the protection holds after a restart
+	g, err = NewEquivocationGuard(path)
+	require.NoError(t, err)
+	require.NoError(t, g.Check(pub, []byte("a"), seal))
+	err = g.Check(pub, []byte("b"), seal)
+	require.True(t, errors.Is(err, ErrEquivocation))
+
+	// the message is not allowed if it cannot be recorded, here because the directory is a file
+	g.path = filepath.Join(path, "protection.json")
+	err = g.Check(pub, []byte("c"), &Equivocation{Kind: BabeSealKind, Position: babeSealPosition(11)})
+	require.Error(t, err)
+	require.Equal(t, babeSealPosition(10), g.records[pub.Hex()+"/"+string(BabeSealKind)].Position)
+}
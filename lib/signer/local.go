@@ -0,0 +1,101 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signer
+
+import (
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+)
+
+// Local is a signer holding the private keys in the keystores of the node process. It does not protect against
+// double signing, since the node decides itself what to sign.
+type Local struct {
+	ks *keystore.GlobalKeystore
+}
+
+var _ Signer = (*Local)(nil)
+
+// NewLocal returns a signer signing with the keys of the given keystores
+func NewLocal(ks *keystore.GlobalKeystore) *Local {
+	return &Local{ks: ks}
+}
+
+// NewLocalFromKeypair returns a signer signing with the given keypair, as a key of the keystore with the given name
+func NewLocalFromKeypair(name keystore.Name, kp crypto.Keypair) (*Local, error) {
+	ks := keystore.NewGlobalKeystore()
+	named, err := ks.GetKeystore([]byte(name))
+	if err != nil {
+		return nil, err
+	}
+
+	err = named.Insert(kp)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLocal(ks), nil
+}
+
+// PublicKeys returns the public keys of the keystore with the given name
+func (l *Local) PublicKeys(name keystore.Name) ([]crypto.PublicKey, error) {
+	ks, err := l.keystore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return ks.PublicKeys(), nil
+}
+
+// Sign signs the message with the private key of the given public key of the keystore with the given name
+func (l *Local) Sign(name keystore.Name, pub crypto.PublicKey, msg []byte, _ *Equivocation) ([]byte, error) {
+	kp, err := l.keypair(name, pub)
+	if err != nil {
+		return nil, err
+	}
+
+	return kp.Sign(msg)
+}
+
+// VrfSign evaluates the VRF of the transcript with the private key of the given public key of the keystore with
+// the given name
+func (l *Local) VrfSign(name keystore.Name, pub *sr25519.PublicKey, t *crypto.VRFTranscript) (
+	[sr25519.VRFOutputLength]byte, [sr25519.VRFProofLength]byte, error) {
+	kp, err := l.keypair(name, pub)
+	if err != nil {
+		return [sr25519.VRFOutputLength]byte{}, [sr25519.VRFProofLength]byte{}, err
+	}
+
+	srkp, ok := kp.(*sr25519.Keypair)
+	if !ok {
+		return [sr25519.VRFOutputLength]byte{}, [sr25519.VRFProofLength]byte{},
+			fmt.Errorf("cannot evaluate VRF with key of type %s", kp.Type())
+	}
+
+	return srkp.VrfSign(t.Merlin())
+}
+
+func (l *Local) keystore(name keystore.Name) (keystore.Keystore, error) {
+	if l.ks == nil {
+		return nil, fmt.Errorf("%w: no keystore", ErrKeyNotFound)
+	}
+
+	return l.ks.GetKeystore([]byte(name))
+}
+
+func (l *Local) keypair(name keystore.Name, pub crypto.PublicKey) (crypto.Keypair, error) {
+	ks, err := l.keystore(name)
+	if err != nil {
+		return nil, err
+	}
+
+	kp := ks.GetKeypair(pub)
+	if kp == nil {
+		return nil, fmt.Errorf("%w: %s in keystore %s", ErrKeyNotFound, pub.Hex(), name)
+	}
+
+	return kp, nil
+}
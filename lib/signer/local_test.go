@@ -0,0 +1,85 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signer
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeystore(t *testing.T) *keystore.GlobalKeystore {
+	ks := keystore.NewGlobalKeystore()
+
+	srkr, err := keystore.NewSr25519Keyring()
+	require.NoError(t, err)
+	err = ks.Babe.Insert(srkr.Alice())
+	require.NoError(t, err)
+
+	edkr, err := keystore.NewEd25519Keyring()
+	require.NoError(t, err)
+	err = ks.Gran.Insert(edkr.Alice())
+	require.NoError(t, err)
+
+	return ks
+}
+
+func TestLocal_Sign(t *testing.T) {
+	ks := newTestKeystore(t)
+	l := NewLocal(ks)
+
+	pubs, err := l.PublicKeys(keystore.GranName)
+	require.NoError(t, err)
+	require.Equal(t, ks.Gran.PublicKeys(), pubs)
+
+	msg := []byte("helloworld")
+	sig, err := l.Sign(keystore.GranName, pubs[0], msg, nil)
+	require.NoError(t, err)
+
+	ok, err := pubs[0].Verify(msg, sig)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	// the key is not in the BABE keystore
+	_, err = l.Sign(keystore.BabeName, pubs[0], msg, nil)
+	require.True(t, errors.Is(err, ErrKeyNotFound))
+}
+
+func TestLocal_VrfSign(t *testing.T) {
+	ks := newTestKeystore(t)
+	l := NewLocal(ks)
+
+	pub := ks.Babe.PublicKeys()[0].(*sr25519.PublicKey)
+	transcript := crypto.NewVRFTranscript("BABE")
+	transcript.AppendUint64("slot number", 1)
+
+	out, proof, err := l.VrfSign(keystore.BabeName, pub, transcript)
+	require.NoError(t, err)
+
+	ok, err := pub.VrfVerify(transcript.Merlin(), out, proof)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestNewLocalFromKeypair(t *testing.T) {
+	kp, err := ed25519.GenerateKeypair()
+	require.NoError(t, err)
+
+	l, err := NewLocalFromKeypair(keystore.GranName, kp)
+	require.NoError(t, err)
+
+	pubs, err := l.PublicKeys(keystore.GranName)
+	require.NoError(t, err)
+	require.Equal(t, []crypto.PublicKey{kp.Public()}, pubs)
+
+	// the keystore of the name only holds keys of its type
+	_, err = NewLocalFromKeypair(keystore.BabeName, kp)
+	require.Error(t, err)
+}
@@ -0,0 +1,197 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+)
+
+// the remote signing protocol is a JSON request posted to one endpoint per method, authenticated with a bearer token
+const (
+	publicKeysPath = "/public_keys"
+	signPath       = "/sign"
+	vrfSignPath    = "/vrf_sign"
+
+	defaultRemoteTimeout = 10 * time.Second
+)
+
+type publicKeysRequest struct {
+	Name keystore.Name `json:"name"`
+}
+
+type publicKey struct {
+	Type      crypto.KeyType `json:"type"`
+	PublicKey []byte         `json:"publicKey"`
+}
+
+type publicKeysResponse struct {
+	Keys []publicKey `json:"keys"`
+}
+
+type signRequest struct {
+	Name         keystore.Name `json:"name"`
+	Key          publicKey     `json:"key"`
+	Message      []byte        `json:"message"`
+	Equivocation *Equivocation `json:"equivocation,omitempty"`
+}
+
+type signResponse struct {
+	Signature []byte `json:"signature"`
+}
+
+type vrfSignRequest struct {
+	Name       keystore.Name         `json:"name"`
+	PublicKey  []byte                `json:"publicKey"`
+	Transcript *crypto.VRFTranscript `json:"transcript"`
+}
+
+type vrfSignResponse struct {
+	Output []byte `json:"output"`
+	Proof  []byte `json:"proof"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Remote is a signer whose private keys are held by an isolated signing process, served by a Server and reached
+// over HTTP
+type Remote struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+var _ Signer = (*Remote)(nil)
+
+// NewRemote returns a signer sending its requests to the signing server at the given URL, authenticated with the
+// given token
+func NewRemote(url, token string) *Remote {
+	return &Remote{
+		url:    strings.TrimSuffix(url, "/"),
+		token:  token,
+		client: &http.Client{Timeout: defaultRemoteTimeout},
+	}
+}
+
+// PublicKeys returns the public keys of the keystore with the given name of the signing server
+func (r *Remote) PublicKeys(name keystore.Name) ([]crypto.PublicKey, error) {
+	res := new(publicKeysResponse)
+	err := r.post(publicKeysPath, &publicKeysRequest{Name: name}, res)
+	if err != nil {
+		return nil, err
+	}
+
+	pubs := make([]crypto.PublicKey, len(res.Keys))
+	for i, key := range res.Keys {
+		pubs[i], err = decodePublicKey(key.Type, key.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("cannot decode public key: %w", err)
+		}
+	}
+
+	return pubs, nil
+}
+
+// Sign requests the signing server to sign the message with the private key of the given public key
+func (r *Remote) Sign(name keystore.Name, pub crypto.PublicKey, msg []byte, eq *Equivocation) ([]byte, error) {
+	keytype, err := keyType(pub)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &signRequest{
+		Name:         name,
+		Key:          publicKey{Type: keytype, PublicKey: pub.Encode()},
+		Message:      msg,
+		Equivocation: eq,
+	}
+
+	res := new(signResponse)
+	err = r.post(signPath, req, res)
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Signature, nil
+}
+
+// VrfSign requests the signing server to evaluate the VRF of the transcript with the private key of the given
+// public key
+func (r *Remote) VrfSign(name keystore.Name, pub *sr25519.PublicKey, t *crypto.VRFTranscript) (
+	out [sr25519.VRFOutputLength]byte, proof [sr25519.VRFProofLength]byte, err error) {
+	req := &vrfSignRequest{
+		Name:       name,
+		PublicKey:  pub.Encode(),
+		Transcript: t,
+	}
+
+	res := new(vrfSignResponse)
+	err = r.post(vrfSignPath, req, res)
+	if err != nil {
+		return out, proof, err
+	}
+
+	if len(res.Output) != sr25519.VRFOutputLength || len(res.Proof) != sr25519.VRFProofLength {
+		return out, proof, errors.New("invalid VRF output or proof length")
+	}
+
+	copy(out[:], res.Output)
+	copy(proof[:], res.Proof)
+	return out, proof, nil
+}
+
+// post posts the JSON encoded request to the given endpoint of the signing server and decodes its response
+func (r *Remote) post(path string, req, res interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	httpReq, err := http.NewRequest(http.MethodPost, r.url+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+r.token)
+
+	httpRes, err := r.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("cannot reach signing server: %w", err)
+	}
+	defer httpRes.Body.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return err
+	}
+
+	if httpRes.StatusCode != http.StatusOK {
+		errRes := new(errorResponse)
+		_ = json.Unmarshal(data, errRes)
+
+		switch httpRes.StatusCode {
+		case http.StatusNotFound:
+			return fmt.Errorf("%w: %s", ErrKeyNotFound, errRes.Error)
+		case http.StatusConflict:
+			return fmt.Errorf("%w: %s", ErrEquivocation, errRes.Error)
+		default:
+			return fmt.Errorf("signing server error %d: %s", httpRes.StatusCode, errRes.Error)
+		}
+	}
+
+	return json.Unmarshal(data, res)
+}
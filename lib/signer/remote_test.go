@@ -0,0 +1,196 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signer
+
+import (
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "secret"
+
+func newTestServer(t *testing.T, ks *keystore.GlobalKeystore) *httptest.Server {
+	guard, err := NewEquivocationGuard("")
+	require.NoError(t, err)
+
+	srv, err := NewServer(NewLocal(ks), testToken, guard)
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(srv)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestNewServer(t *testing.T) {
+	guard, err := NewEquivocationGuard("")
+	require.NoError(t, err)
+
+	_, err = NewServer(NewLocal(nil), "", guard)
+	require.Error(t, err)
+
+	_, err = NewServer(NewLocal(nil), testToken, nil)
+	require.Error(t, err)
+}
+
+// newTestSeal returns the hash of a header of the given slot, which is the message of its seal, and the
+// equivocation of the seal
+func newTestSeal(t *testing.T, slot uint64, parent common.Hash) ([]byte, *Equivocation) {
+	t.Helper()
+
+	preDigest, err := types.NewBabeSecondaryPlainPreDigest(0, slot).ToPreRuntimeDigest()
+	require.NoError(t, err)
+
+	digest := types.NewDigest()
+	require.NoError(t, digest.Add(*preDigest))
+
+	header, err := types.NewHeader(parent, common.Hash{}, common.Hash{}, big.NewInt(1), digest)
+	require.NoError(t, err)
+
+	eq, err := NewBabeSealEquivocation(header)
+	require.NoError(t, err)
+
+	hash := header.Hash()
+	return hash[:], eq
+}
+
+// newTestVote returns an encoded GRANDPA vote
+func newTestVote(t *testing.T, stage byte, hash common.Hash, round, setID uint64) []byte {
+	t.Helper()
+
+	msg, err := scale.Marshal(grandpaFullVote{
+		Stage: stage,
+		Vote:  types.GrandpaVote{Hash: hash, Number: 1},
+		Round: round,
+		SetID: setID,
+	})
+	require.NoError(t, err)
+	return msg
+}
+
+func TestRemote_Sign(t *testing.T) {
+	ks := newTestKeystore(t)
+	ts := newTestServer(t, ks)
+	r := NewRemote(ts.URL, testToken)
+
+	pubs, err := r.PublicKeys(keystore.GranName)
+	require.NoError(t, err)
+	require.Equal(t, ks.Gran.PublicKeys(), pubs)
+
+	msg := newTestVote(t, 0, common.Hash{1}, 1, 0)
+	sig, err := r.Sign(keystore.GranName, pubs[0], msg, nil)
+	require.NoError(t, err)
+
+	ok, err := pubs[0].Verify(msg, sig)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	_, err = r.Sign(keystore.AccoName, pubs[0], msg, nil)
+	require.True(t, errors.Is(err, ErrKeyNotFound))
+
+	// messages signed with other keys are not slashable
+	kp, err := sr25519.GenerateKeypair()
+	require.NoError(t, err)
+	require.NoError(t, ks.Acco.Insert(kp))
+
+	_, err = r.Sign(keystore.AccoName, kp.Public(), []byte("helloworld"), nil)
+	require.NoError(t, err)
+}
+
+func TestRemote_Sign_Equivocation(t *testing.T) {
+	ks := newTestKeystore(t)
+	ts := newTestServer(t, ks)
+	r := NewRemote(ts.URL, testToken)
+
+	pub := ks.Babe.PublicKeys()[0]
+	msg, eq := newTestSeal(t, 5, common.Hash{1})
+	_, err := r.Sign(keystore.BabeName, pub, msg, eq)
+	require.NoError(t, err)
+
+	// the slot is derived from the header, so a forged position does not bypass the protection
+	msg, eq = newTestSeal(t, 5, common.Hash{2})
+	eq.Position = babeSealPosition(6)
+	_, err = r.Sign(keystore.BabeName, pub, msg, eq)
+	require.True(t, errors.Is(err, ErrEquivocation))
+
+	// the kind and position of votes are derived from the vote, whatever the equivocation of the request
+	granPub := ks.Gran.PublicKeys()[0]
+	_, err = r.Sign(keystore.GranName, granPub, newTestVote(t, 1, common.Hash{1}, 2, 0), nil)
+	require.NoError(t, err)
+
+	_, err = r.Sign(keystore.GranName, granPub, newTestVote(t, 1, common.Hash{2}, 2, 0),
+		NewGrandpaEquivocation(GrandpaPrecommitKind, 0, 3))
+	require.True(t, errors.Is(err, ErrEquivocation))
+}
+
+func TestRemote_Sign_Unclassified(t *testing.T) {
+	ks := newTestKeystore(t)
+	ts := newTestServer(t, ks)
+	r := NewRemote(ts.URL, testToken)
+
+	requireUnclassified := func(err error) {
+		t.Helper()
+		require.Error(t, err)
+		require.Contains(t, err.Error(), errUnclassifiedMessage.Error())
+	}
+
+	granPub := ks.Gran.PublicKeys()[0]
+	_, err := r.Sign(keystore.GranName, granPub, []byte("helloworld"), nil)
+	requireUnclassified(err)
+
+	_, err = r.Sign(keystore.GranName, granPub, newTestVote(t, 3, common.Hash{1}, 2, 0), nil)
+	requireUnclassified(err)
+
+	babePub := ks.Babe.PublicKeys()[0]
+	msg, eq := newTestSeal(t, 5, common.Hash{1})
+	_, err = r.Sign(keystore.BabeName, babePub, msg, nil)
+	requireUnclassified(err)
+
+	// the header of the seal must be the one signed
+	_, err = r.Sign(keystore.BabeName, babePub, []byte("helloworld"), eq)
+	requireUnclassified(err)
+
+	eq.Header = nil
+	_, err = r.Sign(keystore.BabeName, babePub, msg, eq)
+	requireUnclassified(err)
+}
+
+func TestRemote_InvalidToken(t *testing.T) {
+	ks := newTestKeystore(t)
+	ts := newTestServer(t, ks)
+
+	for _, token := range []string{"", "wrong"} {
+		r := NewRemote(ts.URL, token)
+		_, err := r.PublicKeys(keystore.BabeName)
+		require.EqualError(t, err, "signing server error 401: invalid token")
+	}
+}
+
+func TestRemote_VrfSign(t *testing.T) {
+	ks := newTestKeystore(t)
+	ts := newTestServer(t, ks)
+	r := NewRemote(ts.URL, testToken)
+
+	pub := ks.Babe.PublicKeys()[0].(*sr25519.PublicKey)
+	transcript := crypto.NewVRFTranscript("BABE")
+	transcript.AppendUint64("slot number", 1)
+	transcript.AppendMessage("chain randomness", []byte{1, 2, 3})
+
+	out, proof, err := r.VrfSign(keystore.BabeName, pub, transcript)
+	require.NoError(t, err)
+
+	ok, err := pub.VrfVerify(transcript.Merlin(), out, proof)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
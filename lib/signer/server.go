@@ -0,0 +1,266 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signer
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+// maxRequestSize is the maximum size of the body of a signing request
+const maxRequestSize = 1 << 20
+
+// errUnclassifiedMessage is returned when a message to sign with a BABE or GRANDPA key is neither a block seal
+// nor a vote
+var errUnclassifiedMessage = errors.New("cannot classify message")
+
+// Server serves the requests of Remote signers with a signer, usually a Local signer of the signing process.
+// Requests are authenticated with a shared token, and the ones which would equivocate are refused.
+type Server struct {
+	signer Signer
+	token  []byte
+	guard  *EquivocationGuard
+	mux    *http.ServeMux
+}
+
+var _ http.Handler = (*Server)(nil)
+
+// NewServer returns a new signing server signing with the given signer, which accepts the requests holding the
+// given token and checks the slashable messages with the given double-sign protection
+func NewServer(s Signer, token string, guard *EquivocationGuard) (*Server, error) {
+	if token == "" {
+		return nil, errors.New("signing server requires a token")
+	}
+
+	if guard == nil {
+		return nil, errors.New("signing server requires a double-sign protection")
+	}
+
+	srv := &Server{
+		signer: s,
+		token:  []byte(token),
+		guard:  guard,
+		mux:    http.NewServeMux(),
+	}
+
+	srv.mux.HandleFunc(publicKeysPath, srv.handlePublicKeys)
+	srv.mux.HandleFunc(signPath, srv.handleSign)
+	srv.mux.HandleFunc(vrfSignPath, srv.handleVrfSign)
+	return srv, nil
+}
+
+// ServeHTTP authenticates the request and serves it
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if len(auth) <= len(prefix) || auth[:len(prefix)] != prefix ||
+		subtle.ConstantTimeCompare([]byte(auth[len(prefix):]), s.token) != 1 {
+		writeError(w, http.StatusUnauthorized, errors.New("invalid token"))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxRequestSize)
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handlePublicKeys(w http.ResponseWriter, r *http.Request) {
+	req := new(publicKeysRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	pubs, err := s.signer.PublicKeys(req.Name)
+	if err != nil {
+		writeSignerError(w, err)
+		return
+	}
+
+	res := &publicKeysResponse{Keys: make([]publicKey, len(pubs))}
+	for i, pub := range pubs {
+		keytype, err := keyType(pub)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		res.Keys[i] = publicKey{Type: keytype, PublicKey: pub.Encode()}
+	}
+
+	writeResponse(w, res)
+}
+
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
+	req := new(signRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	pub, err := decodePublicKey(req.Key.Type, req.Key.PublicKey)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	eq, err := classify(req.Name, req.Message, req.Equivocation)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// the message is recorded before it is signed, so that it cannot be signed twice concurrently
+	err = s.guard.Check(pub, req.Message, eq)
+	if err != nil {
+		writeSignerError(w, err)
+		return
+	}
+
+	sig, err := s.signer.Sign(req.Name, pub, req.Message, eq)
+	if err != nil {
+		writeSignerError(w, err)
+		return
+	}
+
+	writeResponse(w, &signResponse{Signature: sig})
+}
+
+// grandpaFullVote is the message signed by GRANDPA voters, encoded like grandpa.FullVote
+type grandpaFullVote struct {
+	Stage byte
+	Vote  types.GrandpaVote
+	Round uint64
+	SetID uint64
+}
+
+// grandpaFullVoteLength is the length of an encoded grandpaFullVote
+const grandpaFullVoteLength = 1 + common.HashLength + 4 + 8 + 8
+
+// classify returns the equivocation of a message to sign with a key of the keystore with the given name, derived
+// from the message itself rather than from the equivocation of the request, which only provides the header of
+// BABE seals. Messages signed with BABE and GRANDPA keys are refused unless they are seals or votes, so that the
+// double-sign protection cannot be bypassed. Messages signed with other keys are not slashable.
+func classify(name keystore.Name, msg []byte, reqEq *Equivocation) (*Equivocation, error) {
+	switch name {
+	case keystore.BabeName:
+		if reqEq == nil || reqEq.Kind != BabeSealKind || len(reqEq.Header) == 0 {
+			return nil, fmt.Errorf("%w: BABE keys only sign block seals with their header", errUnclassifiedMessage)
+		}
+
+		hash, err := common.Blake2bHash(reqEq.Header)
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(hash[:], msg) {
+			return nil, fmt.Errorf("%w: message is not the hash of the sealed header", errUnclassifiedMessage)
+		}
+
+		header := types.NewEmptyHeader()
+		err = scale.Unmarshal(reqEq.Header, header)
+		if err != nil {
+			return nil, fmt.Errorf("%w: cannot decode sealed header: %s", errUnclassifiedMessage, err)
+		}
+
+		return NewBabeSealEquivocation(header)
+	case keystore.GranName:
+		if len(msg) != grandpaFullVoteLength {
+			return nil, fmt.Errorf("%w: GRANDPA keys only sign votes", errUnclassifiedMessage)
+		}
+
+		var vote grandpaFullVote
+		err := scale.Unmarshal(msg, &vote)
+		if err != nil {
+			return nil, fmt.Errorf("%w: cannot decode vote: %s", errUnclassifiedMessage, err)
+		}
+
+		// the stages are the values of grandpa.Subround
+		var kind EquivocationKind
+		switch vote.Stage {
+		case 0:
+			kind = GrandpaPrevoteKind
+		case 1:
+			kind = GrandpaPrecommitKind
+		case 2:
+			kind = GrandpaPrimaryKind
+		default:
+			return nil, fmt.Errorf("%w: unknown vote stage %d", errUnclassifiedMessage, vote.Stage)
+		}
+
+		return NewGrandpaEquivocation(kind, vote.SetID, vote.Round), nil
+	default:
+		return nil, nil
+	}
+}
+
+func (s *Server) handleVrfSign(w http.ResponseWriter, r *http.Request) {
+	req := new(vrfSignRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if req.Transcript == nil {
+		writeError(w, http.StatusBadRequest, errors.New("missing transcript"))
+		return
+	}
+
+	pub, err := sr25519.NewPublicKey(req.PublicKey)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	out, proof, err := s.signer.VrfSign(req.Name, pub, req.Transcript)
+	if err != nil {
+		writeSignerError(w, err)
+		return
+	}
+
+	writeResponse(w, &vrfSignResponse{Output: out[:], Proof: proof[:]})
+}
+
+// writeSignerError writes the error of the signer with the status code matching it
+func writeSignerError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, ErrKeyNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case errors.Is(err, ErrEquivocation):
+		writeError(w, http.StatusConflict, err)
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&errorResponse{Error: err.Error()})
+}
+
+func writeResponse(w http.ResponseWriter, res interface{}) {
+	data, err := json.Marshal(res)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("cannot encode response: %w", err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(data)
+}
@@ -0,0 +1,122 @@
+// Copyright 2021 ChainSafe Systems (ON)
+// SPDX-License-Identifier: LGPL-3.0-only
+
+package signer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/ChainSafe/gossamer/dot/types"
+	"github.com/ChainSafe/gossamer/lib/crypto"
+	"github.com/ChainSafe/gossamer/lib/crypto/ed25519"
+	"github.com/ChainSafe/gossamer/lib/crypto/secp256k1"
+	"github.com/ChainSafe/gossamer/lib/crypto/sr25519"
+	"github.com/ChainSafe/gossamer/lib/keystore"
+	"github.com/ChainSafe/gossamer/pkg/scale"
+)
+
+var (
+	// ErrKeyNotFound is returned when the signer does not hold the private key of a public key
+	ErrKeyNotFound = errors.New("key not found")
+	// ErrEquivocation is returned when signing a message would equivocate with a message signed before
+	ErrEquivocation = errors.New("signing would equivocate")
+)
+
+// EquivocationKind is the kind of messages which must not be signed twice with different contents for the
+// same slot or round
+type EquivocationKind string
+
+//nolint:revive
+const (
+	BabeSealKind         EquivocationKind = "babe-seal"
+	GrandpaPrevoteKind   EquivocationKind = "grandpa-prevote"
+	GrandpaPrecommitKind EquivocationKind = "grandpa-precommit"
+	GrandpaPrimaryKind   EquivocationKind = "grandpa-primary"
+)
+
+// Equivocation identifies a message which must be the only one signed by a key for its kind and position, for
+// instance the seal of the block of a BABE slot. Positions are compared as big endian numbers, so that a signer
+// with double-sign protection refuses to sign a message for a position before the last one signed.
+type Equivocation struct {
+	Kind     EquivocationKind `json:"kind"`
+	Position []byte           `json:"position"`
+	// Header is the SCALE encoded header of a BABE seal, whose hash is the signed message. A signing server
+	// derives the slot of the seal from it rather than trusting the position.
+	Header []byte `json:"header,omitempty"`
+}
+
+// NewBabeSealEquivocation returns the equivocation of the seal of the given header, whose slot is the one of
+// its BABE pre-runtime digest
+func NewBabeSealEquivocation(header *types.Header) (*Equivocation, error) {
+	enc, err := scale.Marshal(*header)
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := types.GetSlotFromHeader(header)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Equivocation{Kind: BabeSealKind, Position: babeSealPosition(slot), Header: enc}, nil
+}
+
+func babeSealPosition(slot uint64) []byte {
+	pos := make([]byte, 8)
+	binary.BigEndian.PutUint64(pos, slot)
+	return pos
+}
+
+// NewGrandpaEquivocation returns the equivocation of a GRANDPA vote of the given kind for the given set and round
+func NewGrandpaEquivocation(kind EquivocationKind, setID, round uint64) *Equivocation {
+	pos := make([]byte, 16)
+	binary.BigEndian.PutUint64(pos, setID)
+	binary.BigEndian.PutUint64(pos[8:], round)
+	return &Equivocation{Kind: kind, Position: pos}
+}
+
+// Signer signs messages and evaluates VRFs with the private keys of the node, which may be held by the node
+// itself or by an isolated signing process
+type Signer interface {
+	// PublicKeys returns the public keys of the keystore with the given name
+	PublicKeys(name keystore.Name) ([]crypto.PublicKey, error)
+	// Sign signs the message with the private key of the given public key of the keystore with the given name.
+	// The equivocation of the message is nil if the message is not slashable.
+	Sign(name keystore.Name, pub crypto.PublicKey, msg []byte, eq *Equivocation) ([]byte, error)
+	// VrfSign evaluates the VRF of the transcript with the private key of the given public key of the keystore
+	// with the given name, and returns the VRF output and proof
+	VrfSign(name keystore.Name, pub *sr25519.PublicKey, t *crypto.VRFTranscript) (
+		[sr25519.VRFOutputLength]byte, [sr25519.VRFProofLength]byte, error)
+}
+
+// keyType returns the key type of the public key
+func keyType(pub crypto.PublicKey) (crypto.KeyType, error) {
+	switch pub.(type) {
+	case *sr25519.PublicKey:
+		return crypto.Sr25519Type, nil
+	case *ed25519.PublicKey:
+		return crypto.Ed25519Type, nil
+	case *secp256k1.PublicKey:
+		return crypto.Secp256k1Type, nil
+	default:
+		return "", fmt.Errorf("unsupported public key type %T", pub)
+	}
+}
+
+// decodePublicKey decodes the public key of the given key type
+func decodePublicKey(keytype crypto.KeyType, in []byte) (crypto.PublicKey, error) {
+	switch keytype {
+	case crypto.Sr25519Type:
+		return sr25519.NewPublicKey(in)
+	case crypto.Ed25519Type:
+		return ed25519.NewPublicKey(in)
+	case crypto.Secp256k1Type:
+		pub := new(secp256k1.PublicKey)
+		err := pub.Decode(in)
+		return pub, err
+	default:
+		return nil, fmt.Errorf("unsupported key type %s", keytype)
+	}
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/ChainSafe/gossamer/lib/common"
 )
 
-// Pool represents the transaction pool
+// Pool represents the transaction pool. It holds the transactions that aren't ready to be included in a block yet,
+// either because they were received since the last block was imported or because they require tags that no ready
+// transaction provides.
 type Pool struct {
 	transactions map[common.Hash]*ValidTransaction
 	mu           sync.RWMutex
@@ -46,6 +48,13 @@ func (p *Pool) Insert(tx *ValidTransaction) common.Hash {
 	return hash
 }
 
+// Get returns the transaction with the given hash, or nil if it isn't in the pool
+func (p *Pool) Get(hash common.Hash) *ValidTransaction {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.transactions[hash]
+}
+
 // Remove removes a transaction from the pool
 func (p *Pool) Remove(hash common.Hash) {
 	p.mu.Lock()
@@ -15,6 +15,13 @@ import (
 // ErrTransactionExists is returned when trying to add a transaction to the queue that already exists
 var ErrTransactionExists = errors.New("transaction is already in queue")
 
+// ErrNilValidity is returned when trying to add a transaction without a validity to the queue
+var ErrNilValidity = errors.New("transaction has no validity")
+
+// ErrTooLowPriority is returned when trying to add a transaction to the queue that provides a tag
+// already provided by a queued transaction with a higher or equal priority
+var ErrTooLowPriority = errors.New("transaction has too low priority to replace a transaction in queue")
+
 // An Item is something we manage in a priority queue.
 type Item struct {
 	data *ValidTransaction
@@ -67,48 +74,95 @@ func (pq *priorityQueue) Pop() interface{} {
 	return item
 }
 
-// PriorityQueue is a thread safe wrapper over `priorityQueue`
+// PriorityQueue is a thread safe wrapper over `priorityQueue`. It is the ready queue of the transaction pool:
+// a transaction is only returned by Pop or Peek once no other transaction in the queue provides a tag it requires.
 type PriorityQueue struct {
 	pq        priorityQueue
 	currOrder uint64
 	txs       map[common.Hash]*Item
+	// provided maps each tag provided by a transaction in the queue to the hash of that transaction
+	provided map[string]common.Hash
 	sync.Mutex
 }
 
 // NewPriorityQueue creates new instance of PriorityQueue
 func NewPriorityQueue() *PriorityQueue {
 	spq := &PriorityQueue{
-		pq:  make(priorityQueue, 0),
-		txs: make(map[common.Hash]*Item),
+		pq:       make(priorityQueue, 0),
+		txs:      make(map[common.Hash]*Item),
+		provided: make(map[string]common.Hash),
 	}
 
 	heap.Init(&spq.pq)
 	return spq
 }
 
-// RemoveExtrinsic removes an extrinsic from the queue
-func (spq *PriorityQueue) RemoveExtrinsic(ext types.Extrinsic) {
+// RemoveExtrinsic removes an extrinsic from the queue and returns it, or nil if it isn't in the queue
+func (spq *PriorityQueue) RemoveExtrinsic(ext types.Extrinsic) *ValidTransaction {
+	return spq.Remove(ext.Hash())
+}
+
+// Remove removes the transaction with the given hash from the queue and returns it,
+// or nil if it isn't in the queue
+func (spq *PriorityQueue) Remove(hash common.Hash) *ValidTransaction {
 	spq.Lock()
 	defer spq.Unlock()
 
-	hash := ext.Hash()
 	item, ok := spq.txs[hash]
 	if !ok {
-		return
+		return nil
 	}
 
 	heap.Remove(&spq.pq, item.index)
-	delete(spq.txs, hash)
+	spq.forget(item)
+	return item.data
 }
 
-// Push inserts a valid transaction with priority p into the queue
+// Push inserts a valid transaction with priority p into the queue. Queued transactions providing
+// the same tags with a lower priority are replaced by it, see Replace.
 func (spq *PriorityQueue) Push(txn *ValidTransaction) (common.Hash, error) {
+	hash, _, err := spq.Replace(txn)
+	return hash, err
+}
+
+// Replace inserts a valid transaction into the queue and returns the queued transactions it replaced.
+// If a queued transaction provides a tag that is also provided by txn, the one with the higher priority
+// is kept. If the queued transaction wins, txn isn't inserted and ErrTooLowPriority is returned.
+func (spq *PriorityQueue) Replace(txn *ValidTransaction) (common.Hash, []*ValidTransaction, error) {
 	spq.Lock()
 	defer spq.Unlock()
 
 	hash := txn.Extrinsic.Hash()
+	if txn.Validity == nil {
+		return hash, nil, ErrNilValidity
+	}
+
 	if spq.txs[hash] != nil {
-		return hash, ErrTransactionExists
+		return hash, nil, ErrTransactionExists
+	}
+
+	var conflicts []*Item
+	for _, tag := range txn.Validity.Provides {
+		other, has := spq.provided[string(tag)]
+		if !has {
+			continue
+		}
+
+		item := spq.txs[other]
+		if item.priority >= txn.Validity.Priority {
+			return hash, nil, ErrTooLowPriority
+		}
+
+		if !containsItem(conflicts, item) {
+			conflicts = append(conflicts, item)
+		}
+	}
+
+	replaced := make([]*ValidTransaction, len(conflicts))
+	for i, item := range conflicts {
+		heap.Remove(&spq.pq, item.index)
+		spq.forget(item)
+		replaced[i] = item.data
 	}
 
 	item := &Item{
@@ -121,20 +175,26 @@ func (spq *PriorityQueue) Push(txn *ValidTransaction) (common.Hash, error) {
 	heap.Push(&spq.pq, item)
 	spq.txs[hash] = item
 
-	return hash, nil
+	for _, tag := range txn.Validity.Provides {
+		spq.provided[string(tag)] = hash
+	}
+
+	return hash, replaced, nil
 }
 
 // Pop removes the transaction with has the highest priority value from the queue and returns it.
 // If there are multiple transaction with same priority value then it return them in FIFO order.
+// Transactions requiring a tag provided by another queued transaction are skipped until that transaction is popped.
 func (spq *PriorityQueue) Pop() *ValidTransaction {
 	spq.Lock()
 	defer spq.Unlock()
-	if spq.pq.Len() == 0 {
+
+	item := spq.next()
+	if item == nil {
 		return nil
 	}
 
-	item := heap.Pop(&spq.pq).(*Item)
-	delete(spq.txs, item.hash)
+	spq.forget(item)
 	return item.data
 }
 
@@ -142,10 +202,81 @@ func (spq *PriorityQueue) Pop() *ValidTransaction {
 func (spq *PriorityQueue) Peek() *ValidTransaction {
 	spq.Lock()
 	defer spq.Unlock()
-	if spq.pq.Len() == 0 {
+
+	item := spq.next()
+	if item == nil {
 		return nil
 	}
-	return spq.pq[0].data
+
+	heap.Push(&spq.pq, item)
+	return item.data
+}
+
+// Provides returns true if a transaction in the queue provides the given tag
+func (spq *PriorityQueue) Provides(tag []byte) bool {
+	spq.Lock()
+	defer spq.Unlock()
+
+	_, has := spq.provided[string(tag)]
+	return has
+}
+
+// next pops the highest priority item from the heap whose required tags aren't provided by
+// another queued transaction. It returns nil if there is no such item.
+func (spq *PriorityQueue) next() *Item {
+	var (
+		next    *Item
+		blocked []*Item
+	)
+
+	for spq.pq.Len() > 0 {
+		item := heap.Pop(&spq.pq).(*Item)
+		if spq.isBlocked(item) {
+			blocked = append(blocked, item)
+			continue
+		}
+
+		next = item
+		break
+	}
+
+	for _, item := range blocked {
+		heap.Push(&spq.pq, item)
+	}
+
+	return next
+}
+
+func (spq *PriorityQueue) isBlocked(item *Item) bool {
+	for _, tag := range item.data.Validity.Requires {
+		provider, has := spq.provided[string(tag)]
+		if has && provider != item.hash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// forget removes an item that is no longer in the heap from the lookup maps
+func (spq *PriorityQueue) forget(item *Item) {
+	delete(spq.txs, item.hash)
+
+	for _, tag := range item.data.Validity.Provides {
+		if spq.provided[string(tag)] == item.hash {
+			delete(spq.provided, string(tag))
+		}
+	}
+}
+
+func containsItem(items []*Item, item *Item) bool {
+	for _, i := range items {
+		if i == item {
+			return true
+		}
+	}
+
+	return false
 }
 
 // Pending returns all the transactions currently in the queue
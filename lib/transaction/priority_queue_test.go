@@ -6,6 +6,8 @@ package transaction
 import (
 	"reflect"
 	"testing"
+
+	"github.com/stretchr/testify/require"
 )
 
 func TestPriorityQueue(t *testing.T) {
@@ -246,3 +248,64 @@ func TestRemoveExtrinsic(t *testing.T) {
 		t.Fatalf("Fail: got %v expected %v", res, tests[1])
 	}
 }
+
+func TestPriorityQueue_Requires(t *testing.T) {
+	// b requires the tag provided by a, so a must be popped first despite its lower priority
+	tests := []*ValidTransaction{
+		{
+			Extrinsic: []byte("a"),
+			Validity:  &Validity{Priority: 1, Provides: [][]byte{{1}}},
+		},
+		{
+			Extrinsic: []byte("b"),
+			Validity:  &Validity{Priority: 5, Requires: [][]byte{{1}}},
+		},
+		{
+			Extrinsic: []byte("c"),
+			Validity:  &Validity{Priority: 3},
+		},
+	}
+
+	pq := NewPriorityQueue()
+	for _, node := range tests {
+		_, err := pq.Push(node)
+		require.NoError(t, err)
+	}
+
+	require.True(t, pq.Provides([]byte{1}))
+	require.Equal(t, tests[2], pq.Peek())
+	require.Equal(t, tests[2], pq.Pop())
+	require.Equal(t, tests[0], pq.Pop())
+	require.False(t, pq.Provides([]byte{1}))
+	require.Equal(t, tests[1], pq.Pop())
+	require.Nil(t, pq.Pop())
+}
+
+func TestPriorityQueue_Replace(t *testing.T) {
+	low := &ValidTransaction{
+		Extrinsic: []byte("low"),
+		Validity:  &Validity{Priority: 1, Provides: [][]byte{{1}, {2}}},
+	}
+	high := &ValidTransaction{
+		Extrinsic: []byte("high"),
+		Validity:  &Validity{Priority: 2, Provides: [][]byte{{1}}},
+	}
+	equal := &ValidTransaction{
+		Extrinsic: []byte("equal"),
+		Validity:  &Validity{Priority: 2, Provides: [][]byte{{1}}},
+	}
+
+	pq := NewPriorityQueue()
+	_, err := pq.Push(low)
+	require.NoError(t, err)
+
+	_, replaced, err := pq.Replace(high)
+	require.NoError(t, err)
+	require.Equal(t, []*ValidTransaction{low}, replaced)
+	require.False(t, pq.Provides([]byte{2}))
+
+	_, _, err = pq.Replace(equal)
+	require.ErrorIs(t, err, ErrTooLowPriority)
+
+	require.Equal(t, []*ValidTransaction{high}, pq.Pending())
+}
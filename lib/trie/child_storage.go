@@ -58,7 +58,7 @@ func (t *Trie) PutIntoChild(keyToChild, key, value []byte) error {
 		return err
 	}
 
-	t.childTries[origChildHash] = nil
+	delete(t.childTries, origChildHash)
 	t.childTries[childHash] = child
 
 	return t.PutChild(keyToChild, child)
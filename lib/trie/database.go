@@ -43,6 +43,16 @@ func (t *Trie) store(db chaindb.Batch, curr Node) error {
 		return err
 	}
 
+	// always hash root even if encoding is under 32 bytes
+	if curr == t.root {
+		h, err := common.Blake2bHash(enc)
+		if err != nil {
+			return err
+		}
+
+		hash = h[:]
+	}
+
 	err = db.Put(hash, enc)
 	if err != nil {
 		return err
@@ -69,12 +79,16 @@ func (t *Trie) store(db chaindb.Batch, curr Node) error {
 }
 
 // LoadFromProof create a partial trie based on the proof slice, as it only contains nodes that are in the proof afaik.
+// The child tries whose root node is in the proof are loaded as well.
 func (t *Trie) LoadFromProof(proof [][]byte, root []byte) error {
 	if len(proof) == 0 {
 		return ErrEmptyProof
 	}
 
 	mappedNodes := make(map[string]Node, len(proof))
+	// rootNodes maps the nodes by the hash of their encoding, which is how the root of a
+	// trie is referenced even when its encoding is shorter than 32 bytes
+	rootNodes := make(map[common.Hash]Node, len(proof))
 
 	// map all the proofs hash -> decoded node
 	// and takes the loop to indentify the root node
@@ -94,15 +108,48 @@ func (t *Trie) LoadFromProof(proof [][]byte, root []byte) error {
 
 		mappedNodes[common.BytesToHex(computedRoot)] = decNode
 
-		if bytes.Equal(computedRoot, root) {
+		encodingHash, err := common.Blake2bHash(rawNode)
+		if err != nil {
+			return err
+		}
+
+		rootNodes[encodingHash] = decNode
+
+		if bytes.Equal(encodingHash[:], root) {
 			t.root = decNode
 		}
 	}
 
 	t.loadProof(mappedNodes, t.root)
+	t.loadChildTriesFromProof(mappedNodes, rootNodes)
 	return nil
 }
 
+// loadChildTriesFromProof creates the partial child tries whose root hash is stored
+// in the partial trie and whose root node is in the proof nodes
+func (t *Trie) loadChildTriesFromProof(proof map[string]Node, rootNodes map[common.Hash]Node) {
+	for _, key := range t.GetKeysWithPrefix(ChildStorageKeyPrefix) {
+		childHash := t.Get(key)
+		if len(childHash) != common.HashLength {
+			continue
+		}
+
+		hash := common.BytesToHash(childHash)
+		root, ok := rootNodes[hash]
+		if !ok {
+			continue
+		}
+
+		child := NewTrie(root)
+		child.loadProof(proof, root)
+
+		if t.childTries == nil {
+			t.childTries = make(map[common.Hash]*Trie)
+		}
+		t.childTries[hash] = child
+	}
+}
+
 // loadProof is a recursive function that will create all the trie paths based
 // on the mapped proofs slice starting by the root
 func (t *Trie) loadProof(proof map[string]Node, curr Node) {
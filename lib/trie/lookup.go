@@ -6,6 +6,7 @@ package trie
 import (
 	"bytes"
 
+	"github.com/ChainSafe/gossamer/internal/trie/codec"
 	"github.com/ChainSafe/gossamer/internal/trie/node"
 	"github.com/ChainSafe/gossamer/internal/trie/record"
 )
@@ -41,10 +42,179 @@ func find(parent Node, key []byte, recorder recorder) error {
 		return nil
 	}
 
-	// did not find value
-	if bytes.Equal(b.Key[:length], key) && len(key) < len(b.Key) {
+	// did not find value, the key is shorter than or diverges from the branch key
+	if length < len(b.Key) {
 		return nil
 	}
 
-	return find(b.Children[key[length]], key[length+1:], recorder)
+	child := b.Children[key[length]]
+	if child == nil {
+		// did not find value, there is no child on the path of the key
+		return nil
+	}
+
+	return find(child, key[length+1:], recorder)
+}
+
+// IsProven returns false if the path of the given key in a partial trie built with LoadFromProof goes
+// through a node missing from the proof, in which case the proof does not cover the key.
+func (t *Trie) IsProven(key []byte) bool {
+	return isProven(t.root, codec.KeyLEToNibbles(key))
+}
+
+func isProven(parent Node, key []byte) bool {
+	switch n := parent.(type) {
+	case *node.Branch:
+		length := lenCommonPrefix(n.Key, key)
+		if bytes.Equal(n.Key, key) || length < len(n.Key) {
+			return true
+		}
+
+		return isProven(n.Children[key[length]], key[length+1:])
+	case *node.Leaf:
+		return !isHashOnly(n)
+	default:
+		return true
+	}
+}
+
+// IsPrefixProven returns false if a node holding keys starting with the given prefix, or on the path to them,
+// is missing from the proof the partial trie was built from.
+func (t *Trie) IsPrefixProven(prefix []byte) bool {
+	return isPrefixProven(t.root, codec.KeyLEToNibbles(prefix))
+}
+
+func isPrefixProven(parent Node, prefix []byte) bool {
+	switch n := parent.(type) {
+	case *node.Branch:
+		length := lenCommonPrefix(n.Key, prefix)
+		if length == len(prefix) {
+			// all the keys of the branch start with the prefix
+			return isSubtrieProven(n)
+		}
+
+		if length < len(n.Key) {
+			return true
+		}
+
+		return isPrefixProven(n.Children[prefix[length]], prefix[length+1:])
+	case *node.Leaf:
+		return !isHashOnly(n)
+	default:
+		return true
+	}
+}
+
+func isSubtrieProven(parent Node) bool {
+	switch n := parent.(type) {
+	case *node.Branch:
+		for _, child := range n.Children {
+			if !isSubtrieProven(child) {
+				return false
+			}
+		}
+		return true
+	case *node.Leaf:
+		return !isHashOnly(n)
+	default:
+		return true
+	}
+}
+
+// IsNodeProven returns false if the given node of a partial trie built with LoadFromProof is missing from
+// the proof, the parent branch only holding the hash of the node.
+func IsNodeProven(n Node) bool {
+	l, ok := n.(*node.Leaf)
+	return !ok || !isHashOnly(l)
+}
+
+// isHashOnly returns true if the leaf only stubs the child of a decoded branch with the child hash,
+// meaning the child node was not loaded.
+func isHashOnly(l *node.Leaf) bool {
+	return !l.IsDirty() && l.Key == nil && l.Value == nil && l.GetHash() != nil
+}
+
+// MergedOnDelete returns the node a branch is merged with when the given key is deleted from the trie,
+// or nil if deleting the key does not merge any branch. The merged node is read by the deletion
+// although it is not on the path of the key.
+func (t *Trie) MergedOnDelete(key []byte) Node {
+	return mergedOnDelete(t.root, codec.KeyLEToNibbles(key), false)
+}
+
+// MergedOnClearPrefix returns the node a branch is merged with when the keys starting with the given
+// prefix are deleted from the trie, or nil if deleting them does not merge any branch.
+func (t *Trie) MergedOnClearPrefix(prefix []byte) Node {
+	p := codec.KeyLEToNibbles(prefix)
+	if len(p) > 0 && p[len(p)-1] == 0 {
+		p = p[:len(p)-1]
+	}
+
+	return mergedOnDelete(t.root, p, true)
+}
+
+func mergedOnDelete(parent Node, key []byte, prefix bool) Node {
+	b, ok := parent.(*node.Branch)
+	if !ok {
+		return nil
+	}
+
+	length := lenCommonPrefix(b.Key, key)
+	if prefix && length == len(key) {
+		// the whole branch is deleted, which does not merge it
+		return nil
+	}
+
+	if !prefix && bytes.Equal(b.Key, key) {
+		// the value of the branch is deleted, the branch is merged with its only child
+		if b.Value != nil && b.NumChildren() == 1 {
+			return onlyChild(b)
+		}
+		return nil
+	}
+
+	if length < len(b.Key) {
+		// the key diverges from the branch key, nothing is deleted
+		return nil
+	}
+
+	child := b.Children[key[length]]
+	if !isDeletedWhole(child, key[length+1:], prefix) {
+		return mergedOnDelete(child, key[length+1:], prefix)
+	}
+
+	// the child is removed, the branch is merged with its other child
+	if b.Value == nil && b.NumChildren() == 2 {
+		for i, c := range b.Children {
+			if c != nil && byte(i) != key[length] {
+				return c
+			}
+		}
+	}
+	return nil
+}
+
+// isDeletedWhole returns true if deleting the given key, or the keys starting with it if prefix is true,
+// removes the given node from the trie
+func isDeletedWhole(n Node, key []byte, prefix bool) bool {
+	switch c := n.(type) {
+	case *node.Branch:
+		// deleting a single key never removes a branch
+		return prefix && lenCommonPrefix(c.Key, key) == len(key)
+	case *node.Leaf:
+		if prefix {
+			return lenCommonPrefix(c.Key, key) == len(key)
+		}
+		return bytes.Equal(c.Key, key)
+	default:
+		return false
+	}
+}
+
+func onlyChild(b *node.Branch) Node {
+	for _, c := range b.Children {
+		if c != nil {
+			return c
+		}
+	}
+	return nil
 }
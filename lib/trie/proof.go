@@ -34,18 +34,23 @@ var (
 
 // GenerateProof receive the keys to proof, the trie root and a reference to database
 func GenerateProof(root []byte, keys [][]byte, db chaindb.Database) ([][]byte, error) {
-	trackedProofs := make(map[string][]byte)
-
 	proofTrie := NewEmptyTrie()
 	if err := proofTrie.Load(db, common.BytesToHash(root)); err != nil {
 		return nil, err
 	}
 
+	return GenerateProofFromTrie(proofTrie, keys)
+}
+
+// GenerateProofFromTrie receive the keys to proof and the in-memory trie holding them
+func GenerateProofFromTrie(t *Trie, keys [][]byte) ([][]byte, error) {
+	trackedProofs := make(map[string][]byte)
+
 	for _, k := range keys {
 		nk := codec.KeyLEToNibbles(k)
 
 		recorder := record.NewRecorder()
-		err := findAndRecord(proofTrie, nk, recorder)
+		err := findAndRecord(t, nk, recorder)
 		if err != nil {
 			return nil, err
 		}
@@ -158,3 +158,65 @@ func TestVerifyProof_ShouldReturnTrueWithouCompareValues(t *testing.T) {
 	require.True(t, v)
 	require.NoError(t, err)
 }
+
+func TestGenerateProofFromTrie(t *testing.T) {
+	t.Parallel()
+
+	trie := NewEmptyTrie()
+	entries := []Pair{
+		{Key: []byte("alpha"), Value: make([]byte, 32)},
+		{Key: []byte("bravo"), Value: []byte("bravo")},
+		{Key: []byte("do"), Value: []byte("verb")},
+		{Key: []byte("dog"), Value: []byte("puppy")},
+		{Key: []byte("doge"), Value: make([]byte, 32)},
+		{Key: []byte("horse"), Value: []byte("stallion")},
+		{Key: []byte("house"), Value: []byte("building")},
+	}
+
+	for _, e := range entries {
+		trie.Put(e.Key, e.Value)
+	}
+
+	proof, err := GenerateProofFromTrie(trie, [][]byte{[]byte("bravo"), []byte("dog")})
+	require.NoError(t, err)
+
+	root := trie.MustHash()
+	v, err := VerifyProof(proof, root[:], entries[1:2])
+	require.True(t, v)
+	require.NoError(t, err)
+
+	v, err = VerifyProof(proof, root[:], entries[3:4])
+	require.True(t, v)
+	require.NoError(t, err)
+}
+
+func TestLoadFromProof_ChildTrie(t *testing.T) {
+	t.Parallel()
+
+	trie := NewEmptyTrie()
+	trie.Put([]byte("alpha"), make([]byte, 32))
+	trie.Put([]byte("bravo"), []byte("bravo"))
+
+	child := NewEmptyTrie()
+	child.Put([]byte("do"), []byte("verb"))
+	child.Put([]byte("dog"), []byte("puppy"))
+	err := trie.PutChild([]byte("child"), child)
+	require.NoError(t, err)
+
+	keyToChild := append(append([]byte{}, ChildStorageKeyPrefix...), []byte("child")...)
+	proof, err := GenerateProofFromTrie(trie, [][]byte{keyToChild})
+	require.NoError(t, err)
+
+	childProof, err := GenerateProofFromTrie(child, [][]byte{[]byte("dog")})
+	require.NoError(t, err)
+
+	root := trie.MustHash()
+	proofTrie := NewEmptyTrie()
+	err = proofTrie.LoadFromProof(append(proof, childProof...), root[:])
+	require.NoError(t, err)
+
+	value, err := proofTrie.GetFromChild([]byte("child"), []byte("dog"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("puppy"), value)
+	require.Nil(t, proofTrie.Get([]byte("bravo")))
+}
@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/ChainSafe/gossamer/dot/rpc/modules"
+	"github.com/ChainSafe/gossamer/lib/common"
+	"github.com/ChainSafe/gossamer/lib/runtime"
 	"github.com/ChainSafe/gossamer/tests/utils"
 	"github.com/stretchr/testify/require"
 )
@@ -36,13 +38,22 @@ func TestStateRPCResponseValidation(t *testing.T) {
 	blockHash, err := utils.GetBlockHash(t, nodes[0], "")
 	require.NoError(t, err)
 
+	t.Run("Test state_call", func(t *testing.T) {
+		res := getResponse(t, &testCase{
+			method:   "state_call",
+			params:   fmt.Sprintf(`["Core_version", "0x", "%s"]`, blockHash),
+			expected: modules.StateCallResponse(""),
+		}).(*modules.StateCallResponse)
+
+		ret, err := common.HexToBytes(string(*res))
+		require.NoError(t, err)
+
+		version, err := runtime.DecodeVersion(ret)
+		require.NoError(t, err)
+		require.NotEmpty(t, version.SpecName())
+	})
+
 	testCases := []*testCase{
-		{
-			description: "Test state_call",
-			method:      "state_call",
-			params:      `["", "","0x580d77a9136035a0bc3c3cd86286172f7f81291164c5914266073a30466fba21"]`,
-			expected:    modules.StateCallResponse{},
-		},
 		{ //TODO disable skip when implemented
 			description: "Test state_getKeysPaged",
 			method:      "state_getKeysPaged",